@@ -0,0 +1,124 @@
+package balanceMonitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// alert is the JSON body posted to the alert webhook. The `text` field makes it directly
+// usable with Slack / Discord compatible incoming webhooks.
+type alert struct {
+	Text                  string  `json:"text"`
+	Kind                  string  `json:"kind"`
+	Chain                 string  `json:"chain"`
+	Address               string  `json:"address"`
+	Asset                 string  `json:"asset"`
+	Balance               float64 `json:"balance"`
+	Threshold             float64 `json:"threshold"`
+	BurnRatePerHour       float64 `json:"burnRatePerHour"`
+	TimeUntilEmptySeconds float64 `json:"timeUntilEmptySeconds,omitempty"`
+	TxHash                string  `json:"txHash,omitempty"`
+	Error                 string  `json:"error,omitempty"`
+}
+
+const (
+	alertKindLowBalance  = "low_balance"
+	alertKindTopUp       = "top_up"
+	alertKindTopUpFailed = "top_up_failed"
+)
+
+// webhookAlerter posts alerts to a webhook, sending at most one alert per key and kind
+// every cooldown.
+type webhookAlerter struct {
+	url      string
+	cooldown time.Duration
+	client   *http.Client
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time
+}
+
+func newWebhookAlerter(url string, cooldown time.Duration) *webhookAlerter {
+	return &webhookAlerter{
+		url:      url,
+		cooldown: cooldown,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		lastSent: make(map[string]time.Time),
+	}
+}
+
+// Alert sends the given alert, unless an alert of the same kind has been sent for the same
+// key within the cooldown. Successful top-up alerts are never rate limited.
+func (a *webhookAlerter) Alert(ctx context.Context, key string, al *alert) {
+	slog.Warn(al.Text, "kind", al.Kind, "chain", al.Chain, "address", al.Address, "asset", al.Asset)
+
+	if a.url == "" {
+		return
+	}
+
+	if al.Kind != alertKindTopUp {
+		key = fmt.Sprintf("%s-%s", key, al.Kind)
+
+		a.mu.Lock()
+		last, ok := a.lastSent[key]
+
+		if ok && time.Since(last) < a.cooldown {
+			a.mu.Unlock()
+			return
+		}
+
+		a.lastSent[key] = time.Now()
+		a.mu.Unlock()
+	}
+
+	if err := a.post(ctx, al); err != nil {
+		alertsSentCounter.WithLabelValues(al.Kind, "failed").Inc()
+		slog.Error("Failed to send alert", "kind", al.Kind, "error", err)
+
+		return
+	}
+
+	alertsSentCounter.WithLabelValues(al.Kind, "sent").Inc()
+}
+
+// Resolve clears the cooldown for the given key, so the next low balance is alerted immediately.
+func (a *webhookAlerter) Resolve(key string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.lastSent, fmt.Sprintf("%s-%s", key, alertKindLowBalance))
+	delete(a.lastSent, fmt.Sprintf("%s-%s", key, alertKindTopUpFailed))
+}
+
+func (a *webhookAlerter) post(ctx context.Context, al *alert) error {
+	body, err := json.Marshal(al)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected webhook response status code: %v", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -33,6 +33,8 @@ type ethClient interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
 	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
 }
 
 type BalanceMonitor struct {
@@ -43,6 +45,10 @@ type BalanceMonitor struct {
 	interval           int
 	wg                 *sync.WaitGroup
 	erc20DecimalsCache map[common.Address]uint8
+	thresholds         []*Threshold
+	burnRates          *burnRateTracker
+	alerter            *webhookAlerter
+	topUpper           *topUpper
 }
 
 // InitFromCli inits a new Indexer from command line or environment variables.
@@ -72,6 +78,16 @@ func InitFromConfig(ctx context.Context, b *BalanceMonitor, cfg *Config) (err er
 	b.erc20Addresses = cfg.ERC20Addresses
 	b.interval = cfg.Interval
 	b.erc20DecimalsCache = make(map[common.Address]uint8)
+	b.wg = &sync.WaitGroup{}
+	b.thresholds = cfg.Thresholds.Thresholds
+	b.burnRates = newBurnRateTracker(cfg.BurnRateWindow)
+	b.alerter = newWebhookAlerter(cfg.AlertWebhookURL, cfg.AlertCooldown)
+
+	if cfg.TopUpPrivateKey != nil {
+		b.topUpper = newTopUpper(cfg.TopUpPrivateKey, cfg.Thresholds.DailyLimits)
+
+		slog.Info("Top-ups enabled", "wallet", b.topUpper.from.Hex())
+	}
 
 	return nil
 }
@@ -107,6 +123,10 @@ func (b *BalanceMonitor) Start() error {
 			// Add a 1 second sleep between address checks
 			time.Sleep(time.Second)
 		}
+
+		for _, threshold := range b.thresholds {
+			b.checkThreshold(context.Background(), threshold)
+		}
 	}
 
 	return nil
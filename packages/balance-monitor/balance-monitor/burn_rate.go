@@ -0,0 +1,79 @@
+package balanceMonitor
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+type balanceSample struct {
+	at      time.Time
+	balance float64
+}
+
+// burnRateTracker keeps historical balance samples per threshold, and estimates how fast
+// the balance is being spent.
+type burnRateTracker struct {
+	mu      sync.Mutex
+	window  time.Duration
+	samples map[string][]balanceSample
+}
+
+func newBurnRateTracker(window time.Duration) *burnRateTracker {
+	return &burnRateTracker{
+		window:  window,
+		samples: make(map[string][]balanceSample),
+	}
+}
+
+// Record adds a balance sample, and drops samples older than the tracker window.
+func (t *burnRateTracker) Record(key string, at time.Time, balance float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	samples := append(t.samples[key], balanceSample{at: at, balance: balance})
+
+	i := 0
+	for i < len(samples)-1 && at.Sub(samples[i].at) > t.window {
+		i++
+	}
+
+	t.samples[key] = samples[i:]
+}
+
+// Rate returns the burn rate in token units per second. Only balance decreases are
+// counted, so top-ups and deposits do not hide the spending.
+func (t *burnRateTracker) Rate(key string) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	samples := t.samples[key]
+	if len(samples) < 2 {
+		return 0
+	}
+
+	elapsed := samples[len(samples)-1].at.Sub(samples[0].at).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+
+	var spent float64
+
+	for i := 1; i < len(samples); i++ {
+		if d := samples[i-1].balance - samples[i].balance; d > 0 {
+			spent += d
+		}
+	}
+
+	return spent / elapsed
+}
+
+// timeUntilEmpty returns how many seconds the given balance lasts at the given burn rate,
+// +Inf if nothing is being spent.
+func timeUntilEmpty(balance float64, rate float64) float64 {
+	if rate <= 0 {
+		return math.Inf(1)
+	}
+
+	return balance / rate
+}
@@ -1,17 +1,27 @@
 package balanceMonitor
 
 import (
+	"crypto/ecdsa"
+	"fmt"
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/taikoxyz/taiko-mono/packages/balance-monitor/cmd/flags"
 	"github.com/urfave/cli/v2"
 )
 
 type Config struct {
-	Addresses      []common.Address
-	L1RPCUrl       string
-	L2RPCUrl       string
-	ERC20Addresses []common.Address
-	Interval       int
+	Addresses       []common.Address
+	L1RPCUrl        string
+	L2RPCUrl        string
+	ERC20Addresses  []common.Address
+	Interval        int
+	Thresholds      *ThresholdsConfig
+	AlertWebhookURL string
+	AlertCooldown   time.Duration
+	BurnRateWindow  time.Duration
+	TopUpPrivateKey *ecdsa.PrivateKey
 }
 
 func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
@@ -25,11 +35,32 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		erc20Addresses = append(erc20Addresses, common.HexToAddress(addressStr))
 	}
 
+	thresholds := &ThresholdsConfig{}
+	if c.IsSet(flags.ThresholdsFile.Name) {
+		var err error
+		if thresholds, err = LoadThresholdsConfig(c.String(flags.ThresholdsFile.Name)); err != nil {
+			return nil, err
+		}
+	}
+
+	var topUpPrivateKey *ecdsa.PrivateKey
+	if c.IsSet(flags.TopUpPrivateKey.Name) {
+		var err error
+		if topUpPrivateKey, err = crypto.ToECDSA(common.FromHex(c.String(flags.TopUpPrivateKey.Name))); err != nil {
+			return nil, fmt.Errorf("invalid top-up private key: %w", err)
+		}
+	}
+
 	return &Config{
-		Addresses:      addresses,
-		L1RPCUrl:       c.String(flags.L1RPCUrl.Name),
-		L2RPCUrl:       c.String(flags.L2RPCUrl.Name),
-		ERC20Addresses: erc20Addresses,
-		Interval:       c.Int(flags.Interval.Name),
+		Addresses:       addresses,
+		L1RPCUrl:        c.String(flags.L1RPCUrl.Name),
+		L2RPCUrl:        c.String(flags.L2RPCUrl.Name),
+		ERC20Addresses:  erc20Addresses,
+		Interval:        c.Int(flags.Interval.Name),
+		Thresholds:      thresholds,
+		AlertWebhookURL: c.String(flags.AlertWebhookURL.Name),
+		AlertCooldown:   c.Duration(flags.AlertCooldown.Name),
+		BurnRateWindow:  c.Duration(flags.BurnRateWindow.Name),
+		TopUpPrivateKey: topUpPrivateKey,
 	}, nil
 }
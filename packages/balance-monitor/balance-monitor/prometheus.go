@@ -26,10 +26,66 @@ var (
 		},
 		[]string{"address"},
 	)
+	thresholdBalanceGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "threshold_balance",
+			Help: "Balance of addresses with a configured low-balance threshold",
+		},
+		[]string{"chain", "address", "asset"},
+	)
+	belowThresholdGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "balance_below_threshold",
+			Help: "1 if the balance of the address is below its configured threshold, 0 otherwise",
+		},
+		[]string{"chain", "address", "asset"},
+	)
+	burnRateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "balance_burn_rate_per_hour",
+			Help: "Estimated balance spent per hour, in token units",
+		},
+		[]string{"chain", "address", "asset"},
+	)
+	timeUntilEmptyGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "balance_time_until_empty_seconds",
+			Help: "Estimated time until the balance is empty at the current burn rate",
+		},
+		[]string{"chain", "address", "asset"},
+	)
+	topUpsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "top_ups_total",
+			Help: "Number of automated top-ups, by status",
+		},
+		[]string{"chain", "address", "asset", "status"},
+	)
+	topUpSpentTodayGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "top_up_spent_today",
+			Help: "Amount spent by the top-up wallet in the current UTC day, in token units",
+		},
+		[]string{"limit"},
+	)
+	alertsSentCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "alerts_sent_total",
+			Help: "Number of webhook alerts, by kind and status",
+		},
+		[]string{"kind", "status"},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(l1EthBalanceGauge)
 	prometheus.MustRegister(l2EthBalanceGauge)
 	prometheus.MustRegister(l1Erc20BalanceGauge)
+	prometheus.MustRegister(thresholdBalanceGauge)
+	prometheus.MustRegister(belowThresholdGauge)
+	prometheus.MustRegister(burnRateGauge)
+	prometheus.MustRegister(timeUntilEmptyGauge)
+	prometheus.MustRegister(topUpsCounter)
+	prometheus.MustRegister(topUpSpentTodayGauge)
+	prometheus.MustRegister(alertsSentCounter)
 }
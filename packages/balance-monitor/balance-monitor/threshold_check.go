@@ -0,0 +1,169 @@
+package balanceMonitor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/slog"
+)
+
+// checkThreshold fetches the balance for the given threshold, updates its burn rate
+// estimation, and alerts and tops up the address if it fell under its threshold.
+func (b *BalanceMonitor) checkThreshold(ctx context.Context, t *Threshold) {
+	client := b.l1EthClient
+	if t.Chain == "L2" {
+		client = b.l2EthClient
+	}
+
+	decimals := uint8(18)
+	if t.Token != (common.Address{}) {
+		decimals = b.tokenDecimals(ctx, client, t.Token)
+	}
+
+	balance, err := b.thresholdBalance(ctx, client, t)
+	if err != nil {
+		slog.Warn("Failed to get threshold balance", "key", t.Key(), "error", err)
+		return
+	}
+
+	var (
+		balanceFloat   = toTokenUnits(balance, decimals)
+		minFloat, _    = t.Min.Float64()
+		labels         = []string{t.Chain, t.Address.Hex(), t.AssetLabel()}
+		now            = time.Now()
+		rate           float64
+		untilEmptySecs float64
+	)
+
+	b.burnRates.Record(t.Key(), now, balanceFloat)
+	rate = b.burnRates.Rate(t.Key())
+	untilEmptySecs = timeUntilEmpty(balanceFloat, rate)
+
+	thresholdBalanceGauge.WithLabelValues(labels...).Set(balanceFloat)
+	burnRateGauge.WithLabelValues(labels...).Set(rate * time.Hour.Seconds())
+	timeUntilEmptyGauge.WithLabelValues(labels...).Set(untilEmptySecs)
+
+	if balance.Cmp(toBaseUnits(t.Min, decimals)) >= 0 {
+		belowThresholdGauge.WithLabelValues(labels...).Set(0)
+		b.alerter.Resolve(t.Key())
+
+		return
+	}
+
+	belowThresholdGauge.WithLabelValues(labels...).Set(1)
+
+	al := &alert{
+		Text: fmt.Sprintf(
+			"%s %s balance of %s is %v, below threshold %v",
+			t.Chain, t.AssetLabel(), t.Address.Hex(), balanceFloat, minFloat,
+		),
+		Kind:            alertKindLowBalance,
+		Chain:           t.Chain,
+		Address:         t.Address.Hex(),
+		Asset:           t.AssetLabel(),
+		Balance:         balanceFloat,
+		Threshold:       minFloat,
+		BurnRatePerHour: rate * time.Hour.Seconds(),
+	}
+
+	// JSON can not encode +Inf, omit the estimation when nothing is being spent.
+	if !math.IsInf(untilEmptySecs, 1) {
+		al.TimeUntilEmptySeconds = untilEmptySecs
+	}
+
+	b.alerter.Alert(ctx, t.Key(), al)
+
+	if b.topUpper == nil || t.TopUpTo == nil {
+		return
+	}
+
+	b.wg.Add(1)
+
+	go func() {
+		defer b.wg.Done()
+
+		b.topUp(ctx, client, t, balance, decimals)
+	}()
+}
+
+// topUp tops up the threshold address, and alerts about the result.
+func (b *BalanceMonitor) topUp(ctx context.Context, client ethClient, t *Threshold, balance *big.Int, decimals uint8) {
+	labels := []string{t.Chain, t.Address.Hex(), t.AssetLabel()}
+
+	tx, err := b.topUpper.TopUp(ctx, client, t, balance, decimals)
+	if err != nil {
+		topUpsCounter.WithLabelValues(append(labels, "failed")...).Inc()
+
+		al := &alert{
+			Text:    fmt.Sprintf("Failed to top up %s %s balance of %s: %v", t.Chain, t.AssetLabel(), t.Address.Hex(), err),
+			Kind:    alertKindTopUpFailed,
+			Chain:   t.Chain,
+			Address: t.Address.Hex(),
+			Asset:   t.AssetLabel(),
+			Balance: toTokenUnits(balance, decimals),
+			Error:   err.Error(),
+		}
+		if tx != nil {
+			al.TxHash = tx.Hash().Hex()
+		}
+
+		b.alerter.Alert(ctx, t.Key(), al)
+
+		return
+	}
+
+	// another top-up for this threshold is still in flight.
+	if tx == nil {
+		return
+	}
+
+	topUpsCounter.WithLabelValues(append(labels, "success")...).Inc()
+
+	topUpTo, _ := t.TopUpTo.Float64()
+
+	b.alerter.Alert(ctx, t.Key(), &alert{
+		Text:      fmt.Sprintf("Topped up %s %s balance of %s to %v", t.Chain, t.AssetLabel(), t.Address.Hex(), topUpTo),
+		Kind:      alertKindTopUp,
+		Chain:     t.Chain,
+		Address:   t.Address.Hex(),
+		Asset:     t.AssetLabel(),
+		Balance:   toTokenUnits(balance, decimals),
+		Threshold: topUpTo,
+		TxHash:    tx.Hash().Hex(),
+	})
+}
+
+// thresholdBalance returns the balance the given threshold applies to, in base units.
+func (b *BalanceMonitor) thresholdBalance(ctx context.Context, client ethClient, t *Threshold) (*big.Int, error) {
+	switch t.Asset {
+	case AssetETH:
+		return b.getEthBalance(ctx, client, t.Address)
+	case AssetERC20:
+		return b.getErc20Balance(ctx, client, t.Token, t.Address)
+	case AssetBond:
+		return b.getErc20BondBalance(ctx, client, t.BondContract, t.Address)
+	default:
+		return nil, fmt.Errorf("unsupported asset %q", t.Asset)
+	}
+}
+
+// tokenDecimals returns the cached decimals of the given token, defaulting to 18.
+func (b *BalanceMonitor) tokenDecimals(ctx context.Context, client ethClient, tokenAddress common.Address) uint8 {
+	if decimals, ok := b.erc20DecimalsCache[tokenAddress]; ok {
+		return decimals
+	}
+
+	decimals, err := b.getErc20Decimals(ctx, client, tokenAddress)
+	if err != nil {
+		slog.Warn("Failed to get ERC-20 decimals for token. Use default value: 18", "tokenAddress", tokenAddress.Hex(), "error", err)
+		decimals = 18
+	}
+
+	b.erc20DecimalsCache[tokenAddress] = decimals
+
+	return decimals
+}
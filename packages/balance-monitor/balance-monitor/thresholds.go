@@ -0,0 +1,203 @@
+package balanceMonitor
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v3"
+)
+
+// AssetKind is the kind of balance a threshold applies to.
+type AssetKind string
+
+const (
+	AssetETH   AssetKind = "eth"
+	AssetERC20 AssetKind = "erc20"
+	AssetBond  AssetKind = "bond"
+)
+
+// Threshold is a low-balance threshold for a single address and asset on one chain.
+type Threshold struct {
+	Chain   string
+	Address common.Address
+	Asset   AssetKind
+	// Token is the ERC-20 token for erc20 thresholds, and the bond token for bond
+	// thresholds (zero address for ETH bonds).
+	Token common.Address
+	// BondContract is the contract exposing `bondBalanceOf` / `depositBond`.
+	BondContract common.Address
+	// Min is the low-balance threshold, in token units.
+	Min *big.Float
+	// TopUpTo is the balance to top up to when under Min, in token units. Nil disables top-ups.
+	TopUpTo *big.Float
+}
+
+// Key returns a unique key identifying the threshold.
+func (t *Threshold) Key() string {
+	return fmt.Sprintf("%s-%s-%s", t.Chain, t.Address.Hex(), t.AssetLabel())
+}
+
+// AssetLabel returns the asset label used in metrics and alerts.
+func (t *Threshold) AssetLabel() string {
+	if t.Asset == AssetETH {
+		return string(AssetETH)
+	}
+
+	return fmt.Sprintf("%s:%s", t.Asset, t.Token.Hex())
+}
+
+// ThresholdsConfig is the parsed thresholds file.
+type ThresholdsConfig struct {
+	Thresholds []*Threshold
+	// DailyLimits maps a spendLimitKey to the maximum amount, in token units, the top-up
+	// wallet may send in one UTC day.
+	DailyLimits map[string]*big.Float
+}
+
+type thresholdsFile struct {
+	Thresholds []struct {
+		Chain        string `yaml:"chain"`
+		Address      string `yaml:"address"`
+		Asset        string `yaml:"asset"`
+		Token        string `yaml:"token"`
+		BondContract string `yaml:"bondContract"`
+		Min          string `yaml:"min"`
+		TopUpTo      string `yaml:"topUpTo"`
+	} `yaml:"thresholds"`
+	DailyLimits []struct {
+		Chain string `yaml:"chain"`
+		Asset string `yaml:"asset"`
+		Token string `yaml:"token"`
+		Limit string `yaml:"limit"`
+	} `yaml:"dailyLimits"`
+}
+
+// spendLimitKey returns the key daily spend limits are tracked by. Bond top-ups spend
+// the bond token, so they share the ERC-20 limit of that token.
+func spendLimitKey(chain string, asset AssetKind, token common.Address) string {
+	if asset == AssetETH || (asset == AssetBond && token == (common.Address{})) {
+		return fmt.Sprintf("%s-%s", strings.ToUpper(chain), AssetETH)
+	}
+
+	return fmt.Sprintf("%s-%s", strings.ToUpper(chain), token.Hex())
+}
+
+// LoadThresholdsConfig reads and validates the thresholds file at the given path.
+func LoadThresholdsConfig(path string) (*ThresholdsConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f thresholdsFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse thresholds file: %w", err)
+	}
+
+	cfg := &ThresholdsConfig{DailyLimits: make(map[string]*big.Float)}
+
+	for i, t := range f.Thresholds {
+		chain := strings.ToUpper(t.Chain)
+		if chain != "L1" && chain != "L2" {
+			return nil, fmt.Errorf("threshold %d: invalid chain %q", i, t.Chain)
+		}
+
+		if !common.IsHexAddress(t.Address) {
+			return nil, fmt.Errorf("threshold %d: invalid address %q", i, t.Address)
+		}
+
+		threshold := &Threshold{
+			Chain:   chain,
+			Address: common.HexToAddress(t.Address),
+			Asset:   AssetKind(strings.ToLower(t.Asset)),
+		}
+
+		switch threshold.Asset {
+		case AssetETH:
+		case AssetERC20:
+			if !common.IsHexAddress(t.Token) {
+				return nil, fmt.Errorf("threshold %d: invalid token %q", i, t.Token)
+			}
+		case AssetBond:
+			if !common.IsHexAddress(t.BondContract) {
+				return nil, fmt.Errorf("threshold %d: invalid bond contract %q", i, t.BondContract)
+			}
+
+			if t.Token != "" && !common.IsHexAddress(t.Token) {
+				return nil, fmt.Errorf("threshold %d: invalid token %q", i, t.Token)
+			}
+
+			threshold.BondContract = common.HexToAddress(t.BondContract)
+		default:
+			return nil, fmt.Errorf("threshold %d: invalid asset %q", i, t.Asset)
+		}
+
+		if t.Token != "" {
+			threshold.Token = common.HexToAddress(t.Token)
+		}
+
+		if threshold.Min, err = parseAmount(t.Min); err != nil {
+			return nil, fmt.Errorf("threshold %d: invalid min: %w", i, err)
+		}
+
+		if t.TopUpTo != "" {
+			if threshold.TopUpTo, err = parseAmount(t.TopUpTo); err != nil {
+				return nil, fmt.Errorf("threshold %d: invalid topUpTo: %w", i, err)
+			}
+
+			if threshold.TopUpTo.Cmp(threshold.Min) <= 0 {
+				return nil, fmt.Errorf("threshold %d: topUpTo must be greater than min", i)
+			}
+		}
+
+		cfg.Thresholds = append(cfg.Thresholds, threshold)
+	}
+
+	for i, l := range f.DailyLimits {
+		limit, err := parseAmount(l.Limit)
+		if err != nil {
+			return nil, fmt.Errorf("daily limit %d: invalid limit: %w", i, err)
+		}
+
+		if l.Token != "" && !common.IsHexAddress(l.Token) {
+			return nil, fmt.Errorf("daily limit %d: invalid token %q", i, l.Token)
+		}
+
+		cfg.DailyLimits[spendLimitKey(l.Chain, AssetKind(strings.ToLower(l.Asset)), common.HexToAddress(l.Token))] = limit
+	}
+
+	return cfg, nil
+}
+
+// parseAmount parses a non-negative decimal amount in token units.
+func parseAmount(s string) (*big.Float, error) {
+	f, ok := new(big.Float).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+
+	if f.Sign() < 0 {
+		return nil, fmt.Errorf("negative amount %q", s)
+	}
+
+	return f, nil
+}
+
+// toBaseUnits converts an amount in token units to base units using the given decimals.
+func toBaseUnits(amount *big.Float, decimals uint8) *big.Int {
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	result, _ := new(big.Float).Mul(amount, scale).Int(nil)
+
+	return result
+}
+
+// toTokenUnits converts an amount in base units to token units using the given decimals.
+func toTokenUnits(amount *big.Int, decimals uint8) float64 {
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	result, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), scale).Float64()
+
+	return result
+}
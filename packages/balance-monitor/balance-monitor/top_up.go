@@ -0,0 +1,320 @@
+package balanceMonitor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/exp/slog"
+)
+
+const erc20TransferABI = `[{"constant":false,"inputs":[{"name":"_to","type":"address"},{"name":"_value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"type":"function"},{"constant":false,"inputs":[{"name":"_spender","type":"address"},{"name":"_value","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"type":"function"}]`
+const depositBondABI = `[{"inputs":[{"name":"_amount","type":"uint256"}],"name":"depositBond","outputs":[],"stateMutability":"payable","type":"function"}]`
+
+var (
+	errNoDailyLimit        = errors.New("no daily spend limit configured")
+	errDailyLimitReached   = errors.New("daily spend limit reached")
+	errUnsupportedETHBond  = errors.New("ETH bonds can only be topped up for the top-up wallet itself")
+	errTopUpAmountTooSmall = errors.New("top-up amount is zero")
+	errBondNotDeposited    = errors.New("bond tokens were transferred to the prover set but not deposited")
+)
+
+// topUpper sends ETH, ERC-20 tokens or bonds from a funded hot wallet to addresses which fell
+// under their threshold, within per chain / asset daily spend limits.
+type topUpper struct {
+	privateKey  *ecdsa.PrivateKey
+	from        common.Address
+	dailyLimits map[string]*big.Float
+
+	mu         sync.Mutex
+	day        string
+	spent      map[string]*big.Int
+	inFlight   map[string]bool
+	chainLocks map[string]*sync.Mutex
+}
+
+func newTopUpper(privateKey *ecdsa.PrivateKey, dailyLimits map[string]*big.Float) *topUpper {
+	return &topUpper{
+		privateKey:  privateKey,
+		from:        crypto.PubkeyToAddress(privateKey.PublicKey),
+		dailyLimits: dailyLimits,
+		spent:       make(map[string]*big.Int),
+		inFlight:    make(map[string]bool),
+		chainLocks:  make(map[string]*sync.Mutex),
+	}
+}
+
+// chainLock returns the lock serializing the top-ups on the given chain, concurrent top-ups
+// from the same wallet would race for the same nonce.
+func (u *topUpper) chainLock(chain string) *sync.Mutex {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	lock, ok := u.chainLocks[chain]
+	if !ok {
+		lock = new(sync.Mutex)
+		u.chainLocks[chain] = lock
+	}
+
+	return lock
+}
+
+// reserve reserves the given amount against the daily spend limit, returning a function
+// to release the reservation if the top-up fails.
+func (u *topUpper) reserve(limitKey string, amount *big.Int, decimals uint8) (func(), error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	// spend limits reset every UTC day.
+	if day := time.Now().UTC().Format(time.DateOnly); day != u.day {
+		u.day = day
+		u.spent = make(map[string]*big.Int)
+	}
+
+	limit, ok := u.dailyLimits[limitKey]
+	if !ok {
+		return nil, errNoDailyLimit
+	}
+
+	spent, ok := u.spent[limitKey]
+	if !ok {
+		spent = new(big.Int)
+		u.spent[limitKey] = spent
+	}
+
+	if new(big.Int).Add(spent, amount).Cmp(toBaseUnits(limit, decimals)) > 0 {
+		return nil, errDailyLimitReached
+	}
+
+	spent.Add(spent, amount)
+	topUpSpentTodayGauge.WithLabelValues(limitKey).Set(toTokenUnits(spent, decimals))
+
+	return func() {
+		u.mu.Lock()
+		defer u.mu.Unlock()
+
+		if s, ok := u.spent[limitKey]; ok {
+			s.Sub(s, amount)
+			topUpSpentTodayGauge.WithLabelValues(limitKey).Set(toTokenUnits(s, decimals))
+		}
+	}, nil
+}
+
+// TopUp tops up the threshold address from its current balance to its TopUpTo balance.
+func (u *topUpper) TopUp(
+	ctx context.Context,
+	client ethClient,
+	t *Threshold,
+	balance *big.Int,
+	decimals uint8,
+) (*types.Transaction, error) {
+	amount := new(big.Int).Sub(toBaseUnits(t.TopUpTo, decimals), balance)
+	if amount.Sign() <= 0 {
+		return nil, errTopUpAmountTooSmall
+	}
+
+	// the balance will only be refreshed on the next tick, make sure we do not
+	// top up the same address twice concurrently.
+	u.mu.Lock()
+	if u.inFlight[t.Key()] {
+		u.mu.Unlock()
+		return nil, nil
+	}
+
+	u.inFlight[t.Key()] = true
+	u.mu.Unlock()
+
+	defer func() {
+		u.mu.Lock()
+		delete(u.inFlight, t.Key())
+		u.mu.Unlock()
+	}()
+
+	release, err := u.reserve(spendLimitKey(t.Chain, t.Asset, t.Token), amount, decimals)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := u.chainLock(t.Chain)
+	lock.Lock()
+	defer lock.Unlock()
+
+	tx, err := u.send(ctx, client, t, amount)
+	if err != nil {
+		// the tokens already left the top-up wallet, keep them counted against the limit.
+		if !errors.Is(err, errBondNotDeposited) {
+			release()
+		}
+
+		return nil, err
+	}
+
+	// wait for the top-up to be mined, so the next balance check sees the new balance.
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return tx, err
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		// a reverted deposit leaves the transferred tokens in the prover set.
+		if !u.transfersBeforeDeposit(t) {
+			release()
+		}
+
+		return tx, fmt.Errorf("transaction %s reverted", tx.Hash().Hex())
+	}
+
+	slog.Info(
+		"Topped up address",
+		"chain", t.Chain,
+		"address", t.Address.Hex(),
+		"asset", t.AssetLabel(),
+		"amount", amount.String(),
+		"txHash", tx.Hash().Hex(),
+	)
+
+	return tx, nil
+}
+
+// send sends the top-up transactions, and returns the last one.
+func (u *topUpper) send(ctx context.Context, client ethClient, t *Threshold, amount *big.Int) (*types.Transaction, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(u.privateKey, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Context = ctx
+
+	switch t.Asset {
+	case AssetETH:
+		opts.Value = amount
+		return u.transact(ctx, client, opts, t.Address, "", "")
+	case AssetERC20:
+		return u.transact(ctx, client, opts, t.Token, erc20TransferABI, "transfer", t.Address, amount)
+	case AssetBond:
+		return u.depositBond(ctx, client, opts, t, amount)
+	default:
+		return nil, fmt.Errorf("unsupported asset %q", t.Asset)
+	}
+}
+
+// depositBond deposits a bond for the threshold address. When the address is the top-up
+// wallet itself, the bond token is approved and deposited directly into the bond contract.
+// Otherwise the address is expected to be a prover set on which the top-up wallet is
+// authorized: the tokens are transferred to it, and then deposited by the prover set.
+func (u *topUpper) depositBond(
+	ctx context.Context,
+	client ethClient,
+	opts *bind.TransactOpts,
+	t *Threshold,
+	amount *big.Int,
+) (*types.Transaction, error) {
+	if t.Address == u.from {
+		if t.Token == (common.Address{}) {
+			opts.Value = amount
+			return u.transact(ctx, client, opts, t.BondContract, depositBondABI, "depositBond", amount)
+		}
+
+		if _, err := u.transactAndWait(
+			ctx, client, opts, t.Token, erc20TransferABI, "approve", t.BondContract, amount,
+		); err != nil {
+			return nil, fmt.Errorf("failed to approve bond token: %w", err)
+		}
+
+		return u.transact(ctx, client, opts, t.BondContract, depositBondABI, "depositBond", amount)
+	}
+
+	if t.Token == (common.Address{}) {
+		return nil, errUnsupportedETHBond
+	}
+
+	if _, err := u.transactAndWait(
+		ctx, client, opts, t.Token, erc20TransferABI, "transfer", t.Address, amount,
+	); err != nil {
+		return nil, fmt.Errorf("failed to transfer bond token to prover set: %w", err)
+	}
+
+	tx, err := u.transact(ctx, client, opts, t.Address, depositBondABI, "depositBond", amount)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errBondNotDeposited, err)
+	}
+
+	return tx, nil
+}
+
+// transfersBeforeDeposit returns whether the bond tokens of the given threshold are transferred
+// out of the top-up wallet before the final deposit transaction is sent.
+func (u *topUpper) transfersBeforeDeposit(t *Threshold) bool {
+	return t.Asset == AssetBond && t.Address != u.from
+}
+
+// transact sends a transaction calling the given method, or a plain transfer if no
+// method is given.
+func (u *topUpper) transact(
+	ctx context.Context,
+	client ethClient,
+	opts *bind.TransactOpts,
+	to common.Address,
+	abiDef string,
+	method string,
+	params ...interface{},
+) (*types.Transaction, error) {
+	var parsedABI abi.ABI
+
+	if abiDef != "" {
+		var err error
+		if parsedABI, err = abi.JSON(strings.NewReader(abiDef)); err != nil {
+			return nil, err
+		}
+	}
+
+	contract := bind.NewBoundContract(to, parsedABI, client, client, client)
+
+	if method == "" {
+		return contract.Transfer(opts)
+	}
+
+	return contract.Transact(opts, method, params...)
+}
+
+// transactAndWait sends a transaction and waits for it to be mined successfully.
+func (u *topUpper) transactAndWait(
+	ctx context.Context,
+	client ethClient,
+	opts *bind.TransactOpts,
+	to common.Address,
+	abiDef string,
+	method string,
+	params ...interface{},
+) (*types.Receipt, error) {
+	tx, err := u.transact(ctx, client, opts, to, abiDef, method, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s reverted", tx.Hash().Hex())
+	}
+
+	return receipt, nil
+}
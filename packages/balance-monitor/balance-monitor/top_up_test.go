@@ -0,0 +1,271 @@
+package balanceMonitor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errTestSendFailed = errors.New("send failed")
+	testBondToken     = common.HexToAddress("0x2")
+)
+
+// fakeTopUpClient mines every sent transaction immediately, optionally failing or reverting
+// the n-th (1-based) sent transaction.
+type fakeTopUpClient struct {
+	mu         sync.Mutex
+	sent       int
+	failSendAt int
+	revertAt   int
+	receipts   map[common.Hash]*types.Receipt
+	pending    int
+	maxPending int
+}
+
+func newFakeTopUpClient() *fakeTopUpClient {
+	return &fakeTopUpClient{receipts: make(map[common.Hash]*types.Receipt)}
+}
+
+func (c *fakeTopUpClient) BalanceAt(context.Context, common.Address, *big.Int) (*big.Int, error) {
+	return common.Big0, nil
+}
+
+func (c *fakeTopUpClient) CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func (c *fakeTopUpClient) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (c *fakeTopUpClient) PendingCodeAt(context.Context, common.Address) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (c *fakeTopUpClient) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return uint64(c.sent), nil
+}
+
+func (c *fakeTopUpClient) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	return 100_000, nil
+}
+
+func (c *fakeTopUpClient) SendTransaction(_ context.Context, tx *types.Transaction) error {
+	c.mu.Lock()
+	c.sent++
+	sent := c.sent
+
+	if sent == c.failSendAt {
+		c.mu.Unlock()
+		return errTestSendFailed
+	}
+
+	c.pending++
+	c.maxPending = max(c.maxPending, c.pending)
+
+	status := types.ReceiptStatusSuccessful
+	if sent == c.revertAt {
+		status = types.ReceiptStatusFailed
+	}
+
+	c.receipts[tx.Hash()] = &types.Receipt{Status: status, TxHash: tx.Hash(), BlockNumber: common.Big1}
+	c.mu.Unlock()
+
+	// widen the window for concurrent top-ups to overlap.
+	time.Sleep(10 * time.Millisecond)
+
+	return nil
+}
+
+func (c *fakeTopUpClient) FilterLogs(context.Context, ethereum.FilterQuery) ([]types.Log, error) {
+	return nil, nil
+}
+
+func (c *fakeTopUpClient) SubscribeFilterLogs(
+	context.Context,
+	ethereum.FilterQuery,
+	chan<- types.Log,
+) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeTopUpClient) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	return &types.Header{Number: common.Big1, BaseFee: common.Big1}, nil
+}
+
+func (c *fakeTopUpClient) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return common.Big2, nil
+}
+
+func (c *fakeTopUpClient) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	return common.Big1, nil
+}
+
+func (c *fakeTopUpClient) ChainID(context.Context) (*big.Int, error) {
+	return common.Big1, nil
+}
+
+func (c *fakeTopUpClient) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	receipt, ok := c.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+
+	c.pending--
+
+	return receipt, nil
+}
+
+func newTestTopUpper(t *testing.T) *topUpper {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	return newTopUpper(key, map[string]*big.Float{
+		spendLimitKey("L1", AssetETH, common.Address{}): big.NewFloat(10),
+		spendLimitKey("L1", AssetBond, testBondToken):   big.NewFloat(10),
+	})
+}
+
+func spentToday(u *topUpper, limitKey string) int64 {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if spent, ok := u.spent[limitKey]; ok {
+		return spent.Int64()
+	}
+
+	return 0
+}
+
+func TestTopUpperReserve(t *testing.T) {
+	u := newTestTopUpper(t)
+
+	_, err := u.reserve("L2-ETH", common.Big1, 0)
+	require.ErrorIs(t, err, errNoDailyLimit)
+
+	release, err := u.reserve("L1-ETH", big.NewInt(6), 0)
+	require.Nil(t, err)
+
+	_, err = u.reserve("L1-ETH", big.NewInt(5), 0)
+	require.ErrorIs(t, err, errDailyLimitReached)
+
+	release()
+
+	_, err = u.reserve("L1-ETH", big.NewInt(10), 0)
+	require.Nil(t, err)
+	require.Equal(t, int64(10), spentToday(u, "L1-ETH"))
+}
+
+func TestTopUpReleasesFailedTopUps(t *testing.T) {
+	threshold := &Threshold{
+		Chain:   "L1",
+		Address: common.HexToAddress("0x1"),
+		Asset:   AssetETH,
+		TopUpTo: big.NewFloat(4),
+	}
+
+	u := newTestTopUpper(t)
+
+	client := newFakeTopUpClient()
+	client.failSendAt = 1
+
+	_, err := u.TopUp(context.Background(), client, threshold, common.Big1, 0)
+	require.ErrorIs(t, err, errTestSendFailed)
+	require.Equal(t, int64(0), spentToday(u, "L1-ETH"))
+
+	client = newFakeTopUpClient()
+	client.revertAt = 1
+
+	_, err = u.TopUp(context.Background(), client, threshold, common.Big1, 0)
+	require.NotNil(t, err)
+	require.Equal(t, int64(0), spentToday(u, "L1-ETH"))
+
+	tx, err := u.TopUp(context.Background(), newFakeTopUpClient(), threshold, common.Big1, 0)
+	require.Nil(t, err)
+	require.NotNil(t, tx)
+	require.Equal(t, int64(3), spentToday(u, "L1-ETH"))
+}
+
+func TestTopUpKeepsTransferredBondTokensReserved(t *testing.T) {
+	limitKey := spendLimitKey("L1", AssetBond, testBondToken)
+	threshold := &Threshold{
+		Chain:        "L1",
+		Address:      common.HexToAddress("0x1"),
+		Asset:        AssetBond,
+		Token:        testBondToken,
+		BondContract: common.HexToAddress("0x3"),
+		TopUpTo:      big.NewFloat(4),
+	}
+
+	u := newTestTopUpper(t)
+
+	// the transfer to the prover set fails, nothing left the top-up wallet.
+	client := newFakeTopUpClient()
+	client.failSendAt = 1
+
+	_, err := u.TopUp(context.Background(), client, threshold, common.Big1, 0)
+	require.ErrorIs(t, err, errTestSendFailed)
+	require.NotErrorIs(t, err, errBondNotDeposited)
+	require.Equal(t, int64(0), spentToday(u, limitKey))
+
+	// the deposit fails after the transfer to the prover set.
+	client = newFakeTopUpClient()
+	client.failSendAt = 2
+
+	_, err = u.TopUp(context.Background(), client, threshold, common.Big1, 0)
+	require.ErrorIs(t, err, errBondNotDeposited)
+	require.Equal(t, int64(3), spentToday(u, limitKey))
+
+	// the deposit reverts after the transfer to the prover set.
+	client = newFakeTopUpClient()
+	client.revertAt = 2
+
+	_, err = u.TopUp(context.Background(), client, threshold, common.Big1, 0)
+	require.NotNil(t, err)
+	require.Equal(t, int64(6), spentToday(u, limitKey))
+}
+
+func TestTopUpSerializesTopUpsPerChain(t *testing.T) {
+	u := newTestTopUpper(t)
+	client := newFakeTopUpClient()
+
+	var wg sync.WaitGroup
+
+	for i := int64(1); i <= 3; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := u.TopUp(context.Background(), client, &Threshold{
+				Chain:   "L1",
+				Address: common.BigToAddress(big.NewInt(i)),
+				Asset:   AssetETH,
+				TopUpTo: big.NewFloat(2),
+			}, common.Big1, 0)
+			assert.Nil(t, err)
+		}()
+	}
+
+	wg.Wait()
+
+	require.Equal(t, 3, client.sent)
+	require.Equal(t, 1, client.maxPending)
+}
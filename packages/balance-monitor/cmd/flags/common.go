@@ -1,6 +1,8 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/urfave/cli/v2"
 )
 
@@ -45,6 +47,43 @@ var (
 		Category: commonCategory,
 		EnvVars:  []string{"INTERVAL"},
 	}
+	ThresholdsFile = &cli.StringFlag{
+		Name:     "thresholdsFile",
+		Usage:    "Path to a YAML file with per-address / per-token low-balance thresholds and top-up daily limits",
+		Required: false,
+		Category: commonCategory,
+		EnvVars:  []string{"THRESHOLDS_FILE"},
+	}
+	AlertWebhookURL = &cli.StringFlag{
+		Name:     "alert.webhookUrl",
+		Usage:    "Webhook URL low-balance and top-up alerts are posted to",
+		Required: false,
+		Category: commonCategory,
+		EnvVars:  []string{"ALERT_WEBHOOK_URL"},
+	}
+	AlertCooldown = &cli.DurationFlag{
+		Name:     "alert.cooldown",
+		Usage:    "Minimum time between two low-balance alerts for the same address and asset",
+		Required: false,
+		Value:    1 * time.Hour,
+		Category: commonCategory,
+		EnvVars:  []string{"ALERT_COOLDOWN"},
+	}
+	BurnRateWindow = &cli.DurationFlag{
+		Name:     "burnRate.window",
+		Usage:    "Window of historical balance samples used to estimate the burn rate",
+		Required: false,
+		Value:    24 * time.Hour,
+		Category: commonCategory,
+		EnvVars:  []string{"BURN_RATE_WINDOW"},
+	}
+	TopUpPrivateKey = &cli.StringFlag{
+		Name:     "topUp.privateKey",
+		Usage:    "Private key of the funded hot wallet used to top up addresses under their threshold, top-ups are disabled if not set",
+		Required: false,
+		Category: commonCategory,
+		EnvVars:  []string{"TOP_UP_PRIVATE_KEY"},
+	}
 	MetricsHTTPPort = &cli.Uint64Flag{
 		Name:     "metrics.port",
 		Usage:    "Port to run metrics http server on",
@@ -61,5 +100,10 @@ var CommonFlags = []cli.Flag{
 	L2RPCUrl,
 	ERC20Addresses,
 	Interval,
+	ThresholdsFile,
+	AlertWebhookURL,
+	AlertCooldown,
+	BurnRateWindow,
+	TopUpPrivateKey,
 	MetricsHTTPPort,
 }
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultListenAddr       = ":8080"
+	defaultMaxResponseBytes = 10 * 1024 * 1024
+	defaultMaxBatchSize     = 100
+
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultHealthCheckTimeout  = 5 * time.Second
+	defaultMaxBlockLag         = 10
+	defaultMaxAttempts         = 3
+)
+
+// Config is the gateway configuration, loaded from a YAML file.
+type Config struct {
+	// ListenAddr is the address the gateway listens on.
+	ListenAddr string `yaml:"listenAddr"`
+	// WebSocket starts the gateway in WebSocket mode instead of RPC mode.
+	WebSocket bool `yaml:"websocket"`
+	// Upstreams are the nodes requests are forwarded to.
+	Upstreams UpstreamsConfig `yaml:"upstreams"`
+	// HealthCheck configures the active upstream health checks.
+	HealthCheck HealthCheckConfig `yaml:"healthCheck"`
+	// Retry configures the failover of idempotent calls.
+	Retry RetryConfig `yaml:"retry"`
+	// PrimaryMethods are the methods routed to the primary upstream, all other
+	// methods are routed to the secondary upstream.
+	PrimaryMethods []string `yaml:"primaryMethods"`
+	// Methods is the method allow / deny list.
+	Methods MethodsConfig `yaml:"methods"`
+	// RateLimits are the token-bucket rate limits applied to each call.
+	RateLimits RateLimitsConfig `yaml:"rateLimits"`
+	// MaxResponseBytes caps the size of an upstream response.
+	MaxResponseBytes int64 `yaml:"maxResponseBytes"`
+	// MaxBatchSize caps the number of calls in a single JSON-RPC batch.
+	MaxBatchSize int `yaml:"maxBatchSize"`
+	// TrustForwardedFor uses the right-most X-Forwarded-For entry as the client IP, only
+	// enable it when the gateway runs directly behind a trusted proxy which appends it.
+	TrustForwardedFor bool `yaml:"trustForwardedFor"`
+}
+
+// UpstreamsConfig contains the upstream node URLs of each role. Calls are balanced over the
+// healthy upstreams of a role.
+type UpstreamsConfig struct {
+	Primary   []string `yaml:"primary"`
+	Secondary []string `yaml:"secondary"`
+	WebSocket []string `yaml:"websocket"`
+}
+
+// HealthCheckConfig configures the active upstream health checks. An upstream is unhealthy if
+// it does not respond, is syncing, or is more than MaxBlockLag blocks behind the highest
+// upstream.
+type HealthCheckConfig struct {
+	Interval    time.Duration `yaml:"interval"`
+	Timeout     time.Duration `yaml:"timeout"`
+	MaxBlockLag uint64        `yaml:"maxBlockLag"`
+}
+
+// RetryConfig configures how many upstreams an idempotent call is tried on before failing.
+type RetryConfig struct {
+	MaxAttempts int `yaml:"maxAttempts"`
+}
+
+// MethodsConfig contains the method allow / deny list. Entries are either exact method
+// names, or prefixes ending with `*`, e.g. `debug_*`.
+type MethodsConfig struct {
+	Allow []string `yaml:"allow"`
+	Deny  []string `yaml:"deny"`
+}
+
+// RateLimitsConfig contains the rate limits applied per client IP. PerMethod limits apply
+// to each client IP and method pair, in addition to the PerIP limit.
+type RateLimitsConfig struct {
+	PerIP     *RateLimit           `yaml:"perIP"`
+	PerMethod map[string]RateLimit `yaml:"perMethod"`
+}
+
+// RateLimit is a token bucket, refilled with Rate tokens per second up to Burst tokens.
+type RateLimit struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+// loadConfig reads, defaults and validates the configuration file at the given path.
+func loadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	return cfg.finalize()
+}
+
+// loadConfigFromEnv builds the configuration from the environment variables the gateway was
+// configured with before config files were supported, with a single upstream per role.
+func loadConfigFromEnv() (*Config, error) {
+	cfg := &Config{
+		WebSocket:      os.Getenv("IS_WEBSOCKET") == "true",
+		PrimaryMethods: splitEnvList(os.Getenv("PRIMARY_METHODS")),
+	}
+
+	if webSocketURL := os.Getenv("WEBSOCKET_TARGET_URL"); webSocketURL != "" {
+		cfg.Upstreams.WebSocket = []string{webSocketURL}
+	}
+
+	if !cfg.WebSocket {
+		cfg.Upstreams.Primary = []string{os.Getenv("TARGET_URL_PRIMARY")}
+		cfg.Upstreams.Secondary = []string{os.Getenv("TARGET_URL_SECONDARY")}
+	}
+
+	// The debug methods are denied, unless debug endpoints are explicitly enabled, which only
+	// exposes the block tracing ones
+	cfg.Methods.Deny = []string{"debug_*"}
+	if os.Getenv("ENABLE_DEBUG_ENDPOINTS") == "true" {
+		cfg.Methods.Allow = []string{"*", "debug_traceBlock", "debug_traceBlockByNumber"}
+	}
+
+	return cfg.finalize()
+}
+
+// finalize applies the default values of the unset options, and validates the configuration.
+func (c *Config) finalize() (*Config, error) {
+	if c.ListenAddr == "" {
+		c.ListenAddr = defaultListenAddr
+	}
+
+	if c.MaxResponseBytes == 0 {
+		c.MaxResponseBytes = defaultMaxResponseBytes
+	}
+
+	if c.MaxBatchSize == 0 {
+		c.MaxBatchSize = defaultMaxBatchSize
+	}
+
+	if c.HealthCheck.Interval == 0 {
+		c.HealthCheck.Interval = defaultHealthCheckInterval
+	}
+
+	if c.HealthCheck.Timeout == 0 {
+		c.HealthCheck.Timeout = defaultHealthCheckTimeout
+	}
+
+	if c.HealthCheck.MaxBlockLag == 0 {
+		c.HealthCheck.MaxBlockLag = defaultMaxBlockLag
+	}
+
+	if c.Retry.MaxAttempts == 0 {
+		c.Retry.MaxAttempts = defaultMaxAttempts
+	}
+
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Config) validate() error {
+	if c.WebSocket {
+		if _, err := parseUpstreamURLs(c.Upstreams.WebSocket); err != nil {
+			return fmt.Errorf("invalid websocket upstreams: %w", err)
+		}
+	} else {
+		if _, err := parseUpstreamURLs(c.Upstreams.Primary); err != nil {
+			return fmt.Errorf("invalid primary upstreams: %w", err)
+		}
+
+		if _, err := parseUpstreamURLs(c.Upstreams.Secondary); err != nil {
+			return fmt.Errorf("invalid secondary upstreams: %w", err)
+		}
+	}
+
+	if c.HealthCheck.Interval < 0 || c.HealthCheck.Timeout < 0 || c.Retry.MaxAttempts < 0 {
+		return errors.New("health check durations and retry attempts must not be negative")
+	}
+
+	if c.RateLimits.PerIP != nil {
+		if err := c.RateLimits.PerIP.validate(); err != nil {
+			return fmt.Errorf("invalid per IP rate limit: %w", err)
+		}
+	}
+
+	for method, limit := range c.RateLimits.PerMethod {
+		if err := limit.validate(); err != nil {
+			return fmt.Errorf("invalid rate limit for method %s: %w", method, err)
+		}
+	}
+
+	if c.MaxResponseBytes < 0 || c.MaxBatchSize < 0 {
+		return errors.New("maxResponseBytes and maxBatchSize must not be negative")
+	}
+
+	return nil
+}
+
+func (l RateLimit) validate() error {
+	if l.Rate <= 0 || l.Burst <= 0 {
+		return errors.New("rate and burst must be positive")
+	}
+
+	return nil
+}
+
+func parseUpstreamURLs(urls []string) ([]*url.URL, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no upstream configured")
+	}
+
+	parsed := make([]*url.URL, len(urls))
+	for i, s := range urls {
+		u, err := parseUpstreamURL(s)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed[i] = u
+	}
+
+	return parsed, nil
+}
+
+func parseUpstreamURL(s string) (*url.URL, error) {
+	if s == "" {
+		return nil, errors.New("empty URL")
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("URL %q must have a scheme and host", s)
+	}
+
+	return u, nil
+}
+
+// splitEnvList splits a comma separated environment variable, skipping empty entries.
+func splitEnvList(s string) []string {
+	var list []string
+
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,525 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var errResponseTooLarge = errors.New("response too large")
+
+// nonIdempotentMethods are never retried on another upstream, since the first upstream might
+// have executed the call before failing.
+var nonIdempotentMethods = map[string]bool{
+	"eth_sendRawTransaction":   true,
+	"eth_sendTransaction":      true,
+	"personal_sendTransaction": true,
+}
+
+// gateway routes JSON-RPC calls to the upstream nodes, enforcing the method policy, rate
+// limits and response size cap.
+type gateway struct {
+	cfg            *Config
+	policy         *methodPolicy
+	limiter        *rateLimiter
+	primaryMethods map[string]bool
+	primary        *upstreamPool
+	secondary      *upstreamPool
+	webSocket      *upstreamPool
+	client         *http.Client
+}
+
+func newGateway(cfg *Config) (*gateway, error) {
+	g := &gateway{
+		cfg:            cfg,
+		policy:         newMethodPolicy(cfg.Methods),
+		limiter:        newRateLimiter(cfg.RateLimits),
+		primaryMethods: make(map[string]bool),
+		client:         http.DefaultClient,
+	}
+
+	for _, method := range cfg.PrimaryMethods {
+		g.primaryMethods[strings.TrimSpace(method)] = true
+	}
+
+	if cfg.WebSocket {
+		urls, err := parseUpstreamURLs(cfg.Upstreams.WebSocket)
+		if err != nil {
+			return nil, err
+		}
+
+		g.webSocket = newUpstreamPool(roleWebSocket, urls)
+
+		return g, nil
+	}
+
+	primaryURLs, err := parseUpstreamURLs(cfg.Upstreams.Primary)
+	if err != nil {
+		return nil, err
+	}
+
+	secondaryURLs, err := parseUpstreamURLs(cfg.Upstreams.Secondary)
+	if err != nil {
+		return nil, err
+	}
+
+	g.primary = newUpstreamPool(rolePrimary, primaryURLs)
+	g.secondary = newUpstreamPool(roleSecondary, secondaryURLs)
+
+	// WebSocket upgrades are also accepted in RPC mode, if WebSocket upstreams are configured
+	if len(cfg.Upstreams.WebSocket) > 0 {
+		urls, err := parseUpstreamURLs(cfg.Upstreams.WebSocket)
+		if err != nil {
+			return nil, err
+		}
+
+		g.webSocket = newUpstreamPool(roleWebSocket, urls)
+	}
+
+	return g, nil
+}
+
+// pools returns all configured upstream pools.
+func (g *gateway) pools() []*upstreamPool {
+	var pools []*upstreamPool
+
+	for _, pool := range []*upstreamPool{g.primary, g.secondary, g.webSocket} {
+		if pool != nil {
+			pools = append(pools, pool)
+		}
+	}
+
+	return pools
+}
+
+// targetFor returns the upstream pool the given method is routed to.
+func (g *gateway) targetFor(method string) *upstreamPool {
+	if g.primaryMethods[method] {
+		return g.primary
+	}
+
+	return g.secondary
+}
+
+// checkCall applies the method policy and rate limits to a single call, returning an error
+// response if the call is rejected.
+func (g *gateway) checkCall(ip string, call *JSONRPCRequest) *JSONRPCResponse {
+	if call.invalid || call.Method == "" {
+		return newErrorResponse(call.ID, errCodeInvalidRequest, "invalid request")
+	}
+
+	if !g.policy.Allowed(call.Method) {
+		return newErrorResponse(
+			call.ID,
+			errCodeMethodNotAllowed,
+			fmt.Sprintf("the method %s does not exist/is not available", call.Method),
+		)
+	}
+
+	if !g.limiter.Allow(ip, call.Method) {
+		return newErrorResponse(call.ID, errCodeRateLimited, "rate limit exceeded")
+	}
+
+	return nil
+}
+
+func (g *gateway) rootHandler(w http.ResponseWriter, r *http.Request) {
+	// Check for WebSocket Upgrade
+	if strings.ToLower(r.Header.Get("Upgrade")) == "websocket" {
+		if g.webSocket == nil {
+			http.Error(w, "WebSocket is not supported", http.StatusBadRequest)
+			return
+		}
+
+		g.handleWebSocket(w, r)
+
+		return
+	}
+
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if len(bodyBytes) == 0 {
+		w.Write([]byte("OK"))
+		return
+	}
+
+	calls, isBatch, err := parseJSONRPCRequests(bodyBytes)
+	if err != nil {
+		writeJSON(w, newErrorResponse(nil, errCodeParseError, "parse error"))
+		return
+	}
+
+	if isBatch && g.cfg.MaxBatchSize > 0 && len(calls) > g.cfg.MaxBatchSize {
+		writeJSON(w, newErrorResponse(
+			nil,
+			errCodeInvalidRequest,
+			fmt.Sprintf("batch size %d exceeds the limit of %d", len(calls), g.cfg.MaxBatchSize),
+		))
+
+		return
+	}
+
+	ip := clientIP(r, g.cfg.TrustForwardedFor)
+
+	if !isBatch {
+		if errResp := g.checkCall(ip, calls[0]); errResp != nil {
+			writeJSON(w, errResp)
+			return
+		}
+
+		// Forward the original JSON payload as-is to the target URL
+		g.forwardRequest(
+			w,
+			r,
+			g.targetFor(calls[0].Method),
+			bodyBytes,
+			calls[0].ID,
+			!nonIdempotentMethods[calls[0].Method],
+		)
+
+		return
+	}
+
+	g.handleBatch(w, r, ip, calls)
+}
+
+// handleBatch splits a batch into one sub-batch per upstream, forwards the sub-batches and
+// reassembles the responses in the order of the original calls.
+func (g *gateway) handleBatch(w http.ResponseWriter, r *http.Request, ip string, calls []*JSONRPCRequest) {
+	responses := make([]*JSONRPCResponse, len(calls))
+	groups := make(map[*upstreamPool][]int)
+
+	for i, call := range calls {
+		if errResp := g.checkCall(ip, call); errResp != nil {
+			responses[i] = errResp
+			continue
+		}
+
+		target := g.targetFor(call.Method)
+		groups[target] = append(groups[target], i)
+	}
+
+	// Each sub-batch writes to distinct indices, so they can be forwarded concurrently
+	var wg sync.WaitGroup
+	for target, indices := range groups {
+		wg.Add(1)
+
+		go func(target *upstreamPool, indices []int) {
+			defer wg.Done()
+			g.forwardBatch(r, target, calls, indices, responses)
+		}(target, indices)
+	}
+
+	wg.Wait()
+
+	// Notifications get no response
+	out := make([]*JSONRPCResponse, 0, len(calls))
+	for i, call := range calls {
+		if call.isNotification() {
+			continue
+		}
+
+		out = append(out, responses[i])
+	}
+
+	if len(out) == 0 {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	writeJSON(w, out)
+}
+
+// forwardBatch sends the calls at the given indices as a single batch to the target, and
+// stores their responses at the same indices. The call IDs are replaced by their index in
+// the original batch, so that duplicated client IDs can still be matched unambiguously.
+func (g *gateway) forwardBatch(
+	r *http.Request,
+	target *upstreamPool,
+	calls []*JSONRPCRequest,
+	indices []int,
+	responses []*JSONRPCResponse,
+) {
+	idempotent := true
+	batch := make([]*JSONRPCRequest, len(indices))
+
+	for i, idx := range indices {
+		if nonIdempotentMethods[calls[idx].Method] {
+			idempotent = false
+		}
+
+		call := *calls[idx]
+		if !call.isNotification() {
+			call.ID = json.RawMessage(fmt.Sprintf("%d", idx))
+		}
+
+		batch[i] = &call
+	}
+
+	failAll := func(code int, message string) {
+		for _, idx := range indices {
+			responses[idx] = newErrorResponse(calls[idx].ID, code, message)
+		}
+	}
+
+	body, err := json.Marshal(batch)
+	if err != nil {
+		failAll(errCodeUpstreamError, "failed to encode request")
+		return
+	}
+
+	_, respBody, err := g.doUpstream(r.Context(), r, target, body, idempotent)
+	if err != nil {
+		if errors.Is(err, errResponseTooLarge) {
+			failAll(errCodeResponseTooLarge, "response too large")
+		} else {
+			log.Printf("Failed to forward batch to %s upstreams: %v", target.role, err)
+			failAll(errCodeUpstreamError, "failed to reach target server")
+		}
+
+		return
+	}
+
+	var upstreamResponses []*JSONRPCResponse
+	if err := json.Unmarshal(respBody, &upstreamResponses); err != nil {
+		// The upstream might reject the whole batch with a single error object
+		var single JSONRPCResponse
+		if err := json.Unmarshal(respBody, &single); err == nil && single.Error != nil {
+			failAll(single.Error.Code, single.Error.Message)
+		} else {
+			failAll(errCodeUpstreamError, "invalid response from target server")
+		}
+
+		return
+	}
+
+	inGroup := make(map[int]bool, len(indices))
+	for _, idx := range indices {
+		inGroup[idx] = true
+	}
+
+	for _, resp := range upstreamResponses {
+		var idx int
+		if resp == nil || json.Unmarshal(resp.ID, &idx) != nil || !inGroup[idx] {
+			continue
+		}
+
+		resp.ID = calls[idx].ID
+		responses[idx] = resp
+	}
+
+	for _, idx := range indices {
+		if responses[idx] == nil && !calls[idx].isNotification() {
+			responses[idx] = newErrorResponse(calls[idx].ID, errCodeUpstreamError, "missing response from target server")
+		}
+	}
+}
+
+// Function to forward the request to the target URL
+func (g *gateway) forwardRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	target *upstreamPool,
+	bodyBytes []byte,
+	id json.RawMessage,
+	idempotent bool,
+) {
+	resp, respBody, err := g.doUpstream(r.Context(), r, target, bodyBytes, idempotent)
+	if err != nil {
+		if errors.Is(err, errResponseTooLarge) {
+			writeJSON(w, newErrorResponse(id, errCodeResponseTooLarge, "response too large"))
+			return
+		}
+
+		log.Printf("Failed to forward request to %s upstreams: %v", target.role, err)
+		http.Error(w, "Failed to reach target server", http.StatusBadGateway)
+
+		return
+	}
+
+	// Copy headers from the response
+	for name, values := range resp.Header {
+		switch name {
+		case "Content-Length", "Transfer-Encoding", "Connection":
+			// Skip these headers
+			continue
+		default:
+			for _, value := range values {
+				w.Header().Add(name, value)
+			}
+		}
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json") // default if not provided
+	}
+
+	w.WriteHeader(resp.StatusCode)
+
+	if _, err := w.Write(respBody); err != nil {
+		log.Printf("Error writing response: %v", err)
+	}
+}
+
+// doUpstream sends the body to an upstream of the pool, returning the response and its body.
+// Idempotent requests fail over to the next upstream on connection errors and gateway errors,
+// up to the configured number of attempts.
+func (g *gateway) doUpstream(
+	ctx context.Context,
+	r *http.Request,
+	pool *upstreamPool,
+	bodyBytes []byte,
+	idempotent bool,
+) (*http.Response, []byte, error) {
+	maxAttempts := g.cfg.Retry.MaxAttempts
+	if !idempotent || maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+
+	for i, u := range pool.candidates() {
+		if i >= maxAttempts || ctx.Err() != nil {
+			break
+		}
+
+		if i > 0 {
+			upstreamFailovers.WithLabelValues(pool.role).Inc()
+			log.Printf("Retrying request on %s upstream %s: %v", pool.role, u.name, lastErr)
+		}
+
+		resp, respBody, err := g.sendUpstream(ctx, r, u, bodyBytes)
+		if err == nil {
+			return resp, respBody, nil
+		}
+
+		// An oversized response would be just as large on any other upstream
+		if errors.Is(err, errResponseTooLarge) {
+			return nil, nil, err
+		}
+
+		lastErr = err
+	}
+
+	if lastErr == nil {
+		lastErr = ctx.Err()
+	}
+
+	return nil, nil, lastErr
+}
+
+// sendUpstream sends the body to a single upstream. The response body is read up to the
+// configured maximum response size. Gateway errors of the upstream are returned as errors,
+// so that they can be retried.
+func (g *gateway) sendUpstream(
+	ctx context.Context,
+	r *http.Request,
+	u *upstream,
+	bodyBytes []byte,
+) (*http.Response, []byte, error) {
+	proxyReq, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		u.url.String()+r.URL.RequestURI(),
+		bytes.NewReader(bodyBytes),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Copy headers from the original request, excluding Accept-Encoding and Content-Length
+	for name, values := range r.Header {
+		if name == "Accept-Encoding" || name == "Content-Length" {
+			continue
+		}
+
+		for _, value := range values {
+			proxyReq.Header.Add(name, value)
+		}
+	}
+
+	proxyReq.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+
+	resp, err := g.client.Do(proxyReq)
+	if err != nil {
+		upstreamRequests.WithLabelValues(u.role, u.name, "error").Inc()
+
+		// A cancelled client request says nothing about the health of the upstream
+		if ctx.Err() == nil {
+			u.markFailed("connection")
+		}
+
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := readLimited(resp.Body, g.cfg.MaxResponseBytes)
+
+	upstreamLatency.WithLabelValues(u.role, u.name).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		if errors.Is(err, errResponseTooLarge) {
+			upstreamErrors.WithLabelValues(u.role, u.name, "response_too_large").Inc()
+		}
+
+		upstreamRequests.WithLabelValues(u.role, u.name, "error").Inc()
+
+		return nil, nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		upstreamRequests.WithLabelValues(u.role, u.name, "error").Inc()
+		u.markFailed("status_" + strconv.Itoa(resp.StatusCode))
+
+		return nil, nil, fmt.Errorf("upstream %s responded with status code %d", u.name, resp.StatusCode)
+	}
+
+	upstreamRequests.WithLabelValues(u.role, u.name, "success").Inc()
+
+	return resp, respBody, nil
+}
+
+// readLimited reads the whole reader, returning errResponseTooLarge if it holds more than
+// limit bytes. A limit of zero disables the check.
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > limit {
+		return nil, errResponseTooLarge
+	}
+
+	return body, nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Error writing response: %v", err)
+	}
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newTestUpstream starts an upstream answering every call with its own name as the result,
+// and counting the received requests.
+func newTestUpstream(t *testing.T, name string) (*httptest.Server, *atomic.Int64) {
+	var requests atomic.Int64
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		calls, isBatch, err := parseJSONRPCRequests(readBody(t, r))
+		if err != nil {
+			t.Errorf("upstream %s received an invalid request: %v", name, err)
+			return
+		}
+
+		responses := make([]*JSONRPCResponse, len(calls))
+		for i, call := range calls {
+			responses[i] = &JSONRPCResponse{
+				JSONRPC: "2.0",
+				ID:      call.ID,
+				Result:  mustMarshal(name + ":" + call.Method),
+			}
+		}
+
+		if isBatch {
+			writeJSON(w, responses)
+		} else {
+			writeJSON(w, responses[0])
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func readBody(t *testing.T, r *http.Request) []byte {
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r.Body); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func newTestGateway(t *testing.T, cfg *Config) *gateway {
+	cfg, err := cfg.finalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := newGateway(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g
+}
+
+// post sends the body to the gateway, and returns the decoded JSON-RPC responses.
+func post(t *testing.T, g *gateway, body string) []*JSONRPCResponse {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.RemoteAddr = "127.0.0.1:1234"
+
+	rec := httptest.NewRecorder()
+	g.rootHandler(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", rec.Code)
+	}
+
+	var responses []*JSONRPCResponse
+	if strings.HasPrefix(strings.TrimSpace(body), "[") {
+		if err := json.Unmarshal(rec.Body.Bytes(), &responses); err != nil {
+			t.Fatalf("invalid batch response %s: %v", rec.Body.String(), err)
+		}
+
+		return responses
+	}
+
+	var single JSONRPCResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &single); err != nil {
+		t.Fatalf("invalid response %s: %v", rec.Body.String(), err)
+	}
+
+	return []*JSONRPCResponse{&single}
+}
+
+func TestGatewayRouting(t *testing.T) {
+	primary, primaryRequests := newTestUpstream(t, "primary")
+	secondary, secondaryRequests := newTestUpstream(t, "secondary")
+
+	g := newTestGateway(t, &Config{
+		Upstreams: UpstreamsConfig{
+			Primary:   []string{primary.URL},
+			Secondary: []string{secondary.URL},
+		},
+		PrimaryMethods: []string{"eth_sendRawTransaction"},
+		Methods:        MethodsConfig{Deny: []string{"debug_*"}},
+		MaxBatchSize:   3,
+	})
+
+	type result struct {
+		id     string
+		result string
+		code   int
+	}
+
+	tests := []struct {
+		name      string
+		body      string
+		want      []result
+		primary   int64
+		secondary int64
+	}{
+		{
+			"single primary call",
+			`{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction"}`,
+			[]result{{"1", "primary:eth_sendRawTransaction", 0}},
+			1,
+			0,
+		},
+		{
+			"single secondary call",
+			`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`,
+			[]result{{"1", "secondary:eth_blockNumber", 0}},
+			0,
+			1,
+		},
+		{
+			"single denied call",
+			`{"jsonrpc":"2.0","id":1,"method":"debug_traceCall"}`,
+			[]result{{"1", "", errCodeMethodNotAllowed}},
+			0,
+			0,
+		},
+		{
+			"mixed batch keeps the call order and IDs",
+			`[{"jsonrpc":"2.0","id":"a","method":"eth_blockNumber"},` +
+				`{"jsonrpc":"2.0","id":"b","method":"eth_sendRawTransaction"},` +
+				`{"jsonrpc":"2.0","id":"a","method":"eth_chainId"}]`,
+			[]result{
+				{`"a"`, "secondary:eth_blockNumber", 0},
+				{`"b"`, "primary:eth_sendRawTransaction", 0},
+				{`"a"`, "secondary:eth_chainId", 0},
+			},
+			1,
+			1,
+		},
+		{
+			"batch with a denied call",
+			`[{"jsonrpc":"2.0","id":1,"method":"debug_traceCall"},{"jsonrpc":"2.0","id":2,"method":"eth_chainId"}]`,
+			[]result{
+				{"1", "", errCodeMethodNotAllowed},
+				{"2", "secondary:eth_chainId", 0},
+			},
+			0,
+			1,
+		},
+		{
+			"batch without responses for notifications",
+			`[{"jsonrpc":"2.0","method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"eth_chainId"}]`,
+			[]result{{"2", "secondary:eth_chainId", 0}},
+			0,
+			1,
+		},
+		{
+			"batch with a null call",
+			`[null]`,
+			[]result{{"null", "", errCodeInvalidRequest}},
+			0,
+			0,
+		},
+		{
+			"batch with calls which are not objects",
+			`[1,{"jsonrpc":"2.0","id":2,"method":"eth_chainId"},"a"]`,
+			[]result{
+				{"null", "", errCodeInvalidRequest},
+				{"2", "secondary:eth_chainId", 0},
+				{"null", "", errCodeInvalidRequest},
+			},
+			0,
+			1,
+		},
+		{
+			"batch too large",
+			`[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"eth_chainId"},` +
+				`{"jsonrpc":"2.0","id":3,"method":"eth_chainId"},{"jsonrpc":"2.0","id":4,"method":"eth_chainId"}]`,
+			nil,
+			0,
+			0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primaryRequests.Store(0)
+			secondaryRequests.Store(0)
+
+			if tt.want == nil {
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+				rec := httptest.NewRecorder()
+				g.rootHandler(rec, req)
+
+				if !strings.Contains(rec.Body.String(), "exceeds the limit") {
+					t.Fatalf("expected batch size error, got %s", rec.Body.String())
+				}
+			} else {
+				responses := post(t, g, tt.body)
+				if len(responses) != len(tt.want) {
+					t.Fatalf("expected %d responses, got %d", len(tt.want), len(responses))
+				}
+
+				for i, want := range tt.want {
+					resp := responses[i]
+					if string(resp.ID) != want.id {
+						t.Fatalf("response %d: expected ID %s, got %s", i, want.id, resp.ID)
+					}
+
+					if want.code != 0 {
+						if resp.Error == nil || resp.Error.Code != want.code {
+							t.Fatalf("response %d: expected error code %d, got %+v", i, want.code, resp.Error)
+						}
+
+						continue
+					}
+
+					var result string
+					if err := json.Unmarshal(resp.Result, &result); err != nil || result != want.result {
+						t.Fatalf("response %d: expected result %s, got %s", i, want.result, resp.Result)
+					}
+				}
+			}
+
+			if primaryRequests.Load() != tt.primary || secondaryRequests.Load() != tt.secondary {
+				t.Fatalf(
+					"expected %d primary and %d secondary requests, got %d and %d",
+					tt.primary, tt.secondary, primaryRequests.Load(), secondaryRequests.Load(),
+				)
+			}
+		})
+	}
+}
+
+func TestGatewayRateLimit(t *testing.T) {
+	upstream, requests := newTestUpstream(t, "upstream")
+
+	g := newTestGateway(t, &Config{
+		Upstreams: UpstreamsConfig{
+			Primary:   []string{upstream.URL},
+			Secondary: []string{upstream.URL},
+		},
+		RateLimits: RateLimitsConfig{PerIP: &RateLimit{Rate: 0.001, Burst: 2}},
+	})
+
+	// every call of a batch consumes a token.
+	responses := post(
+		t,
+		g,
+		`[{"jsonrpc":"2.0","id":1,"method":"eth_chainId"},{"jsonrpc":"2.0","id":2,"method":"eth_chainId"}]`,
+	)
+	for _, resp := range responses {
+		if resp.Error != nil {
+			t.Fatalf("expected no error, got %+v", resp.Error)
+		}
+	}
+
+	responses = post(t, g, `{"jsonrpc":"2.0","id":3,"method":"eth_chainId"}`)
+	if responses[0].Error == nil || responses[0].Error.Code != errCodeRateLimited {
+		t.Fatalf("expected rate limit error, got %+v", responses[0])
+	}
+
+	if requests.Load() != 1 {
+		t.Fatalf("expected 1 upstream request, got %d", requests.Load())
+	}
+}
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	t.Setenv("TARGET_URL_PRIMARY", "http://primary:8545")
+	t.Setenv("TARGET_URL_SECONDARY", "http://secondary:8545")
+	t.Setenv("WEBSOCKET_TARGET_URL", "ws://primary:8546")
+	t.Setenv("PRIMARY_METHODS", "eth_sendRawTransaction, eth_getTransactionCount,")
+	t.Setenv("ENABLE_DEBUG_ENDPOINTS", "true")
+
+	cfg, err := loadConfigFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.WebSocket || cfg.ListenAddr != defaultListenAddr {
+		t.Fatalf("unexpected mode or listen address: %+v", cfg)
+	}
+
+	if len(cfg.PrimaryMethods) != 2 || cfg.PrimaryMethods[1] != "eth_getTransactionCount" {
+		t.Fatalf("unexpected primary methods: %v", cfg.PrimaryMethods)
+	}
+
+	policy := newMethodPolicy(cfg.Methods)
+	for method, allowed := range map[string]bool{
+		"eth_call":                 true,
+		"debug_traceBlock":         true,
+		"debug_traceBlockByNumber": true,
+		"debug_traceTransaction":   false,
+	} {
+		if policy.Allowed(method) != allowed {
+			t.Fatalf("expected %s allowed %v", method, allowed)
+		}
+	}
+
+	// The debug methods are denied by default
+	for _, enabled := range []string{"", "false", "1"} {
+		t.Setenv("ENABLE_DEBUG_ENDPOINTS", enabled)
+
+		if cfg, err = loadConfigFromEnv(); err != nil {
+			t.Fatal(err)
+		}
+
+		policy := newMethodPolicy(cfg.Methods)
+		for method, allowed := range map[string]bool{
+			"eth_call":               true,
+			"debug_traceBlock":       false,
+			"debug_traceTransaction": false,
+		} {
+			if policy.Allowed(method) != allowed {
+				t.Fatalf("ENABLE_DEBUG_ENDPOINTS=%q: expected %s allowed %v", enabled, method, allowed)
+			}
+		}
+	}
+
+	t.Setenv("TARGET_URL_PRIMARY", "")
+
+	if _, err := loadConfigFromEnv(); err == nil {
+		t.Fatal("expected error for missing primary URL")
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// healthCheckRequest asks an upstream for its sync status and latest block in one batch.
+var healthCheckRequest = []byte(`[` +
+	`{"jsonrpc":"2.0","id":0,"method":"eth_syncing","params":[]},` +
+	`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}` +
+	`]`)
+
+// healthStatus is the result of a single upstream health check.
+type healthStatus struct {
+	syncing     bool
+	blockNumber uint64
+}
+
+// healthChecker periodically checks all upstreams, an upstream is healthy if it responds,
+// is not syncing and is at most maxBlockLag blocks behind the highest upstream.
+type healthChecker struct {
+	cfg       HealthCheckConfig
+	upstreams []*upstream
+	client    *http.Client
+}
+
+func newHealthChecker(cfg HealthCheckConfig, pools ...*upstreamPool) *healthChecker {
+	h := &healthChecker{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+
+	for _, pool := range pools {
+		if pool != nil {
+			h.upstreams = append(h.upstreams, pool.upstreams...)
+		}
+	}
+
+	return h
+}
+
+// Start checks the upstreams every interval until the context is cancelled.
+func (h *healthChecker) Start(ctx context.Context) {
+	h.checkAll(ctx)
+
+	ticker := time.NewTicker(h.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.checkAll(ctx)
+		}
+	}
+}
+
+func (h *healthChecker) checkAll(ctx context.Context) {
+	statuses := make([]*healthStatus, len(h.upstreams))
+
+	var wg sync.WaitGroup
+	for i, u := range h.upstreams {
+		wg.Add(1)
+
+		go func(i int, u *upstream) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, h.cfg.Timeout)
+			defer cancel()
+
+			status, err := h.check(ctx, u)
+			if err != nil {
+				log.Printf("Health check of %s upstream %s failed: %v", u.role, u.name, err)
+				upstreamErrors.WithLabelValues(u.role, u.name, "health_check").Inc()
+
+				return
+			}
+
+			statuses[i] = status
+		}(i, u)
+	}
+
+	wg.Wait()
+
+	// All upstreams serve the same chain, so their heights are compared across roles
+	var highest uint64
+	for _, status := range statuses {
+		if status != nil && status.blockNumber > highest {
+			highest = status.blockNumber
+		}
+	}
+
+	for i, u := range h.upstreams {
+		status := statuses[i]
+		if status == nil {
+			u.setHealthy(false)
+			continue
+		}
+
+		u.blockNumber.Store(status.blockNumber)
+		upstreamBlockNumber.WithLabelValues(u.role, u.name).Set(float64(status.blockNumber))
+
+		healthy := !status.syncing && highest-status.blockNumber <= h.cfg.MaxBlockLag
+		if healthy != u.healthy.Load() {
+			log.Printf(
+				"Upstream %s (%s) is now healthy=%v, syncing=%v, block=%d, highest=%d",
+				u.name, u.role, healthy, status.syncing, status.blockNumber, highest,
+			)
+		}
+
+		u.setHealthy(healthy)
+	}
+}
+
+// check sends the health check request to the upstream, over HTTP or WebSocket depending on
+// the upstream URL.
+func (h *healthChecker) check(ctx context.Context, u *upstream) (*healthStatus, error) {
+	var (
+		body []byte
+		err  error
+	)
+
+	if strings.HasPrefix(u.url.Scheme, "ws") {
+		body, err = h.callWebSocket(ctx, u)
+	} else {
+		body, err = h.callHTTP(ctx, u)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []*JSONRPCResponse
+	if err := json.Unmarshal(body, &responses); err != nil {
+		return nil, fmt.Errorf("invalid health check response: %w", err)
+	}
+
+	status := &healthStatus{}
+	found := 0
+
+	for _, resp := range responses {
+		if resp.Error != nil {
+			return nil, fmt.Errorf("health check call failed: %s", resp.Error.Message)
+		}
+
+		switch string(resp.ID) {
+		case "0":
+			// eth_syncing returns false, or an object describing the sync progress
+			status.syncing = string(bytes.TrimSpace(resp.Result)) != "false"
+			found++
+		case "1":
+			var hex string
+			if err := json.Unmarshal(resp.Result, &hex); err != nil {
+				return nil, fmt.Errorf("invalid block number: %w", err)
+			}
+
+			if status.blockNumber, err = strconv.ParseUint(strings.TrimPrefix(hex, "0x"), 16, 64); err != nil {
+				return nil, fmt.Errorf("invalid block number: %w", err)
+			}
+
+			found++
+		}
+	}
+
+	if found != 2 {
+		return nil, errors.New("incomplete health check response")
+	}
+
+	return status, nil
+}
+
+func (h *healthChecker) callHTTP(ctx context.Context, u *upstream) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.url.String(), bytes.NewReader(healthCheckRequest))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return readLimited(resp.Body, defaultMaxResponseBytes)
+}
+
+func (h *healthChecker) callWebSocket(ctx context.Context, u *upstream) ([]byte, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, u.url.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := conn.WriteMessage(websocket.TextMessage, healthCheckRequest); err != nil {
+		return nil, err
+	}
+
+	_, message, err := conn.ReadMessage()
+
+	return message, err
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// JSON-RPC error codes returned by the gateway itself.
+const (
+	errCodeParseError       = -32700
+	errCodeInvalidRequest   = -32600
+	errCodeMethodNotAllowed = -32601
+	errCodeUpstreamError    = -32603
+	errCodeRateLimited      = -32005
+	errCodeResponseTooLarge = -32008
+)
+
+var errEmptyBatch = errors.New("empty batch")
+
+// JSONRPCRequest is a single JSON-RPC call.
+type JSONRPCRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+
+	// invalid is set for a batch element which is not a call object, it is answered with an
+	// invalid request error.
+	invalid bool
+}
+
+// isNotification returns whether the call is a notification, which gets no response.
+func (r *JSONRPCRequest) isNotification() bool {
+	return !r.invalid && len(r.ID) == 0
+}
+
+// JSONRPCResponse is a single JSON-RPC response.
+type JSONRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *JSONRPCError   `json:"error,omitempty"`
+}
+
+// JSONRPCError is a JSON-RPC error object.
+type JSONRPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// newErrorResponse creates an error response for the call with the given ID.
+func newErrorResponse(id json.RawMessage, code int, message string) *JSONRPCResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+
+	return &JSONRPCResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   &JSONRPCError{Code: code, Message: message},
+	}
+}
+
+// parseJSONRPCRequests parses a request body, returning the calls and whether the body was
+// a batch.
+func parseJSONRPCRequests(body []byte) ([]*JSONRPCRequest, bool, error) {
+	body = bytes.TrimSpace(body)
+
+	if len(body) > 0 && body[0] == '[' {
+		var elements []json.RawMessage
+		if err := json.Unmarshal(body, &elements); err != nil {
+			return nil, true, err
+		}
+
+		if len(elements) == 0 {
+			return nil, true, errEmptyBatch
+		}
+
+		// Elements which are not call objects, e.g. null, are kept as invalid calls, so that
+		// each of them gets its own error response
+		batch := make([]*JSONRPCRequest, len(elements))
+		for i, element := range elements {
+			call := new(JSONRPCRequest)
+			if element[0] != '{' || json.Unmarshal(element, call) != nil {
+				call = &JSONRPCRequest{invalid: true}
+			}
+
+			batch[i] = call
+		}
+
+		return batch, true, nil
+	}
+
+	var single JSONRPCRequest
+	if err := json.Unmarshal(body, &single); err != nil {
+		return nil, false, err
+	}
+
+	return []*JSONRPCRequest{&single}, false, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import "strings"
+
+// methodPolicy decides which JSON-RPC methods the gateway forwards.
+//
+// The most specific matching entry decides: an exact method name is more specific than any
+// wildcard, and a longer wildcard prefix is more specific than a shorter one. If an allow
+// and a deny entry are equally specific, deny wins. A method matching no entry is allowed
+// only if the allow list is empty.
+type methodPolicy struct {
+	allow []string
+	deny  []string
+}
+
+func newMethodPolicy(cfg MethodsConfig) *methodPolicy {
+	return &methodPolicy{allow: cfg.Allow, deny: cfg.Deny}
+}
+
+// Allowed returns whether the given method may be forwarded.
+func (p *methodPolicy) Allowed(method string) bool {
+	allowScore := bestMatch(p.allow, method)
+	denyScore := bestMatch(p.deny, method)
+
+	if allowScore < 0 && denyScore < 0 {
+		return len(p.allow) == 0
+	}
+
+	return allowScore > denyScore
+}
+
+// bestMatch returns the specificity of the most specific pattern matching the method,
+// or -1 if none matches.
+func bestMatch(patterns []string, method string) int {
+	best := -1
+
+	for _, pattern := range patterns {
+		score := -1
+
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(method, prefix) {
+				score = len(prefix)
+			}
+		} else if pattern == method {
+			// exact matches always beat wildcards.
+			score = len(method) + 1
+		}
+
+		if score > best {
+			best = score
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestMethodPolicyAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     MethodsConfig
+		method  string
+		allowed bool
+	}{
+		{"empty policy", MethodsConfig{}, "eth_call", true},
+		{"denied prefix", MethodsConfig{Deny: []string{"debug_*"}}, "debug_traceTransaction", false},
+		{"not denied", MethodsConfig{Deny: []string{"debug_*"}}, "eth_call", true},
+		{"not in allow list", MethodsConfig{Allow: []string{"eth_*"}}, "net_version", false},
+		{"in allow list", MethodsConfig{Allow: []string{"eth_*"}}, "eth_call", true},
+		{
+			"exact allow beats denied prefix",
+			MethodsConfig{Allow: []string{"debug_traceBlock"}, Deny: []string{"debug_*"}},
+			"debug_traceBlock",
+			true,
+		},
+		{
+			"longer denied prefix beats allowed prefix",
+			MethodsConfig{Allow: []string{"*"}, Deny: []string{"debug_*"}},
+			"debug_traceCall",
+			false,
+		},
+		{
+			"deny wins ties",
+			MethodsConfig{Allow: []string{"eth_call"}, Deny: []string{"eth_call"}},
+			"eth_call",
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if allowed := newMethodPolicy(tt.cfg).Allowed(tt.method); allowed != tt.allowed {
+				t.Fatalf("expected allowed %v, got %v", tt.allowed, allowed)
+			}
+		})
+	}
+}
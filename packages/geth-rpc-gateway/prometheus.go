@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	upstreamRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_upstream_requests_ops_total",
+		Help: "The total number of requests sent to each upstream, by result",
+	}, []string{"role", "upstream", "result"})
+	upstreamLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gateway_upstream_request_duration_seconds",
+		Help:    "The latency of requests sent to each upstream",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"role", "upstream"})
+	upstreamErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_upstream_errors_ops_total",
+		Help: "The total number of upstream errors, by reason",
+	}, []string{"role", "upstream", "reason"})
+	upstreamHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gateway_upstream_healthy",
+		Help: "Whether the upstream passed its last health check",
+	}, []string{"role", "upstream"})
+	upstreamBlockNumber = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gateway_upstream_block_number",
+		Help: "The latest block number reported by the upstream",
+	}, []string{"role", "upstream"})
+	upstreamFailovers = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_upstream_failovers_ops_total",
+		Help: "The total number of calls retried on another upstream",
+	}, []string{"role"})
+	webSocketReconnects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gateway_websocket_reconnects_ops_total",
+		Help: "The total number of WebSocket sessions moved to another upstream, by result",
+	}, []string{"result"})
+	webSocketSessions = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "gateway_websocket_sessions",
+		Help: "The number of open WebSocket sessions",
+	})
+)
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const limiterIdleTimeout = 10 * time.Minute
+
+// rateLimiter applies token-bucket rate limits per client IP, and per client IP and method.
+type rateLimiter struct {
+	cfg RateLimitsConfig
+
+	mu       sync.Mutex
+	limiters map[string]*limiterEntry
+}
+
+type limiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newRateLimiter(cfg RateLimitsConfig) *rateLimiter {
+	l := &rateLimiter{
+		cfg:      cfg,
+		limiters: make(map[string]*limiterEntry),
+	}
+
+	go l.cleanupLoop()
+
+	return l
+}
+
+// Allow consumes one token from the client IP bucket and from the client IP / method bucket,
+// and returns whether the call is within both limits. No token is consumed if either limit
+// is exceeded.
+func (l *rateLimiter) Allow(ip string, method string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+
+	var reservations []*rate.Reservation
+
+	if methodLimit, ok := l.cfg.PerMethod[method]; ok {
+		reservations = append(reservations, l.get(ip+"|"+method, methodLimit, now).ReserveN(now, 1))
+	}
+
+	if l.cfg.PerIP != nil {
+		reservations = append(reservations, l.get(ip, *l.cfg.PerIP, now).ReserveN(now, 1))
+	}
+
+	for _, r := range reservations {
+		if !r.OK() || r.DelayFrom(now) > 0 {
+			for _, r := range reservations {
+				r.CancelAt(now)
+			}
+
+			return false
+		}
+	}
+
+	return true
+}
+
+func (l *rateLimiter) get(key string, limit RateLimit, now time.Time) *rate.Limiter {
+	entry, ok := l.limiters[key]
+	if !ok {
+		entry = &limiterEntry{limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)}
+		l.limiters[key] = entry
+	}
+
+	entry.lastSeen = now
+
+	return entry.limiter
+}
+
+// cleanupLoop drops the buckets of clients which have been idle for a while.
+func (l *rateLimiter) cleanupLoop() {
+	ticker := time.NewTicker(limiterIdleTimeout)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		l.mu.Lock()
+		for key, entry := range l.limiters {
+			if time.Since(entry.lastSeen) > limiterIdleTimeout {
+				delete(l.limiters, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
+
+// clientIP returns the IP of the client which sent the request. When the X-Forwarded-For
+// header is trusted, its right-most entry is used, which is the one appended by the trusted
+// proxy, the entries before it are set by the client.
+func clientIP(r *http.Request, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if values := r.Header.Values("X-Forwarded-For"); len(values) > 0 {
+			entries := strings.Split(values[len(values)-1], ",")
+			if ip := strings.TrimSpace(entries[len(entries)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RateLimitsConfig
+		calls   []string
+		allowed []bool
+	}{
+		{
+			"no limits",
+			RateLimitsConfig{},
+			[]string{"eth_call", "eth_call", "eth_call"},
+			[]bool{true, true, true},
+		},
+		{
+			"per IP",
+			RateLimitsConfig{PerIP: &RateLimit{Rate: 0.001, Burst: 2}},
+			[]string{"eth_call", "eth_getLogs", "eth_call"},
+			[]bool{true, true, false},
+		},
+		{
+			"per method",
+			RateLimitsConfig{PerMethod: map[string]RateLimit{"eth_getLogs": {Rate: 0.001, Burst: 1}}},
+			[]string{"eth_getLogs", "eth_getLogs", "eth_call"},
+			[]bool{true, false, true},
+		},
+		{
+			// the rejected method call must not consume a token of the per IP bucket.
+			"per method rejection keeps per IP tokens",
+			RateLimitsConfig{
+				PerIP:     &RateLimit{Rate: 0.001, Burst: 2},
+				PerMethod: map[string]RateLimit{"eth_getLogs": {Rate: 0.001, Burst: 1}},
+			},
+			[]string{"eth_getLogs", "eth_getLogs", "eth_call", "eth_call"},
+			[]bool{true, false, true, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newRateLimiter(tt.cfg)
+
+			for i, method := range tt.calls {
+				if allowed := l.Allow("127.0.0.1", method); allowed != tt.allowed[i] {
+					t.Fatalf("call %d (%s): expected allowed %v, got %v", i, method, tt.allowed[i], allowed)
+				}
+			}
+
+			// other clients have their own buckets.
+			if !l.Allow("127.0.0.2", tt.calls[0]) {
+				t.Fatal("expected call of another client to be allowed")
+			}
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	r, err := http.NewRequest(http.MethodPost, "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.RemoteAddr = "10.0.0.1:1234"
+	// the client forged the first entry, the trusted proxy appended the second one.
+	r.Header.Set("X-Forwarded-For", "6.6.6.6, 1.2.3.4")
+
+	if ip := clientIP(r, false); ip != "10.0.0.1" {
+		t.Fatalf("expected remote address, got %s", ip)
+	}
+
+	if ip := clientIP(r, true); ip != "1.2.3.4" {
+		t.Fatalf("expected forwarded address, got %s", ip)
+	}
+
+	// a forged header sent separately is ignored as well.
+	r.Header.Set("X-Forwarded-For", "6.6.6.6")
+	r.Header.Add("X-Forwarded-For", "1.2.3.5")
+
+	if ip := clientIP(r, true); ip != "1.2.3.5" {
+		t.Fatalf("expected forwarded address, got %s", ip)
+	}
+
+	r.Header.Del("X-Forwarded-For")
+
+	if ip := clientIP(r, true); ip != "10.0.0.1" {
+		t.Fatalf("expected remote address without forwarded header, got %s", ip)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"net/url"
+	"sync/atomic"
+)
+
+const (
+	rolePrimary   = "primary"
+	roleSecondary = "secondary"
+	roleWebSocket = "websocket"
+)
+
+// upstream is a single upstream node.
+type upstream struct {
+	role string
+	url  *url.URL
+	// name identifies the upstream in logs and metrics, it never contains the URL path or
+	// credentials, which might hold API keys.
+	name string
+
+	healthy     atomic.Bool
+	blockNumber atomic.Uint64
+}
+
+func newUpstream(role string, u *url.URL) *upstream {
+	up := &upstream{role: role, url: u, name: u.Host}
+
+	// Upstreams are considered healthy until the first health check says otherwise.
+	up.setHealthy(true)
+
+	return up
+}
+
+func (u *upstream) setHealthy(healthy bool) {
+	u.healthy.Store(healthy)
+
+	value := 0.0
+	if healthy {
+		value = 1
+	}
+
+	upstreamHealthy.WithLabelValues(u.role, u.name).Set(value)
+}
+
+// markFailed marks the upstream unhealthy after a failed request, it is marked healthy
+// again by the next successful health check.
+func (u *upstream) markFailed(reason string) {
+	upstreamErrors.WithLabelValues(u.role, u.name, reason).Inc()
+	u.setHealthy(false)
+}
+
+// upstreamPool is the set of upstreams serving a role.
+type upstreamPool struct {
+	role      string
+	upstreams []*upstream
+	next      atomic.Uint64
+}
+
+func newUpstreamPool(role string, urls []*url.URL) *upstreamPool {
+	p := &upstreamPool{role: role}
+
+	for _, u := range urls {
+		p.upstreams = append(p.upstreams, newUpstream(role, u))
+	}
+
+	return p
+}
+
+// candidates returns the upstreams to try, in order. Healthy upstreams come first and are
+// rotated round-robin between calls; unhealthy upstreams follow as a last resort, so that
+// a pool whose upstreams all failed their health checks keeps serving if it can.
+func (p *upstreamPool) candidates() []*upstream {
+	start := int(p.next.Add(1) % uint64(len(p.upstreams)))
+
+	healthy := make([]*upstream, 0, len(p.upstreams))
+	unhealthy := make([]*upstream, 0)
+
+	for i := range p.upstreams {
+		u := p.upstreams[(start+i)%len(p.upstreams)]
+		if u.healthy.Load() {
+			healthy = append(healthy, u)
+		} else {
+			unhealthy = append(unhealthy, u)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
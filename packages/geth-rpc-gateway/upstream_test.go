@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newFailingUpstream starts an upstream responding with the given status code to every request.
+func newFailingUpstream(t *testing.T, statusCode int) (*httptest.Server, *atomic.Int64) {
+	var requests atomic.Int64
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(statusCode)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func TestGatewayFailover(t *testing.T) {
+	healthy, healthyRequests := newTestUpstream(t, "healthy")
+	failing, failingRequests := newFailingUpstream(t, http.StatusServiceUnavailable)
+
+	g := newTestGateway(t, &Config{
+		Upstreams: UpstreamsConfig{
+			Primary:   []string{failing.URL, healthy.URL},
+			Secondary: []string{failing.URL, healthy.URL},
+		},
+		PrimaryMethods: []string{"eth_sendRawTransaction"},
+	})
+
+	// round-robin might start at either upstream, the failing one is tried at most once.
+	responses := post(t, g, `{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`)
+	if responses[0].Error != nil || string(responses[0].Result) != `"healthy:eth_chainId"` {
+		t.Fatalf("expected the call to fail over to the healthy upstream, got %+v", responses[0])
+	}
+
+	if healthyRequests.Load() != 1 || failingRequests.Load() > 1 {
+		t.Fatalf("unexpected upstream requests: healthy %d, failing %d", healthyRequests.Load(), failingRequests.Load())
+	}
+
+	if failingRequests.Load() == 1 && g.secondary.upstreams[0].healthy.Load() {
+		t.Fatal("expected the failing upstream to be marked unhealthy")
+	}
+
+	// the failing upstreams are marked unhealthy, and are not tried first anymore.
+	g.secondary.upstreams[0].setHealthy(false)
+	failingRequests.Store(0)
+
+	for i := 0; i < 4; i++ {
+		post(t, g, `{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`)
+	}
+
+	if failingRequests.Load() != 0 {
+		t.Fatalf("expected unhealthy upstream to be skipped, got %d requests", failingRequests.Load())
+	}
+}
+
+func TestGatewayDoesNotRetryTransactions(t *testing.T) {
+	failing, failingRequests := newFailingUpstream(t, http.StatusBadGateway)
+	other, otherRequests := newFailingUpstream(t, http.StatusBadGateway)
+
+	g := newTestGateway(t, &Config{
+		Upstreams: UpstreamsConfig{
+			Primary:   []string{failing.URL, other.URL},
+			Secondary: []string{failing.URL, other.URL},
+		},
+		PrimaryMethods: []string{"eth_sendRawTransaction"},
+	})
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/",
+		strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["0x00"]}`),
+	)
+	rec := httptest.NewRecorder()
+
+	g.rootHandler(rec, req)
+
+	if rec.Code != http.StatusBadGateway {
+		t.Fatalf("expected status code 502, got %d", rec.Code)
+	}
+
+	if total := failingRequests.Load() + otherRequests.Load(); total != 1 {
+		t.Fatalf("expected a single upstream request, got %d", total)
+	}
+}
+
+func TestHealthCheckerMarksLaggingUpstreams(t *testing.T) {
+	newNode := func(syncing bool, blockNumber uint64) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(
+				w,
+				`[{"jsonrpc":"2.0","id":0,"result":%v},{"jsonrpc":"2.0","id":1,"result":"0x%x"}]`,
+				syncing, blockNumber,
+			)
+		}))
+
+		t.Cleanup(srv.Close)
+
+		return srv
+	}
+
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	urls, err := parseUpstreamURLs([]string{
+		newNode(false, 100).URL,
+		newNode(false, 95).URL,
+		newNode(false, 80).URL,
+		newNode(true, 100).URL,
+		down.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := newUpstreamPool(rolePrimary, urls)
+
+	cfg, err := (&Config{Upstreams: UpstreamsConfig{Primary: []string{down.URL}, Secondary: []string{down.URL}}}).
+		finalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newHealthChecker(cfg.HealthCheck, pool).checkAll(context.Background())
+
+	for i, want := range []bool{true, true, false, false, false} {
+		if healthy := pool.upstreams[i].healthy.Load(); healthy != want {
+			t.Fatalf("upstream %d: expected healthy %v, got %v", i, want, healthy)
+		}
+	}
+}
@@ -0,0 +1,557 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	methodSubscribe    = "eth_subscribe"
+	methodUnsubscribe  = "eth_unsubscribe"
+	methodSubscription = "eth_subscription"
+
+	reconnectBackoff = time.Second
+)
+
+var upgrader = websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+
+// wsMessage is any JSON-RPC message received from a WebSocket upstream: a response, or a
+// subscription notification.
+type wsMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *JSONRPCError   `json:"error,omitempty"`
+}
+
+// subscriptionParams are the params of an eth_subscription notification.
+type subscriptionParams struct {
+	Subscription string          `json:"subscription"`
+	Result       json.RawMessage `json:"result"`
+}
+
+// subscription is an active eth_subscribe subscription of a client. The client keeps using
+// the ID it received first, even after the subscription is recreated on another upstream.
+type subscription struct {
+	params     json.RawMessage
+	upstreamID string
+}
+
+// wsSession proxies a client WebSocket connection to a single upstream. The session sticks to
+// its upstream so that subscriptions keep working; if the upstream connection is lost, the
+// session moves to another upstream, fails the calls in flight and recreates the client's
+// subscriptions there.
+type wsSession struct {
+	g      *gateway
+	ip     string
+	client *websocket.Conn
+	// clientMu serialises writes to the client connection, which does not support
+	// concurrent writers.
+	clientMu sync.Mutex
+
+	mu           sync.Mutex
+	upstream     *upstream
+	conn         *websocket.Conn
+	closed       bool
+	pending      map[string]*JSONRPCRequest
+	subs         map[string]*subscription
+	upstreamSubs map[string]string
+	resubscribes map[string]string
+	nextID       uint64
+}
+
+// handleWebSocket proxies a WebSocket connection to the upstreams. Messages from the client
+// are checked against the method policy and rate limits, rejected messages are answered by
+// the gateway and never reach the upstream.
+func (g *gateway) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	ip := clientIP(r, g.cfg.TrustForwardedFor)
+
+	clientConn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade connection: %v", err)
+		return
+	}
+	defer clientConn.Close()
+
+	s := &wsSession{
+		g:            g,
+		ip:           ip,
+		client:       clientConn,
+		pending:      make(map[string]*JSONRPCRequest),
+		subs:         make(map[string]*subscription),
+		upstreamSubs: make(map[string]string),
+		resubscribes: make(map[string]string),
+	}
+
+	u, conn, err := s.dial()
+	if err != nil {
+		log.Printf("Failed to connect to target WebSocket server: %v", err)
+		s.closeClient(websocket.CloseTryAgainLater, "upstream unavailable")
+
+		return
+	}
+
+	s.upstream, s.conn = u, conn
+
+	webSocketSessions.Inc()
+	defer webSocketSessions.Dec()
+
+	go s.clientLoop()
+
+	s.upstreamLoop()
+}
+
+// dial connects to the first reachable upstream of the WebSocket pool.
+func (s *wsSession) dial() (*upstream, *websocket.Conn, error) {
+	var lastErr error
+
+	for _, u := range s.g.webSocket.candidates() {
+		conn, _, err := websocket.DefaultDialer.Dial(u.url.String(), nil)
+		if err != nil {
+			u.markFailed("connection")
+			lastErr = err
+
+			continue
+		}
+
+		if s.g.cfg.MaxResponseBytes > 0 {
+			conn.SetReadLimit(s.g.cfg.MaxResponseBytes)
+		}
+
+		return u, conn, nil
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("no upstream configured")
+	}
+
+	return nil, nil, lastErr
+}
+
+// clientLoop forwards client messages to the current upstream connection until the client
+// goes away.
+func (s *wsSession) clientLoop() {
+	defer func() {
+		s.mu.Lock()
+		s.closed = true
+		if s.conn != nil {
+			// Unblock the upstream read loop
+			s.conn.Close()
+		}
+		s.mu.Unlock()
+	}()
+
+	for {
+		messageType, message, err := s.client.ReadMessage()
+		if err != nil {
+			log.Printf("Error reading message from client: %v", err)
+			return
+		}
+
+		// Binary frames are checked too, they can carry JSON-RPC calls just like text frames
+		if rejection := s.g.checkWebSocketMessage(s.ip, message); rejection != nil {
+			if err := s.writeClient(websocket.TextMessage, rejection); err != nil {
+				log.Printf("Error writing message to client: %v", err)
+				return
+			}
+
+			continue
+		}
+
+		if err := s.forward(messageType, message); err != nil {
+			log.Printf("Error writing message to client: %v", err)
+			return
+		}
+	}
+}
+
+// forward records the calls of a client message and sends it to the upstream. Unsubscribe
+// calls are rewritten to the subscription IDs of the current upstream. It only returns an
+// error if the client connection failed.
+func (s *wsSession) forward(messageType int, message []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Messages which passed checkWebSocketMessage always parse
+	calls, isBatch, err := parseJSONRPCRequests(message)
+	if err == nil && s.trackCalls(calls) {
+		if isBatch {
+			message = mustMarshal(calls)
+		} else {
+			message = mustMarshal(calls[0])
+		}
+	}
+
+	if s.conn != nil {
+		if err := s.conn.WriteMessage(messageType, message); err != nil {
+			// The upstream read loop notices the broken connection, and fails the pending calls
+			log.Printf("Error writing message to target server: %v", err)
+		}
+
+		return nil
+	}
+
+	// The upstream connection is being replaced, fail the calls right away
+	for _, call := range calls {
+		if call.isNotification() {
+			continue
+		}
+
+		delete(s.pending, string(call.ID))
+
+		if err := s.writeClient(websocket.TextMessage, mustMarshal(newErrorResponse(
+			call.ID, errCodeUpstreamError, "upstream unavailable",
+		))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// trackCalls records the calls awaiting a response and rewrites unsubscribe calls, returning
+// whether any call was rewritten. s.mu must be held.
+func (s *wsSession) trackCalls(calls []*JSONRPCRequest) bool {
+	rewritten := false
+
+	for _, call := range calls {
+		if !call.isNotification() {
+			s.pending[string(call.ID)] = call
+		}
+
+		if call.Method != methodUnsubscribe {
+			continue
+		}
+
+		var params []string
+		if err := json.Unmarshal(call.Params, &params); err != nil || len(params) != 1 {
+			continue
+		}
+
+		sub, ok := s.subs[params[0]]
+		if !ok {
+			continue
+		}
+
+		delete(s.subs, params[0])
+		delete(s.upstreamSubs, sub.upstreamID)
+
+		if sub.upstreamID != params[0] {
+			call.Params = mustMarshal([]string{sub.upstreamID})
+			rewritten = true
+		}
+	}
+
+	return rewritten
+}
+
+// upstreamLoop forwards upstream messages to the client, moving the session to another
+// upstream whenever the connection is lost.
+func (s *wsSession) upstreamLoop() {
+	for {
+		s.mu.Lock()
+		conn := s.conn
+		s.mu.Unlock()
+
+		messageType, message, err := conn.ReadMessage()
+		if err != nil {
+			conn.Close()
+
+			if s.isClosed() {
+				return
+			}
+
+			log.Printf("Error reading message from target server: %v", err)
+
+			if !s.reconnect() {
+				return
+			}
+
+			continue
+		}
+
+		if message = s.handleUpstreamMessage(message); message == nil {
+			continue
+		}
+
+		if err := s.writeClient(messageType, message); err != nil {
+			log.Printf("Error writing message to client: %v", err)
+			return
+		}
+	}
+}
+
+// handleUpstreamMessage updates the session state for an upstream message, and returns the
+// message to forward to the client, or nil if it must not be forwarded.
+func (s *wsSession) handleUpstreamMessage(message []byte) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var batch []*wsMessage
+	if err := json.Unmarshal(message, &batch); err == nil {
+		for _, m := range batch {
+			if m != nil {
+				s.handleResponse(m)
+			}
+		}
+
+		return message
+	}
+
+	var m wsMessage
+	if err := json.Unmarshal(message, &m); err != nil {
+		return message
+	}
+
+	if m.Method == methodSubscription {
+		var params subscriptionParams
+		if err := json.Unmarshal(m.Params, &params); err != nil {
+			return message
+		}
+
+		clientID, ok := s.upstreamSubs[params.Subscription]
+		if !ok || clientID == params.Subscription {
+			return message
+		}
+
+		params.Subscription = clientID
+		m.Params = mustMarshal(params)
+
+		return mustMarshal(m)
+	}
+
+	if !s.handleResponse(&m) {
+		return nil
+	}
+
+	return message
+}
+
+// handleResponse updates the session state for a response, and returns whether the response
+// is meant for the client. s.mu must be held.
+func (s *wsSession) handleResponse(m *wsMessage) bool {
+	if len(m.ID) == 0 {
+		return true
+	}
+
+	key := string(m.ID)
+
+	if clientID, ok := s.resubscribes[key]; ok {
+		delete(s.resubscribes, key)
+
+		var upstreamID string
+		if m.Error != nil || json.Unmarshal(m.Result, &upstreamID) != nil {
+			log.Printf("Failed to recreate subscription %s on upstream %s", clientID, s.upstream.name)
+			delete(s.subs, clientID)
+
+			return false
+		}
+
+		if sub, ok := s.subs[clientID]; ok {
+			sub.upstreamID = upstreamID
+			s.upstreamSubs[upstreamID] = clientID
+		}
+
+		return false
+	}
+
+	call, ok := s.pending[key]
+	if !ok {
+		return true
+	}
+
+	delete(s.pending, key)
+
+	if call.Method == methodSubscribe && m.Error == nil {
+		var id string
+		if err := json.Unmarshal(m.Result, &id); err == nil {
+			s.subs[id] = &subscription{params: call.Params, upstreamID: id}
+			s.upstreamSubs[id] = id
+		}
+	}
+
+	return true
+}
+
+// reconnect moves the session to another upstream, returning false if no upstream could be
+// reached and the session was closed.
+func (s *wsSession) reconnect() bool {
+	s.mu.Lock()
+	failed := s.upstream
+	pending := s.pending
+	s.conn = nil
+	s.pending = make(map[string]*JSONRPCRequest)
+	s.resubscribes = make(map[string]string)
+	s.mu.Unlock()
+
+	failed.markFailed("websocket_disconnect")
+
+	// The calls in flight might or might not have been executed, let the client decide
+	for _, call := range pending {
+		if err := s.writeClient(websocket.TextMessage, mustMarshal(newErrorResponse(
+			call.ID, errCodeUpstreamError, "upstream connection lost",
+		))); err != nil {
+			return false
+		}
+	}
+
+	for attempt := 0; attempt < s.g.cfg.Retry.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(reconnectBackoff)
+		}
+
+		if s.isClosed() {
+			return false
+		}
+
+		u, conn, err := s.dial()
+		if err != nil {
+			log.Printf("Failed to reconnect to target WebSocket server: %v", err)
+			continue
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+
+			return false
+		}
+
+		s.upstream, s.conn = u, conn
+		err = s.resubscribe()
+		s.mu.Unlock()
+
+		if err != nil {
+			log.Printf("Failed to resubscribe on upstream %s: %v", u.name, err)
+			conn.Close()
+
+			// The upstream read loop notices the closed connection and reconnects again
+			return true
+		}
+
+		log.Printf("WebSocket session moved from upstream %s to %s", failed.name, u.name)
+		webSocketReconnects.WithLabelValues("success").Inc()
+
+		return true
+	}
+
+	webSocketReconnects.WithLabelValues("failed").Inc()
+	s.closeClient(websocket.CloseTryAgainLater, "upstream unavailable")
+
+	return false
+}
+
+// resubscribe recreates all subscriptions of the client on the current upstream. s.mu must
+// be held.
+func (s *wsSession) resubscribe() error {
+	for clientID, sub := range s.subs {
+		delete(s.upstreamSubs, sub.upstreamID)
+
+		s.nextID++
+		id := mustMarshal(fmt.Sprintf("gateway-resubscribe-%d", s.nextID))
+		s.resubscribes[string(id)] = clientID
+
+		message := mustMarshal(&JSONRPCRequest{
+			JSONRPC: "2.0",
+			ID:      id,
+			Method:  methodSubscribe,
+			Params:  sub.params,
+		})
+
+		if err := s.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *wsSession) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.closed
+}
+
+func (s *wsSession) writeClient(messageType int, message []byte) error {
+	s.clientMu.Lock()
+	defer s.clientMu.Unlock()
+
+	return s.client.WriteMessage(messageType, message)
+}
+
+func (s *wsSession) closeClient(code int, reason string) {
+	s.clientMu.Lock()
+	defer s.clientMu.Unlock()
+
+	if err := s.client.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason),
+		time.Now().Add(time.Second),
+	); err != nil {
+		log.Printf("Error closing client connection: %v", err)
+	}
+}
+
+// checkWebSocketMessage returns the encoded error response for a client message which must not
+// be forwarded, or nil if the message may be forwarded. A batch is rejected as a whole if any
+// of its calls is rejected.
+func (g *gateway) checkWebSocketMessage(ip string, message []byte) []byte {
+	calls, isBatch, err := parseJSONRPCRequests(message)
+	if err != nil {
+		return mustMarshal(newErrorResponse(nil, errCodeParseError, "parse error"))
+	}
+
+	if !isBatch {
+		if errResp := g.checkCall(ip, calls[0]); errResp != nil {
+			return mustMarshal(errResp)
+		}
+
+		return nil
+	}
+
+	if g.cfg.MaxBatchSize > 0 && len(calls) > g.cfg.MaxBatchSize {
+		return mustMarshal(newErrorResponse(nil, errCodeInvalidRequest, "batch too large"))
+	}
+
+	responses := make([]*JSONRPCResponse, len(calls))
+	rejected := false
+
+	for i, call := range calls {
+		if responses[i] = g.checkCall(ip, call); responses[i] != nil {
+			rejected = true
+		}
+	}
+
+	if !rejected {
+		return nil
+	}
+
+	for i, call := range calls {
+		if responses[i] == nil {
+			responses[i] = newErrorResponse(call.ID, errCodeInvalidRequest, "batch contains rejected calls")
+		}
+	}
+
+	return mustMarshal(responses)
+}
+
+func mustMarshal(v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("Failed to encode response: %v", err)
+		return []byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32603,"message":"internal error"}}`)
+	}
+
+	return b
+}
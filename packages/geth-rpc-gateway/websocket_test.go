@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// newTestWebSocketUpstream starts a WebSocket upstream answering every call with its method
+// as the result.
+func newTestWebSocketUpstream(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			messageType, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			var call JSONRPCRequest
+			if err := json.Unmarshal(message, &call); err != nil {
+				return
+			}
+
+			resp := mustMarshal(&JSONRPCResponse{JSONRPC: "2.0", ID: call.ID, Result: mustMarshal(call.Method)})
+			if err := conn.WriteMessage(messageType, resp); err != nil {
+				return
+			}
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestWebSocketChecksAllFrameTypes(t *testing.T) {
+	upstream := newTestWebSocketUpstream(t)
+
+	g := newTestGateway(t, &Config{
+		WebSocket: true,
+		Upstreams: UpstreamsConfig{WebSocket: []string{"ws" + strings.TrimPrefix(upstream.URL, "http")}},
+		Methods:   MethodsConfig{Deny: []string{"debug_*"}},
+	})
+
+	gw := httptest.NewServer(http.HandlerFunc(g.rootWebSocketHandler))
+	defer gw.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(gw.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	tests := []struct {
+		name        string
+		messageType int
+		method      string
+		wantCode    int
+	}{
+		{"allowed text frame", websocket.TextMessage, "eth_chainId", 0},
+		{"denied text frame", websocket.TextMessage, "debug_traceCall", errCodeMethodNotAllowed},
+		{"allowed binary frame", websocket.BinaryMessage, "eth_chainId", 0},
+		{"denied binary frame", websocket.BinaryMessage, "debug_traceCall", errCodeMethodNotAllowed},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			call := mustMarshal(&JSONRPCRequest{JSONRPC: "2.0", ID: mustMarshal(i), Method: tt.method})
+			if err := conn.WriteMessage(tt.messageType, call); err != nil {
+				t.Fatal(err)
+			}
+
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var resp JSONRPCResponse
+			if err := json.Unmarshal(message, &resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantCode != 0 {
+				if resp.Error == nil || resp.Error.Code != tt.wantCode {
+					t.Fatalf("expected error code %d, got %s", tt.wantCode, message)
+				}
+
+				return
+			}
+
+			if resp.Error != nil || string(resp.Result) != `"`+tt.method+`"` {
+				t.Fatalf("expected result of %s, got %s", tt.method, message)
+			}
+		})
+	}
+}
+
+func TestWebSocketRejectsInvalidBatchCalls(t *testing.T) {
+	upstream := newTestWebSocketUpstream(t)
+
+	g := newTestGateway(t, &Config{
+		WebSocket: true,
+		Upstreams: UpstreamsConfig{WebSocket: []string{"ws" + strings.TrimPrefix(upstream.URL, "http")}},
+	})
+
+	gw := httptest.NewServer(http.HandlerFunc(g.rootWebSocketHandler))
+	defer gw.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(gw.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(`[null]`)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, message, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var responses []*JSONRPCResponse
+	if err := json.Unmarshal(message, &responses); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(responses) != 1 || responses[0].Error == nil || responses[0].Error.Code != errCodeInvalidRequest {
+		t.Fatalf("expected an invalid request error, got %s", message)
+	}
+
+	// The connection is still served after the rejected batch
+	call := mustMarshal(&JSONRPCRequest{JSONRPC: "2.0", ID: mustMarshal(1), Method: "eth_chainId"})
+	if err := conn.WriteMessage(websocket.TextMessage, call); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, message, err = conn.ReadMessage(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(message), `"eth_chainId"`) {
+		t.Fatalf("expected result of eth_chainId, got %s", message)
+	}
+}
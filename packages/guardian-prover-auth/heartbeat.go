@@ -0,0 +1,108 @@
+// Package guardianproverauth encodes the messages guardian provers sign for the guardian prover health
+// check server. Both the guardian provers and the server import it, so that they always sign and verify
+// the same bytes.
+package guardianproverauth
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// HeartbeatVersionLegacy is the original heartbeat protocol, which signs a static
+	// message and therefore provides no replay protection.
+	HeartbeatVersionLegacy uint64 = 1
+	// HeartbeatVersion is the current heartbeat protocol, which signs a timestamp, a nonce
+	// and the latest L1/L2 heads of the guardian prover, and the block ID of signed blocks.
+	HeartbeatVersion uint64 = 2
+)
+
+// HeartbeatAuth contains the fields guardian provers include in the signed payload of
+// heartbeats and startup messages, so the server can reject stale or replayed messages.
+// Legacy payloads only contain the latest L1 / L2 block numbers.
+type HeartbeatAuth struct {
+	Version           uint64 `json:"version,omitempty"`
+	Timestamp         uint64 `json:"timestamp,omitempty"`
+	Nonce             string `json:"nonce,omitempty"`
+	LatestL1Block     uint64 `json:"latestL1Block"`
+	LatestL1BlockHash string `json:"latestL1BlockHash,omitempty"`
+	LatestL2Block     uint64 `json:"latestL2Block"`
+	LatestL2BlockHash string `json:"latestL2BlockHash,omitempty"`
+}
+
+// IsLegacyVersion returns whether the given version is the legacy heartbeat protocol, older
+// guardian provers do not send a version at all.
+func IsLegacyVersion(version uint64) bool {
+	return version == 0 || version == HeartbeatVersionLegacy
+}
+
+// IsLegacy returns whether the payload was sent using the legacy heartbeat protocol.
+func (a *HeartbeatAuth) IsLegacy() bool {
+	return IsLegacyVersion(a.Version)
+}
+
+// Encode returns the packed encoding of the authenticated fields, which is appended to the
+// signed message.
+func (a *HeartbeatAuth) Encode() []byte {
+	b := make([]byte, 0, 8*4+common.HashLength*3)
+
+	b = binary.BigEndian.AppendUint64(b, a.Version)
+	b = binary.BigEndian.AppendUint64(b, a.Timestamp)
+	b = append(b, common.HexToHash(a.Nonce).Bytes()...)
+	b = binary.BigEndian.AppendUint64(b, a.LatestL1Block)
+	b = append(b, common.HexToHash(a.LatestL1BlockHash).Bytes()...)
+	b = binary.BigEndian.AppendUint64(b, a.LatestL2Block)
+	b = append(b, common.HexToHash(a.LatestL2BlockHash).Bytes()...)
+
+	return b
+}
+
+// HeartbeatMessage returns the message a guardian prover signs when sending a heartbeat.
+func HeartbeatMessage(prover common.Address, auth *HeartbeatAuth) []byte {
+	if auth.IsLegacy() {
+		return crypto.Keccak256Hash([]byte("HEART_BEAT")).Bytes()
+	}
+
+	return crypto.Keccak256Hash([]byte("HEART_BEAT"), prover.Bytes(), auth.Encode()).Bytes()
+}
+
+// SignedBlockMessage returns the message a guardian prover signs for the L2 block with the given
+// ID and hash. Legacy guardian provers only sign the block hash, so that the signature is not
+// bound to the block ID.
+func SignedBlockMessage(version uint64, blockID uint64, blockHash common.Hash) []byte {
+	if IsLegacyVersion(version) {
+		return blockHash.Bytes()
+	}
+
+	return crypto.Keccak256Hash(
+		[]byte("SIGNED_BLOCK"),
+		binary.BigEndian.AppendUint64(nil, blockID),
+		blockHash.Bytes(),
+	).Bytes()
+}
+
+// StartupMessage returns the message a guardian prover signs when sending a startup message.
+func StartupMessage(
+	prover common.Address,
+	revision string,
+	guardianVersion string,
+	l1NodeVersion string,
+	l2NodeVersion string,
+	auth *HeartbeatAuth,
+) []byte {
+	data := [][]byte{
+		prover.Bytes(),
+		[]byte(revision),
+		[]byte(guardianVersion),
+		[]byte(l1NodeVersion),
+		[]byte(l2NodeVersion),
+	}
+
+	if !auth.IsLegacy() {
+		data = append(data, auth.Encode())
+	}
+
+	return crypto.Keccak256Hash(data...).Bytes()
+}
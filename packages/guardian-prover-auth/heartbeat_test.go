@@ -0,0 +1,105 @@
+package guardianproverauth
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// The golden vectors pin the signed messages, a change of them breaks all the deployed guardian
+// provers or health check servers.
+var (
+	testHeartbeatProver = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	testHeartbeatAuth   = HeartbeatAuth{
+		Version:           HeartbeatVersion,
+		Timestamp:         1700000000,
+		Nonce:             "0x01",
+		LatestL1Block:     100,
+		LatestL1BlockHash: "0x02",
+		LatestL2Block:     200,
+		LatestL2BlockHash: "0x03",
+	}
+)
+
+func Test_HeartbeatAuth_Encode(t *testing.T) {
+	assert.Equal(
+		t,
+		"0000000000000002000000006553f100"+
+			"0000000000000000000000000000000000000000000000000000000000000001"+
+			"0000000000000064"+
+			"0000000000000000000000000000000000000000000000000000000000000002"+
+			"00000000000000c8"+
+			"0000000000000000000000000000000000000000000000000000000000000003",
+		hex.EncodeToString(testHeartbeatAuth.Encode()),
+	)
+}
+
+func Test_HeartbeatMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		auth HeartbeatAuth
+		want string
+	}{
+		{
+			"legacy",
+			HeartbeatAuth{LatestL1Block: 100, LatestL2Block: 200},
+			"0x9c89024140500af745d4ba223bc7acabdacb82ce383070c096962829d69203e7",
+		},
+		{
+			"v2",
+			testHeartbeatAuth,
+			"0x0bd2c624b6f33faf60faa0e155dd3d3305ac7ad45ea8e48104194c1e9b1237f8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, common.BytesToHash(HeartbeatMessage(testHeartbeatProver, &tt.auth)).Hex())
+		})
+	}
+}
+
+func Test_StartupMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		auth HeartbeatAuth
+		want string
+	}{
+		{
+			"legacy",
+			HeartbeatAuth{LatestL1Block: 100, LatestL2Block: 200},
+			"0xbda3a07611b099b91ac3ba8c838b31d21b287942dd917b259176cdafecc59281",
+		},
+		{
+			"v2",
+			testHeartbeatAuth,
+			"0xe1c603b15d9d2096437134af9cfde7dcbfedbd9b2369817ddcc4b400fd9188ed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := StartupMessage(testHeartbeatProver, "abc123", "v1.0.0", "geth/v1", "taiko-geth/v1", &tt.auth)
+			assert.Equal(t, tt.want, common.BytesToHash(msg).Hex())
+		})
+	}
+}
+
+func Test_SignedBlockMessage(t *testing.T) {
+	blockHash := common.HexToHash("0x03")
+
+	assert.Equal(t, blockHash.Bytes(), SignedBlockMessage(0, 200, blockHash))
+	assert.Equal(t, blockHash.Bytes(), SignedBlockMessage(HeartbeatVersionLegacy, 200, blockHash))
+	assert.Equal(
+		t,
+		"0x9ffa5f599707ec7ad80d17e962f40b30161056899dd4726c442b231f4fff777d",
+		common.BytesToHash(SignedBlockMessage(HeartbeatVersion, 200, blockHash)).Hex(),
+	)
+	assert.NotEqual(
+		t,
+		SignedBlockMessage(HeartbeatVersion, 200, blockHash),
+		SignedBlockMessage(HeartbeatVersion, 201, blockHash),
+	)
+}
@@ -45,6 +45,34 @@ var (
 		Value:    4102,
 		EnvVars:  []string{"HTTP_PORT"},
 	}
+	HeartbeatMaxAge = &cli.DurationFlag{
+		Name:     "heartbeat.maxAge",
+		Usage:    "Maximum age (and clock skew) of a signed heartbeat or startup message timestamp",
+		Value:    1 * time.Minute,
+		Category: healthCheckCategory,
+		EnvVars:  []string{"HEARTBEAT_MAX_AGE"},
+	}
+	AllowLegacyHeartbeats = &cli.BoolFlag{
+		Name:     "heartbeat.allowLegacy",
+		Usage:    "Accept legacy heartbeats and startup messages which are not replay-protected",
+		Value:    false,
+		Category: healthCheckCategory,
+		EnvVars:  []string{"HEARTBEAT_ALLOW_LEGACY"},
+	}
+	HeartbeatHeadTolerance = &cli.Uint64Flag{
+		Name:     "heartbeat.headTolerance",
+		Usage:    "Number of blocks a signed L1 / L2 head may be ahead of the server's nodes",
+		Value:    2,
+		Category: healthCheckCategory,
+		EnvVars:  []string{"HEARTBEAT_HEAD_TOLERANCE"},
+	}
+	HeartbeatReorgDepth = &cli.Uint64Flag{
+		Name:     "heartbeat.reorgDepth",
+		Usage:    "Number of most recent blocks of the server's nodes whose hash may differ from a signed L1 / L2 head",
+		Value:    8,
+		Category: healthCheckCategory,
+		EnvVars:  []string{"HEARTBEAT_REORG_DEPTH"},
+	}
 	CORSOrigins = &cli.StringFlag{
 		Name:     "http.corsOrigins",
 		Usage:    "Comma-delinated list of cors origins",
@@ -58,6 +86,10 @@ var HealthCheckFlags = MergeFlags(CommonFlags, []cli.Flag{
 	HTTPPort,
 	CORSOrigins,
 	Backoff,
+	HeartbeatMaxAge,
+	AllowLegacyHeartbeats,
+	HeartbeatHeadTolerance,
+	HeartbeatReorgDepth,
 	GuardianProverContractAddress,
 	L1RPCUrl,
 	L2RPCUrl,
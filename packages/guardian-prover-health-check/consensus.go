@@ -0,0 +1,163 @@
+package guardianproverhealthcheck
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// HashAgreement represents a single block hash signed by one or more guardian
+// provers for a given block ID.
+type HashAgreement struct {
+	BlockHash  string   `json:"blockHash"`
+	NumSigners int      `json:"numSigners"`
+	Signers    []string `json:"signers"`
+	Majority   bool     `json:"majority"`
+	Canonical  bool     `json:"canonical"`
+}
+
+// BlockAgreement summarizes which block hashes have been signed by how many
+// guardian provers for a single block ID, compared against the majority and
+// the canonical L2 chain.
+type BlockAgreement struct {
+	BlockID       uint64          `json:"blockID"`
+	CanonicalHash string          `json:"canonicalHash"`
+	MajorityHash  string          `json:"majorityHash"`
+	NumSigners    int             `json:"numSigners"`
+	Hashes        []HashAgreement `json:"hashes"`
+	Divergent     []string        `json:"divergent"`
+}
+
+// GuardianDivergence counts how many signed blocks of a single guardian prover
+// disagree with the majority of guardian provers, or with the canonical chain.
+type GuardianDivergence struct {
+	GuardianProverID      uint64 `json:"guardianProverID"`
+	GuardianProverAddress string `json:"guardianProverAddress"`
+	SignedBlocks          int    `json:"signedBlocks"`
+	MajorityDivergences   int    `json:"majorityDivergences"`
+	CanonicalDivergences  int    `json:"canonicalDivergences"`
+}
+
+// ComputeBlockAgreement computes the agreement between guardian provers for the given
+// block ID. Signed blocks for other block IDs are ignored. The majority hash is the hash
+// signed by more than half of the signers, and is left empty when no such hash exists.
+// An empty canonicalHash disables the comparison against the canonical chain.
+func ComputeBlockAgreement(
+	blockID uint64,
+	signedBlocks []*SignedBlock,
+	canonicalHash string,
+) *BlockAgreement {
+	agreement := &BlockAgreement{
+		BlockID:   blockID,
+		Hashes:    make([]HashAgreement, 0),
+		Divergent: make([]string, 0),
+	}
+
+	if canonicalHash != "" {
+		agreement.CanonicalHash = common.HexToHash(canonicalHash).Hex()
+	}
+
+	signersByHash := make(map[string][]string)
+
+	for _, b := range signedBlocks {
+		if b.BlockID != blockID {
+			continue
+		}
+
+		h := common.HexToHash(b.BlockHash).Hex()
+		signersByHash[h] = append(signersByHash[h], b.RecoveredAddress)
+		agreement.NumSigners++
+	}
+
+	for h, signers := range signersByHash {
+		if len(signers)*2 > agreement.NumSigners {
+			agreement.MajorityHash = h
+		}
+
+		sort.Strings(signers)
+
+		agreement.Hashes = append(agreement.Hashes, HashAgreement{
+			BlockHash:  h,
+			NumSigners: len(signers),
+			Signers:    signers,
+			Canonical:  h == agreement.CanonicalHash,
+		})
+	}
+
+	// most signed hashes first, so the response is stable and easy to consume.
+	sort.Slice(agreement.Hashes, func(i, j int) bool {
+		if agreement.Hashes[i].NumSigners != agreement.Hashes[j].NumSigners {
+			return agreement.Hashes[i].NumSigners > agreement.Hashes[j].NumSigners
+		}
+
+		return agreement.Hashes[i].BlockHash < agreement.Hashes[j].BlockHash
+	})
+
+	for i, h := range agreement.Hashes {
+		agreement.Hashes[i].Majority = h.BlockHash == agreement.MajorityHash
+
+		if agreement.DivergesFromMajority(h.BlockHash) || agreement.DivergesFromCanonical(h.BlockHash) {
+			agreement.Divergent = append(agreement.Divergent, h.Signers...)
+		}
+	}
+
+	sort.Strings(agreement.Divergent)
+
+	return agreement
+}
+
+// DivergesFromMajority returns whether the given block hash differs from the
+// hash signed by the majority of guardian provers.
+func (a *BlockAgreement) DivergesFromMajority(blockHash string) bool {
+	return a.MajorityHash != "" && common.HexToHash(blockHash).Hex() != a.MajorityHash
+}
+
+// DivergesFromCanonical returns whether the given block hash differs from the
+// canonical L2 block hash.
+func (a *BlockAgreement) DivergesFromCanonical(blockHash string) bool {
+	return a.CanonicalHash != "" && common.HexToHash(blockHash).Hex() != a.CanonicalHash
+}
+
+// ComputeGuardianDivergences counts, for each guardian prover, how many of the given
+// signed blocks diverge from the majority and from the canonical chain. canonicalHashes
+// maps a block ID to its canonical L2 block hash, block IDs missing from it are only
+// compared against the majority.
+func ComputeGuardianDivergences(
+	signedBlocks []*SignedBlock,
+	canonicalHashes map[uint64]string,
+) map[string]*GuardianDivergence {
+	blocksByID := make(map[uint64][]*SignedBlock)
+
+	for _, b := range signedBlocks {
+		blocksByID[b.BlockID] = append(blocksByID[b.BlockID], b)
+	}
+
+	divergences := make(map[string]*GuardianDivergence)
+
+	for blockID, blocks := range blocksByID {
+		agreement := ComputeBlockAgreement(blockID, blocks, canonicalHashes[blockID])
+
+		for _, b := range blocks {
+			d, ok := divergences[b.RecoveredAddress]
+			if !ok {
+				d = &GuardianDivergence{
+					GuardianProverID:      b.GuardianProverID,
+					GuardianProverAddress: b.RecoveredAddress,
+				}
+				divergences[b.RecoveredAddress] = d
+			}
+
+			d.SignedBlocks++
+
+			if agreement.DivergesFromMajority(b.BlockHash) {
+				d.MajorityDivergences++
+			}
+
+			if agreement.DivergesFromCanonical(b.BlockHash) {
+				d.CanonicalDivergences++
+			}
+		}
+	}
+
+	return divergences
+}
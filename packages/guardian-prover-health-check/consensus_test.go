@@ -0,0 +1,96 @@
+package guardianproverhealthcheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	hashA = "0x000000000000000000000000000000000000000000000000000000000000000a"
+	hashB = "0x000000000000000000000000000000000000000000000000000000000000000b"
+)
+
+func Test_ComputeBlockAgreement(t *testing.T) {
+	signedBlocks := []*SignedBlock{
+		{GuardianProverID: 1, BlockID: 1, BlockHash: hashA, RecoveredAddress: "0x1"},
+		{GuardianProverID: 2, BlockID: 1, BlockHash: hashA, RecoveredAddress: "0x2"},
+		{GuardianProverID: 3, BlockID: 1, BlockHash: hashB, RecoveredAddress: "0x3"},
+		{GuardianProverID: 1, BlockID: 2, BlockHash: hashB, RecoveredAddress: "0x1"},
+	}
+
+	tests := []struct {
+		name          string
+		canonicalHash string
+		wantMajority  string
+		wantDivergent []string
+	}{
+		{
+			"canonicalAgreesWithMajority",
+			hashA,
+			hashA,
+			[]string{"0x3"},
+		},
+		{
+			"canonicalAgreesWithMinority",
+			hashB,
+			hashA,
+			[]string{"0x1", "0x2", "0x3"},
+		},
+		{
+			"noCanonicalHash",
+			"",
+			hashA,
+			[]string{"0x3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := ComputeBlockAgreement(1, signedBlocks, tt.canonicalHash)
+			assert.Equal(t, 3, a.NumSigners)
+			assert.Equal(t, tt.wantMajority, a.MajorityHash)
+			assert.Equal(t, tt.wantDivergent, a.Divergent)
+			assert.Equal(t, 2, len(a.Hashes))
+			assert.Equal(t, hashA, a.Hashes[0].BlockHash)
+			assert.Equal(t, 2, a.Hashes[0].NumSigners)
+			assert.True(t, a.Hashes[0].Majority)
+		})
+	}
+}
+
+func Test_ComputeBlockAgreement_NoMajority(t *testing.T) {
+	a := ComputeBlockAgreement(1, []*SignedBlock{
+		{GuardianProverID: 1, BlockID: 1, BlockHash: hashA, RecoveredAddress: "0x1"},
+		{GuardianProverID: 2, BlockID: 1, BlockHash: hashB, RecoveredAddress: "0x2"},
+	}, "")
+
+	assert.Equal(t, "", a.MajorityHash)
+	assert.Equal(t, 0, len(a.Divergent))
+	assert.False(t, a.DivergesFromMajority(hashA))
+	assert.False(t, a.DivergesFromCanonical(hashB))
+}
+
+func Test_ComputeGuardianDivergences(t *testing.T) {
+	signedBlocks := []*SignedBlock{
+		{GuardianProverID: 1, BlockID: 1, BlockHash: hashA, RecoveredAddress: "0x1"},
+		{GuardianProverID: 2, BlockID: 1, BlockHash: hashA, RecoveredAddress: "0x2"},
+		{GuardianProverID: 3, BlockID: 1, BlockHash: hashB, RecoveredAddress: "0x3"},
+		{GuardianProverID: 1, BlockID: 2, BlockHash: hashB, RecoveredAddress: "0x1"},
+		{GuardianProverID: 3, BlockID: 2, BlockHash: hashB, RecoveredAddress: "0x3"},
+	}
+
+	d := ComputeGuardianDivergences(signedBlocks, map[uint64]string{1: hashA, 2: hashA})
+
+	assert.Equal(t, 3, len(d))
+	assert.Equal(t, &GuardianDivergence{
+		GuardianProverID:      1,
+		GuardianProverAddress: "0x1",
+		SignedBlocks:          2,
+		MajorityDivergences:   0,
+		CanonicalDivergences:  1,
+	}, d["0x1"])
+	assert.Equal(t, 0, d["0x2"].CanonicalDivergences)
+	assert.Equal(t, 1, d["0x3"].MajorityDivergences)
+	assert.Equal(t, 2, d["0x3"].CanonicalDivergences)
+}
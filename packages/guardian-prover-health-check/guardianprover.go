@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/prometheus/client_golang/prometheus"
@@ -16,6 +17,12 @@ type GuardianProver struct {
 	ID                 *big.Int
 	HealthCheckCounter prometheus.Counter
 	SignedBlockCounter prometheus.Counter
+	// MajorityDivergenceCounter counts signed blocks whose hash differs from the
+	// hash signed by the majority of guardian provers.
+	MajorityDivergenceCounter prometheus.Counter
+	// CanonicalDivergenceCounter counts signed blocks whose hash differs from the
+	// canonical L2 block hash.
+	CanonicalDivergenceCounter prometheus.Counter
 }
 
 func SignatureToGuardianProver(
@@ -28,19 +35,24 @@ func SignatureToGuardianProver(
 		return nil, err
 	}
 
-	// recover the public key from the signature
-	r, err := crypto.SigToPub(msg, b64DecodedSig)
-	if err != nil {
-		return nil, err
-	}
+	// Guardian provers using a remote signer sign the message as an EIP-191 personal message instead,
+	// since remote signers never sign raw hashes.
+	var recoveredAddr common.Address
+	for _, hash := range [][]byte{msg, accounts.TextHash(msg)} {
+		// recover the public key from the signature
+		r, err := crypto.SigToPub(hash, b64DecodedSig)
+		if err != nil {
+			return nil, err
+		}
 
-	// convert it to address type
-	recoveredAddr := crypto.PubkeyToAddress(*r)
+		// convert it to address type
+		recoveredAddr = crypto.PubkeyToAddress(*r)
 
-	// see if any of our known guardian provers have that recovered address
-	for _, p := range guardianProvers {
-		if recoveredAddr.Cmp(p.Address) == 0 {
-			return &p, nil
+		// see if any of our known guardian provers have that recovered address
+		for _, p := range guardianProvers {
+			if recoveredAddr.Cmp(p.Address) == 0 {
+				return &p, nil
+			}
 		}
 	}
 
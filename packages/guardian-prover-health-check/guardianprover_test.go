@@ -0,0 +1,57 @@
+package guardianproverhealthcheck
+
+import (
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SignatureToGuardianProver(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	assert.Nil(t, err)
+
+	otherKey, err := crypto.GenerateKey()
+	assert.Nil(t, err)
+
+	guardianProvers := []GuardianProver{
+		{Address: crypto.PubkeyToAddress(key.PublicKey), ID: big.NewInt(1)},
+	}
+
+	msg := crypto.Keccak256([]byte("HEART_BEAT"))
+
+	tests := []struct {
+		name    string
+		hash    []byte
+		key     string
+		wantErr bool
+	}{
+		{"raw", msg, "guardian", false},
+		{"eip191", accounts.TextHash(msg), "guardian", false},
+		{"unknownSigner", msg, "other", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signingKey := key
+			if tt.key == "other" {
+				signingKey = otherKey
+			}
+
+			sig, err := crypto.Sign(tt.hash, signingKey)
+			assert.Nil(t, err)
+
+			p, err := SignatureToGuardianProver(msg, base64.StdEncoding.EncodeToString(sig), guardianProvers)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, guardianProvers[0].Address, p.Address)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package healthchecker
 
 import (
 	"strings"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	"gorm.io/driver/mysql"
@@ -27,6 +28,10 @@ type Config struct {
 	GuardianProverContractAddress string
 	L1RPCUrl                      string
 	L2RPCUrl                      string
+	HeartbeatMaxAge               time.Duration
+	AllowLegacyHeartbeats         bool
+	HeartbeatHeadTolerance        uint64
+	HeartbeatReorgDepth           uint64
 	OpenDBFunc                    func() (db.DB, error)
 }
 
@@ -45,6 +50,10 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		L1RPCUrl:                      c.String(flags.L1RPCUrl.Name),
 		L2RPCUrl:                      c.String(flags.L2RPCUrl.Name),
 		HTTPPort:                      c.Uint64(flags.HTTPPort.Name),
+		HeartbeatMaxAge:               c.Duration(flags.HeartbeatMaxAge.Name),
+		AllowLegacyHeartbeats:         c.Bool(flags.AllowLegacyHeartbeats.Name),
+		HeartbeatHeadTolerance:        c.Uint64(flags.HeartbeatHeadTolerance.Name),
+		HeartbeatReorgDepth:           c.Uint64(flags.HeartbeatReorgDepth.Name),
 		OpenDBFunc: func() (db.DB, error) {
 			return db.OpenDBConnection(db.DBConnectionOpts{
 				Name:            c.String(flags.DatabaseUsername.Name),
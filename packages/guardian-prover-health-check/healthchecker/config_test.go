@@ -1,8 +1,10 @@
 package healthchecker
 
 import (
-	"github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check/db"
 	"testing"
+	"time"
+
+	"github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check/db"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check/cmd/flags"
@@ -47,6 +49,10 @@ func TestNewConfigFromCliContext(t *testing.T) {
 		assert.Equal(t, uint64(10), c.DatabaseMaxOpenConns)
 		assert.Equal(t, uint64(30), c.DatabaseMaxConnLifetime)
 		assert.Equal(t, uint64(1000), c.HTTPPort)
+		assert.Equal(t, 30*time.Second, c.HeartbeatMaxAge)
+		assert.Equal(t, true, c.AllowLegacyHeartbeats)
+		assert.Equal(t, uint64(16), c.HeartbeatHeadTolerance)
+		assert.Equal(t, uint64(4), c.HeartbeatReorgDepth)
 
 		c.OpenDBFunc = func() (db.DB, error) {
 			return &mock.DB{}, nil
@@ -70,6 +76,10 @@ func TestNewConfigFromCliContext(t *testing.T) {
 		"--" + flags.DatabaseMaxIdleConns.Name, databaseMaxIdleConns,
 		"--" + flags.DatabaseConnMaxLifetime.Name, databaseMaxConnLifetime,
 		"--" + flags.HTTPPort.Name, HTTPPort,
+		"--" + flags.HeartbeatMaxAge.Name, "30s",
+		"--" + flags.AllowLegacyHeartbeats.Name,
+		"--" + flags.HeartbeatHeadTolerance.Name, "16",
+		"--" + flags.HeartbeatReorgDepth.Name, "4",
 		"--" + flags.GuardianProverContractAddress.Name, guardianProverAddress,
 	}))
 }
@@ -136,16 +136,29 @@ func InitFromConfig(ctx context.Context, h *HealthChecker, cfg *Config) (err err
 				Name: fmt.Sprintf("guardian_prover_%v_signed_block_ops_total", guardianId.Uint64()),
 				Help: "The total number of signed blocks",
 			}),
+			MajorityDivergenceCounter: promauto.NewCounter(prometheus.CounterOpts{
+				Name: fmt.Sprintf("guardian_prover_%v_majority_divergences_ops_total", guardianId.Uint64()),
+				Help: "The total number of signed blocks diverging from the guardian prover majority",
+			}),
+			CanonicalDivergenceCounter: promauto.NewCounter(prometheus.CounterOpts{
+				Name: fmt.Sprintf("guardian_prover_%v_canonical_divergences_ops_total", guardianId.Uint64()),
+				Help: "The total number of signed blocks diverging from the canonical L2 chain",
+			}),
 		})
 	}
 
 	h.httpSrv, err = hchttp.NewServer(hchttp.NewServerOpts{
-		Echo:            echo.New(),
-		EthClient:       l2EthClient,
-		HealthCheckRepo: healthCheckRepo,
-		SignedBlockRepo: signedBlockRepo,
-		StartupRepo:     startupRepo,
-		GuardianProvers: guardianProvers,
+		Echo:                  echo.New(),
+		EthClient:             l2EthClient,
+		L1EthClient:           l1EthClient,
+		HealthCheckRepo:       healthCheckRepo,
+		SignedBlockRepo:       signedBlockRepo,
+		StartupRepo:           startupRepo,
+		GuardianProvers:       guardianProvers,
+		HeartbeatMaxAge:       cfg.HeartbeatMaxAge,
+		AllowLegacyHeartbeats: cfg.AllowLegacyHeartbeats,
+		HeadTolerance:         cfg.HeartbeatHeadTolerance,
+		ReorgDepth:            cfg.HeartbeatReorgDepth,
 	})
 
 	if err != nil {
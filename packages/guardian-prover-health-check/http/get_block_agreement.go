@@ -0,0 +1,78 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum"
+	echo "github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	guardianproverhealthcheck "github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check"
+)
+
+// GetBlockAgreement
+//
+//	 returns which block hashes have been signed by how many guardian provers
+//	 for the given block ID, compared against the majority and the canonical L2 chain.
+//
+//			@Summary		Get block agreement
+//			@ID			   	get-block-agreement
+//			@Accept			json
+//			@Produce		json
+//			@Success		200	{object} guardianproverhealthcheck.BlockAgreement
+//			@Router			/blockAgreement/:blockID [get]
+
+func (srv *Server) GetBlockAgreement(c echo.Context) error {
+	blockID, err := strconv.ParseUint(c.Param("blockID"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	signedBlocks, err := srv.signedBlockRepo.GetByBlockID(c.Request().Context(), blockID)
+	if err != nil {
+		log.Error("Failed to get signed blocks by block ID", "error", err, "blockID", blockID)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	canonicalHash, err := srv.canonicalBlockHash(c.Request().Context(), blockID)
+	if err != nil {
+		log.Error("Failed to get canonical L2 block hash", "error", err, "blockID", blockID)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		guardianproverhealthcheck.ComputeBlockAgreement(blockID, signedBlocks, canonicalHash),
+	)
+}
+
+// canonicalBlockHash returns the hash of the canonical L2 block with the given ID, or an
+// empty string if the block does not exist yet or no L2 client has been configured. Found
+// hashes are cached for a short while, so that listing divergences does not look up every
+// block again on each request.
+func (srv *Server) canonicalBlockHash(ctx context.Context, blockID uint64) (string, error) {
+	key := strconv.FormatUint(blockID, 10)
+	if hash, ok := srv.canonicalHashes.Get(key); ok {
+		return hash.(string), nil
+	}
+
+	if srv.ethClient == nil {
+		return "", nil
+	}
+
+	header, err := srv.ethClient.HeaderByNumber(ctx, new(big.Int).SetUint64(blockID))
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	srv.canonicalHashes.SetDefault(key, header.Hash().Hex())
+
+	return header.Hash().Hex(), nil
+}
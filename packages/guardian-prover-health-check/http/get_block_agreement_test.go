@@ -0,0 +1,71 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cyberhorsey/webutils/testutils"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	guardianproverhealthcheck "github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check"
+)
+
+func Test_GetBlockAgreement(t *testing.T) {
+	srv := newTestServer("")
+
+	for i, hash := range []string{"0x1", "0x1", "0x2"} {
+		err := srv.signedBlockRepo.Save(context.Background(), &guardianproverhealthcheck.SaveSignedBlockOpts{
+			GuardianProverID: uint64(i),
+			BlockID:          1,
+			BlockHash:        hash,
+			Signature:        "0x456",
+			RecoveredAddress: fmt.Sprintf("0x%v", i),
+		})
+		assert.Nil(t, err)
+	}
+
+	tests := []struct {
+		name                  string
+		blockID               string
+		wantStatus            int
+		wantBodyRegexpMatches []string
+	}{
+		{
+			"success",
+			"1",
+			http.StatusOK,
+			[]string{`"numSigners":3`, `"divergent":\["0x2"\]`},
+		},
+		{
+			"noSignedBlocks",
+			"2",
+			http.StatusOK,
+			[]string{`"blockID":2`, `"numSigners":0`, `"hashes":\[\]`},
+		},
+		{
+			"invalidBlockID",
+			"abc",
+			http.StatusBadRequest,
+			[]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testutils.NewUnauthenticatedRequest(
+				echo.GET,
+				fmt.Sprintf("/blockAgreement/%v", tt.blockID),
+				nil,
+			)
+
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+
+			testutils.AssertStatusAndBody(t, rec, tt.wantStatus, tt.wantBodyRegexpMatches)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"net/http"
+
+	echo "github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	guardianproverhealthcheck "github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check"
+)
+
+// map of guardian prover address to its divergence counts
+type divergenceResponse map[string]*guardianproverhealthcheck.GuardianDivergence
+
+// GetDivergences
+//
+//	 returns, for each guardian prover, how many of its recently signed blocks
+//	 diverge from the guardian prover majority and from the canonical L2 chain.
+//
+//			@Summary		Get guardian prover divergences
+//			@ID			   	get-divergences
+//			@Accept			json
+//			@Produce		json
+//			@Success		200	{object} divergenceResponse
+//			@Router			/divergences [get]
+//		    @Param			start	query		string		false	"starting block ID"
+
+func (srv *Server) GetDivergences(c echo.Context) error {
+	start, err := srv.startingBlockID(c)
+	if err != nil {
+		return err
+	}
+
+	signedBlocks, err := srv.signedBlockRepo.GetByStartingBlockID(
+		c.Request().Context(),
+		guardianproverhealthcheck.GetSignedBlocksByStartingBlockIDOpts{
+			StartingBlockID: start,
+		},
+	)
+	if err != nil {
+		log.Error("Failed to get signed blocks", "error", err, "start", start)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	canonicalHashes := make(map[uint64]string)
+
+	for _, v := range signedBlocks {
+		if _, ok := canonicalHashes[v.BlockID]; ok {
+			continue
+		}
+
+		canonicalHash, err := srv.canonicalBlockHash(c.Request().Context(), v.BlockID)
+		if err != nil {
+			log.Error("Failed to get canonical L2 block hash", "error", err, "blockID", v.BlockID)
+			return echo.NewHTTPError(http.StatusInternalServerError, err)
+		}
+
+		canonicalHashes[v.BlockID] = canonicalHash
+	}
+
+	return c.JSON(
+		http.StatusOK,
+		divergenceResponse(guardianproverhealthcheck.ComputeGuardianDivergences(signedBlocks, canonicalHashes)),
+	)
+}
@@ -37,6 +37,49 @@ type blockResponse map[uint64][]block
 //		    @Param			start	query		string		false	"unix timestamp of starting block"
 
 func (srv *Server) GetSignedBlocks(c echo.Context) error {
+	start, err := srv.startingBlockID(c)
+	if err != nil {
+		return err
+	}
+
+	signedBlocks, err := srv.signedBlockRepo.GetByStartingBlockID(
+		c.Request().Context(),
+		guardianproverhealthcheck.GetSignedBlocksByStartingBlockIDOpts{
+			StartingBlockID: start,
+		},
+	)
+
+	if err != nil {
+		log.Error("Failed to get latest L2 block", "error", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	// sort signed blocks for easier to consume data
+	blocks := make(blockResponse)
+	// then iterate over each one and create a more easily parsable api response
+	// for the frontend to consume, arranged by a mapping of block ID
+	// to the signed blocks for each prover by that block ID.
+	for _, v := range signedBlocks {
+		b := block{
+			GuardianProverID:      v.GuardianProverID,
+			GuardianProverAddress: v.RecoveredAddress,
+			BlockHash:             v.BlockHash,
+			Signature:             v.Signature,
+		}
+
+		if _, ok := blocks[v.BlockID]; !ok {
+			blocks[v.BlockID] = make([]block, 0)
+		}
+
+		blocks[v.BlockID] = append(blocks[v.BlockID], b)
+	}
+
+	return c.JSON(http.StatusOK, blocks)
+}
+
+// startingBlockID returns the block ID to start returning signed blocks from, either
+// from the optional `start` query param, or the latest L2 block rewound by numBlocks.
+func (srv *Server) startingBlockID(c echo.Context) (uint64, error) {
 	// getSignedBlocks should rewind either startingBlockID - numBlocksToReturn if startingBlockID
 	// is passed in, but it is optional, so if it is not, we should get latest and rewind from
 	// there.
@@ -48,7 +91,7 @@ func (srv *Server) GetSignedBlocks(c echo.Context) error {
 		start, err = strconv.ParseUint(c.QueryParam("start"), 10, 64)
 		if err != nil {
 			log.Error("Failed to parse start", "error", err)
-			return echo.NewHTTPError(http.StatusBadRequest, err)
+			return 0, echo.NewHTTPError(http.StatusBadRequest, err)
 		}
 	}
 
@@ -59,7 +102,7 @@ func (srv *Server) GetSignedBlocks(c echo.Context) error {
 		if err != nil {
 			if err != nil {
 				log.Error("Failed to get latest L2 block", "error", err)
-				return echo.NewHTTPError(http.StatusInternalServerError, err)
+				return 0, echo.NewHTTPError(http.StatusInternalServerError, err)
 			}
 		}
 
@@ -74,44 +117,12 @@ func (srv *Server) GetSignedBlocks(c echo.Context) error {
 			)
 			if err != nil {
 				log.Error("Failed to get L2 block", "error", err, "blockNum", blockNum)
-				return echo.NewHTTPError(http.StatusInternalServerError, err)
+				return 0, echo.NewHTTPError(http.StatusInternalServerError, err)
 			}
 
 			start = block.NumberU64()
 		}
 	}
 
-	signedBlocks, err := srv.signedBlockRepo.GetByStartingBlockID(
-		c.Request().Context(),
-		guardianproverhealthcheck.GetSignedBlocksByStartingBlockIDOpts{
-			StartingBlockID: start,
-		},
-	)
-
-	if err != nil {
-		log.Error("Failed to get latest L2 block", "error", err)
-		return echo.NewHTTPError(http.StatusInternalServerError, err)
-	}
-
-	// sort signed blocks for easier to consume data
-	blocks := make(blockResponse)
-	// then iterate over each one and create a more easily parsable api response
-	// for the frontend to consume, arranged by a mapping of block ID
-	// to the signed blocks for each prover by that block ID.
-	for _, v := range signedBlocks {
-		b := block{
-			GuardianProverID:      v.GuardianProverID,
-			GuardianProverAddress: v.RecoveredAddress,
-			BlockHash:             v.BlockHash,
-			Signature:             v.Signature,
-		}
-
-		if _, ok := blocks[v.BlockID]; !ok {
-			blocks[v.BlockID] = make([]block, 0)
-		}
-
-		blocks[v.BlockID] = append(blocks[v.BlockID], b)
-	}
-
-	return c.JSON(http.StatusOK, blocks)
+	return start, nil
 }
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	echo "github.com/labstack/echo/v4"
+
+	guardianproverauth "github.com/taikoxyz/taiko-mono/packages/guardian-prover-auth"
+)
+
+var (
+	defaultHeartbeatMaxAge = 1 * time.Minute
+)
+
+var (
+	errUnsupportedHeartbeatVersion = errors.New("unsupported heartbeat version")
+	errStaleHeartbeat              = errors.New("heartbeat timestamp outside of accepted window")
+	errReplayedHeartbeat           = errors.New("heartbeat nonce has already been used")
+	errMissingHeartbeatNonce       = errors.New("heartbeat nonce is missing")
+)
+
+type heartbeatProtocolResponse struct {
+	Versions []uint64 `json:"versions"`
+}
+
+// GetHeartbeatProtocol
+//
+//	 returns the heartbeat protocol versions accepted by this server, so guardian provers
+//	 can negotiate which payload format to send.
+//
+//			@Summary		Get heartbeat protocol versions
+//			@ID			   	get-heartbeat-protocol
+//			@Accept			json
+//			@Produce		json
+//			@Success		200	{object} heartbeatProtocolResponse
+//			@Router			/heartbeatProtocol [get]
+
+func (srv *Server) GetHeartbeatProtocol(c echo.Context) error {
+	versions := []uint64{guardianproverauth.HeartbeatVersion}
+
+	if srv.allowLegacyHeartbeats {
+		versions = append([]uint64{guardianproverauth.HeartbeatVersionLegacy}, versions...)
+	}
+
+	return c.JSON(http.StatusOK, heartbeatProtocolResponse{Versions: versions})
+}
+
+// verifyHeartbeatAuth checks that an already signature-verified heartbeat or startup message
+// is fresh, has not been seen before, and references L1/L2 heads that exist on the chain.
+func (srv *Server) verifyHeartbeatAuth(
+	ctx context.Context,
+	prover common.Address,
+	auth *guardianproverauth.HeartbeatAuth,
+) error {
+	if auth.IsLegacy() {
+		if !srv.allowLegacyHeartbeats {
+			return errUnsupportedHeartbeatVersion
+		}
+
+		return nil
+	}
+
+	if auth.Version != guardianproverauth.HeartbeatVersion {
+		return errUnsupportedHeartbeatVersion
+	}
+
+	if auth.Nonce == "" {
+		return errMissingHeartbeatNonce
+	}
+
+	age := time.Since(time.Unix(int64(auth.Timestamp), 0))
+	if age > srv.heartbeatMaxAge || age < -srv.heartbeatMaxAge {
+		return errStaleHeartbeat
+	}
+
+	// the nil checks must be done before the clients are converted to the headReader
+	// interface, a typed nil pointer would not be nil anymore.
+	if srv.l1EthClient != nil {
+		if err := verifyHead(
+			ctx, srv.l1EthClient, srv.headTolerance, srv.reorgDepth, auth.LatestL1Block, auth.LatestL1BlockHash,
+		); err != nil {
+			return fmt.Errorf("invalid L1 head: %w", err)
+		}
+	}
+
+	if srv.ethClient != nil {
+		if err := verifyHead(
+			ctx, srv.ethClient, srv.headTolerance, srv.reorgDepth, auth.LatestL2Block, auth.LatestL2BlockHash,
+		); err != nil {
+			return fmt.Errorf("invalid L2 head: %w", err)
+		}
+	}
+
+	// a nonce only needs to be remembered for as long as the timestamp check would
+	// still accept the message.
+	key := fmt.Sprintf("%v-%v", prover.Hex(), common.HexToHash(auth.Nonce).Hex())
+	if err := srv.heartbeatNonces.Add(key, struct{}{}, 2*srv.heartbeatMaxAge); err != nil {
+		return errReplayedHeartbeat
+	}
+
+	return nil
+}
+
+// headReader reads the chain heads the guardian provers sign, it is implemented by
+// *ethclient.Client.
+type headReader interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// verifyHead checks that the block with the given number exists and has the given hash.
+// The server's own node may lag behind, or be on a different fork than the guardian
+// prover's node, so heads up to headTolerance blocks ahead of the local head, and hash
+// mismatches of the reorgDepth most recent blocks, are accepted.
+func verifyHead(
+	ctx context.Context,
+	client headReader,
+	headTolerance uint64,
+	reorgDepth uint64,
+	number uint64,
+	hash string,
+) error {
+	localHead, err := client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	if number > localHead {
+		if number-localHead > headTolerance {
+			return fmt.Errorf("block %v is too far ahead of the local head %v", number, localHead)
+		}
+
+		return nil
+	}
+
+	header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return fmt.Errorf("block %v not found", number)
+		}
+
+		return err
+	}
+
+	if header.Hash() != common.HexToHash(hash) && localHead-number >= reorgDepth {
+		return fmt.Errorf("block %v hash mismatch, expected %v, got %v", number, header.Hash().Hex(), hash)
+	}
+
+	return nil
+}
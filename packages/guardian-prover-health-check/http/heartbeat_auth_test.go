@@ -0,0 +1,110 @@
+package http
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeHeadReader struct {
+	head    uint64
+	headers map[uint64]*types.Header
+}
+
+func newFakeHeadReader(head uint64) *fakeHeadReader {
+	r := &fakeHeadReader{head: head, headers: make(map[uint64]*types.Header)}
+
+	for i := uint64(0); i <= head; i++ {
+		r.headers[i] = &types.Header{Number: new(big.Int).SetUint64(i), Difficulty: common.Big0}
+	}
+
+	return r
+}
+
+func (r *fakeHeadReader) BlockNumber(_ context.Context) (uint64, error) {
+	return r.head, nil
+}
+
+func (r *fakeHeadReader) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	header, ok := r.headers[number.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+
+	return header, nil
+}
+
+func Test_verifyHead(t *testing.T) {
+	client := newFakeHeadReader(100)
+	wrongHash := common.BigToHash(big.NewInt(1)).Hex()
+
+	tests := []struct {
+		name       string
+		reorgDepth uint64
+		number     uint64
+		hash       string
+		wantErr    bool
+	}{
+		{
+			"success",
+			8,
+			50,
+			client.headers[50].Hash().Hex(),
+			false,
+		},
+		{
+			"localHead",
+			8,
+			100,
+			client.headers[100].Hash().Hex(),
+			false,
+		},
+		{
+			"aheadWithinTolerance",
+			8,
+			102,
+			wrongHash,
+			false,
+		},
+		{
+			"aheadOutsideTolerance",
+			8,
+			103,
+			wrongHash,
+			true,
+		},
+		{
+			"recentMismatch",
+			8,
+			93,
+			wrongHash,
+			false,
+		},
+		{
+			"oldMismatch",
+			8,
+			92,
+			wrongHash,
+			true,
+		},
+		{
+			"localHeadMismatchWithoutReorgDepth",
+			0,
+			100,
+			wrongHash,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyHead(context.Background(), client, 2, tt.reorgDepth, tt.number, tt.hash)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
@@ -5,20 +5,16 @@ import (
 	"log/slog"
 	"net/http"
 
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/common"
 	echo "github.com/labstack/echo/v4"
+	guardianproverauth "github.com/taikoxyz/taiko-mono/packages/guardian-prover-auth"
 	guardianproverhealthcheck "github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check"
 )
 
-var (
-	msg = crypto.Keccak256Hash([]byte("HEART_BEAT")).Bytes()
-)
-
 type healthCheckReq struct {
+	guardianproverauth.HeartbeatAuth
 	ProverAddress      string `json:"prover"`
 	HeartBeatSignature string `json:"heartBeatSignature"`
-	LatestL1Block      uint64 `json:"latestL1Block"`
-	LatestL2Block      uint64 `json:"latestL2Block"`
 }
 
 // PostHealthCheck
@@ -45,13 +41,15 @@ func (srv *Server) PostHealthCheck(c echo.Context) error {
 	}
 
 	recoveredGuardianProver, err := guardianproverhealthcheck.SignatureToGuardianProver(
-		msg,
+		guardianproverauth.HeartbeatMessage(common.HexToAddress(req.ProverAddress), &req.HeartbeatAuth),
 		req.HeartBeatSignature,
 		srv.guardianProvers,
 	)
 
 	// if not, we want to return an error
 	if err != nil {
+		guardianproverhealthcheck.RejectedHeartbeats.Inc()
+
 		slog.Error("error recovering guardian prover",
 			"error", err, "signature", req.HeartBeatSignature,
 		)
@@ -59,6 +57,24 @@ func (srv *Server) PostHealthCheck(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, err)
 	}
 
+	// reject stale or replayed heartbeats, and heartbeats for heads which do not exist.
+	if err := srv.verifyHeartbeatAuth(
+		c.Request().Context(),
+		recoveredGuardianProver.Address,
+		&req.HeartbeatAuth,
+	); err != nil {
+		guardianproverhealthcheck.RejectedHeartbeats.Inc()
+
+		slog.Error("error verifying heartbeat",
+			"error", err,
+			"guardianProver", recoveredGuardianProver.Address.Hex(),
+			"version", req.Version,
+			"timestamp", req.Timestamp,
+		)
+
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
 	// otherwise, we can store it in the database.
 	// expected address and recovered address will be the same until we have an auth
 	// mechanism which will allow us to store health checks that ecrecover to an unexpected
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cyberhorsey/webutils/testutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	guardianproverauth "github.com/taikoxyz/taiko-mono/packages/guardian-prover-auth"
+	guardianproverhealthcheck "github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check"
+)
+
+func Test_PostHealthCheck(t *testing.T) {
+	srv := newTestServer("")
+
+	key, err := crypto.GenerateKey()
+	assert.Nil(t, err)
+
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	srv.guardianProvers = append(srv.guardianProvers, guardianproverhealthcheck.GuardianProver{
+		Address:            address,
+		ID:                 common.Big1,
+		HealthCheckCounter: prometheus.NewCounter(prometheus.CounterOpts{Name: "test_health_checks"}),
+	})
+
+	newReq := func(auth guardianproverauth.HeartbeatAuth) healthCheckReq {
+		sig, err := crypto.Sign(guardianproverauth.HeartbeatMessage(address, &auth), key)
+		assert.Nil(t, err)
+
+		return healthCheckReq{
+			HeartbeatAuth:      auth,
+			ProverAddress:      address.Hex(),
+			HeartBeatSignature: base64.StdEncoding.EncodeToString(sig),
+		}
+	}
+
+	auth := guardianproverauth.HeartbeatAuth{
+		Version:           guardianproverauth.HeartbeatVersion,
+		Timestamp:         uint64(time.Now().Unix()),
+		Nonce:             common.BigToHash(big.NewInt(1)).Hex(),
+		LatestL1Block:     1,
+		LatestL1BlockHash: common.BigToHash(big.NewInt(2)).Hex(),
+		LatestL2Block:     3,
+		LatestL2BlockHash: common.BigToHash(big.NewInt(4)).Hex(),
+	}
+
+	stale := auth
+	stale.Nonce = common.BigToHash(big.NewInt(2)).Hex()
+	stale.Timestamp = uint64(time.Now().Add(-2 * defaultHeartbeatMaxAge).Unix())
+
+	tampered := newReq(auth)
+	tampered.LatestL2Block = 4
+
+	tests := []struct {
+		name       string
+		body       healthCheckReq
+		wantStatus int
+	}{
+		{
+			"success",
+			newReq(auth),
+			http.StatusOK,
+		},
+		{
+			"replayed",
+			newReq(auth),
+			http.StatusBadRequest,
+		},
+		{
+			"stale",
+			newReq(stale),
+			http.StatusBadRequest,
+		},
+		{
+			"tamperedPayload",
+			tampered,
+			http.StatusBadRequest,
+		},
+		{
+			"legacyNotAllowed",
+			newReq(guardianproverauth.HeartbeatAuth{LatestL1Block: 1, LatestL2Block: 3}),
+			http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testutils.NewUnauthenticatedRequest(
+				echo.POST,
+				"/healthCheck",
+				tt.body,
+			)
+
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+
+			testutils.AssertStatusAndBody(t, rec, tt.wantStatus, []string{})
+		})
+	}
+}
+
+func Test_GetHeartbeatProtocol(t *testing.T) {
+	srv := newTestServer("")
+
+	req := testutils.NewUnauthenticatedRequest(echo.GET, "/heartbeatProtocol", nil)
+	rec := httptest.NewRecorder()
+
+	srv.ServeHTTP(rec, req)
+
+	testutils.AssertStatusAndBody(t, rec, http.StatusOK, []string{`{"versions":\[2\]}`})
+}
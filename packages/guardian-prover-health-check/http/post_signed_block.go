@@ -1,22 +1,30 @@
 package http
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	echo "github.com/labstack/echo/v4"
+	"github.com/patrickmn/go-cache"
+	guardianproverauth "github.com/taikoxyz/taiko-mono/packages/guardian-prover-auth"
 	guardianproverhealthcheck "github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check"
 )
 
 type signedBlock struct {
+	Version   uint64         `json:"version"`
 	BlockID   uint64         `json:"blockID"`
 	BlockHash string         `json:"blockHash"`
 	Signature string         `json:"signature"`
 	Prover    common.Address `json:"proverAddress"`
 }
 
+var errUnverifiedLegacySignedBlock = errors.New("legacy signed block is not the canonical block of its block ID")
+
 // PostSignedBlock
 //
 //	 post a signed block to store in the database
@@ -39,7 +47,7 @@ func (srv *Server) PostSignedBlock(c echo.Context) error {
 	}
 
 	recoveredGuardianProver, err := guardianproverhealthcheck.SignatureToGuardianProver(
-		common.HexToHash(req.BlockHash).Bytes(),
+		guardianproverauth.SignedBlockMessage(req.Version, req.BlockID, common.HexToHash(req.BlockHash)),
 		req.Signature,
 		srv.guardianProvers,
 	)
@@ -51,6 +59,12 @@ func (srv *Server) PostSignedBlock(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, err)
 	}
 
+	if err := srv.verifySignedBlockVersion(c.Request().Context(), req); err != nil {
+		slog.Error("error verifying signed block", "error", err, "blockID", req.BlockID)
+
+		return c.JSON(http.StatusBadRequest, err)
+	}
+
 	// otherwise, we can store it in the database.
 	if err := srv.signedBlockRepo.Save(
 		c.Request().Context(),
@@ -79,7 +93,114 @@ func (srv *Server) PostSignedBlock(c echo.Context) error {
 		}
 	}
 
+	srv.checkSignedBlockAgreement(c.Request().Context(), recoveredGuardianProver, req)
+
 	slog.Info("successful signed block", "guardianProver", recoveredGuardianProver.Address.Hex())
 
 	return c.JSON(http.StatusOK, nil)
 }
+
+// verifySignedBlockVersion checks that the block ID of a signed block is covered by its signature. A
+// legacy signature only covers the block hash, and could be replayed for any other block ID, so it is
+// only accepted if legacy messages are allowed, and the hash is the canonical one of the block ID.
+func (srv *Server) verifySignedBlockVersion(ctx context.Context, req *signedBlock) error {
+	if !guardianproverauth.IsLegacyVersion(req.Version) {
+		if req.Version != guardianproverauth.HeartbeatVersion {
+			return errUnsupportedHeartbeatVersion
+		}
+
+		return nil
+	}
+
+	if !srv.allowLegacyHeartbeats {
+		return errUnsupportedHeartbeatVersion
+	}
+
+	canonicalHash, err := srv.canonicalBlockHash(ctx, req.BlockID)
+	if err != nil {
+		return err
+	}
+
+	if canonicalHash == "" || common.HexToHash(canonicalHash) != common.HexToHash(req.BlockHash) {
+		return errUnverifiedLegacySignedBlock
+	}
+
+	return nil
+}
+
+// checkSignedBlockAgreement recomputes the agreement between guardian provers for the block of a newly
+// signed block, and increments the divergence metrics of every guardian prover which now disagrees with
+// the majority or the canonical L2 chain. A late signature can change the majority, so that the guardian
+// provers which signed earlier are checked again as well. Each divergence is only counted once.
+func (srv *Server) checkSignedBlockAgreement(
+	ctx context.Context,
+	guardianProver *guardianproverhealthcheck.GuardianProver,
+	req *signedBlock,
+) {
+	signedBlocks, err := srv.signedBlockRepo.GetByBlockID(ctx, req.BlockID)
+	if err != nil {
+		slog.Error("error getting signed blocks by block ID", "error", err, "blockID", req.BlockID)
+
+		return
+	}
+
+	canonicalHash, err := srv.canonicalBlockHash(ctx, req.BlockID)
+	if err != nil {
+		guardianproverhealthcheck.SignedBlockCanonicalLookupErrors.Inc()
+
+		slog.Error("error getting canonical block hash", "error", err, "blockID", req.BlockID)
+	}
+
+	agreement := guardianproverhealthcheck.ComputeBlockAgreement(req.BlockID, signedBlocks, canonicalHash)
+
+	for _, b := range signedBlocks {
+		signer := srv.guardianProverByAddress(b.RecoveredAddress)
+		if signer == nil {
+			continue
+		}
+
+		if agreement.DivergesFromMajority(b.BlockHash) && srv.countDivergence(b, "majority") {
+			guardianproverhealthcheck.SignedBlockMajorityDivergences.Inc()
+			signer.MajorityDivergenceCounter.Inc()
+
+			slog.Warn("signed block diverges from guardian prover majority",
+				"guardianProver", b.RecoveredAddress,
+				"blockID", b.BlockID,
+				"blockHash", b.BlockHash,
+				"majorityHash", agreement.MajorityHash,
+				"latestSigner", guardianProver.Address.Hex(),
+			)
+		}
+
+		if agreement.DivergesFromCanonical(b.BlockHash) && srv.countDivergence(b, "canonical") {
+			guardianproverhealthcheck.SignedBlockCanonicalDivergences.Inc()
+			signer.CanonicalDivergenceCounter.Inc()
+
+			slog.Warn("signed block diverges from canonical chain",
+				"guardianProver", b.RecoveredAddress,
+				"blockID", b.BlockID,
+				"blockHash", b.BlockHash,
+				"canonicalHash", agreement.CanonicalHash,
+			)
+		}
+	}
+}
+
+// countDivergence marks the given kind of divergence of the signed block as counted, and reports
+// whether it has not been counted before.
+func (srv *Server) countDivergence(b *guardianproverhealthcheck.SignedBlock, kind string) bool {
+	key := fmt.Sprintf("%d-%s-%s-%s", b.BlockID, b.RecoveredAddress, b.BlockHash, kind)
+
+	return srv.countedDivergences.Add(key, struct{}{}, cache.DefaultExpiration) == nil
+}
+
+// guardianProverByAddress returns the configured guardian prover with the given address.
+func (srv *Server) guardianProverByAddress(address string) *guardianproverhealthcheck.GuardianProver {
+	for i, v := range srv.guardianProvers {
+		if v.Address.Hex() == address {
+			return &srv.guardianProvers[i]
+		}
+	}
+
+	return nil
+}
@@ -1,12 +1,23 @@
 package http
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/cyberhorsey/webutils/testutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	guardianproverauth "github.com/taikoxyz/taiko-mono/packages/guardian-prover-auth"
+	guardianproverhealthcheck "github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check"
 )
 
 func Test_PostSignedBlock(t *testing.T) {
@@ -44,3 +55,194 @@ func Test_PostSignedBlock(t *testing.T) {
 		})
 	}
 }
+
+func Test_PostSignedBlock_BlockID(t *testing.T) {
+	srv := newTestServer("")
+
+	key, err := crypto.GenerateKey()
+	assert.Nil(t, err)
+
+	srv.guardianProvers = append(srv.guardianProvers, guardianproverhealthcheck.GuardianProver{
+		Address:                    crypto.PubkeyToAddress(key.PublicKey),
+		ID:                         big.NewInt(1),
+		SignedBlockCounter:         prometheus.NewCounter(prometheus.CounterOpts{}),
+		MajorityDivergenceCounter:  prometheus.NewCounter(prometheus.CounterOpts{}),
+		CanonicalDivergenceCounter: prometheus.NewCounter(prometheus.CounterOpts{}),
+	})
+
+	blockHash := common.HexToHash("0x0a")
+	srv.canonicalHashes.SetDefault("1", blockHash.Hex())
+	srv.canonicalHashes.SetDefault("2", common.HexToHash("0x0b").Hex())
+
+	sign := func(version uint64, blockID uint64) string {
+		sig, err := crypto.Sign(guardianproverauth.SignedBlockMessage(version, blockID, blockHash), key)
+		assert.Nil(t, err)
+
+		return base64.StdEncoding.EncodeToString(sig)
+	}
+
+	tests := []struct {
+		name        string
+		allowLegacy bool
+		body        signedBlock
+		wantStatus  int
+	}{
+		{
+			"success",
+			false,
+			signedBlock{
+				Version:   guardianproverauth.HeartbeatVersion,
+				BlockID:   1,
+				BlockHash: blockHash.Hex(),
+				Signature: sign(guardianproverauth.HeartbeatVersion, 1),
+			},
+			http.StatusOK,
+		},
+		{
+			"replayedForAnotherBlockID",
+			false,
+			signedBlock{
+				Version:   guardianproverauth.HeartbeatVersion,
+				BlockID:   2,
+				BlockHash: blockHash.Hex(),
+				Signature: sign(guardianproverauth.HeartbeatVersion, 1),
+			},
+			http.StatusBadRequest,
+		},
+		{
+			"unsupportedVersion",
+			false,
+			signedBlock{
+				Version:   guardianproverauth.HeartbeatVersion + 1,
+				BlockID:   1,
+				BlockHash: blockHash.Hex(),
+				Signature: sign(guardianproverauth.HeartbeatVersion+1, 1),
+			},
+			http.StatusBadRequest,
+		},
+		{
+			"legacyNotAllowed",
+			false,
+			signedBlock{
+				BlockID:   1,
+				BlockHash: blockHash.Hex(),
+				Signature: sign(guardianproverauth.HeartbeatVersionLegacy, 1),
+			},
+			http.StatusBadRequest,
+		},
+		{
+			"legacyCanonical",
+			true,
+			signedBlock{
+				BlockID:   1,
+				BlockHash: blockHash.Hex(),
+				Signature: sign(guardianproverauth.HeartbeatVersionLegacy, 1),
+			},
+			http.StatusOK,
+		},
+		{
+			"legacyReplayedForAnotherBlockID",
+			true,
+			signedBlock{
+				BlockID:   2,
+				BlockHash: blockHash.Hex(),
+				Signature: sign(guardianproverauth.HeartbeatVersionLegacy, 1),
+			},
+			http.StatusBadRequest,
+		},
+		{
+			"legacyUnknownBlockID",
+			true,
+			signedBlock{
+				BlockID:   3,
+				BlockHash: blockHash.Hex(),
+				Signature: sign(guardianproverauth.HeartbeatVersionLegacy, 1),
+			},
+			http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv.allowLegacyHeartbeats = tt.allowLegacy
+
+			req := testutils.NewUnauthenticatedRequest(echo.POST, "/signedBlock", tt.body)
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func Test_PostSignedBlock_LateSignerChangesMajority(t *testing.T) {
+	srv := newTestServer("")
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		assert.Nil(t, err)
+
+		keys[i] = key
+		srv.guardianProvers = append(srv.guardianProvers, guardianproverhealthcheck.GuardianProver{
+			Address:                    crypto.PubkeyToAddress(key.PublicKey),
+			ID:                         big.NewInt(int64(i)),
+			SignedBlockCounter:         prometheus.NewCounter(prometheus.CounterOpts{}),
+			MajorityDivergenceCounter:  prometheus.NewCounter(prometheus.CounterOpts{}),
+			CanonicalDivergenceCounter: prometheus.NewCounter(prometheus.CounterOpts{}),
+		})
+	}
+
+	hashA := common.HexToHash("0x0a").Hex()
+	hashB := common.HexToHash("0x0b").Hex()
+
+	postSignedBlock := func(key *ecdsa.PrivateKey, blockHash string) {
+		sig, err := crypto.Sign(
+			guardianproverauth.SignedBlockMessage(guardianproverauth.HeartbeatVersion, 1, common.HexToHash(blockHash)),
+			key,
+		)
+		assert.Nil(t, err)
+
+		req := testutils.NewUnauthenticatedRequest(echo.POST, "/signedBlock", signedBlock{
+			Version:   guardianproverauth.HeartbeatVersion,
+			BlockID:   1,
+			BlockHash: blockHash,
+			Signature: base64.StdEncoding.EncodeToString(sig),
+			Prover:    crypto.PubkeyToAddress(key.PublicKey),
+		})
+
+		rec := httptest.NewRecorder()
+
+		srv.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	majorityDivergences := func(i int) float64 {
+		m := &dto.Metric{}
+		assert.Nil(t, srv.guardianProvers[i].MajorityDivergenceCounter.Write(m))
+
+		return m.GetCounter().GetValue()
+	}
+
+	// No majority yet, nobody diverges.
+	postSignedBlock(keys[0], hashA)
+	postSignedBlock(keys[1], hashB)
+	assert.Equal(t, float64(0), majorityDivergences(0))
+	assert.Equal(t, float64(0), majorityDivergences(1))
+
+	// The late signer makes hashB the majority, so the first signer diverges now.
+	postSignedBlock(keys[2], hashB)
+	assert.Equal(t, float64(1), majorityDivergences(0))
+	assert.Equal(t, float64(0), majorityDivergences(1))
+	assert.Equal(t, float64(0), majorityDivergences(2))
+
+	// The same divergence is only counted once.
+	srv.checkSignedBlockAgreement(
+		context.Background(),
+		&srv.guardianProvers[2],
+		&signedBlock{BlockID: 1, BlockHash: hashB},
+	)
+	assert.Equal(t, float64(1), majorityDivergences(0))
+}
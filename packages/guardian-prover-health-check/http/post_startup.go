@@ -6,12 +6,13 @@ import (
 	"net/http"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	echo "github.com/labstack/echo/v4"
+	guardianproverauth "github.com/taikoxyz/taiko-mono/packages/guardian-prover-auth"
 	guardianproverhealthcheck "github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check"
 )
 
 type startupReq struct {
+	guardianproverauth.HeartbeatAuth
 	ProverAddress   string `json:"prover"`
 	GuardianVersion string `json:"guardianVersion"`
 	L1NodeVersion   string `json:"l1NodeVersion"`
@@ -39,13 +40,14 @@ func (srv *Server) PostStartup(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, err)
 	}
 
-	msg := crypto.Keccak256Hash(
-		common.HexToAddress(req.ProverAddress).Bytes(),
-		[]byte(req.Revision),
-		[]byte(req.GuardianVersion),
-		[]byte(req.L1NodeVersion),
-		[]byte(req.L2NodeVersion),
-	).Bytes()
+	msg := guardianproverauth.StartupMessage(
+		common.HexToAddress(req.ProverAddress),
+		req.Revision,
+		req.GuardianVersion,
+		req.L1NodeVersion,
+		req.L2NodeVersion,
+		&req.HeartbeatAuth,
+	)
 
 	recoveredGuardianProver, err := guardianproverhealthcheck.SignatureToGuardianProver(
 		msg,
@@ -55,9 +57,29 @@ func (srv *Server) PostStartup(c echo.Context) error {
 
 	// if not, we want to return an error
 	if err != nil {
+		guardianproverhealthcheck.RejectedHeartbeats.Inc()
+
 		return c.JSON(http.StatusBadRequest, err)
 	}
 
+	// reject stale or replayed startup messages, and startups for heads which do not exist.
+	if err := srv.verifyHeartbeatAuth(
+		c.Request().Context(),
+		recoveredGuardianProver.Address,
+		&req.HeartbeatAuth,
+	); err != nil {
+		guardianproverhealthcheck.RejectedHeartbeats.Inc()
+
+		slog.Error("error verifying startup",
+			"error", err,
+			"guardianProver", recoveredGuardianProver.Address.Hex(),
+			"version", req.Version,
+			"timestamp", req.Timestamp,
+		)
+
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
 	// otherwise, we can store it in the database.
 	// expected address and recovered address will be the same until we have an auth
 	// mechanism which will allow us to store health checks that ecrecover to an unexpected
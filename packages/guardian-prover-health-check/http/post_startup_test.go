@@ -0,0 +1,139 @@
+package http
+
+import (
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cyberhorsey/webutils/testutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	guardianproverauth "github.com/taikoxyz/taiko-mono/packages/guardian-prover-auth"
+	guardianproverhealthcheck "github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check"
+)
+
+func Test_PostStartup(t *testing.T) {
+	srv := newTestServer("")
+
+	key, err := crypto.GenerateKey()
+	assert.Nil(t, err)
+
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	srv.guardianProvers = append(srv.guardianProvers, guardianproverhealthcheck.GuardianProver{
+		Address: address,
+		ID:      common.Big1,
+	})
+
+	newReq := func(auth guardianproverauth.HeartbeatAuth) startupReq {
+		req := startupReq{
+			HeartbeatAuth:   auth,
+			ProverAddress:   address.Hex(),
+			GuardianVersion: "v1.0.0",
+			L1NodeVersion:   "geth/v1",
+			L2NodeVersion:   "taiko-geth/v1",
+			Revision:        "abc123",
+		}
+
+		sig, err := crypto.Sign(guardianproverauth.StartupMessage(
+			address,
+			req.Revision,
+			req.GuardianVersion,
+			req.L1NodeVersion,
+			req.L2NodeVersion,
+			&auth,
+		), key)
+		assert.Nil(t, err)
+
+		req.Signature = base64.StdEncoding.EncodeToString(sig)
+
+		return req
+	}
+
+	auth := guardianproverauth.HeartbeatAuth{
+		Version:           guardianproverauth.HeartbeatVersion,
+		Timestamp:         uint64(time.Now().Unix()),
+		Nonce:             common.BigToHash(big.NewInt(1)).Hex(),
+		LatestL1Block:     1,
+		LatestL1BlockHash: common.BigToHash(big.NewInt(2)).Hex(),
+		LatestL2Block:     3,
+		LatestL2BlockHash: common.BigToHash(big.NewInt(4)).Hex(),
+	}
+
+	stale := auth
+	stale.Nonce = common.BigToHash(big.NewInt(2)).Hex()
+	stale.Timestamp = uint64(time.Now().Add(-2 * defaultHeartbeatMaxAge).Unix())
+
+	tampered := newReq(auth)
+	tampered.Revision = "def456"
+
+	tests := []struct {
+		name       string
+		body       startupReq
+		wantStatus int
+	}{
+		{
+			"success",
+			newReq(auth),
+			http.StatusOK,
+		},
+		{
+			"replayed",
+			newReq(auth),
+			http.StatusBadRequest,
+		},
+		{
+			"stale",
+			newReq(stale),
+			http.StatusBadRequest,
+		},
+		{
+			"tamperedPayload",
+			tampered,
+			http.StatusBadRequest,
+		},
+		{
+			"legacyNotAllowed",
+			newReq(guardianproverauth.HeartbeatAuth{LatestL1Block: 1, LatestL2Block: 3}),
+			http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testutils.NewUnauthenticatedRequest(
+				echo.POST,
+				"/startup",
+				tt.body,
+			)
+
+			rec := httptest.NewRecorder()
+
+			srv.ServeHTTP(rec, req)
+
+			testutils.AssertStatusAndBody(t, rec, tt.wantStatus, []string{})
+		})
+	}
+
+	srv.allowLegacyHeartbeats = true
+
+	t.Run("legacyAllowed", func(t *testing.T) {
+		req := testutils.NewUnauthenticatedRequest(
+			echo.POST,
+			"/startup",
+			newReq(guardianproverauth.HeartbeatAuth{LatestL1Block: 1, LatestL2Block: 3}),
+		)
+
+		rec := httptest.NewRecorder()
+
+		srv.ServeHTTP(rec, req)
+
+		testutils.AssertStatusAndBody(t, rec, http.StatusOK, []string{})
+	})
+}
@@ -18,8 +18,14 @@ func (srv *Server) configureRoutes() {
 
 	srv.echo.POST("/signedBlock", srv.PostSignedBlock)
 
+	srv.echo.GET("/blockAgreement/:blockID", srv.GetBlockAgreement)
+
+	srv.echo.GET("/divergences", srv.GetDivergences)
+
 	srv.echo.POST("/healthCheck", srv.PostHealthCheck)
 
+	srv.echo.GET("/heartbeatProtocol", srv.GetHeartbeatProtocol)
+
 	srv.echo.GET("/startups/:address", srv.GetStartupsByGuardianProverAddress)
 
 	srv.echo.GET("/mostRecentStartup/:address", srv.GetMostRecentStartupByGuardianProverAddress)
@@ -4,14 +4,25 @@ import (
 	"context"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/patrickmn/go-cache"
 	guardianproverhealthcheck "github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check"
 
 	echo "github.com/labstack/echo/v4"
 )
 
+var (
+	// canonicalHashCacheTTL is how long a canonical L2 block hash is cached, short enough to
+	// follow L2 reorgs.
+	canonicalHashCacheTTL = 1 * time.Minute
+	// countedDivergenceTTL is how long a counted divergence is remembered, so that it is not
+	// counted again when more guardian provers sign the same block.
+	countedDivergenceTTL = 24 * time.Hour
+)
+
 // @title Taiko Guardian Prover Health Check API
 // @version 1.0
 // @termsOfService http://swagger.io/terms/
@@ -25,32 +36,58 @@ import (
 // @host healthcheck.internal.taiko.xyz
 // Server represents a guardian prover health check http server instance.
 type Server struct {
-	echo            *echo.Echo
-	ethClient       *ethclient.Client
-	healthCheckRepo guardianproverhealthcheck.HealthCheckRepository
-	signedBlockRepo guardianproverhealthcheck.SignedBlockRepository
-	startupRepo     guardianproverhealthcheck.StartupRepository
-	guardianProvers []guardianproverhealthcheck.GuardianProver
+	echo                  *echo.Echo
+	ethClient             *ethclient.Client
+	l1EthClient           *ethclient.Client
+	healthCheckRepo       guardianproverhealthcheck.HealthCheckRepository
+	signedBlockRepo       guardianproverhealthcheck.SignedBlockRepository
+	startupRepo           guardianproverhealthcheck.StartupRepository
+	guardianProvers       []guardianproverhealthcheck.GuardianProver
+	heartbeatMaxAge       time.Duration
+	allowLegacyHeartbeats bool
+	headTolerance         uint64
+	reorgDepth            uint64
+	heartbeatNonces       *cache.Cache
+	canonicalHashes       *cache.Cache
+	countedDivergences    *cache.Cache
 }
 
 type NewServerOpts struct {
-	Echo            *echo.Echo
-	EthClient       *ethclient.Client
-	HealthCheckRepo guardianproverhealthcheck.HealthCheckRepository
-	SignedBlockRepo guardianproverhealthcheck.SignedBlockRepository
-	StartupRepo     guardianproverhealthcheck.StartupRepository
-	CorsOrigins     []string
-	GuardianProvers []guardianproverhealthcheck.GuardianProver
+	Echo                  *echo.Echo
+	EthClient             *ethclient.Client
+	L1EthClient           *ethclient.Client
+	HealthCheckRepo       guardianproverhealthcheck.HealthCheckRepository
+	SignedBlockRepo       guardianproverhealthcheck.SignedBlockRepository
+	StartupRepo           guardianproverhealthcheck.StartupRepository
+	CorsOrigins           []string
+	GuardianProvers       []guardianproverhealthcheck.GuardianProver
+	HeartbeatMaxAge       time.Duration
+	AllowLegacyHeartbeats bool
+	HeadTolerance         uint64
+	ReorgDepth            uint64
 }
 
 func NewServer(opts NewServerOpts) (*Server, error) {
+	heartbeatMaxAge := opts.HeartbeatMaxAge
+	if heartbeatMaxAge == 0 {
+		heartbeatMaxAge = defaultHeartbeatMaxAge
+	}
+
 	srv := &Server{
-		echo:            opts.Echo,
-		ethClient:       opts.EthClient,
-		healthCheckRepo: opts.HealthCheckRepo,
-		guardianProvers: opts.GuardianProvers,
-		signedBlockRepo: opts.SignedBlockRepo,
-		startupRepo:     opts.StartupRepo,
+		echo:                  opts.Echo,
+		ethClient:             opts.EthClient,
+		l1EthClient:           opts.L1EthClient,
+		healthCheckRepo:       opts.HealthCheckRepo,
+		guardianProvers:       opts.GuardianProvers,
+		signedBlockRepo:       opts.SignedBlockRepo,
+		startupRepo:           opts.StartupRepo,
+		heartbeatMaxAge:       heartbeatMaxAge,
+		allowLegacyHeartbeats: opts.AllowLegacyHeartbeats,
+		headTolerance:         opts.HeadTolerance,
+		reorgDepth:            opts.ReorgDepth,
+		heartbeatNonces:       cache.New(2*heartbeatMaxAge, heartbeatMaxAge),
+		canonicalHashes:       cache.New(canonicalHashCacheTTL, 2*canonicalHashCacheTTL),
+		countedDivergences:    cache.New(countedDivergenceTTL, countedDivergenceTTL),
 	}
 
 	corsOrigins := opts.CorsOrigins
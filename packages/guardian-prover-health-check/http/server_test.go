@@ -8,6 +8,7 @@ import (
 
 	"github.com/joho/godotenv"
 	echo "github.com/labstack/echo/v4"
+	"github.com/patrickmn/go-cache"
 	"github.com/stretchr/testify/assert"
 	guardianproverhealthcheck "github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check"
 	"github.com/taikoxyz/taiko-mono/packages/guardian-prover-health-check/mock"
@@ -17,11 +18,15 @@ func newTestServer(url string) *Server {
 	_ = godotenv.Load("../.test.env")
 
 	srv := &Server{
-		echo:            echo.New(),
-		healthCheckRepo: mock.NewHealthCheckRepository(),
-		signedBlockRepo: mock.NewSignedBlockRepository(),
-		startupRepo:     mock.NewStartupRepository(),
-		guardianProvers: make([]guardianproverhealthcheck.GuardianProver, 0),
+		echo:               echo.New(),
+		healthCheckRepo:    mock.NewHealthCheckRepository(),
+		signedBlockRepo:    mock.NewSignedBlockRepository(),
+		startupRepo:        mock.NewStartupRepository(),
+		guardianProvers:    make([]guardianproverhealthcheck.GuardianProver, 0),
+		heartbeatMaxAge:    defaultHeartbeatMaxAge,
+		heartbeatNonces:    cache.New(2*defaultHeartbeatMaxAge, defaultHeartbeatMaxAge),
+		canonicalHashes:    cache.New(canonicalHashCacheTTL, 2*canonicalHashCacheTTL),
+		countedDivergences: cache.New(countedDivergenceTTL, countedDivergenceTTL),
 	}
 
 	srv.configureMiddleware([]string{"*"})
@@ -45,6 +45,21 @@ func (r *SignedBlockRepo) GetByStartingBlockID(
 	return sb, nil
 }
 
+func (r *SignedBlockRepo) GetByBlockID(
+	ctx context.Context,
+	blockID uint64,
+) ([]*guardianproverhealthcheck.SignedBlock, error) {
+	sb := make([]*guardianproverhealthcheck.SignedBlock, 0)
+
+	for _, v := range r.signedBlocks {
+		if v.BlockID == blockID {
+			sb = append(sb, v)
+		}
+	}
+
+	return sb, nil
+}
+
 func (r *SignedBlockRepo) GetMostRecentByGuardianProverAddress(
 	ctx context.Context,
 	address string,
@@ -10,4 +10,20 @@ var (
 		Name: "events_processed_ops_total",
 		Help: "The total number of processed events",
 	})
+	RejectedHeartbeats = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rejected_heartbeats_ops_total",
+		Help: "The total number of rejected stale, replayed or otherwise invalid heartbeats and startups",
+	})
+	SignedBlockMajorityDivergences = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "signed_block_majority_divergences_ops_total",
+		Help: "The total number of signed blocks whose hash differs from the guardian prover majority",
+	})
+	SignedBlockCanonicalDivergences = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "signed_block_canonical_divergences_ops_total",
+		Help: "The total number of signed blocks whose hash differs from the canonical L2 block hash",
+	})
+	SignedBlockCanonicalLookupErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "signed_block_canonical_lookup_errors_ops_total",
+		Help: "The total number of failed canonical L2 block hash lookups",
+	})
 )
@@ -54,6 +54,19 @@ func (r *SignedBlockRepository) GetByStartingBlockID(
 	return sb, nil
 }
 
+func (r *SignedBlockRepository) GetByBlockID(
+	ctx context.Context,
+	blockID uint64,
+) ([]*guardianproverhealthcheck.SignedBlock, error) {
+	var sb []*guardianproverhealthcheck.SignedBlock
+
+	if err := r.startQuery(ctx).Where("block_id = ?", blockID).Find(&sb).Error; err != nil {
+		return nil, err
+	}
+
+	return sb, nil
+}
+
 func (r *SignedBlockRepository) GetMostRecentByGuardianProverAddress(ctx context.Context, address string) (
 	*guardianproverhealthcheck.SignedBlock,
 	error) {
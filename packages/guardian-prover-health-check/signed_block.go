@@ -31,5 +31,6 @@ type GetSignedBlocksByStartingBlockIDOpts struct {
 type SignedBlockRepository interface {
 	Save(ctx context.Context, opts *SaveSignedBlockOpts) error
 	GetByStartingBlockID(ctx context.Context, opts GetSignedBlocksByStartingBlockIDOpts) ([]*SignedBlock, error)
+	GetByBlockID(ctx context.Context, blockID uint64) ([]*SignedBlock, error)
 	GetMostRecentByGuardianProverAddress(ctx context.Context, address string) (*SignedBlock, error)
 }
@@ -33,6 +33,10 @@ type Config struct {
 
 // NewConfigFromCliContext creates a new config instance from command line flags.
 func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
+	if err := flags.CheckRequired(c, flags.SrcRPCUrl, flags.DestRPCUrl, flags.DestTaikoAddress); err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		DatabaseUsername:        c.String(flags.DatabaseUsername.Name),
 		DatabasePassword:        c.String(flags.DatabasePassword.Name),
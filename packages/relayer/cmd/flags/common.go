@@ -1,6 +1,8 @@
 package flags
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli/v2"
 )
 
@@ -45,14 +47,12 @@ var (
 	SrcRPCUrl = &cli.StringFlag{
 		Name:     "srcRpcUrl",
 		Usage:    "RPC URL for the source chain",
-		Required: true,
 		Category: commonCategory,
 		EnvVars:  []string{"SRC_RPC_URL"},
 	}
 	DestRPCUrl = &cli.StringFlag{
 		Name:     "destRpcUrl",
 		Usage:    "RPC URL for the destination chain",
-		Required: true,
 		Category: commonCategory,
 		EnvVars:  []string{"DEST_RPC_URL"},
 	}
@@ -107,6 +107,13 @@ var (
 		Value:    12,
 		EnvVars:  []string{"BACKOFF_RETRY_INTERVAL"},
 	}
+	Routes = &cli.StringFlag{
+		Name: "routes",
+		Usage: "Path to a YAML routes file describing the srcChain => destChain pairs to serve. " +
+			"One instance is run per route, and the per chain pair flags are ignored",
+		Category: commonCategory,
+		EnvVars:  []string{"ROUTES_FILE"},
+	}
 	BackOffMaxRetrys = &cli.Uint64Flag{
 		Name:     "backoff.maxRetrys",
 		Usage:    "Max retry times when there is an error",
@@ -145,3 +152,19 @@ func MergeFlags(groups ...[]cli.Flag) []cli.Flag {
 
 	return merged
 }
+
+// CheckRequired returns an error if one of the given per chain pair flags is not set. They
+// are only required when no routes file is given, the routes provide them otherwise.
+func CheckRequired(c *cli.Context, required ...cli.Flag) error {
+	if c.IsSet(Routes.Name) {
+		return nil
+	}
+
+	for _, f := range required {
+		if name := f.Names()[0]; !c.IsSet(name) {
+			return fmt.Errorf("required flag %q not set", name)
+		}
+	}
+
+	return nil
+}
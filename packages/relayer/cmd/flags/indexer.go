@@ -10,14 +10,12 @@ var (
 	SrcBridgeAddress = &cli.StringFlag{
 		Name:     "srcBridgeAddress",
 		Usage:    "Bridge address on the source chain",
-		Required: true,
 		Category: indexerCategory,
 		EnvVars:  []string{"SRC_BRIDGE_ADDRESS"},
 	}
 	DestBridgeAddress = &cli.StringFlag{
 		Name:     "destBridgeAddress",
 		Usage:    "Bridge address for the destination chain",
-		Required: true,
 		Category: commonCategory,
 		EnvVars:  []string{"DEST_BRIDGE_ADDRESS"},
 	}
@@ -142,4 +140,5 @@ var IndexerFlags = MergeFlags(CommonFlags, QueueFlags, []cli.Flag{
 	TargetBlockNumber,
 	WaitForConfirmationTimeout,
 	IndexingConfirmations,
+	Routes,
 })
@@ -6,16 +6,15 @@ import (
 
 var (
 	ProcessorPrivateKey = &cli.StringFlag{
-		Name:     "processorPrivateKey",
-		Usage:    "Private key to process messages on the destination chain",
-		Required: true,
+		Name: "processorPrivateKey",
+		Usage: "Private key to process messages on the destination chain, " +
+			"required unless a keystore file or a remote signer is set",
 		Category: processorCategory,
 		EnvVars:  []string{"PROCESSOR_PRIVATE_KEY"},
 	}
 	DestTaikoAddress = &cli.StringFlag{
 		Name:     "destTaikoAddress",
 		Usage:    "Taiko address for the destination chain",
-		Required: true,
 		Category: processorCategory,
 		EnvVars:  []string{"DEST_TAIKO_ADDRESS"},
 	}
@@ -23,21 +22,18 @@ var (
 		Name:     "destERC20VaultAddress",
 		Usage:    "ERC20Vault address for the destination chain, only required if you want to process NFTs",
 		Category: processorCategory,
-		Required: true,
 		EnvVars:  []string{"DEST_ERC20_VAULT_ADDRESS"},
 	}
 	DestERC1155VaultAddress = &cli.StringFlag{
 		Name:     "destERC1155Address",
 		Usage:    "ERC1155Vault address for the destination chain",
 		Category: processorCategory,
-		Required: true,
 		EnvVars:  []string{"DEST_ERC1155_VAULT_ADDRESS"},
 	}
 	DestERC721VaultAddress = &cli.StringFlag{
 		Name:     "destERC721Address",
 		Usage:    "ERC721Vault address for the destination chain",
 		Category: processorCategory,
-		Required: true,
 		EnvVars:  []string{"DEST_ERC721_VAULT_ADDRESS"},
 	}
 )
@@ -150,6 +146,67 @@ var (
 		Value:    0,
 		EnvVars:  []string{"MIN_FEE_TO_PROCESS"},
 	}
+	ProcessorPrivateKeys = &cli.StringSliceFlag{
+		Name:     "processorPrivateKeys",
+		Usage:    "Additional private keys to process messages with, each key has its own nonce stream",
+		Category: processorCategory,
+		Required: false,
+		EnvVars:  []string{"PROCESSOR_PRIVATE_KEYS"},
+	}
+	ProcessorKeystore = &cli.StringFlag{
+		Name:     "processorKeystore",
+		Usage:    "Encrypted keystore file of the processor key, instead of its raw private key",
+		Category: processorCategory,
+		EnvVars:  []string{"PROCESSOR_KEYSTORE"},
+	}
+	ProcessorKeystorePassword = &cli.StringFlag{
+		Name:     "processorKeystorePassword",
+		Usage:    "Path to the file containing the password of the processor keystore file",
+		Category: processorCategory,
+		EnvVars:  []string{"PROCESSOR_KEYSTORE_PASSWORD"},
+	}
+	ProcessorRemoteSigner = &cli.StringFlag{
+		Name: "processorRemoteSigner",
+		Usage: "Endpoint of the Web3Signer compatible remote signer which signs for the processor key, " +
+			"instead of its raw private key",
+		Category: processorCategory,
+		EnvVars:  []string{"PROCESSOR_REMOTE_SIGNER"},
+	}
+	ProcessorRemoteSignerAddress = &cli.StringFlag{
+		Name:     "processorRemoteSignerAddress",
+		Usage:    "Address of the processor account in the remote signer",
+		Category: processorCategory,
+		EnvVars:  []string{"PROCESSOR_REMOTE_SIGNER_ADDRESS"},
+	}
+	MaxConcurrentMessages = &cli.Uint64Flag{
+		Name:     "maxConcurrentMessages",
+		Usage:    "Maximum number of queue messages processed concurrently",
+		Category: processorCategory,
+		Value:    16,
+		EnvVars:  []string{"MAX_CONCURRENT_MESSAGES"},
+	}
+	MaxPendingTxsPerSigner = &cli.Uint64Flag{
+		Name:     "maxPendingTxsPerSigner",
+		Usage:    "Maximum number of processMessage transactions in flight per processor key",
+		Category: processorCategory,
+		Value:    4,
+		EnvVars:  []string{"MAX_PENDING_TXS_PER_SIGNER"},
+	}
+	FeeCheckInterval = &cli.Uint64Flag{
+		Name:     "feeCheckInterval",
+		Usage:    "Time in seconds between dest chain fee checks, which reorder pending messages and release parked ones",
+		Category: processorCategory,
+		Value:    12,
+		EnvVars:  []string{"FEE_CHECK_INTERVAL"},
+	}
+	MaxMessageParkDuration = &cli.Uint64Flag{
+		Name: "maxMessageParkDuration",
+		Usage: "Time in seconds an unprofitable message is held waiting for lower fees before it is sent " +
+			"to the unprofitable queue. At most half of queue.prefetch messages are held",
+		Category: processorCategory,
+		Value:    600,
+		EnvVars:  []string{"MAX_MESSAGE_PARK_DURATION"},
+	}
 )
 
 var ProcessorFlags = MergeFlags(CommonFlags, QueueFlags, TxmgrFlags, []cli.Flag{
@@ -175,4 +232,14 @@ var ProcessorFlags = MergeFlags(CommonFlags, QueueFlags, TxmgrFlags, []cli.Flag{
 	MaxMessageRetries,
 	MinFeeToProcess,
 	DestQuotaManagerAddress,
+	ProcessorPrivateKeys,
+	ProcessorKeystore,
+	ProcessorKeystorePassword,
+	ProcessorRemoteSigner,
+	ProcessorRemoteSignerAddress,
+	MaxConcurrentMessages,
+	MaxPendingTxsPerSigner,
+	FeeCheckInterval,
+	MaxMessageParkDuration,
+	Routes,
 })
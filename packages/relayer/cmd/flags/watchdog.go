@@ -22,4 +22,5 @@ var WatchdogFlags = MergeFlags(CommonFlags, QueueFlags, TxmgrFlags, []cli.Flag{
 	QueuePrefetchCount,
 	DestBridgeAddress,
 	SrcBridgeAddress,
+	Routes,
 })
@@ -51,21 +51,21 @@ func main() {
 			Flags:       flags.IndexerFlags,
 			Usage:       "Starts the indexer software",
 			Description: "Taiko relayer indexer software",
-			Action:      utils.SubcommandAction(new(indexer.Indexer)),
+			Action:      utils.RoutedSubcommandAction(func() utils.RoutedApplication { return new(indexer.Indexer) }),
 		},
 		{
 			Name:        "processor",
 			Flags:       flags.ProcessorFlags,
 			Usage:       "Starts the processor software",
 			Description: "Taiko relayer processor software",
-			Action:      utils.SubcommandAction(new(processor.Processor)),
+			Action:      utils.RoutedSubcommandAction(func() utils.RoutedApplication { return new(processor.Processor) }),
 		},
 		{
 			Name:        "watchdog",
 			Flags:       flags.WatchdogFlags,
 			Usage:       "Starts the watchdog software",
 			Description: "Taiko relayer watchdog software",
-			Action:      utils.SubcommandAction(new(watchdog.Watchdog)),
+			Action:      utils.RoutedSubcommandAction(func() utils.RoutedApplication { return new(watchdog.Watchdog) }),
 		},
 		{
 			Name:        "bridge",
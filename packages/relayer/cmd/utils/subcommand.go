@@ -2,13 +2,17 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"log/slog"
 
+	"github.com/taikoxyz/taiko-mono/packages/relayer"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/cmd/flags"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/metrics"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/routes"
 	"github.com/urfave/cli/v2"
 )
 
@@ -19,6 +23,18 @@ type SubcommandApplication interface {
 	Close(context.Context)
 }
 
+// finishingApplication is a SubcommandApplication which can stop on its own, the channel
+// returned by Done is closed once it has, or nil if it runs until it is stopped.
+type finishingApplication interface {
+	Done() <-chan struct{}
+}
+
+// RoutedApplication is a SubcommandApplication serving a single route of a routes file.
+type RoutedApplication interface {
+	SubcommandApplication
+	InitFromRoute(context.Context, *cli.Context, routes.Route) error
+}
+
 func SubcommandAction(app SubcommandApplication) cli.ActionFunc {
 	return func(c *cli.Context) error {
 		ctx, ctxClose := context.WithCancel(context.Background())
@@ -49,15 +65,115 @@ func SubcommandAction(app SubcommandApplication) cli.ActionFunc {
 			slog.Info("Application stopped", "name", app.Name())
 		}()
 
-		quitCh := make(chan os.Signal, 1)
-		signal.Notify(quitCh, []os.Signal{
-			os.Interrupt,
-			os.Kill,
-			syscall.SIGTERM,
-			syscall.SIGQUIT,
-		}...)
-		<-quitCh
+		waitForQuit(app)
+
+		return nil
+	}
+}
+
+// RoutedSubcommandAction runs one application per route of the routes file given by the
+// routes flag, all in this process. A route which fails to start is logged and skipped,
+// so a misconfigured or unreachable chain does not stop the other routes. Without a routes
+// file, it behaves like SubcommandAction.
+func RoutedSubcommandAction(newApp func() RoutedApplication) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if !c.IsSet(flags.Routes.Name) {
+			return SubcommandAction(newApp())(c)
+		}
+
+		rs, err := routes.Load(c.String(flags.Routes.Name))
+		if err != nil {
+			return err
+		}
+
+		ctx, ctxClose := context.WithCancel(context.Background())
+		defer func() { ctxClose() }()
+
+		var apps []SubcommandApplication
+
+		for _, route := range rs {
+			app := newApp()
+
+			if err := app.InitFromRoute(ctx, c, route); err != nil {
+				slog.Error("Initializing route error", "name", app.Name(), "route", route.Name, "error", err)
+				relayer.RouteUp.WithLabelValues(route.Name).Set(0)
+
+				continue
+			}
+
+			slog.Info("Starting Taiko relayer application", "name", app.Name(), "route", route.Name)
+
+			if err := app.Start(); err != nil {
+				slog.Error("Starting route error", "name", app.Name(), "route", route.Name, "error", err)
+				relayer.RouteUp.WithLabelValues(route.Name).Set(0)
+
+				app.Close(ctx)
+
+				continue
+			}
+
+			relayer.RouteUp.WithLabelValues(route.Name).Set(1)
+
+			apps = append(apps, app)
+		}
+
+		if len(apps) == 0 {
+			return errors.New("no route could be started")
+		}
+
+		_, startMetrics := metrics.Serve(ctx, c)
+
+		go func() {
+			if err := startMetrics(); err != nil {
+				slog.Error("Starting metrics server error", "error", err)
+			}
+		}()
+
+		defer func() {
+			ctxClose()
+
+			for _, app := range apps {
+				app.Close(ctx)
+			}
+
+			slog.Info("Application stopped", "name", apps[0].Name(), "routes", len(apps))
+		}()
+
+		waitForQuit(apps...)
 
 		return nil
 	}
 }
+
+// waitForQuit blocks until the process is signaled to quit, or all the given applications
+// have stopped on their own.
+func waitForQuit(apps ...SubcommandApplication) {
+	quitCh := make(chan os.Signal, 1)
+	signal.Notify(quitCh, []os.Signal{
+		os.Interrupt,
+		os.Kill,
+		syscall.SIGTERM,
+		syscall.SIGQUIT,
+	}...)
+
+	doneCh := make(chan struct{})
+
+	go func() {
+		for _, app := range apps {
+			finishing, ok := app.(finishingApplication)
+			if !ok || finishing.Done() == nil {
+				// runs until it is stopped
+				return
+			}
+
+			<-finishing.Done()
+		}
+
+		close(doneCh)
+	}()
+
+	select {
+	case <-quitCh:
+	case <-doneCh:
+	}
+}
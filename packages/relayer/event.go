@@ -82,6 +82,7 @@ type Event struct {
 	IsProfitable            *bool          `json:"isProfitable"`
 	EstimatedOnchainFee     *uint64        `json:"estimatedOnchainFee"`
 	IsProfitableEvaluatedAt *time.Time     `json:"isProfitableEvaluatedAt"`
+	RevertReason            *string        `json:"revertReason"`
 }
 
 // SaveEventOpts
@@ -132,6 +133,7 @@ type EventRepository interface {
 	Save(ctx context.Context, opts *SaveEventOpts) (*Event, error)
 	UpdateStatus(ctx context.Context, id int, status EventStatus) error
 	UpdateFeesAndProfitability(ctx context.Context, id int, opts *UpdateFeesAndProfitabilityOpts) error
+	UpdateRevertReason(ctx context.Context, id int, reason string) error
 	FindAllByAddress(
 		ctx context.Context,
 		req *http.Request,
@@ -8,6 +8,7 @@ import (
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/db"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/queue"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/queue/rabbitmq"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/routes"
 	"github.com/urfave/cli/v2"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -55,10 +56,37 @@ type Config struct {
 	OpenDBFunc                       func() (db.DB, error)
 	ConfirmationTimeout              time.Duration
 	Confirmations                    uint64
+	// RouteName labels the metrics of the indexer, it defaults to "<srcChainId>-<destChainId>"
+	RouteName string
+}
+
+// ForRoute returns a copy of the config indexing the given route of a routes file.
+func (c *Config) ForRoute(r routes.Route) *Config {
+	cfg := *c
+
+	cfg.RouteName = r.Name
+	cfg.SrcRPCUrl = r.SrcRPCUrl
+	cfg.DestRPCUrl = r.DestRPCUrl
+	cfg.SrcBridgeAddress = r.SrcBridgeAddress
+	cfg.SrcSignalServiceAddress = r.SrcSignalServiceAddress
+	cfg.SrcTaikoAddress = r.SrcTaikoAddress
+	cfg.DestBridgeAddress = r.DestBridgeAddress
+
+	return &cfg
 }
 
 // NewConfigFromCliContext creates a new config instance from command line flags.
 func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
+	if err := flags.CheckRequired(
+		c,
+		flags.SrcRPCUrl,
+		flags.DestRPCUrl,
+		flags.SrcBridgeAddress,
+		flags.DestBridgeAddress,
+	); err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		SrcBridgeAddress:                 common.HexToAddress(c.String(flags.SrcBridgeAddress.Name)),
 		SrcTaikoAddress:                  common.HexToAddress(c.String(flags.SrcTaikoAddress.Name)),
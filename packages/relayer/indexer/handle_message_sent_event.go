@@ -125,7 +125,7 @@ func (i *Indexer) handleMessageSentEvent(
 		return errors.Wrap(err, "i.queue.Publish")
 	}
 
-	relayer.MessageSentEventsIndexed.Inc()
+	relayer.MessageSentEventsIndexed.WithLabelValues(i.route).Inc()
 
 	return nil
 }
@@ -26,6 +26,7 @@ import (
 	v2 "github.com/taikoxyz/taiko-mono/packages/relayer/bindings/v2/taikol1"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/queue"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/repo"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/routes"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/utils"
 )
 
@@ -104,6 +105,9 @@ type Indexer struct {
 	srcChainId  *big.Int
 	destChainId *big.Int
 
+	// route labels the indexer metrics
+	route string
+
 	watchMode WatchMode
 	syncMode  SyncMode
 
@@ -137,6 +141,16 @@ func (i *Indexer) InitFromCli(ctx context.Context, c *cli.Context) error {
 	return InitFromConfig(ctx, i, cfg)
 }
 
+// InitFromRoute inits a new Indexer for one route of a routes file.
+func (i *Indexer) InitFromRoute(ctx context.Context, c *cli.Context, route routes.Route) error {
+	cfg, err := NewConfigFromCliContext(c)
+	if err != nil {
+		return err
+	}
+
+	return InitFromConfig(ctx, i, cfg.ForRoute(route))
+}
+
 // InitFromConfig inits a new Indexer from a provided Config struct
 func InitFromConfig(ctx context.Context, i *Indexer, cfg *Config) (err error) {
 	db, err := cfg.OpenDBFunc()
@@ -232,6 +246,11 @@ func InitFromConfig(ctx context.Context, i *Indexer, cfg *Config) (err error) {
 	i.srcChainId = srcChainID
 	i.destChainId = destChainID
 
+	i.route = cfg.RouteName
+	if i.route == "" {
+		i.route = routes.DefaultName(srcChainID, destChainID)
+	}
+
 	i.syncMode = cfg.SyncMode
 	i.watchMode = cfg.WatchMode
 
@@ -405,7 +424,7 @@ func (i *Indexer) filter(ctx context.Context) error {
 			if err := i.withRetry(func() error { return i.indexMessageSentEvents(ctx, filterOpts) }); err != nil {
 				// We will skip the error after retrying, as we want the indexer to continue.
 				slog.Error("i.indexMessageSentEvents", "error", err)
-				relayer.MessageSentEventsAfterRetryErrorCount.Inc()
+				relayer.MessageSentEventsAfterRetryErrorCount.WithLabelValues(i.route).Inc()
 			}
 
 			// we dont want to watch for message status changed events
@@ -415,22 +434,24 @@ func (i *Indexer) filter(ctx context.Context) error {
 			if i.watchMode != CrawlPastBlocks {
 				if err := i.withRetry(func() error { return i.indexMessageStatusChangedEvents(ctx, filterOpts) }); err != nil {
 					slog.Error("i.indexMessageStatusChangedEvents", "error", err)
-					relayer.MessageStatusChangedEventsAfterRetryErrorCount.Inc()
+					relayer.MessageStatusChangedEventsAfterRetryErrorCount.WithLabelValues(i.route).Inc()
 				}
 
 				// we also want to index chain data synced events.
 				if err := i.withRetry(func() error { return i.indexChainDataSyncedEvents(ctx, filterOpts) }); err != nil {
 					slog.Error("i.indexChainDataSyncedEvents", "error", err)
-					relayer.ChainDataSyncedEventsAfterRetryErrorCount.Inc()
+					relayer.ChainDataSyncedEventsAfterRetryErrorCount.WithLabelValues(i.route).Inc()
 				}
 			}
 		case relayer.EventNameMessageProcessed:
 			if err := i.withRetry(func() error { return i.indexMessageProcessedEvents(ctx, filterOpts) }); err != nil {
 				slog.Error("i.indexMessageProcessedEvents", "error", err)
-				relayer.MessageProcessedEventsAfterRetryErrorCount.Inc()
+				relayer.MessageProcessedEventsAfterRetryErrorCount.WithLabelValues(i.route).Inc()
 			}
 		}
 
+		relayer.BlocksProcessed.WithLabelValues(i.route).Add(float64(end - j + 1))
+
 		i.latestIndexedBlockNumber = end
 	}
 
@@ -466,7 +487,8 @@ func (i *Indexer) indexMessageSentEvents(ctx context.Context,
 		group.Go(func() error {
 			err := i.handleMessageSentEvent(ctx, i.srcChainId, event, true)
 			if err != nil {
-				relayer.ErrorEvents.Inc()
+				relayer.ErrorEvents.WithLabelValues(i.route).Inc()
+				relayer.MessageSentEventsIndexingErrors.WithLabelValues(i.route).Inc()
 				// log error but always return nil to keep other goroutines active
 				slog.Error("error handling event", "err", err.Error())
 
@@ -530,7 +552,7 @@ func (i *Indexer) indexMessageProcessedEvents(ctx context.Context,
 		group.Go(func() error {
 			err := i.handleMessageProcessedEvent(ctx, i.srcChainId, event, true)
 			if err != nil {
-				relayer.MessageProcessedEventsIndexingErrors.Inc()
+				relayer.MessageProcessedEventsIndexingErrors.WithLabelValues(i.route).Inc()
 				// log error but always return nil to keep other goroutines active
 				slog.Error("error handling event", "err", err.Error())
 
@@ -569,7 +591,7 @@ func (i *Indexer) indexMessageStatusChangedEvents(ctx context.Context,
 		group.Go(func() error {
 			err := i.handleMessageStatusChangedEvent(ctx, i.srcChainId, event)
 			if err != nil {
-				relayer.MessageStatusChangedEventsIndexingErrors.Inc()
+				relayer.MessageStatusChangedEventsIndexingErrors.WithLabelValues(i.route).Inc()
 				// log error but always return nil to keep other goroutines active
 				slog.Error("error handling messageStatusChanged", "err", err.Error())
 
@@ -615,7 +637,7 @@ func (i *Indexer) indexChainDataSyncedEvents(ctx context.Context,
 		group.Go(func() error {
 			err := i.handleChainDataSyncedEvent(ctx, event, true)
 			if err != nil {
-				relayer.MessageStatusChangedEventsIndexingErrors.Inc()
+				relayer.ChainDataSyncedEventsIndexingErrors.WithLabelValues(i.route).Inc()
 
 				// log error but always return nil to keep other goroutines active
 				slog.Error("error handling chainDataSynced", "err", err.Error())
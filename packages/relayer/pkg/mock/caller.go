@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
@@ -14,7 +15,24 @@ type Caller struct {
 func (c *Caller) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
 	if method == "eth_getProof" {
 		b := hexutil.MustDecode("0x01")
-		return json.Unmarshal(json.RawMessage([]byte(fmt.Sprintf(`{"storageProof": [{"value": "%x"}]}`, b))), result)
+
+		// one storage proof per requested key
+		keys := 1
+		if len(args) > 1 {
+			if k, ok := args[1].([]string); ok {
+				keys = len(k)
+			}
+		}
+
+		storageProofs := make([]string, keys)
+		for i := range storageProofs {
+			storageProofs[i] = fmt.Sprintf(`{"value": "%x"}`, b)
+		}
+
+		return json.Unmarshal(
+			json.RawMessage([]byte(fmt.Sprintf(`{"storageProof": [%v]}`, strings.Join(storageProofs, ",")))),
+			result,
+		)
 	}
 
 	return nil
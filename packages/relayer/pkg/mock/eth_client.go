@@ -113,6 +113,10 @@ func (c *EthClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.
 	return Header, nil
 }
 
+func (c *EthClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return []byte{}, nil
+}
+
 func (c *EthClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
 	return 1, nil
 }
@@ -68,6 +68,18 @@ func (r *EventRepository) UpdateStatus(ctx context.Context, id int, status relay
 	return nil
 }
 
+func (r *EventRepository) UpdateRevertReason(ctx context.Context, id int, reason string) error {
+	for _, e := range r.events {
+		if e.ID == id {
+			e.RevertReason = &reason
+
+			return nil
+		}
+	}
+
+	return nil
+}
+
 func (r *EventRepository) UpdateFeesAndProfitability(
 	ctx context.Context,
 	id int, opts *relayer.UpdateFeesAndProfitabilityOpts,
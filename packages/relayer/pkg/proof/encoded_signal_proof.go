@@ -8,7 +8,6 @@ import (
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/encoding"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 )
 
@@ -45,15 +44,13 @@ func (p *Prover) abiEncodeSignalProofWithHops(ctx context.Context,
 			return nil, errors.Wrap(err, "p.blockHeader")
 		}
 
-		ethProof, err := p.getProof(
-			ctx,
-			hop.Caller,
-			hop.SignalServiceAddress,
-			common.Bytes2Hex(hop.Key[:]),
-			int64(hop.BlockNumber),
-		)
+		accountProof, storageProof, err := p.storageProof(ctx, hop, block.Hash(), block.NumberU64())
 		if err != nil {
-			return nil, errors.Wrap(err, "hop p.getEncodedMerkleProof")
+			return nil, errors.Wrap(err, "hop p.storageProof")
+		}
+
+		if storageProof.isZero() {
+			return nil, errors.New("proof will not be valid, expected storageProof to not be 0 but was not")
 		}
 
 		hopProofs = append(hopProofs, encoding.HopProof{
@@ -61,8 +58,8 @@ func (p *Prover) abiEncodeSignalProofWithHops(ctx context.Context,
 			ChainID:      hop.ChainID.Uint64(),
 			RootHash:     block.Root(),
 			CacheOption:  encoding.CACHE_NOTHING,
-			AccountProof: ethProof.AccountProof,
-			StorageProof: ethProof.StorageProof[0].Proof,
+			AccountProof: accountProof,
+			StorageProof: storageProof.Proof,
 		},
 		)
 	}
@@ -75,32 +72,35 @@ func (p *Prover) abiEncodeSignalProofWithHops(ctx context.Context,
 	return encodedSignalProof, nil
 }
 
-// getProof rlp and abi encodes a proof for SignalService,
-// where `proof` is an rlp and abi encoded (bytes, bytes) consisting of storageProof.Proofs[0]
-// response from `eth_getProof`, and returns the storageHash to be used as the signalRoot.
-func (p *Prover) getProof(
+// storageProof returns the account proof of the hop's signal service, and the proof of
+// the hop's storage slot, at the given block. Proofs are served from the cache when the
+// prover has one.
+func (p *Prover) storageProof(
 	ctx context.Context,
-	c relayer.Caller,
-	signalServiceAddress common.Address,
-	key string,
-	blockNumber int64,
-) (*StorageProof, error) {
-	var ethProof StorageProof
-
-	err := c.CallContext(ctx,
-		&ethProof,
-		"eth_getProof",
-		signalServiceAddress,
-		[]string{key},
-		hexutil.EncodeBig(new(big.Int).SetInt64(blockNumber)),
-	)
-	if err != nil {
-		return nil, errors.Wrap(err, "c.CallContext")
-	}
+	hop HopParams,
+	blockHash common.Hash,
+	blockNumber uint64,
+) (Slice, StorageResult, error) {
+	if p.cache == nil {
+		ethProof, err := getProof(ctx, hop.Caller, hop.SignalServiceAddress, []common.Hash{common.Hash(hop.Key)}, blockHash)
+		if err != nil {
+			return nil, StorageResult{}, err
+		}
 
-	if new(big.Int).SetBytes(ethProof.StorageProof[0].Value).Int64() == int64(0) {
-		return nil, errors.New("proof will not be valid, expected storageProof to not be 0 but was not")
+		return ethProof.AccountProof, ethProof.StorageProof[0], nil
 	}
 
-	return &ethProof, nil
+	return p.cache.storageProof(
+		ctx,
+		hop.Caller,
+		proofCacheKey{
+			proofScope: proofScope{
+				chainID:              hop.ChainID.Uint64(),
+				signalServiceAddress: hop.SignalServiceAddress,
+			},
+			blockHash: blockHash,
+		},
+		blockNumber,
+		common.Hash(hop.Key),
+	)
 }
@@ -0,0 +1,229 @@
+package proof
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/taikoxyz/taiko-mono/packages/relayer"
+)
+
+// getProofTimeout bounds a batched `eth_getProof` call, which is shared by all the
+// requests waiting for it.
+var getProofTimeout = time.Minute
+
+// proofScope is a signal service on a chain. Only the proofs of the latest synced block
+// of each scope are cached.
+type proofScope struct {
+	chainID              uint64
+	signalServiceAddress common.Address
+}
+
+// proofCacheKey identifies the state a proof is generated against.
+type proofCacheKey struct {
+	proofScope
+	blockHash common.Hash
+}
+
+// proofResult is the result of a storage proof request waiting for a batch.
+type proofResult struct {
+	accountProof Slice
+	storageProof StorageResult
+	err          error
+}
+
+// proofCacheEntry holds the proofs generated against one block of a signal service.
+type proofCacheEntry struct {
+	blockNumber  uint64
+	accountProof Slice
+	storage      map[common.Hash]StorageResult
+
+	// pending are the keys requested while a batch was in flight, they are fetched by
+	// the next batch.
+	pending  map[common.Hash][]chan proofResult
+	inFlight bool
+}
+
+// proofCache caches the `eth_getProof` responses for signal service storage slots, and
+// batches the slots requested concurrently for the same block into a single call. When a
+// newer block of a signal service is used, the proofs of older blocks are evicted.
+type proofCache struct {
+	mu      sync.Mutex
+	entries map[proofCacheKey]*proofCacheEntry
+	latest  map[proofScope]uint64
+}
+
+func newProofCache() *proofCache {
+	return &proofCache{
+		entries: make(map[proofCacheKey]*proofCacheEntry),
+		latest:  make(map[proofScope]uint64),
+	}
+}
+
+// storageProof returns the proof of a signal service storage slot at the given block,
+// from the cache if possible.
+func (c *proofCache) storageProof(
+	ctx context.Context,
+	caller relayer.Caller,
+	key proofCacheKey,
+	blockNumber uint64,
+	slot common.Hash,
+) (Slice, StorageResult, error) {
+	c.mu.Lock()
+
+	// a newer synced block is used, the proofs of older blocks will not be requested again
+	if blockNumber > c.latest[key.proofScope] {
+		c.latest[key.proofScope] = blockNumber
+
+		for k, entry := range c.entries {
+			if k.proofScope == key.proofScope && entry.blockNumber < blockNumber && !entry.inFlight {
+				delete(c.entries, k)
+			}
+		}
+	}
+
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &proofCacheEntry{
+			blockNumber: blockNumber,
+			storage:     make(map[common.Hash]StorageResult),
+			pending:     make(map[common.Hash][]chan proofResult),
+		}
+
+		c.entries[key] = entry
+	}
+
+	if storageProof, ok := entry.storage[slot]; ok {
+		accountProof := entry.accountProof
+
+		c.mu.Unlock()
+
+		relayer.ProofCacheLookups.WithLabelValues("hit").Inc()
+
+		return accountProof, storageProof, nil
+	}
+
+	relayer.ProofCacheLookups.WithLabelValues("miss").Inc()
+
+	ch := make(chan proofResult, 1)
+	entry.pending[slot] = append(entry.pending[slot], ch)
+
+	if !entry.inFlight {
+		entry.inFlight = true
+
+		go c.fetch(caller, key, entry)
+	}
+
+	c.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, StorageResult{}, ctx.Err()
+	case result := <-ch:
+		return result.accountProof, result.storageProof, result.err
+	}
+}
+
+// fetch requests the proofs of all pending slots of the entry in one call, until no
+// slots are pending.
+func (c *proofCache) fetch(caller relayer.Caller, key proofCacheKey, entry *proofCacheEntry) {
+	for {
+		c.mu.Lock()
+
+		if len(entry.pending) == 0 {
+			entry.inFlight = false
+
+			// the entry was kept while in flight, evict it now if a newer block is used
+			if entry.blockNumber < c.latest[key.proofScope] {
+				delete(c.entries, key)
+			}
+
+			c.mu.Unlock()
+
+			return
+		}
+
+		pending := entry.pending
+		entry.pending = make(map[common.Hash][]chan proofResult)
+
+		c.mu.Unlock()
+
+		slots := make([]common.Hash, 0, len(pending))
+		for slot := range pending {
+			slots = append(slots, slot)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), getProofTimeout)
+		ethProof, err := getProof(ctx, caller, key.signalServiceAddress, slots, key.blockHash)
+
+		cancel()
+
+		c.mu.Lock()
+
+		for i, slot := range slots {
+			result := proofResult{err: err}
+
+			if err == nil {
+				result.accountProof = ethProof.AccountProof
+				result.storageProof = ethProof.StorageProof[i]
+
+				entry.accountProof = ethProof.AccountProof
+				entry.storage[slot] = ethProof.StorageProof[i]
+			}
+
+			for _, ch := range pending[slot] {
+				ch <- result
+			}
+		}
+
+		c.mu.Unlock()
+	}
+}
+
+// getProof calls `eth_getProof` for the given storage slots of a signal service, at the
+// block with the given hash.
+func getProof(
+	ctx context.Context,
+	c relayer.Caller,
+	signalServiceAddress common.Address,
+	slots []common.Hash,
+	blockHash common.Hash,
+) (*StorageProof, error) {
+	keys := make([]string, len(slots))
+	for i, slot := range slots {
+		keys[i] = slot.Hex()
+	}
+
+	var ethProof StorageProof
+
+	relayer.EthGetProofCalls.Inc()
+
+	err := c.CallContext(ctx,
+		&ethProof,
+		"eth_getProof",
+		signalServiceAddress,
+		keys,
+		map[string]interface{}{"blockHash": blockHash},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "c.CallContext")
+	}
+
+	if len(ethProof.StorageProof) != len(slots) {
+		return nil, errors.Errorf(
+			"expected %v storage proofs, got %v",
+			len(slots),
+			len(ethProof.StorageProof),
+		)
+	}
+
+	return &ethProof, nil
+}
+
+// isZero returns whether the proven storage value is zero.
+func (r StorageResult) isZero() bool {
+	return new(big.Int).SetBytes(r.Value).Sign() == 0
+}
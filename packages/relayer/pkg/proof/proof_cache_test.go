@@ -0,0 +1,115 @@
+package proof
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/mock"
+)
+
+// countingCaller counts eth_getProof calls and the keys requested by each, and blocks
+// every call until release is closed.
+type countingCaller struct {
+	mock.Caller
+
+	mu      sync.Mutex
+	calls   [][]string
+	release chan struct{}
+}
+
+func (c *countingCaller) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	c.mu.Lock()
+	c.calls = append(c.calls, args[1].([]string))
+	c.mu.Unlock()
+
+	<-c.release
+
+	return c.Caller.CallContext(ctx, result, method, args...)
+}
+
+func testProofCacheKey(blockHash common.Hash) proofCacheKey {
+	return proofCacheKey{
+		proofScope: proofScope{chainID: 1, signalServiceAddress: common.HexToAddress("0x1")},
+		blockHash:  blockHash,
+	}
+}
+
+func Test_proofCache_batchesAndCaches(t *testing.T) {
+	cache := newProofCache()
+	caller := &countingCaller{release: make(chan struct{})}
+	key := testProofCacheKey(common.HexToHash("0x10"))
+
+	var wg sync.WaitGroup
+
+	// the first request starts a call, the others wait for the next batch
+	for i := 1; i <= 3; i++ {
+		wg.Add(1)
+
+		go func(slot common.Hash) {
+			defer wg.Done()
+
+			_, storageProof, err := cache.storageProof(context.Background(), caller, key, 10, slot)
+			assert.Nil(t, err)
+			assert.False(t, storageProof.isZero())
+		}(common.BigToHash(big.NewInt(int64(i))))
+
+		if i == 1 {
+			assert.Eventually(t, func() bool {
+				caller.mu.Lock()
+				defer caller.mu.Unlock()
+
+				return len(caller.calls) == 1
+			}, time.Second, time.Millisecond)
+		}
+	}
+
+	assert.Eventually(t, func() bool {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+
+		return len(cache.entries[key].pending) == 2
+	}, time.Second, time.Millisecond)
+
+	close(caller.release)
+	wg.Wait()
+
+	assert.Len(t, caller.calls, 2)
+	assert.Len(t, caller.calls[1], 2)
+
+	// cached slots do not call the node again
+	_, _, err := cache.storageProof(context.Background(), caller, key, 10, common.BigToHash(common.Big1))
+	assert.Nil(t, err)
+	assert.Len(t, caller.calls, 2)
+}
+
+func Test_proofCache_evictsOlderBlocks(t *testing.T) {
+	cache := newProofCache()
+	caller := &countingCaller{release: make(chan struct{})}
+
+	close(caller.release)
+
+	oldKey := testProofCacheKey(common.HexToHash("0x10"))
+	newKey := testProofCacheKey(common.HexToHash("0x11"))
+
+	_, _, err := cache.storageProof(context.Background(), caller, oldKey, 10, common.Hash{})
+	assert.Nil(t, err)
+
+	_, _, err = cache.storageProof(context.Background(), caller, newKey, 11, common.Hash{})
+	assert.Nil(t, err)
+
+	// the old entry is evicted once its call returned
+	assert.Eventually(t, func() bool {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+
+		_, hasOld := cache.entries[oldKey]
+		_, hasNew := cache.entries[newKey]
+
+		return !hasOld && hasNew
+	}, time.Second, time.Millisecond)
+}
@@ -21,6 +21,7 @@ type blocker interface {
 type Prover struct {
 	blocker     blocker
 	cacheOption int
+	cache       *proofCache
 }
 
 func New(blocker blocker, cacheOption int) (*Prover, error) {
@@ -31,5 +32,6 @@ func New(blocker blocker, cacheOption int) (*Prover, error) {
 	return &Prover{
 		blocker:     blocker,
 		cacheOption: cacheOption,
+		cache:       newProofCache(),
 	}, nil
 }
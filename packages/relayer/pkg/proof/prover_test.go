@@ -14,6 +14,7 @@ func newTestProver() *Prover {
 	return &Prover{
 		blocker:     &mock.Blocker{},
 		cacheOption: encoding.CACHE_BOTH,
+		cache:       newProofCache(),
 	}
 }
 
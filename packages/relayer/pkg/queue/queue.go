@@ -3,7 +3,9 @@ package queue
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/taikoxyz/taiko-mono/packages/relayer/bindings/bridge"
 )
@@ -12,6 +14,37 @@ var (
 	ErrClosed = errors.New("queue connection closed")
 )
 
+// RetryDelays are the delays of the retry queues. Every retry queue has a fixed message
+// TTL, since RabbitMQ only expires the message at the head of a queue, and a message with
+// a long expiration would hold back shorter ones queued behind it.
+var RetryDelays = []time.Duration{
+	15 * time.Second,
+	30 * time.Second,
+	1 * time.Minute,
+	2 * time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	30 * time.Minute,
+}
+
+// RetryQueueName returns the name of the retry queue of the given queue, whose messages are
+// routed back to it after the given delay.
+func RetryQueueName(queueName string, delay time.Duration) string {
+	return fmt.Sprintf("%v-retry-%vs", queueName, int64(delay.Seconds()))
+}
+
+// RetryDelay rounds the given delay up to the delay of a retry queue, delays longer than
+// the longest retry queue delay are capped to it.
+func RetryDelay(delay time.Duration) time.Duration {
+	for _, d := range RetryDelays {
+		if delay <= d {
+			return d
+		}
+	}
+
+	return RetryDelays[len(RetryDelays)-1]
+}
+
 type Queue interface {
 	Start(ctx context.Context, queueName string) error
 	Close(ctx context.Context)
@@ -217,6 +217,32 @@ func (r *RabbitMQ) Start(ctx context.Context, queueName string) error {
 		return err
 	}
 
+	// we declare a queue per retry delay, with the same dead-letter exchange as the
+	// unprofitable queue. Unlike the unprofitable queue, the expiration is set on the
+	// queue, so messages expire in the order they were published.
+	for _, delay := range queue.RetryDelays {
+		retryQueueName := queue.RetryQueueName(queueName, delay)
+
+		retryArgs := amqp.Table{}
+
+		retryArgs["x-dead-letter-exchange"] = exchange
+		retryArgs["x-dead-letter-routing-key"] = routingKey
+		retryArgs["x-message-ttl"] = delay.Milliseconds()
+
+		slog.Info("declaring rabbitmq retry queue", "queue", retryQueueName)
+
+		if _, err := r.ch.QueueDeclare(
+			retryQueueName,
+			true,
+			false,
+			false,
+			false,
+			retryArgs,
+		); err != nil {
+			return err
+		}
+	}
+
 	r.queue = q
 
 	r.unprofitableQueue = unprofitableQueue
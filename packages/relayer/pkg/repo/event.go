@@ -127,6 +127,18 @@ func (r *EventRepository) UpdateStatus(ctx context.Context, id int, status relay
 	return nil
 }
 
+func (r *EventRepository) UpdateRevertReason(ctx context.Context, id int, reason string) error {
+	tx := r.db.GormDB().WithContext(ctx)
+	tx = tx.Model(&relayer.Event{})
+	tx = tx.Where("id = ?", id)
+
+	if err := tx.Update("revert_reason", reason).Error; err != nil {
+		return errors.Wrap(err, "tx.Update")
+	}
+
+	return nil
+}
+
 func (r *EventRepository) FirstByMsgHash(
 	ctx context.Context,
 	msgHash string,
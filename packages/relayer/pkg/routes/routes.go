@@ -0,0 +1,116 @@
+package routes
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Hop is an intermediary chain a message is proven through, when the route is not a
+// direct srcChain => destChain pair. For instance, from L2A to L2B the hop is the shared L1.
+type Hop struct {
+	RPCUrl               string         `yaml:"rpcUrl"`
+	SignalServiceAddress common.Address `yaml:"signalServiceAddress"`
+	TaikoAddress         common.Address `yaml:"taikoAddress"`
+}
+
+// Route is a srcChain => destChain pair served by the relayer. Each route gets its own
+// indexer, processor or watchdog, with its own queues and metrics labels.
+type Route struct {
+	// Name identifies the route in logs and metrics labels.
+	Name string `yaml:"name"`
+
+	SrcRPCUrl  string `yaml:"srcRpcUrl"`
+	DestRPCUrl string `yaml:"destRpcUrl"`
+
+	SrcBridgeAddress        common.Address `yaml:"srcBridgeAddress"`
+	SrcSignalServiceAddress common.Address `yaml:"srcSignalServiceAddress"`
+	SrcTaikoAddress         common.Address `yaml:"srcTaikoAddress"`
+
+	DestBridgeAddress       common.Address `yaml:"destBridgeAddress"`
+	DestERC20VaultAddress   common.Address `yaml:"destERC20VaultAddress"`
+	DestERC721VaultAddress  common.Address `yaml:"destERC721VaultAddress"`
+	DestERC1155VaultAddress common.Address `yaml:"destERC1155VaultAddress"`
+	DestTaikoAddress        common.Address `yaml:"destTaikoAddress"`
+	DestQuotaManagerAddress common.Address `yaml:"destQuotaManagerAddress"`
+
+	EnableTaikoL2 bool  `yaml:"enableTaikoL2"`
+	Hops          []Hop `yaml:"hops"`
+}
+
+// DefaultName is the route name used when a component is configured from flags for a
+// single chain pair, rather than from a routes file.
+func DefaultName(srcChainID, destChainID *big.Int) string {
+	return fmt.Sprintf("%v-%v", srcChainID, destChainID)
+}
+
+// file is the layout of a routes file.
+type file struct {
+	Routes []Route `yaml:"routes"`
+}
+
+// Load reads and validates a routes file.
+func Load(path string) ([]Route, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "os.ReadFile")
+	}
+
+	return Parse(data)
+}
+
+// Parse decodes and validates the routes of a routes file.
+func Parse(data []byte) ([]Route, error) {
+	var f file
+
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, errors.Wrap(err, "yaml.Unmarshal")
+	}
+
+	if len(f.Routes) == 0 {
+		return nil, errors.New("no routes configured")
+	}
+
+	names := make(map[string]bool, len(f.Routes))
+
+	for i, r := range f.Routes {
+		if err := r.Validate(); err != nil {
+			return nil, fmt.Errorf("routes[%d]: %w", i, err)
+		}
+
+		if names[r.Name] {
+			return nil, fmt.Errorf("routes[%d]: duplicate route name %q", i, r.Name)
+		}
+
+		names[r.Name] = true
+	}
+
+	return f.Routes, nil
+}
+
+// Validate checks the fields every relayer component needs are set.
+func (r Route) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if r.SrcRPCUrl == "" || r.DestRPCUrl == "" {
+		return fmt.Errorf("route %q: srcRpcUrl and destRpcUrl are required", r.Name)
+	}
+
+	if r.SrcBridgeAddress == (common.Address{}) || r.DestBridgeAddress == (common.Address{}) {
+		return fmt.Errorf("route %q: srcBridgeAddress and destBridgeAddress are required", r.Name)
+	}
+
+	for i, hop := range r.Hops {
+		if hop.RPCUrl == "" || hop.SignalServiceAddress == (common.Address{}) {
+			return fmt.Errorf("route %q: hops[%d]: rpcUrl and signalServiceAddress are required", r.Name, i)
+		}
+	}
+
+	return nil
+}
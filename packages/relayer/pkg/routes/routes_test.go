@@ -0,0 +1,135 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+var routesFile = `
+routes:
+  - name: l1-l2
+    srcRpcUrl: http://l1:8545
+    destRpcUrl: http://l2:8545
+    srcBridgeAddress: "0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377"
+    destBridgeAddress: "0x1670000000000000000000000000000000000001"
+    destERC20VaultAddress: "0x1670000000000000000000000000000000000002"
+    enableTaikoL2: true
+  - name: l2a-l2b
+    srcRpcUrl: http://l2a:8545
+    destRpcUrl: http://l2b:8545
+    srcBridgeAddress: "0x1670000000000000000000000000000000000001"
+    destBridgeAddress: "0x1680000000000000000000000000000000000001"
+    hops:
+      - rpcUrl: http://l1:8545
+        signalServiceAddress: "0x63FaC9201494f0bd17B9892B9fae4d52fe3BD357"
+        taikoAddress: "0x63FaC9201494f0bd17B9892B9fae4d52fe3BD358"
+`
+
+func Test_Parse(t *testing.T) {
+	routes, err := Parse([]byte(routesFile))
+	assert.Nil(t, err)
+	assert.Len(t, routes, 2)
+
+	assert.Equal(t, "l1-l2", routes[0].Name)
+	assert.Equal(t, "http://l1:8545", routes[0].SrcRPCUrl)
+	assert.Equal(t, common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377"), routes[0].SrcBridgeAddress)
+	assert.Equal(t, common.HexToAddress("0x1670000000000000000000000000000000000002"), routes[0].DestERC20VaultAddress)
+	assert.True(t, routes[0].EnableTaikoL2)
+	assert.Empty(t, routes[0].Hops)
+
+	assert.Len(t, routes[1].Hops, 1)
+	assert.Equal(t, "http://l1:8545", routes[1].Hops[0].RPCUrl)
+	assert.Equal(t, common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD357"), routes[1].Hops[0].SignalServiceAddress)
+	assert.Equal(t, common.HexToAddress("0x63FaC9201494f0bd17B9892B9fae4d52fe3BD358"), routes[1].Hops[0].TaikoAddress)
+}
+
+func Test_Parse_invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		wantErr string
+	}{
+		{
+			"noRoutes",
+			"routes: []",
+			"no routes configured",
+		},
+		{
+			"missingName",
+			`
+routes:
+  - srcRpcUrl: http://l1:8545
+`,
+			"routes[0]: name is required",
+		},
+		{
+			"missingRPCUrl",
+			`
+routes:
+  - name: l1-l2
+    srcRpcUrl: http://l1:8545
+`,
+			`routes[0]: route "l1-l2": srcRpcUrl and destRpcUrl are required`,
+		},
+		{
+			"missingBridge",
+			`
+routes:
+  - name: l1-l2
+    srcRpcUrl: http://l1:8545
+    destRpcUrl: http://l2:8545
+    srcBridgeAddress: "0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377"
+`,
+			`routes[0]: route "l1-l2": srcBridgeAddress and destBridgeAddress are required`,
+		},
+		{
+			"missingHopSignalService",
+			`
+routes:
+  - name: l2a-l2b
+    srcRpcUrl: http://l2a:8545
+    destRpcUrl: http://l2b:8545
+    srcBridgeAddress: "0x1670000000000000000000000000000000000001"
+    destBridgeAddress: "0x1680000000000000000000000000000000000001"
+    hops:
+      - rpcUrl: http://l1:8545
+`,
+			`routes[0]: route "l2a-l2b": hops[0]: rpcUrl and signalServiceAddress are required`,
+		},
+		{
+			"duplicateName",
+			`
+routes:
+  - name: l1-l2
+    srcRpcUrl: http://l1:8545
+    destRpcUrl: http://l2:8545
+    srcBridgeAddress: "0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377"
+    destBridgeAddress: "0x1670000000000000000000000000000000000001"
+  - name: l1-l2
+    srcRpcUrl: http://l1:8545
+    destRpcUrl: http://l2:8545
+    srcBridgeAddress: "0x63FaC9201494f0bd17B9892B9fae4d52fe3BD377"
+    destBridgeAddress: "0x1670000000000000000000000000000000000001"
+`,
+			`routes[1]: duplicate route name "l1-l2"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.file))
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_Parse_invalidAddress(t *testing.T) {
+	_, err := Parse([]byte(`
+routes:
+  - name: l1-l2
+    srcBridgeAddress: "0x1234"
+`))
+	assert.NotNil(t, err)
+}
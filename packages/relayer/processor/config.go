@@ -17,6 +17,8 @@ import (
 	pkgFlags "github.com/taikoxyz/taiko-mono/packages/relayer/pkg/flags"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/queue"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/queue/rabbitmq"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/routes"
+	"github.com/taikoxyz/taiko-mono/packages/signer"
 )
 
 // hopConfig is a config struct that must be provided for an individual
@@ -42,8 +44,12 @@ type Config struct {
 	DestTaikoAddress        common.Address
 	DestQuotaManagerAddress common.Address
 
-	// private key
+	// private key, nil when the processor key is kept in a keystore file or a remote signer
 	ProcessorPrivateKey *ecdsa.PrivateKey
+	// signer of the processor key
+	ProcessorSigner signer.Signer
+	// additional private keys, messages are spread over all keys
+	ProcessorPrivateKeys []*ecdsa.PrivateKey
 
 	TargetTxHash *common.Hash
 
@@ -88,15 +94,98 @@ type Config struct {
 
 	MaxMessageRetries uint64
 	MinFeeToProcess   uint64
+
+	// concurrency configs
+	MaxConcurrentMessages  uint64
+	MaxPendingTxsPerSigner uint64
+
+	// scheduler configs
+	FeeCheckInterval       uint64
+	MaxMessageParkDuration uint64
+
+	// RouteName labels the metrics of the processor, it defaults to "<srcChainId>-<destChainId>"
+	RouteName string
+}
+
+// ForRoute returns a copy of the config processing the given route of a routes file.
+func (c *Config) ForRoute(r routes.Route) *Config {
+	cfg := *c
+
+	cfg.RouteName = r.Name
+	cfg.SrcRPCUrl = r.SrcRPCUrl
+	cfg.DestRPCUrl = r.DestRPCUrl
+	cfg.SrcSignalServiceAddress = r.SrcSignalServiceAddress
+	cfg.DestBridgeAddress = r.DestBridgeAddress
+	cfg.DestERC20VaultAddress = r.DestERC20VaultAddress
+	cfg.DestERC721VaultAddress = r.DestERC721VaultAddress
+	cfg.DestERC1155VaultAddress = r.DestERC1155VaultAddress
+	cfg.DestTaikoAddress = r.DestTaikoAddress
+	cfg.DestQuotaManagerAddress = r.DestQuotaManagerAddress
+	cfg.EnableTaikoL2 = r.EnableTaikoL2
+
+	cfg.hopConfigs = []hopConfig{}
+	for _, hop := range r.Hops {
+		cfg.hopConfigs = append(cfg.hopConfigs, hopConfig{
+			signalServiceAddress: hop.SignalServiceAddress,
+			taikoAddress:         hop.TaikoAddress,
+			rpcURL:               hop.RPCUrl,
+		})
+	}
+
+	txmgrConfigs := *c.TxmgrConfigs
+	txmgrConfigs.L1RPCURL = r.DestRPCUrl
+	cfg.TxmgrConfigs = &txmgrConfigs
+
+	return &cfg
 }
 
 // NewConfigFromCliContext creates a new config instance from command line flags.
 func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
-	processorPrivateKey, err := crypto.ToECDSA(
-		common.Hex2Bytes(c.String(flags.ProcessorPrivateKey.Name)),
-	)
+	var processorPrivateKey *ecdsa.PrivateKey
+
+	if c.IsSet(flags.ProcessorPrivateKey.Name) {
+		key, err := crypto.ToECDSA(
+			common.Hex2Bytes(c.String(flags.ProcessorPrivateKey.Name)),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid processorPrivateKey: %w", err)
+		}
+
+		processorPrivateKey = key
+	}
+
+	processorSigner, err := signer.New(c.Context, &signer.Config{
+		PrivateKey:           c.String(flags.ProcessorPrivateKey.Name),
+		KeystoreFile:         c.String(flags.ProcessorKeystore.Name),
+		KeystorePasswordFile: c.String(flags.ProcessorKeystorePassword.Name),
+		RemoteEndpoint:       c.String(flags.ProcessorRemoteSigner.Name),
+		RemoteAddress:        common.HexToAddress(c.String(flags.ProcessorRemoteSignerAddress.Name)),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("invalid processorPrivateKey: %w", err)
+		return nil, fmt.Errorf("invalid processor signer: %w", err)
+	}
+
+	if err := flags.CheckRequired(
+		c,
+		flags.SrcRPCUrl,
+		flags.DestRPCUrl,
+		flags.DestTaikoAddress,
+		flags.DestERC20VaultAddress,
+		flags.DestERC1155VaultAddress,
+		flags.DestERC721VaultAddress,
+	); err != nil {
+		return nil, err
+	}
+
+	processorPrivateKeys := []*ecdsa.PrivateKey{}
+
+	for i, key := range c.StringSlice(flags.ProcessorPrivateKeys.Name) {
+		privateKey, err := crypto.ToECDSA(common.Hex2Bytes(key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid processorPrivateKeys[%d]: %w", i, err)
+		}
+
+		processorPrivateKeys = append(processorPrivateKeys, privateKey)
 	}
 
 	hopSignalServiceAddresses := c.StringSlice(flags.HopSignalServiceAddresses.Name)
@@ -140,6 +229,8 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 	return &Config{
 		hopConfigs:                         hopConfigs,
 		ProcessorPrivateKey:                processorPrivateKey,
+		ProcessorSigner:                    processorSigner,
+		ProcessorPrivateKeys:               processorPrivateKeys,
 		SrcSignalServiceAddress:            common.HexToAddress(c.String(flags.SrcSignalServiceAddress.Name)),
 		DestTaikoAddress:                   common.HexToAddress(c.String(flags.DestTaikoAddress.Name)),
 		DestBridgeAddress:                  common.HexToAddress(c.String(flags.DestBridgeAddress.Name)),
@@ -177,8 +268,12 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 			processorPrivateKey,
 			c,
 		),
-		MaxMessageRetries: c.Uint64(flags.MaxMessageRetries.Name),
-		MinFeeToProcess:   c.Uint64(flags.MinFeeToProcess.Name),
+		MaxMessageRetries:      c.Uint64(flags.MaxMessageRetries.Name),
+		MinFeeToProcess:        c.Uint64(flags.MinFeeToProcess.Name),
+		MaxConcurrentMessages:  c.Uint64(flags.MaxConcurrentMessages.Name),
+		MaxPendingTxsPerSigner: c.Uint64(flags.MaxPendingTxsPerSigner.Name),
+		FeeCheckInterval:       c.Uint64(flags.FeeCheckInterval.Name),
+		MaxMessageParkDuration: c.Uint64(flags.MaxMessageParkDuration.Name),
 		OpenDBFunc: func() (db.DB, error) {
 			return db.OpenDBConnection(db.DBConnectionOpts{
 				Name:            c.String(flags.DatabaseUsername.Name),
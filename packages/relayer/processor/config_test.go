@@ -11,6 +11,7 @@ import (
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/db"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/mock"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/queue"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/routes"
 )
 
 var (
@@ -72,6 +73,11 @@ func TestNewConfigFromCliContext(t *testing.T) {
 		assert.Equal(t, true, c.ProfitableOnly)
 		assert.Equal(t, uint64(100), c.QueuePrefetch)
 		assert.Equal(t, true, c.EnableTaikoL2)
+		assert.Equal(t, 1, len(c.ProcessorPrivateKeys))
+		assert.Equal(t, uint64(8), c.MaxConcurrentMessages)
+		assert.Equal(t, uint64(2), c.MaxPendingTxsPerSigner)
+		assert.Equal(t, uint64(6), c.FeeCheckInterval)
+		assert.Equal(t, uint64(300), c.MaxMessageParkDuration)
 
 		c.OpenDBFunc = func() (db.DB, error) {
 			return &mock.DB{}, nil
@@ -118,6 +124,11 @@ func TestNewConfigFromCliContext(t *testing.T) {
 		"--" + flags.ProfitableOnly.Name,
 		"--" + flags.EnableTaikoL2.Name,
 		"--" + flags.DestQuotaManagerAddress.Name, destQuotaManagerAddr,
+		"--" + flags.ProcessorPrivateKeys.Name, dummyEcdsaKey2,
+		"--" + flags.MaxConcurrentMessages.Name, "8",
+		"--" + flags.MaxPendingTxsPerSigner.Name, "2",
+		"--" + flags.FeeCheckInterval.Name, "6",
+		"--" + flags.MaxMessageParkDuration.Name, "300",
 	}))
 }
 
@@ -145,3 +156,73 @@ func TestNewConfigFromCliContext_PrivKeyError(t *testing.T) {
 		"--" + flags.DestQuotaManagerAddress.Name, destQuotaManagerAddr,
 	}), "invalid processorPrivateKey")
 }
+
+func TestNewConfigFromCliContext_RequiredWithoutRoutes(t *testing.T) {
+	app := setupApp()
+	assert.ErrorContains(t, app.Run([]string{
+		"TestingNewConfigFromCliContext",
+		"--" + flags.DatabaseUsername.Name, "dbuser",
+		"--" + flags.DatabasePassword.Name, "dbpass",
+		"--" + flags.DatabaseHost.Name, "dbhost",
+		"--" + flags.DatabaseName.Name, "dbname",
+		"--" + flags.QueueUsername.Name, "queuename",
+		"--" + flags.QueuePassword.Name, "queuepassword",
+		"--" + flags.QueueHost.Name, "queuehost",
+		"--" + flags.QueuePort.Name, "5555",
+		"--" + flags.ProcessorPrivateKey.Name, dummyEcdsaKey,
+	}), `required flag "srcRpcUrl" not set`)
+}
+
+func TestConfig_ForRoute(t *testing.T) {
+	app := setupApp()
+
+	app.Action = func(ctx *cli.Context) error {
+		c, err := NewConfigFromCliContext(ctx)
+		assert.Nil(t, err)
+
+		route := routes.Route{
+			Name:                  "l2a-l2b",
+			SrcRPCUrl:             "l2aRpcUrl",
+			DestRPCUrl:            "l2bRpcUrl",
+			DestBridgeAddress:     common.HexToAddress(destBridgeAddr),
+			DestERC20VaultAddress: common.HexToAddress(destQuotaManagerAddr),
+			Hops: []routes.Hop{{
+				RPCUrl:               "l1RpcUrl",
+				SignalServiceAddress: common.HexToAddress(destBridgeAddr),
+				TaikoAddress:         common.HexToAddress(destQuotaManagerAddr),
+			}},
+		}
+
+		r := c.ForRoute(route)
+		assert.Equal(t, "l2a-l2b", r.RouteName)
+		assert.Equal(t, "l2aRpcUrl", r.SrcRPCUrl)
+		assert.Equal(t, "l2bRpcUrl", r.DestRPCUrl)
+		assert.Equal(t, "l2bRpcUrl", r.TxmgrConfigs.L1RPCURL)
+		assert.Equal(t, common.HexToAddress(destQuotaManagerAddr), r.DestERC20VaultAddress)
+		assert.Equal(t, []hopConfig{{
+			signalServiceAddress: common.HexToAddress(destBridgeAddr),
+			taikoAddress:         common.HexToAddress(destQuotaManagerAddr),
+			rpcURL:               "l1RpcUrl",
+		}}, r.hopConfigs)
+
+		// the config of the flags is left untouched
+		assert.Equal(t, "", c.SrcRPCUrl)
+		assert.Equal(t, "", c.TxmgrConfigs.L1RPCURL)
+
+		return err
+	}
+
+	assert.Nil(t, app.Run([]string{
+		"TestConfig_ForRoute",
+		"--" + flags.DatabaseUsername.Name, "dbuser",
+		"--" + flags.DatabasePassword.Name, "dbpass",
+		"--" + flags.DatabaseHost.Name, "dbhost",
+		"--" + flags.DatabaseName.Name, "dbname",
+		"--" + flags.QueueUsername.Name, "queuename",
+		"--" + flags.QueuePassword.Name, "queuepassword",
+		"--" + flags.QueueHost.Name, "queuehost",
+		"--" + flags.QueuePort.Name, "5555",
+		"--" + flags.ProcessorPrivateKey.Name, dummyEcdsaKey,
+		"--" + flags.Routes.Name, "routes.yaml",
+	}))
+}
@@ -0,0 +1,263 @@
+package processor
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/taikoxyz/taiko-mono/packages/relayer"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/bindings/bridge"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/bindings/quotamanager"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/bindings/signalservice"
+)
+
+// errorClass categorises why processing a message failed. The class decides, through
+// errorPolicies, what happens to the queue message.
+type errorClass string
+
+const (
+	// errorClassTransientRPC is a node which timed out, was unreachable or returned a
+	// server error. Retrying against the same node is expected to succeed.
+	errorClassTransientRPC errorClass = "transient_rpc"
+	// errorClassNonce is a transaction rejected by the mempool because of its nonce or
+	// because it could not replace a pending transaction.
+	errorClassNonce errorClass = "nonce"
+	// errorClassRevert is a call or transaction reverted by the dest chain contracts.
+	errorClassRevert errorClass = "revert"
+	// errorClassQuota is a message exceeding the quota of the dest chain quota manager.
+	errorClassQuota errorClass = "quota"
+	// errorClassUnprofitable is a message whose fee does not cover the cost of processing it.
+	errorClassUnprofitable errorClass = "unprofitable"
+	// errorClassInvalidMessage is a message which can never be processed by this relayer.
+	errorClassInvalidMessage errorClass = "invalid_message"
+	// errorClassUnknown is any error which could not be classified.
+	errorClassUnknown errorClass = "unknown"
+)
+
+// processingError is an error annotated with its class and, for reverts, the name of the
+// decoded custom error or the revert reason string.
+type processingError struct {
+	class  errorClass
+	reason string
+	// retryAfter, if set, is the earliest the message should be retried.
+	retryAfter time.Duration
+	err        error
+}
+
+func (e *processingError) Error() string {
+	if e.reason != "" {
+		return fmt.Sprintf("%v: %v (%v)", e.class, e.err, e.reason)
+	}
+
+	return fmt.Sprintf("%v: %v", e.class, e.err)
+}
+
+func (e *processingError) Unwrap() error {
+	return e.err
+}
+
+// newProcessingError annotates err with the given class. Errors which are already
+// classified keep their class.
+func newProcessingError(class errorClass, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pe *processingError
+	if errors.As(err, &pe) {
+		return err
+	}
+
+	return &processingError{class: class, err: err}
+}
+
+// newRevertError returns a revert error, moving errors the quota manager reverts with to
+// the quota class.
+func newRevertError(reason string, err error) error {
+	class := errorClassRevert
+	if reason == "B_OUT_OF_ETH_QUOTA" || reason == "QM_OUT_OF_QUOTA" {
+		class = errorClassQuota
+	}
+
+	return &processingError{class: class, reason: reason, err: err}
+}
+
+// classifyRPCError classifies an error returned by a node. It is a revert if the node
+// returned revert data, a nonce error if the mempool rejected the transaction, and a
+// transient RPC error otherwise.
+func classifyRPCError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pe *processingError
+	if errors.As(err, &pe) {
+		return err
+	}
+
+	if reason, ok := revertReason(err); ok {
+		return newRevertError(reason, err)
+	}
+
+	if isNonceError(err) {
+		return &processingError{class: errorClassNonce, err: err}
+	}
+
+	return &processingError{class: errorClassTransientRPC, err: err}
+}
+
+// errorClassOf returns the class and revert reason of err. Errors which were not
+// classified where they happened are classified by the sentinel or type they wrap.
+func errorClassOf(err error) (errorClass, string) {
+	var pe *processingError
+	if errors.As(err, &pe) {
+		return pe.class, pe.reason
+	}
+
+	switch {
+	case errors.Is(err, relayer.ErrUnprofitable):
+		return errorClassUnprofitable, ""
+	case errors.Is(err, errUnprocessable),
+		errors.Is(err, errImpossible),
+		errors.Is(err, errAlreadyProcessing),
+		errors.Is(err, errUnknownSigner):
+		return errorClassInvalidMessage, ""
+	case isTransientError(err):
+		return errorClassTransientRPC, ""
+	case isNonceError(err):
+		return errorClassNonce, ""
+	}
+
+	return errorClassUnknown, ""
+}
+
+// isTransientError returns true for network level errors.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, rpc.ErrClientQuit) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == 429 || httpErr.StatusCode >= 500
+	}
+
+	return false
+}
+
+// nonceErrors are the mempool errors for transactions which can not be accepted with
+// their nonce or fees. Errors returned over RPC lose their type, so they are matched on
+// their message.
+var nonceErrors = []string{
+	core.ErrNonceTooLow.Error(),
+	core.ErrNonceTooHigh.Error(),
+	txpool.ErrAlreadyKnown.Error(),
+	txpool.ErrReplaceUnderpriced.Error(),
+	txpool.ErrUnderpriced.Error(),
+	// returned by the transaction manager when a transaction is not picked up by the
+	// mempool before its timeout, usually because a lower nonce is stuck.
+	"failed to get tx into the mempool",
+}
+
+func isNonceError(err error) bool {
+	msg := err.Error()
+
+	for _, nonceErr := range nonceErrors {
+		if strings.Contains(msg, nonceErr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// revertErrors maps the selectors of the custom errors of the contracts a processMessage
+// call goes through to their names.
+var revertErrors = map[[4]byte]string{}
+
+func init() {
+	for _, metaData := range []*bind.MetaData{
+		bridge.BridgeMetaData,
+		quotamanager.QuotaManagerMetaData,
+		signalservice.SignalServiceMetaData,
+	} {
+		contractABI, err := metaData.GetAbi()
+		if err != nil {
+			slog.Error("error getting contract ABI", "error", err)
+			continue
+		}
+
+		for _, abiError := range contractABI.Errors {
+			var selector [4]byte
+
+			copy(selector[:], abiError.ID[:4])
+
+			revertErrors[selector] = abiError.Name
+		}
+	}
+}
+
+// revertReason returns whether err is a revert, and if so the decoded reason when the
+// node returned revert data.
+func revertReason(err error) (string, bool) {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if revertData, decodeErr := hexutil.Decode(data); decodeErr == nil {
+				return decodeRevertData(revertData), true
+			}
+		}
+	}
+
+	if strings.Contains(err.Error(), vm.ErrExecutionReverted.Error()) {
+		return "", true
+	}
+
+	return "", false
+}
+
+// decodeRevertData decodes revert data into the custom error name, or the reason string
+// of `Error(string)` and `Panic(uint256)` reverts.
+func decodeRevertData(data []byte) string {
+	if len(data) < 4 {
+		return ""
+	}
+
+	var selector [4]byte
+
+	copy(selector[:], data[:4])
+
+	if name, ok := revertErrors[selector]; ok {
+		return name
+	}
+
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+
+	return "0x" + hex.EncodeToString(data)
+}
@@ -0,0 +1,113 @@
+package processor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/taikoxyz/taiko-mono/packages/relayer"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/encoding"
+)
+
+// revertDataError mimics the JSON-RPC error returned by a node for a reverted call.
+type revertDataError struct {
+	data string
+}
+
+func (e *revertDataError) Error() string {
+	return "execution reverted"
+}
+
+func (e *revertDataError) ErrorData() interface{} {
+	return e.data
+}
+
+func Test_errorClassOf(t *testing.T) {
+	invalidStatus := encoding.BridgeABI.Errors["B_INVALID_STATUS"].ID
+	outOfQuota := encoding.BridgeABI.Errors["B_OUT_OF_ETH_QUOTA"].ID
+
+	tests := []struct {
+		name       string
+		err        error
+		wantClass  errorClass
+		wantReason string
+	}{
+		{
+			"unprofitable",
+			relayer.ErrUnprofitable,
+			errorClassUnprofitable,
+			"",
+		},
+		{
+			"unprocessable",
+			errUnprocessable,
+			errorClassInvalidMessage,
+			"",
+		},
+		{
+			"timeout",
+			errors.Wrap(context.DeadlineExceeded, "p.waitForConfirmations"),
+			errorClassTransientRPC,
+			"",
+		},
+		{
+			"nonceTooLow",
+			classifyRPCError(errors.New("nonce too low: next nonce 5, tx nonce 4")),
+			errorClassNonce,
+			"",
+		},
+		{
+			"customErrorRevert",
+			errors.Wrap(classifyRPCError(&revertDataError{hexutil.Encode(invalidStatus[:4])}), "EstimateGas"),
+			errorClassRevert,
+			"B_INVALID_STATUS",
+		},
+		{
+			"quotaRevert",
+			classifyRPCError(&revertDataError{hexutil.Encode(outOfQuota[:4])}),
+			errorClassQuota,
+			"B_OUT_OF_ETH_QUOTA",
+		},
+		{
+			"keepsClass",
+			classifyRPCError(newProcessingError(errorClassInvalidMessage, errors.New("invalid"))),
+			errorClassInvalidMessage,
+			"",
+		},
+		{
+			"unknown",
+			errors.New("unknown"),
+			errorClassUnknown,
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class, reason := errorClassOf(tt.err)
+			assert.Equal(t, tt.wantClass, class)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
+
+func Test_errorPolicyFor(t *testing.T) {
+	assert.Equal(t, queueActionAck, errorPolicyFor(errorClassRevert, "B_INVALID_STATUS").action)
+	assert.Equal(t, queueActionRetryLater, errorPolicyFor(errorClassRevert, "B_SIGNAL_NOT_RECEIVED").action)
+	assert.Equal(t, queueActionNack, errorPolicyFor(errorClassRevert, "B_RETRY_FAILED").action)
+	assert.Equal(t, queueActionAck, errorPolicyFor(errorClassInvalidMessage, "").action)
+	assert.Equal(t, queueActionRetryLater, errorPolicyFor(errorClass("other"), "").action)
+	assert.True(t, errorPolicyFor(errorClassTransientRPC, "").transient)
+	assert.False(t, errorPolicyFor(errorClassUnknown, "").transient)
+}
+
+func Test_errorPolicy_delay(t *testing.T) {
+	policy := errorPolicy{action: queueActionRetryLater, backoff: time.Minute}
+
+	assert.Equal(t, time.Minute, policy.delay(0))
+	assert.Equal(t, 4*time.Minute, policy.delay(2))
+	assert.Equal(t, maxRetryBackoff, policy.delay(100))
+}
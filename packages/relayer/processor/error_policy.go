@@ -0,0 +1,102 @@
+package processor
+
+import (
+	"time"
+)
+
+// queueAction is what the processor does with a queue message it failed to process.
+type queueAction int
+
+const (
+	// queueActionAck removes the message from the queue.
+	queueActionAck queueAction = iota
+	// queueActionNack rejects the message without requeueing it, which sends it to the
+	// dead letter queue.
+	queueActionNack
+	// queueActionRetryLater publishes the message to a retry queue, which routes it back
+	// to the main queue once its backoff expired, and acks the original message.
+	queueActionRetryLater
+)
+
+func (a queueAction) String() string {
+	switch a {
+	case queueActionAck:
+		return "ack"
+	case queueActionNack:
+		return "nack"
+	case queueActionRetryLater:
+		return "retry_later"
+	}
+
+	return "unknown"
+}
+
+// maxRetryBackoff caps the exponential backoff of retried messages.
+var maxRetryBackoff = 30 * time.Minute
+
+// errorPolicy is how the processor handles a class of errors.
+type errorPolicy struct {
+	action queueAction
+	// backoff is the delay before the first retry, it doubles with every retry. A zero
+	// backoff uses the unprofitable queue and its configured message expiration.
+	backoff time.Duration
+	// transient errors are not caused by the message itself, retrying them does not count
+	// against the maximum message retries.
+	transient bool
+}
+
+// errorPolicies maps each error class to its policy.
+var errorPolicies = map[errorClass]errorPolicy{
+	errorClassTransientRPC:   {action: queueActionRetryLater, backoff: 15 * time.Second, transient: true},
+	errorClassNonce:          {action: queueActionRetryLater, backoff: signerCooldown, transient: true},
+	errorClassRevert:         {action: queueActionNack},
+	errorClassQuota:          {action: queueActionRetryLater, backoff: 5 * time.Minute},
+	errorClassUnprofitable:   {action: queueActionRetryLater},
+	errorClassInvalidMessage: {action: queueActionAck},
+	errorClassUnknown:        {action: queueActionRetryLater, backoff: time.Minute},
+}
+
+// revertPolicies override the revert policy for reverts which are expected to succeed
+// later, or which will never succeed.
+var revertPolicies = map[string]errorPolicy{
+	// the signal has not been synced to the dest chain yet
+	"B_SIGNAL_NOT_RECEIVED": {action: queueActionRetryLater, backoff: time.Minute},
+	// the bridge is paused
+	"INVALID_PAUSE_STATUS": {action: queueActionRetryLater, backoff: 5 * time.Minute},
+	// the message was already processed by someone else
+	"B_INVALID_STATUS": {action: queueActionAck},
+	// the message can only be processed by its owner
+	"B_PERMISSION_DENIED": {action: queueActionAck},
+	"B_INVALID_GAS_LIMIT": {action: queueActionAck},
+}
+
+// errorPolicyFor returns the policy for an error of the given class and revert reason.
+func errorPolicyFor(class errorClass, reason string) errorPolicy {
+	if class == errorClassRevert {
+		if policy, ok := revertPolicies[reason]; ok {
+			return policy
+		}
+	}
+
+	if policy, ok := errorPolicies[class]; ok {
+		return policy
+	}
+
+	return errorPolicies[errorClassUnknown]
+}
+
+// delay returns the backoff before retrying a message which has already been retried
+// timesRetried times.
+func (p errorPolicy) delay(timesRetried uint64) time.Duration {
+	delay := p.backoff
+
+	for i := uint64(0); i < timesRetried && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+
+	return delay
+}
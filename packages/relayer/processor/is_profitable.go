@@ -37,7 +37,7 @@ func (p *Processor) isProfitable(
 
 	// if processing fee is higher than baseFee * 2 +gasTipCap +  gasLimit,
 	// we should process.
-	estimatedOnchainFee := ((destChainBaseFee * 2) + gasTipCap) * uint64(gasLimit)
+	estimatedOnchainFee := estimateOnchainFee(destChainBaseFee, gasTipCap, gasLimit)
 	if fee > estimatedOnchainFee {
 		shouldProcess = true
 	}
@@ -66,10 +66,22 @@ func (p *Processor) isProfitable(
 	}
 
 	if !shouldProcess {
-		relayer.UnprofitableMessagesDetected.Inc()
+		relayer.UnprofitableMessagesDetected.WithLabelValues(p.route).Inc()
 
 		return false, nil
 	}
 
 	return true, nil
 }
+
+// estimateOnchainFee returns the maximum cost of processing a message with the given gas
+// limit, allowing for the base fee to double before the transaction is included.
+func estimateOnchainFee(destChainBaseFee uint64, gasTipCap uint64, gasLimit uint64) uint64 {
+	return ((destChainBaseFee * 2) + gasTipCap) * gasLimit
+}
+
+// paddedGasLimit returns the gas limit a processMessage call to a contract is sent with,
+// the highest gas limit a message can be processed with.
+func paddedGasLimit(gasLimit uint64) uint64 {
+	return uint64(float64(gasLimit) * 1.1)
+}
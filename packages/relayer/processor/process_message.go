@@ -28,9 +28,11 @@ import (
 )
 
 var (
-	zeroAddress          = common.HexToAddress("0x0000000000000000000000000000000000000000")
-	errUnprocessable     = errors.New("message is unprocessable")
-	errAlreadyProcessing = errors.New("already processing txHash")
+	zeroAddress           = common.HexToAddress("0x0000000000000000000000000000000000000000")
+	errUnprocessable      = errors.New("message is unprocessable")
+	errAlreadyProcessing  = errors.New("already processing txHash")
+	errMessageNotReceived = errors.New("message not received")
+	errQuotaUnavailable   = errors.New("quota not available")
 )
 
 // eventStatusFromMsgHash will check the event's msgHash/signal, and
@@ -49,7 +51,7 @@ func (p *Processor) eventStatusFromMsgHash(
 		Context: ctx,
 	}, signal)
 	if err != nil {
-		return 0, errors.Wrap(err, "svc.destBridge.MessageStatus")
+		return 0, errors.Wrap(classifyRPCError(err), "svc.destBridge.MessageStatus")
 	}
 
 	eventStatus = relayer.EventStatus(messageStatus)
@@ -67,13 +69,13 @@ func (p *Processor) processMessage(
 ) (bool, uint64, error) {
 	msgBody := &queue.QueueMessageSentBody{}
 	if err := json.Unmarshal(msg.Body, msgBody); err != nil {
-		return false, 0, errors.Wrap(err, "json.Unmarshal")
+		return false, 0, newProcessingError(errorClassInvalidMessage, errors.Wrap(err, "json.Unmarshal"))
 	}
 
 	if msgBody.Event == nil {
 		slog.Warn("empty msgBody", "id", msgBody.ID)
 
-		return false, 0, errors.New("empty message body")
+		return false, 0, newProcessingError(errorClassInvalidMessage, errors.New("empty message body"))
 	}
 
 	slog.Info("message received", "srcTxHash", msgBody.Event.Raw.TxHash.Hex())
@@ -108,6 +110,8 @@ func (p *Processor) processMessage(
 	if msgBody.TimesRetried >= p.maxMessageRetries {
 		slog.Warn("max retries reached", "timesRetried", msgBody.TimesRetried)
 
+		relayer.MessageSentEventsMaxRetriesReached.WithLabelValues(p.route).Inc()
+
 		return false, msgBody.TimesRetried, nil
 	}
 
@@ -133,14 +137,14 @@ func (p *Processor) processMessage(
 		ctx,
 		eventStatus,
 		msgBody.Event.Message.SrcOwner,
-		p.relayerAddr,
+		p.signers.relayerAddressFor(msgBody.Event.Message.SrcOwner),
 		uint64(msgBody.Event.Message.GasLimit),
 	) {
 		return false, msgBody.TimesRetried, nil
 	}
 
 	if err := p.waitForConfirmations(ctx, msgBody.Event.Raw.TxHash); err != nil {
-		return false, msgBody.TimesRetried, err
+		return false, msgBody.TimesRetried, classifyRPCError(err)
 	}
 
 	// check paused status
@@ -148,7 +152,7 @@ func (p *Processor) processMessage(
 		Context: ctx,
 	})
 	if err != nil {
-		return false, msgBody.TimesRetried, err
+		return false, msgBody.TimesRetried, classifyRPCError(err)
 	}
 
 	// if paused, lets requeue
@@ -164,7 +168,7 @@ func (p *Processor) processMessage(
 			msgBody.Event.Message.Value,
 		)
 		if err != nil {
-			return false, msgBody.TimesRetried, err
+			return false, msgBody.TimesRetried, newProcessingError(errorClassInvalidMessage, err)
 		}
 
 		// dont check quota for NFTs
@@ -181,17 +185,17 @@ func (p *Processor) processMessage(
 
 			hasQuota, waitUntil, err := p.hasQuotaAvailable(ctx, tokenAddress, value)
 			if err != nil {
-				return false, msgBody.TimesRetried, err
+				return false, msgBody.TimesRetried, classifyRPCError(err)
 			}
 
 			if !hasQuota {
-				// wait until quota available
+				// the scheduler holds the message until quota is available
 				slog.Info("quota not available for token", "waitUntil", waitUntil)
 
-				select {
-				case <-ctx.Done():
-					return false, msgBody.TimesRetried, ctx.Err()
-				case <-time.After(time.Duration(int64(waitUntil)) * time.Second):
+				return false, msgBody.TimesRetried, &processingError{
+					class:      errorClassQuota,
+					retryAfter: time.Duration(waitUntil) * time.Second,
+					err:        errQuotaUnavailable,
 				}
 			}
 		}
@@ -204,6 +208,8 @@ func (p *Processor) processMessage(
 
 	_, err = p.sendProcessMessageCall(ctx, msgBody.ID, msgBody.Event, encodedSignalProof)
 	if err != nil {
+		p.saveRevertReason(ctx, msg, msgBody.ID, err)
+
 		return false, msgBody.TimesRetried, err
 	}
 
@@ -211,7 +217,7 @@ func (p *Processor) processMessage(
 		Context: ctx,
 	}, msgBody.Event.MsgHash)
 	if err != nil {
-		return false, msgBody.TimesRetried, errors.Wrap(err, "p.destBridge.GetMessageStatus")
+		return false, msgBody.TimesRetried, errors.Wrap(classifyRPCError(err), "p.destBridge.GetMessageStatus")
 	}
 
 	slog.Info(
@@ -221,9 +227,9 @@ func (p *Processor) processMessage(
 	)
 
 	if messageStatus == uint8(relayer.EventStatusRetriable) {
-		relayer.RetriableEvents.Inc()
+		relayer.RetriableEvents.WithLabelValues(p.route).Inc()
 	} else if messageStatus == uint8(relayer.EventStatusDone) {
-		relayer.DoneEvents.Inc()
+		relayer.DoneEvents.WithLabelValues(p.route).Inc()
 	}
 
 	// internal will only be set if it's an actual queue message, not a targeted
@@ -260,7 +266,7 @@ func (p *Processor) generateEncodedSignalProof(ctx context.Context,
 			event, err := p.waitHeaderSynced(ctx, hopEthClient, hop.chainID.Uint64(), blockNum)
 
 			if err != nil {
-				return nil, errors.Wrap(err, "p.waitHeaderSynced")
+				return nil, errors.Wrap(classifyRPCError(err), "p.waitHeaderSynced")
 			}
 
 			blockNum = event.SyncedInBlockID
@@ -272,13 +278,13 @@ func (p *Processor) generateEncodedSignalProof(ctx context.Context,
 
 		event, err := p.waitHeaderSynced(ctx, hopEthClient, hopChainID.Uint64(), blockNum)
 		if err != nil {
-			return nil, err
+			return nil, classifyRPCError(err)
 		}
 
 		blockNum = event.SyncedInBlockID
 	} else {
 		if _, err := p.waitHeaderSynced(ctx, p.srcEthClient, p.destChainId.Uint64(), event.Raw.BlockNumber); err != nil {
-			return nil, err
+			return nil, classifyRPCError(err)
 		}
 	}
 
@@ -291,7 +297,7 @@ func (p *Processor) generateEncodedSignalProof(ctx context.Context,
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, classifyRPCError(err)
 	}
 
 	// if we have no hops, this is strictly a srcChain => destChain message.
@@ -345,7 +351,7 @@ func (p *Processor) generateEncodedSignalProof(ctx context.Context,
 			new(big.Int).SetUint64(blockNum),
 		)
 		if err != nil {
-			return nil, err
+			return nil, classifyRPCError(err)
 		}
 
 		hopStorageSlotKey, err := hop.signalService.GetSignalSlot(&bind.CallOpts{
@@ -356,7 +362,7 @@ func (p *Processor) generateEncodedSignalProof(ctx context.Context,
 			block.Root(),
 		)
 		if err != nil {
-			return nil, errors.Wrap(err, "hopSignalService.GetSignalSlot")
+			return nil, errors.Wrap(classifyRPCError(err), "hopSignalService.GetSignalSlot")
 		}
 
 		hops = append(hops, proof.HopParams{
@@ -388,25 +394,24 @@ func (p *Processor) generateEncodedSignalProof(ctx context.Context,
 			"hopsLength", len(hops),
 		)
 
-		return nil, err
+		return nil, classifyRPCError(err)
 	}
 
 	return encodedSignalProof, nil
 }
 
 // sendProcessMessageCall calls `bridge.processMessage` with latest nonce
-// after estimating gas, and checking profitability.
+// after estimating gas, and checking profitability. The transaction is sent
+// by the least busy processor key.
 func (p *Processor) sendProcessMessageCall(
 	ctx context.Context,
 	id int,
 	event *bridge.BridgeMessageSent,
 	proof []byte,
 ) (*types.Receipt, error) {
-	defer p.logRelayerBalance(ctx)
-
 	received, err := p.destBridge.IsMessageReceived(nil, event.Message, proof)
 	if err != nil {
-		return nil, err
+		return nil, classifyRPCError(err)
 	}
 
 	// message will fail when we try to process it
@@ -416,24 +421,24 @@ func (p *Processor) sendProcessMessageCall(
 			"srcChainId", event.Message.SrcChainId,
 		)
 
-		relayer.MessagesNotReceivedOnDestChain.Inc()
+		relayer.MessagesNotReceivedOnDestChain.WithLabelValues(p.route).Inc()
 
-		return nil, errors.New("message not received")
+		return nil, newRevertError("B_SIGNAL_NOT_RECEIVED", errMessageNotReceived)
 	}
 
 	baseFee, err := p.getBaseFee(ctx)
 	if err != nil {
-		return nil, err
+		return nil, classifyRPCError(err)
 	}
 
 	gasTipCap, err := p.destEthClient.SuggestGasTipCap(ctx)
 	if err != nil {
-		return nil, err
+		return nil, classifyRPCError(err)
 	}
 
 	data, err := encoding.BridgeABI.Pack("processMessage", event.Message, proof)
 	if err != nil {
-		return nil, err
+		return nil, newProcessingError(errorClassInvalidMessage, err)
 	}
 
 	gasLimit := uint64(float64(event.Message.GasLimit))
@@ -442,15 +447,34 @@ func (p *Processor) sendProcessMessageCall(
 	// to see if it is a contract address.
 	code, err := p.destEthClient.CodeAt(ctx, event.Message.To, nil)
 	if err != nil {
-		return nil, err
+		return nil, classifyRPCError(err)
 	}
 
 	if len(code) != 0 {
-		gasLimit = uint64(float64(gasLimit) * 1.1)
+		gasLimit = paddedGasLimit(gasLimit)
 	} else {
 		gasLimit = uint64(float64(gasLimit) * 1.05)
 	}
 
+	// messages with a zero gas limit can only be processed by their owner.
+	var requiredSigner *common.Address
+	if event.Message.GasLimit == 0 {
+		requiredSigner = &event.Message.SrcOwner
+	}
+
+	sender, err := p.signers.acquire(ctx, requiredSigner)
+	if err != nil {
+		return nil, err
+	}
+
+	var sendErr error
+
+	defer func() {
+		p.signers.release(sender, sendErr)
+	}()
+
+	defer p.logSignerBalance(ctx, sender)
+
 	var estimatedMaxCost uint64
 
 	if bool(p.profitableOnly) {
@@ -471,20 +495,15 @@ func (p *Processor) sendProcessMessageCall(
 		}
 
 		// now simulate the transaction and lets confirm it is profitable
-		auth, err := bind.NewKeyedTransactorWithChainID(p.ecdsaKey, p.destChainId)
-		if err != nil {
-			return nil, err
-		}
-
 		msg := ethereum.CallMsg{
-			From: auth.From,
+			From: sender.address,
 			To:   &p.cfg.DestBridgeAddress,
 			Data: data,
 		}
 
 		gasUsed, err := p.destEthClient.EstimateGas(context.Background(), msg)
 		if err != nil {
-			return nil, err
+			return nil, classifyRPCError(err)
 		}
 
 		slog.Info("estimatedGasUsed",
@@ -514,7 +533,7 @@ func (p *Processor) sendProcessMessageCall(
 		ctx,
 		eventStatus,
 		event.Message.SrcOwner,
-		p.relayerAddr,
+		sender.address,
 		uint64(event.Message.GasLimit),
 	) {
 		slog.Error("can not process message after waiting for confirmations", "err", errUnprocessable)
@@ -528,29 +547,41 @@ func (p *Processor) sendProcessMessageCall(
 		GasLimit: gasLimit,
 	}
 
-	receipt, err := p.txmgr.Send(ctx, candidate)
+	receipt, err := sender.txmgr.Send(ctx, candidate)
 	if err != nil {
-		slog.Warn("Failed to send ProcessMessage transaction", "error", err.Error())
-		return nil, err
+		sendErr = err
+
+		relayer.SignerTxsSent.WithLabelValues(p.route, sender.address.Hex(), "failed").Inc()
+		slog.Warn("Failed to send ProcessMessage transaction",
+			"signer", sender.address.Hex(),
+			"error", err.Error(),
+		)
+
+		return nil, classifyRPCError(err)
 	}
 
 	slog.Info("Mined tx",
 		"txHash", hex.EncodeToString(receipt.TxHash.Bytes()),
 		"srcTxHash", event.Raw.TxHash.Hex(),
+		"signer", sender.address.Hex(),
 	)
 
+	p.recordGasSpent(sender, receipt)
+
 	if receipt.Status != types.ReceiptStatusSuccessful {
-		relayer.MessageSentEventsProcessedReverted.Inc()
+		relayer.SignerTxsSent.WithLabelValues(p.route, sender.address.Hex(), "reverted").Inc()
+		relayer.MessageSentEventsProcessedReverted.WithLabelValues(p.route).Inc()
 		slog.Warn("Transaction reverted", "txHash", hex.EncodeToString(receipt.TxHash.Bytes()),
 			"srcTxHash", event.Raw.TxHash.Hex(),
 			"status", receipt.Status)
 
-		return nil, errTxReverted
+		return nil, p.revertError(ctx, sender.address, candidate, receipt)
 	}
 
-	relayer.MessageSentEventsProcessed.Inc()
+	relayer.SignerTxsSent.WithLabelValues(p.route, sender.address.Hex(), "succeeded").Inc()
+	relayer.MessageSentEventsProcessed.WithLabelValues(p.route).Inc()
 
-	if p.profitableOnly {
+	if p.profitableOnly && receipt.EffectiveGasPrice != nil {
 		cost := receipt.GasUsed * receipt.EffectiveGasPrice.Uint64()
 
 		slog.Info("tx cost", "txHash", hex.EncodeToString(receipt.TxHash.Bytes()),
@@ -560,9 +591,9 @@ func (p *Processor) sendProcessMessageCall(
 		)
 
 		if cost > estimatedMaxCost {
-			relayer.UnprofitableMessageAfterTransacting.Inc()
+			relayer.UnprofitableMessageAfterTransacting.WithLabelValues(p.route).Inc()
 		} else {
-			relayer.ProfitableMessageAfterTransacting.Inc()
+			relayer.ProfitableMessageAfterTransacting.WithLabelValues(p.route).Inc()
 		}
 	}
 
@@ -573,27 +604,92 @@ func (p *Processor) sendProcessMessageCall(
 	return receipt, nil
 }
 
-// retrieve the balance of the relayer and set Prometheus
-func (p *Processor) logRelayerBalance(ctx context.Context) {
-	balance, err := p.destEthClient.BalanceAt(ctx, p.relayerAddr, nil)
+// revertError replays a reverted processMessage transaction on the state before its
+// block to recover the revert reason, which receipts do not contain.
+func (p *Processor) revertError(
+	ctx context.Context,
+	from common.Address,
+	candidate txmgr.TxCandidate,
+	receipt *types.Receipt,
+) error {
+	if receipt.BlockNumber == nil || receipt.BlockNumber.Sign() == 0 {
+		return newRevertError("", errTxReverted)
+	}
+
+	_, err := p.destEthClient.CallContract(ctx, ethereum.CallMsg{
+		From: from,
+		To:   candidate.To,
+		Gas:  candidate.GasLimit,
+		Data: candidate.TxData,
+	}, new(big.Int).Sub(receipt.BlockNumber, common.Big1))
+	if err == nil {
+		return newRevertError("", errTxReverted)
+	}
+
+	reason, _ := revertReason(err)
+
+	return newRevertError(reason, errTxReverted)
+}
+
+// saveRevertReason stores the decoded revert reason of a failed processMessage call on
+// the event row.
+func (p *Processor) saveRevertReason(ctx context.Context, msg queue.Message, id int, err error) {
+	class, reason := errorClassOf(err)
+	if reason == "" || (class != errorClassRevert && class != errorClassQuota) {
+		return
+	}
+
+	// internal will only be set if it's an actual queue message, not a targeted
+	// transaction hash set via config flag.
+	if msg.Internal == nil {
+		return
+	}
+
+	if err := p.eventRepo.UpdateRevertReason(ctx, id, reason); err != nil {
+		slog.Error("error saving revert reason", "id", id, "reason", reason, "error", err)
+	}
+}
+
+// retrieve the balance of a processor key and set Prometheus
+func (p *Processor) logSignerBalance(ctx context.Context, s *signer) {
+	balance, err := p.destEthClient.BalanceAt(ctx, s.address, nil)
 	if err != nil {
-		slog.Warn("Failed to retrieve relayer balance", "error", err)
+		slog.Warn("Failed to retrieve relayer balance", "relayerAddress", s.address, "error", err)
 		return
 	}
 
-	balanceFloat := new(big.Float).SetInt(balance)
-	balanceEth := new(big.Float).Quo(
-		balanceFloat,
-		big.NewFloat(math.Pow10(18)),
-	)
+	balanceEth := weiToEth(balance)
 
 	slog.Info("Relayer balance",
-		"relayerAddress", p.relayerAddr,
+		"relayerAddress", s.address,
 		"balance", balanceEth.Text('f', 18),
 	)
 
 	balanceEthFloat, _ := balanceEth.Float64()
-	relayer.RelayerKeyBalanceGauge.Set(balanceEthFloat)
+	relayer.SignerBalanceGauge.WithLabelValues(p.route, s.address.Hex()).Set(balanceEthFloat)
+
+	if s == p.signers.primary() {
+		relayer.RelayerKeyBalanceGauge.WithLabelValues(p.route).Set(balanceEthFloat)
+	}
+}
+
+// recordGasSpent adds the gas cost of a mined transaction to the spend of its key.
+func (p *Processor) recordGasSpent(s *signer, receipt *types.Receipt) {
+	if receipt.EffectiveGasPrice == nil {
+		return
+	}
+
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+
+	costEth, _ := weiToEth(cost).Float64()
+	relayer.SignerGasSpent.WithLabelValues(p.route, s.address.Hex()).Add(costEth)
+}
+
+func weiToEth(wei *big.Int) *big.Float {
+	return new(big.Float).Quo(
+		new(big.Float).SetInt(wei),
+		big.NewFloat(math.Pow10(18)),
+	)
 }
 
 // saveMessageStatusChangedEvent writes the MessageStatusChanged event to the
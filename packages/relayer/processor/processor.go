@@ -2,24 +2,20 @@ package processor
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"math/big"
-	"os"
-	"strings"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
-	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	txmgrMetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -36,7 +32,9 @@ import (
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/proof"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/queue"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/repo"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/routes"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/utils"
+	pkgSigner "github.com/taikoxyz/taiko-mono/packages/signer"
 )
 
 // ethClient is a slimmed down interface of a go-ethereum ethclient.Client
@@ -52,6 +50,7 @@ type ethClient interface {
 	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 	ChainID(ctx context.Context) (*big.Int, error)
 	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
 	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
 	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
 	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
@@ -84,8 +83,6 @@ type Processor struct {
 	destEthClient ethClient
 	srcCaller     relayer.Caller
 
-	ecdsaKey *ecdsa.PrivateKey
-
 	srcSignalService relayer.SignalService
 
 	destBridge       relayer.Bridge
@@ -96,7 +93,6 @@ type Processor struct {
 
 	prover *proof.Prover
 
-	relayerAddr             common.Address
 	srcSignalServiceAddress common.Address
 
 	confirmations uint64
@@ -117,13 +113,25 @@ type Processor struct {
 	srcChainId  *big.Int
 	destChainId *big.Int
 
+	// route labels the processor metrics
+	route string
+
 	taikoL2 *taikol2.TaikoL2
 
-	targetTxHash *common.Hash // optional, set to target processing a specific txHash only
+	targetTxHash *common.Hash  // optional, set to target processing a specific txHash only
+	done         chan struct{} // closed once the targetTxHash has been processed
 
 	cfg *Config
 
-	txmgr txmgr.TxManager
+	signers *signerPool
+
+	// workers caps the number of messages processed concurrently
+	workers chan struct{}
+
+	// scheduler orders consumed messages by expected profit, and holds messages waiting
+	// for quota or lower fees.
+	scheduler        *scheduler
+	feeCheckInterval time.Duration
 
 	maxMessageRetries uint64
 
@@ -143,6 +151,16 @@ func (p *Processor) InitFromCli(ctx context.Context, c *cli.Context) error {
 	return InitFromConfig(ctx, p, cfg)
 }
 
+// InitFromRoute creates a new processor for one route of a routes file.
+func (p *Processor) InitFromRoute(ctx context.Context, c *cli.Context, route routes.Route) error {
+	cfg, err := NewConfigFromCliContext(c)
+	if err != nil {
+		return err
+	}
+
+	return InitFromConfig(ctx, p, cfg.ForRoute(route))
+}
+
 // nolint: funlen
 func InitFromConfig(ctx context.Context, p *Processor, cfg *Config) error {
 	p.cfg = cfg
@@ -289,15 +307,6 @@ func InitFromConfig(ctx context.Context, p *Processor, cfg *Config) error {
 		return err
 	}
 
-	publicKey := cfg.ProcessorPrivateKey.Public()
-
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return errors.New("unable to convert public key")
-	}
-
-	relayerAddr := crypto.PubkeyToAddress(*publicKeyECDSA)
-
 	var taikoL2 *taikol2.TaikoL2
 	if cfg.EnableTaikoL2 {
 		taikoL2, err = taikol2.NewTaikoL2(cfg.DestTaikoAddress, destEthClient)
@@ -316,15 +325,20 @@ func InitFromConfig(ctx context.Context, p *Processor, cfg *Config) error {
 		}
 	}
 
-	if p.txmgr, err = txmgr.NewSimpleTxManager(
-		"processor",
-		log.Root(),
-		new(txmgrMetrics.NoopTxMetrics),
-		*cfg.TxmgrConfigs,
-	); err != nil {
+	// the routes to the same destination chain send with the same keys, so they share a
+	// signer pool, otherwise their transaction managers would race on the nonces.
+	signers, err := sharedSignerPool(destChainID, func() (*signerPool, error) {
+		return newProcessorSignerPool(destChainID, cfg)
+	})
+	if err != nil {
 		return err
 	}
 
+	p.route = cfg.RouteName
+	if p.route == "" {
+		p.route = routes.DefaultName(srcChainID, destChainID)
+	}
+
 	p.hops = hops
 	p.prover = prover
 	p.eventRepo = eventRepository
@@ -339,8 +353,25 @@ func InitFromConfig(ctx context.Context, p *Processor, cfg *Config) error {
 	p.destERC20Vault = destERC20Vault
 	p.destERC721Vault = destERC721Vault
 
-	p.ecdsaKey = cfg.ProcessorPrivateKey
-	p.relayerAddr = relayerAddr
+	p.signers = signers
+
+	maxConcurrentMessages := cfg.MaxConcurrentMessages
+	if maxConcurrentMessages == 0 {
+		maxConcurrentMessages = 1
+	}
+
+	p.workers = make(chan struct{}, maxConcurrentMessages)
+
+	p.scheduler = newScheduler(
+		p.route,
+		int(cfg.QueuePrefetch/2),
+		time.Duration(cfg.MaxMessageParkDuration)*time.Second,
+	)
+
+	p.feeCheckInterval = time.Duration(cfg.FeeCheckInterval) * time.Second
+	if p.feeCheckInterval == 0 {
+		p.feeCheckInterval = 12 * time.Second
+	}
 
 	p.profitableOnly = cfg.ProfitableOnly
 
@@ -375,10 +406,61 @@ func InitFromConfig(ctx context.Context, p *Processor, cfg *Config) error {
 	return nil
 }
 
+// newProcessorSignerPool creates the signer pool of the processor keys, on the given
+// destination chain. Every key gets its own transaction manager, so each key has an
+// independent nonce stream and a stuck transaction only blocks the messages of its own key.
+func newProcessorSignerPool(destChainID *big.Int, cfg *Config) (*signerPool, error) {
+	signers := []*signer{}
+	seen := make(map[common.Address]bool)
+
+	processorSigner := cfg.ProcessorSigner
+	if processorSigner == nil {
+		processorSigner = pkgSigner.NewLocalSigner(cfg.ProcessorPrivateKey)
+	}
+
+	processorSigners := []pkgSigner.Signer{processorSigner}
+
+	for _, key := range cfg.ProcessorPrivateKeys {
+		processorSigners = append(processorSigners, pkgSigner.NewLocalSigner(key))
+	}
+
+	for _, s := range processorSigners {
+		address := s.Address()
+		if seen[address] {
+			continue
+		}
+
+		seen[address] = true
+
+		txMgr, err := pkgSigner.NewTxManager(
+			"processor",
+			log.Root().With("signer", address.Hex()),
+			new(txmgrMetrics.NoopTxMetrics),
+			*cfg.TxmgrConfigs,
+			s,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		signers = append(signers, newSigner(address, txMgr))
+	}
+
+	slog.Info("processor keys", "destChainId", destChainID.String(), "count", len(signers))
+
+	return newSignerPool(destChainID.String(), signers, cfg.MaxPendingTxsPerSigner), nil
+}
+
 func (p *Processor) Name() string {
 	return "processor"
 }
 
+// Done returns a channel closed once the processor has processed its targetTxHash, it is
+// nil when the processor processes messages from the queue.
+func (p *Processor) Done() <-chan struct{} {
+	return p.done
+}
+
 func (p *Processor) Close(ctx context.Context) {
 	p.cancel()
 
@@ -397,12 +479,10 @@ func (p *Processor) Start() error {
 
 	// if a targetTxHash is set, we only want to process that specific one.
 	if p.targetTxHash != nil {
-		err := p.processSingle(ctx)
-		if err != nil {
-			slog.Error(err.Error())
-		}
+		p.done = make(chan struct{})
+		defer close(p.done)
 
-		os.Exit(0)
+		return p.processSingle(ctx)
 	}
 
 	// otherwise, we can start the queue, and process messages from it
@@ -430,6 +510,10 @@ func (p *Processor) Start() error {
 
 	go p.eventLoop(ctx)
 
+	go p.dispatchLoop(ctx)
+
+	go p.feeLoop(ctx)
+
 	go func() {
 		if err := backoff.Retry(func() error {
 			return utils.ScanBlocks(ctx, p.srcEthClient, &p.wg)
@@ -446,7 +530,9 @@ func (p *Processor) queueName() string {
 }
 
 // eventLoop is the main event loop of a Processor which should read
-// messages from a queue and then process them.
+// messages from a queue and then process them. At most MaxConcurrentMessages
+// messages are processed at once, further messages stay in the queue until
+// a worker frees up.
 func (p *Processor) eventLoop(ctx context.Context) {
 	p.wg.Add(1)
 	defer p.wg.Done()
@@ -456,79 +542,261 @@ func (p *Processor) eventLoop(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case msg := <-p.msgCh:
-			go func(m queue.Message) {
-				shouldRequeue, timesRetried, err := p.processMessage(ctx, m)
-
-				if err != nil {
-					switch {
-					case errors.Is(err, errUnprocessable):
-						if err := p.queue.Ack(ctx, m); err != nil {
-							slog.Error("Err acking message", "err", err.Error())
-						}
-					case errors.Is(err, relayer.ErrUnprofitable):
-						slog.Info("publishing to unprofitable queue")
-
-						headers := make(map[string]interface{}, 0)
-
-						headers["retries"] = int64(timesRetried + 1)
-
-						if err := p.queue.Publish(
-							ctx,
-							fmt.Sprintf("%v-unprofitable", p.queueName()),
-							m.Body,
-							headers,
-							p.cfg.UnprofitableMessageQueueExpiration,
-						); err != nil {
-							slog.Error("error publishing to unprofitable queue", "error", err)
-						}
-
-						// after publishing successfully, we can acknowledge this message to remove it
-						// from our main queue.
-						if err := p.queue.Ack(ctx, m); err != nil {
-							slog.Error("Err acking message", "err", err.Error())
-						}
-					case errors.Is(err, context.Canceled) ||
-						strings.Contains(err.Error(), "timeout") ||
-						strings.Contains(err.Error(), "i/o") ||
-						strings.Contains(err.Error(), "connect") ||
-						strings.Contains(err.Error(), "failed to get tx into the mempool"):
-						// we want to do nothing, just log, and the message will be re-picked up
-						// by another consumer. no need to nack or ack.
-						slog.Error("process message failed", "err", err.Error())
-					default:
-						slog.Error("process message failed", "err", err.Error())
-
-						// we want to negatively acknowledge the message and requeue it if we
-						// encountered an error, but the message is processable.
-						if err := p.queue.Nack(ctx, m, shouldRequeue); err != nil {
-							slog.Error("Err nacking message", "err", err.Error())
-						}
-					}
+			p.scheduler.add(msg)
+		}
+	}
+}
 
-					return
-				}
+// dispatchLoop hands the most profitable ready message to a worker whenever one is free.
+func (p *Processor) dispatchLoop(ctx context.Context) {
+	p.wg.Add(1)
+	defer p.wg.Done()
 
-				if shouldRequeue {
-					// we want to negatively acknowledge the message
-					if err := p.queue.Nack(ctx, m, true); err != nil {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case p.workers <- struct{}{}:
+		}
+
+		msg, err := p.scheduler.next(ctx)
+		if err != nil {
+			<-p.workers
+			return
+		}
+
+		p.wg.Add(1)
+		relayer.ProcessorInFlightMessages.WithLabelValues(p.route).Inc()
+
+		go func(m queue.Message) {
+			defer func() {
+				<-p.workers
+				relayer.ProcessorInFlightMessages.WithLabelValues(p.route).Dec()
+				p.wg.Done()
+			}()
+
+			p.handleMessage(ctx, m)
+		}(msg)
+	}
+}
+
+// feeLoop periodically re-evaluates the scheduled messages at the current dest chain
+// fees, and sends parked messages which stayed unprofitable for too long to the
+// unprofitable queue.
+func (p *Processor) feeLoop(ctx context.Context) {
+	p.wg.Add(1)
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.feeCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			baseFee, err := p.getBaseFee(ctx)
+			if err != nil {
+				slog.Warn("error getting base fee", "error", err)
+				continue
+			}
+
+			gasTipCap, err := p.destEthClient.SuggestGasTipCap(ctx)
+			if err != nil {
+				slog.Warn("error getting gas tip cap", "error", err)
+				continue
+			}
+
+			for _, sm := range p.scheduler.updateFees(baseFee.Uint64(), gasTipCap.Uint64()) {
+				slog.Info("parked message still unprofitable, publishing to unprofitable queue",
+					"timesRetried", sm.timesRetried,
+				)
+
+				if err := p.retryLater(
+					ctx,
+					sm.msg,
+					sm.timesRetried+1,
+					p.unprofitableQueueName(),
+					p.cfg.UnprofitableMessageQueueExpiration,
+				); err != nil {
+					slog.Error("error publishing to unprofitable queue", "error", err)
+
+					// put the message back on the main queue rather than holding it
+					if err := p.queue.Nack(ctx, sm.msg, true); err != nil {
 						slog.Error("Err nacking message", "err", err.Error())
 					}
 
-					marshalledMsg, err := json.Marshal(msg)
-					if err != nil {
-						slog.Error("err marshaling queue message", "err", err.Error())
-					} else {
-						if err := p.queue.Publish(ctx, p.queueName(), marshalledMsg, nil, nil); err != nil {
-							slog.Error("err publishing to queue", "err", err.Error())
-						}
-					}
-				} else {
-					// otherwise if no error, we can acknowledge it successfully.
-					if err := p.queue.Ack(ctx, m); err != nil {
-						slog.Error("Err acking message", "err", err.Error())
-					}
+					continue
 				}
-			}(msg)
+
+				if err := p.queue.Ack(ctx, sm.msg); err != nil {
+					slog.Error("Err acking message", "err", err.Error())
+				}
+			}
 		}
 	}
 }
+
+// handleMessage processes a single queue message, and acknowledges, negatively
+// acknowledges or requeues it depending on the outcome.
+func (p *Processor) handleMessage(ctx context.Context, m queue.Message) {
+	shouldRequeue, timesRetried, err := p.processMessage(ctx, m)
+
+	if err != nil {
+		p.handleMessageError(ctx, m, timesRetried, err)
+
+		return
+	}
+
+	if shouldRequeue {
+		// we want to negatively acknowledge the message
+		if err := p.queue.Nack(ctx, m, true); err != nil {
+			slog.Error("Err nacking message", "err", err.Error())
+		}
+
+		marshalledMsg, err := json.Marshal(m)
+		if err != nil {
+			slog.Error("err marshaling queue message", "err", err.Error())
+		} else {
+			if err := p.queue.Publish(ctx, p.queueName(), marshalledMsg, nil, nil); err != nil {
+				slog.Error("err publishing to queue", "err", err.Error())
+			}
+		}
+	} else {
+		// otherwise if no error, we can acknowledge it successfully.
+		if err := p.queue.Ack(ctx, m); err != nil {
+			slog.Error("Err acking message", "err", err.Error())
+		}
+	}
+}
+
+// handleMessageError classifies the error processing a message failed with, and acks,
+// nacks or retries the message later according to the policy of its class.
+func (p *Processor) handleMessageError(ctx context.Context, m queue.Message, timesRetried uint64, err error) {
+	// the processor is shutting down, leave the message unacknowledged so it is
+	// redelivered to another consumer.
+	if ctx.Err() != nil {
+		slog.Warn("process message interrupted", "err", err.Error())
+		return
+	}
+
+	class, reason := errorClassOf(err)
+
+	if p.scheduler != nil && p.hold(m, class, err) {
+		slog.Info("message held by scheduler", "class", class, "err", err.Error())
+
+		relayer.MessageProcessingErrors.WithLabelValues(p.route, string(class), "hold").Inc()
+
+		return
+	}
+
+	policy := errorPolicyFor(class, reason)
+
+	slog.Error("process message failed",
+		"class", class,
+		"reason", reason,
+		"action", policy.action.String(),
+		"timesRetried", timesRetried,
+		"err", err.Error(),
+	)
+
+	relayer.MessageProcessingErrors.WithLabelValues(p.route, string(class), policy.action.String()).Inc()
+
+	switch policy.action {
+	case queueActionAck:
+		if err := p.queue.Ack(ctx, m); err != nil {
+			slog.Error("Err acking message", "err", err.Error())
+		}
+	case queueActionNack:
+		if err := p.queue.Nack(ctx, m, false); err != nil {
+			slog.Error("Err nacking message", "err", err.Error())
+		}
+	case queueActionRetryLater:
+		queueName, expiration := p.unprofitableQueueName(), p.cfg.UnprofitableMessageQueueExpiration
+
+		if policy.backoff != 0 {
+			delay := policy.delay(timesRetried)
+
+			var pe *processingError
+			if errors.As(err, &pe) && pe.retryAfter > delay {
+				delay = pe.retryAfter
+			}
+
+			// the retry queues expire their messages themselves.
+			queueName, expiration = queue.RetryQueueName(p.queueName(), queue.RetryDelay(delay)), nil
+		}
+
+		if !policy.transient {
+			timesRetried++
+		}
+
+		if err := p.retryLater(ctx, m, timesRetried, queueName, expiration); err != nil {
+			slog.Error("error publishing to retry queue", "queue", queueName, "error", err)
+
+			// leave the message unacknowledged rather than dropping it.
+			return
+		}
+
+		// after publishing successfully, we can acknowledge this message to remove it
+		// from our main queue.
+		if err := p.queue.Ack(ctx, m); err != nil {
+			slog.Error("Err acking message", "err", err.Error())
+		}
+	}
+}
+
+// hold keeps messages waiting for quota, and messages which are unprofitable at the
+// current fees, in the scheduler rather than sending them through the unprofitable queue.
+func (p *Processor) hold(m queue.Message, class errorClass, err error) bool {
+	switch class {
+	case errorClassUnprofitable:
+		return p.scheduler.park(m)
+	case errorClassQuota:
+		var pe *processingError
+		if errors.As(err, &pe) && pe.retryAfter > 0 {
+			return p.scheduler.delay(m, time.Now().Add(pe.retryAfter))
+		}
+	}
+
+	return false
+}
+
+// retryLater publishes the message with the given retry count to the given queue, which has
+// no consumers and dead letters messages back to the main queue once they expire.
+func (p *Processor) retryLater(
+	ctx context.Context,
+	m queue.Message,
+	timesRetried uint64,
+	queueName string,
+	expiration *string,
+) error {
+	msgBody := &queue.QueueMessageSentBody{}
+	if err := json.Unmarshal(m.Body, msgBody); err != nil {
+		return err
+	}
+
+	msgBody.TimesRetried = timesRetried
+
+	body, err := json.Marshal(msgBody)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]interface{}{
+		"retries": int64(msgBody.TimesRetried),
+	}
+
+	if err := p.queue.Publish(ctx, queueName, body, headers, expiration); err != nil {
+		return err
+	}
+
+	relayer.MessageSentEventsRetries.WithLabelValues(p.route).Inc()
+
+	return nil
+}
+
+// unprofitableQueueName returns the name of the queue unprofitable messages wait in until
+// the configured unprofitable message expiration.
+func (p *Processor) unprofitableQueueName() string {
+	return fmt.Sprintf("%v-unprofitable", p.queueName())
+}
@@ -1,19 +1,30 @@
 package processor
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/encoding"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/mock"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/proof"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/queue"
 )
 
-var dummyEcdsaKey = "8da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f"
+var (
+	dummyEcdsaKey  = "8da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f"
+	dummyEcdsaKey2 = "2bdd21761a483f71054e14f5b827213567971c676928d9a1808cbfa4b7501200"
+)
 
 func newTestProcessor(profitableOnly bool) *Processor {
 	privateKey, _ := crypto.HexToECDSA(dummyEcdsaKey)
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
 
 	prover, _ := proof.New(
 		&mock.Blocker{},
@@ -27,7 +38,8 @@ func newTestProcessor(profitableOnly bool) *Processor {
 		destEthClient:             &mock.EthClient{},
 		destERC20Vault:            &mock.TokenVault{},
 		srcSignalService:          &mock.SignalService{},
-		ecdsaKey:                  privateKey,
+		signers:                   newSignerPool("test", []*signer{newSigner(address, &mock.TxManager{})}, 1),
+		workers:                   make(chan struct{}, 1),
 		prover:                    prover,
 		srcCaller:                 &mock.Caller{},
 		profitableOnly:            profitableOnly,
@@ -40,7 +52,6 @@ func newTestProcessor(profitableOnly bool) *Processor {
 		ethClientTimeout:          10 * time.Second,
 		srcChainId:                mock.MockChainID,
 		destChainId:               mock.MockChainID,
-		txmgr:                     &mock.TxManager{},
 		cfg: &Config{
 			DestBridgeAddress: common.HexToAddress("0xC4279588B8dA563D264e286E2ee7CE8c244444d6"),
 		},
@@ -49,3 +60,108 @@ func newTestProcessor(profitableOnly bool) *Processor {
 		processingTxHashes: make(map[common.Hash]bool, 0),
 	}
 }
+
+// recordingQueue records the messages published to it and the acknowledgements.
+type recordingQueue struct {
+	mock.Queue
+	published map[string][]*queue.QueueMessageSentBody
+	acked     int
+	nacked    int
+}
+
+func (r *recordingQueue) Publish(
+	ctx context.Context,
+	queueName string,
+	msg []byte,
+	headers map[string]interface{},
+	expiration *string,
+) error {
+	body := &queue.QueueMessageSentBody{}
+	if err := json.Unmarshal(msg, body); err != nil {
+		return err
+	}
+
+	r.published[queueName] = append(r.published[queueName], body)
+
+	return nil
+}
+
+func (r *recordingQueue) Ack(ctx context.Context, msg queue.Message) error {
+	r.acked++
+	return nil
+}
+
+func (r *recordingQueue) Nack(ctx context.Context, msg queue.Message, requeue bool) error {
+	r.nacked++
+	return nil
+}
+
+func Test_handleMessageError(t *testing.T) {
+	tests := []struct {
+		name             string
+		err              error
+		timesRetried     uint64
+		wantQueue        string
+		wantTimesRetried uint64
+		wantNacked       int
+	}{
+		{
+			"transientRPCDoesNotCountRetry",
+			newProcessingError(errorClassTransientRPC, errors.New("i/o timeout")),
+			2,
+			"-retry-60s",
+			2,
+			0,
+		},
+		{
+			"unknownIsRequeued",
+			errors.New("something went wrong"),
+			0,
+			"-retry-60s",
+			1,
+			0,
+		},
+		{
+			"unknownBacksOff",
+			errors.New("something went wrong"),
+			3,
+			"-retry-600s",
+			4,
+			0,
+		},
+		{
+			"revertIsNacked",
+			newRevertError("B_RETRY_FAILED", errTxReverted),
+			0,
+			"",
+			0,
+			1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(true)
+
+			q := &recordingQueue{published: make(map[string][]*queue.QueueMessageSentBody)}
+			p.queue = q
+
+			body, err := json.Marshal(&queue.QueueMessageSentBody{ID: 1, TimesRetried: tt.timesRetried})
+			assert.Nil(t, err)
+
+			p.handleMessageError(context.Background(), queue.Message{Body: body}, tt.timesRetried, tt.err)
+
+			assert.Equal(t, tt.wantNacked, q.nacked)
+
+			if tt.wantQueue == "" {
+				assert.Empty(t, q.published)
+				return
+			}
+
+			published := q.published[p.queueName()+tt.wantQueue]
+			assert.Len(t, published, 1)
+			assert.Equal(t, tt.wantTimesRetried, published[0].TimesRetried)
+			assert.Equal(t, 1, q.acked)
+		})
+	}
+}
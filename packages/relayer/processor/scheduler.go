@@ -0,0 +1,335 @@
+package processor
+
+import (
+	"container/heap"
+	"context"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/taikoxyz/taiko-mono/packages/relayer"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/queue"
+)
+
+// scheduledMessage is a queue message held by the scheduler.
+type scheduledMessage struct {
+	msg          queue.Message
+	fee          uint64
+	gasLimit     uint64
+	timesRetried uint64
+
+	// profit is the fee minus the estimated cost at the last seen dest chain fees.
+	profit *big.Int
+	// notBefore is when a message waiting for quota may be processed.
+	notBefore time.Time
+	// parkedAt is when an unprofitable message was parked.
+	parkedAt time.Time
+
+	seq uint64
+}
+
+// newScheduledMessage decodes the fee and gas limit of a queue message. Messages which
+// can not be decoded are scheduled with a zero fee, processing them will fail and drop them.
+func newScheduledMessage(m queue.Message) *scheduledMessage {
+	sm := &scheduledMessage{msg: m, profit: new(big.Int)}
+
+	msgBody := &queue.QueueMessageSentBody{}
+	if err := json.Unmarshal(m.Body, msgBody); err == nil && msgBody.Event != nil {
+		sm.fee = msgBody.Event.Message.Fee
+		sm.gasLimit = uint64(msgBody.Event.Message.GasLimit)
+		sm.timesRetried = msgBody.TimesRetried
+	}
+
+	return sm
+}
+
+// maxBaseFee returns the highest dest chain base fee at which the message is profitable
+// with the given tip, the inverse of estimateOnchainFee.
+func (m *scheduledMessage) maxBaseFee(gasTipCap uint64) uint64 {
+	gasLimit := paddedGasLimit(m.gasLimit)
+	if gasLimit == 0 || m.fee/gasLimit <= gasTipCap {
+		return 0
+	}
+
+	return (m.fee/gasLimit - gasTipCap) / 2
+}
+
+// scheduler orders the messages consumed from the queue by expected profit, holds
+// messages waiting for quota until their wait window ends, and parks unprofitable
+// messages until the dest chain base fee drops enough for them to become profitable.
+// Held messages are not acknowledged, so they are redelivered by the queue if the
+// processor stops.
+type scheduler struct {
+	mu sync.Mutex
+
+	// route labels the scheduler metrics
+	route string
+
+	ready   *messageHeap
+	delayed *messageHeap
+	parked  *messageHeap
+
+	baseFee   uint64
+	gasTipCap uint64
+
+	// maxHeld caps the number of delayed and parked messages, which count against the
+	// queue prefetch.
+	maxHeld         int
+	maxParkDuration time.Duration
+
+	seq uint64
+	// wake is signalled whenever a message becomes ready, or the earliest delayed
+	// message changes.
+	wake chan struct{}
+}
+
+func newScheduler(route string, maxHeld int, maxParkDuration time.Duration) *scheduler {
+	s := &scheduler{
+		route:           route,
+		maxHeld:         maxHeld,
+		maxParkDuration: maxParkDuration,
+		wake:            make(chan struct{}, 1),
+	}
+
+	s.ready = &messageHeap{less: func(a, b *scheduledMessage) bool {
+		if c := a.profit.Cmp(b.profit); c != 0 {
+			return c > 0
+		}
+
+		return a.seq < b.seq
+	}}
+
+	s.delayed = &messageHeap{less: func(a, b *scheduledMessage) bool {
+		return a.notBefore.Before(b.notBefore)
+	}}
+
+	// parked messages which become profitable at the highest base fee are released first
+	s.parked = &messageHeap{less: func(a, b *scheduledMessage) bool {
+		return a.maxBaseFee(s.gasTipCap) > b.maxBaseFee(s.gasTipCap)
+	}}
+
+	return s
+}
+
+// add schedules a message consumed from the queue.
+func (s *scheduler) add(m queue.Message) {
+	sm := newScheduledMessage(m)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	sm.seq = s.seq
+
+	s.pushReady(sm)
+}
+
+// next blocks until a message is ready to be processed, and returns the most profitable
+// one.
+func (s *scheduler) next(ctx context.Context) (queue.Message, error) {
+	for {
+		s.mu.Lock()
+
+		now := time.Now()
+
+		for s.delayed.Len() > 0 && !s.delayed.peek().notBefore.After(now) {
+			s.pushReady(heap.Pop(s.delayed).(*scheduledMessage))
+		}
+
+		if s.ready.Len() > 0 {
+			sm := heap.Pop(s.ready).(*scheduledMessage)
+			s.updateGauges()
+			s.mu.Unlock()
+
+			return sm.msg, nil
+		}
+
+		// wait for the earliest delayed message, or for a message to be added
+		wait := time.Hour
+		if s.delayed.Len() > 0 {
+			wait = s.delayed.peek().notBefore.Sub(now)
+		}
+
+		s.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return queue.Message{}, ctx.Err()
+		case <-s.wake:
+		case <-timer.C:
+		}
+
+		timer.Stop()
+	}
+}
+
+// delay holds a message until the given time. It returns false if the scheduler is full
+// and the caller has to retry the message through the queue instead.
+func (s *scheduler) delay(m queue.Message, until time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.hasCapacity() || time.Until(until) > s.maxParkDuration {
+		return false
+	}
+
+	sm := newScheduledMessage(m)
+
+	s.seq++
+	sm.seq = s.seq
+	sm.notBefore = until
+
+	heap.Push(s.delayed, sm)
+
+	s.updateGauges()
+	s.signal()
+
+	return true
+}
+
+// park holds an unprofitable message until the base fee drops enough for it to become
+// profitable. It returns false if the scheduler is full and the caller has to retry the
+// message through the queue instead.
+func (s *scheduler) park(m queue.Message) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.hasCapacity() {
+		return false
+	}
+
+	sm := newScheduledMessage(m)
+
+	s.seq++
+	sm.seq = s.seq
+	sm.parkedAt = time.Now()
+
+	heap.Push(s.parked, sm)
+
+	s.updateGauges()
+
+	return true
+}
+
+// updateFees re-evaluates the held messages at the latest dest chain fees. Ready messages
+// are reordered, and parked messages which became profitable are released. Parked
+// messages held longer than maxParkDuration are returned, so the caller can hand them
+// back to the queue.
+func (s *scheduler) updateFees(baseFee, gasTipCap uint64) []*scheduledMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.baseFee = baseFee
+	s.gasTipCap = gasTipCap
+
+	for _, sm := range s.ready.items {
+		sm.profit = s.estimateProfit(sm)
+	}
+
+	heap.Init(s.ready)
+	heap.Init(s.parked)
+
+	released := 0
+
+	for s.parked.Len() > 0 && s.parked.peek().maxBaseFee(gasTipCap) >= baseFee {
+		s.pushReady(heap.Pop(s.parked).(*scheduledMessage))
+
+		released++
+	}
+
+	if released > 0 {
+		relayer.SchedulerParkedMessagesReleased.WithLabelValues(s.route).Add(float64(released))
+	}
+
+	var expired []*scheduledMessage
+
+	remaining := s.parked.items[:0]
+
+	for _, sm := range s.parked.items {
+		if time.Since(sm.parkedAt) > s.maxParkDuration {
+			expired = append(expired, sm)
+		} else {
+			remaining = append(remaining, sm)
+		}
+	}
+
+	s.parked.items = remaining
+	heap.Init(s.parked)
+
+	s.updateGauges()
+
+	return expired
+}
+
+// pushReady adds a message to the ready heap. s.mu must be held.
+func (s *scheduler) pushReady(sm *scheduledMessage) {
+	sm.profit = s.estimateProfit(sm)
+
+	heap.Push(s.ready, sm)
+
+	s.updateGauges()
+	s.signal()
+}
+
+// estimateProfit returns the fee minus the estimated cost of processing the message.
+// s.mu must be held.
+func (s *scheduler) estimateProfit(sm *scheduledMessage) *big.Int {
+	cost := estimateOnchainFee(s.baseFee, s.gasTipCap, paddedGasLimit(sm.gasLimit))
+
+	return new(big.Int).Sub(new(big.Int).SetUint64(sm.fee), new(big.Int).SetUint64(cost))
+}
+
+// hasCapacity returns whether another message can be delayed or parked. s.mu must be held.
+func (s *scheduler) hasCapacity() bool {
+	return s.delayed.Len()+s.parked.Len() < s.maxHeld
+}
+
+func (s *scheduler) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// updateGauges sets the scheduler gauges. s.mu must be held.
+func (s *scheduler) updateGauges() {
+	relayer.SchedulerMessages.WithLabelValues(s.route, "ready").Set(float64(s.ready.Len()))
+	relayer.SchedulerMessages.WithLabelValues(s.route, "delayed").Set(float64(s.delayed.Len()))
+	relayer.SchedulerMessages.WithLabelValues(s.route, "parked").Set(float64(s.parked.Len()))
+}
+
+// messageHeap is a container/heap of scheduled messages with a configurable order.
+type messageHeap struct {
+	items []*scheduledMessage
+	less  func(a, b *scheduledMessage) bool
+}
+
+func (h *messageHeap) Len() int { return len(h.items) }
+
+func (h *messageHeap) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+
+func (h *messageHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *messageHeap) Push(x interface{}) {
+	h.items = append(h.items, x.(*scheduledMessage))
+}
+
+func (h *messageHeap) Pop() interface{} {
+	old := h.items
+	n := len(old)
+	sm := old[n-1]
+	old[n-1] = nil
+	h.items = old[:n-1]
+
+	return sm
+}
+
+func (h *messageHeap) peek() *scheduledMessage {
+	return h.items[0]
+}
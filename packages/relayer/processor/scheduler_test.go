@@ -0,0 +1,107 @@
+package processor
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/bindings/bridge"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/queue"
+)
+
+func newScheduledTestMessage(t *testing.T, id int, fee uint64, gasLimit uint32) queue.Message {
+	body, err := json.Marshal(&queue.QueueMessageSentBody{
+		Event: &bridge.BridgeMessageSent{
+			Message: bridge.IBridgeMessage{
+				Fee:      fee,
+				GasLimit: gasLimit,
+			},
+		},
+		ID: id,
+	})
+	assert.Nil(t, err)
+
+	return queue.Message{Body: body}
+}
+
+func messageID(t *testing.T, m queue.Message) int {
+	msgBody := &queue.QueueMessageSentBody{}
+	assert.Nil(t, json.Unmarshal(m.Body, msgBody))
+
+	return msgBody.ID
+}
+
+func Test_scheduler_next_ordersByProfit(t *testing.T) {
+	s := newScheduler("test", 10, time.Minute)
+
+	s.updateFees(10, 1)
+
+	s.add(newScheduledTestMessage(t, 1, 1000, 10))
+	s.add(newScheduledTestMessage(t, 2, 5000, 10))
+	// highest fee, but also the highest cost
+	s.add(newScheduledTestMessage(t, 3, 6000, 1000))
+
+	for _, want := range []int{2, 1, 3} {
+		m, err := s.next(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, want, messageID(t, m))
+	}
+}
+
+func Test_scheduler_park_releasedWhenBaseFeeDrops(t *testing.T) {
+	s := newScheduler("test", 10, time.Minute)
+
+	s.updateFees(100, 1)
+
+	// profitable at a base fee of at most (1100 / 11 - 1) / 2 = 49
+	assert.True(t, s.park(newScheduledTestMessage(t, 1, 1100, 10)))
+
+	assert.Empty(t, s.updateFees(50, 1))
+	assert.Equal(t, 0, s.ready.Len())
+
+	assert.Empty(t, s.updateFees(49, 1))
+	assert.Equal(t, 1, s.ready.Len())
+
+	m, err := s.next(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, messageID(t, m))
+}
+
+func Test_scheduler_park_expires(t *testing.T) {
+	s := newScheduler("test", 10, time.Millisecond)
+
+	assert.True(t, s.park(newScheduledTestMessage(t, 1, 0, 10)))
+
+	time.Sleep(5 * time.Millisecond)
+
+	expired := s.updateFees(100, 1)
+	assert.Len(t, expired, 1)
+	assert.Equal(t, 0, s.parked.Len())
+}
+
+func Test_scheduler_delay(t *testing.T) {
+	s := newScheduler("test", 1, time.Minute)
+
+	assert.True(t, s.delay(newScheduledTestMessage(t, 1, 1000, 10), time.Now().Add(20*time.Millisecond)))
+
+	// the scheduler is full
+	assert.False(t, s.park(newScheduledTestMessage(t, 2, 1000, 10)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+
+	_, err := s.next(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	m, err := s.next(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, messageID(t, m))
+}
+
+func Test_scheduler_delay_tooLong(t *testing.T) {
+	s := newScheduler("test", 10, time.Minute)
+
+	assert.False(t, s.delay(newScheduledTestMessage(t, 1, 1000, 10), time.Now().Add(time.Hour)))
+}
@@ -0,0 +1,219 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/taikoxyz/taiko-mono/packages/relayer"
+)
+
+// signerCooldown is how long a signer is passed over after one of its transactions
+// failed to send, so a stuck nonce stream does not hold up other messages.
+var signerCooldown = 30 * time.Second
+
+var errUnknownSigner = errors.New("message must be processed by a key the relayer does not have")
+
+// signer is a processor key together with its own transaction manager, and therefore its
+// own nonce stream. Nonce tracking and fee bumping of replacement transactions are handled
+// by the transaction manager, which also signs with the key, wherever it is kept.
+type signer struct {
+	address common.Address
+	txmgr   txmgr.TxManager
+
+	// guarded by signerPool.mu
+	pending      int
+	coolingUntil time.Time
+}
+
+func newSigner(address common.Address, txMgr txmgr.TxManager) *signer {
+	return &signer{
+		address: address,
+		txmgr:   txMgr,
+	}
+}
+
+// signerPool assigns messages to signers, capping the number of transactions in flight
+// per signer. There is one pool per destination chain, shared by all its routes.
+type signerPool struct {
+	chainID    string
+	signers    []*signer
+	maxPending int
+
+	mu sync.Mutex
+	// released is closed, and replaced, every time a signer is released.
+	released chan struct{}
+	next     int
+}
+
+func newSignerPool(chainID string, signers []*signer, maxPending uint64) *signerPool {
+	if maxPending == 0 {
+		maxPending = 1
+	}
+
+	return &signerPool{
+		chainID:    chainID,
+		signers:    signers,
+		maxPending: int(maxPending),
+		released:   make(chan struct{}),
+	}
+}
+
+var (
+	signerPoolsMu sync.Mutex
+	signerPools   = make(map[string]*signerPool)
+)
+
+// sharedSignerPool returns the signer pool of the given destination chain, creating it
+// with newPool for the first route to that chain.
+func sharedSignerPool(destChainID *big.Int, newPool func() (*signerPool, error)) (*signerPool, error) {
+	signerPoolsMu.Lock()
+	defer signerPoolsMu.Unlock()
+
+	if pool, ok := signerPools[destChainID.String()]; ok {
+		return pool, nil
+	}
+
+	pool, err := newPool()
+	if err != nil {
+		return nil, err
+	}
+
+	signerPools[destChainID.String()] = pool
+
+	return pool, nil
+}
+
+// primary returns the signer of the main processor key.
+func (p *signerPool) primary() *signer {
+	return p.signers[0]
+}
+
+// get returns the signer with the given address, or nil.
+func (p *signerPool) get(address common.Address) *signer {
+	for _, s := range p.signers {
+		if s.address == address {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// relayerAddressFor returns the address which would process a message owned by the
+// given address: the owner itself if it is one of our keys, the primary key otherwise.
+func (p *signerPool) relayerAddressFor(owner common.Address) common.Address {
+	if s := p.get(owner); s != nil {
+		return s.address
+	}
+
+	return p.primary().address
+}
+
+// acquire reserves a transaction slot on a signer, blocking until one is available. If
+// required is set, only that signer is used, otherwise the signer with the fewest
+// transactions in flight is picked, preferring signers which are not cooling down.
+func (p *signerPool) acquire(ctx context.Context, required *common.Address) (*signer, error) {
+	for {
+		p.mu.Lock()
+
+		s, err := p.pick(required)
+		if err != nil {
+			p.mu.Unlock()
+			return nil, err
+		}
+
+		if s != nil {
+			s.pending++
+			relayer.SignerPendingTxsGauge.WithLabelValues(p.chainID, s.address.Hex()).Set(float64(s.pending))
+			p.mu.Unlock()
+
+			return s, nil
+		}
+
+		released := p.released
+		p.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+// pick returns the signer to use, or nil if all candidates are busy. p.mu must be held.
+func (p *signerPool) pick(required *common.Address) (*signer, error) {
+	if required != nil {
+		s := p.get(*required)
+		if s == nil {
+			return nil, errUnknownSigner
+		}
+
+		if s.pending >= p.maxPending {
+			return nil, nil
+		}
+
+		return s, nil
+	}
+
+	now := time.Now()
+
+	var best, bestCooling *signer
+
+	for i := range p.signers {
+		s := p.signers[(p.next+i)%len(p.signers)]
+		if s.pending >= p.maxPending {
+			continue
+		}
+
+		if now.Before(s.coolingUntil) {
+			if bestCooling == nil || s.pending < bestCooling.pending {
+				bestCooling = s
+			}
+
+			continue
+		}
+
+		if best == nil || s.pending < best.pending {
+			best = s
+		}
+	}
+
+	p.next = (p.next + 1) % len(p.signers)
+
+	// signers cooling down are only used when no other signer is available
+	if best == nil {
+		return bestCooling, nil
+	}
+
+	return best, nil
+}
+
+// release frees the slot reserved by acquire. A failed send puts the signer in cooldown.
+func (p *signerPool) release(s *signer, sendErr error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s.pending--
+	relayer.SignerPendingTxsGauge.WithLabelValues(p.chainID, s.address.Hex()).Set(float64(s.pending))
+
+	if sendErr != nil && !errors.Is(sendErr, context.Canceled) {
+		slog.Warn("signer failed to send transaction, cooling down",
+			"signer", s.address.Hex(),
+			"cooldown", signerCooldown,
+			"error", sendErr,
+		)
+
+		s.coolingUntil = time.Now().Add(signerCooldown)
+	}
+
+	close(p.released)
+	p.released = make(chan struct{})
+}
@@ -0,0 +1,140 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/mock"
+)
+
+func newTestSignerPool(t *testing.T, maxPending uint64) *signerPool {
+	key1, err := crypto.HexToECDSA(dummyEcdsaKey)
+	assert.Nil(t, err)
+
+	key2, err := crypto.HexToECDSA(dummyEcdsaKey2)
+	assert.Nil(t, err)
+
+	return newSignerPool("test", []*signer{
+		newSigner(crypto.PubkeyToAddress(key1.PublicKey), &mock.TxManager{}),
+		newSigner(crypto.PubkeyToAddress(key2.PublicKey), &mock.TxManager{}),
+	}, maxPending)
+}
+
+func Test_signerPool_acquire_leastPending(t *testing.T) {
+	pool := newTestSignerPool(t, 2)
+
+	s1, err := pool.acquire(context.Background(), nil)
+	assert.Nil(t, err)
+
+	s2, err := pool.acquire(context.Background(), nil)
+	assert.Nil(t, err)
+
+	assert.NotEqual(t, s1.address, s2.address)
+
+	pool.release(s1, nil)
+
+	s3, err := pool.acquire(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, s1.address, s3.address)
+}
+
+func Test_signerPool_acquire_required(t *testing.T) {
+	pool := newTestSignerPool(t, 1)
+
+	owner := pool.signers[1].address
+
+	s, err := pool.acquire(context.Background(), &owner)
+	assert.Nil(t, err)
+	assert.Equal(t, owner, s.address)
+
+	unknown := common.HexToAddress("0x1")
+
+	_, err = pool.acquire(context.Background(), &unknown)
+	assert.ErrorIs(t, err, errUnknownSigner)
+}
+
+func Test_signerPool_acquire_cooldown(t *testing.T) {
+	pool := newTestSignerPool(t, 1)
+
+	s, err := pool.acquire(context.Background(), nil)
+	assert.Nil(t, err)
+
+	pool.release(s, errors.New("nonce too low"))
+
+	// the other signer is preferred while s cools down
+	for i := 0; i < 2; i++ {
+		other, err := pool.acquire(context.Background(), nil)
+		assert.Nil(t, err)
+		assert.NotEqual(t, s.address, other.address)
+
+		pool.release(other, nil)
+	}
+}
+
+func Test_signerPool_acquire_blocksWhenBusy(t *testing.T) {
+	pool := newTestSignerPool(t, 1)
+
+	s1, err := pool.acquire(context.Background(), nil)
+	assert.Nil(t, err)
+
+	_, err = pool.acquire(context.Background(), nil)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = pool.acquire(ctx, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	go pool.release(s1, nil)
+
+	s3, err := pool.acquire(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, s1.address, s3.address)
+}
+
+func Test_signerPool_relayerAddressFor(t *testing.T) {
+	pool := newTestSignerPool(t, 1)
+
+	assert.Equal(t, pool.signers[1].address, pool.relayerAddressFor(pool.signers[1].address))
+	assert.Equal(t, pool.primary().address, pool.relayerAddressFor(common.HexToAddress("0x1")))
+}
+
+func Test_sharedSignerPool(t *testing.T) {
+	created := 0
+	newPool := func() (*signerPool, error) {
+		created++
+
+		return newTestSignerPool(t, 1), nil
+	}
+
+	pool1, err := sharedSignerPool(big.NewInt(167001), newPool)
+	assert.Nil(t, err)
+
+	pool2, err := sharedSignerPool(big.NewInt(167001), newPool)
+	assert.Nil(t, err)
+
+	pool3, err := sharedSignerPool(big.NewInt(167002), newPool)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, created)
+	assert.Same(t, pool1, pool2)
+	assert.NotSame(t, pool1, pool3)
+
+	_, err = sharedSignerPool(big.NewInt(167003), func() (*signerPool, error) {
+		return nil, errors.New("no keys")
+	})
+	assert.NotNil(t, err)
+
+	// a failed pool is not registered, the next route to the chain creates it again.
+	pool4, err := sharedSignerPool(big.NewInt(167003), newPool)
+	assert.Nil(t, err)
+	assert.NotNil(t, pool4)
+}
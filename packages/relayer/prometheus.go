@@ -42,112 +42,156 @@ var (
 		Name: "queue_connection_instantiated_errors_ops_total",
 		Help: "The total number of times a queue connection was instantiated with an error",
 	})
-	ChainDataSyncedEventsIndexed = promauto.NewCounter(prometheus.CounterOpts{
+	ChainDataSyncedEventsIndexed = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "chain_data_synced_events_indexed_ops_total",
 		Help: "The total number of ChainDataSynced indexed events",
-	})
-	MessageSentEventsProcessed = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	MessageSentEventsProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "message_sent_events_processed_ops_total",
 		Help: "The total number of MessageSent processed events",
-	})
-	MessageSentEventsProcessedReverted = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	MessageSentEventsProcessedReverted = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "message_sent_events_processed_reverted_ops_total",
 		Help: "The total number of MessageSent processed events that reverted",
-	})
-	MessageSentEventsIndexed = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	MessageSentEventsIndexed = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "message_sent_events_indexed_ops_total",
 		Help: "The total number of MessageSent indexed events",
-	})
-	MessageSentEventsIndexingErrors = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	MessageSentEventsIndexingErrors = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "message_sent_events_indexing_errors_ops_total",
 		Help: "The total number of errors indexing MessageSent events",
-	})
-	MessageSentEventsRetries = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	MessageSentEventsRetries = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "message_sent_events_retries_ops_total",
 		Help: "The total number of MessageSent events retries",
-	})
-	MessageSentEventsMaxRetriesReached = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	MessageSentEventsMaxRetriesReached = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "message_sent_events_max_retries_reached_ops_total",
 		Help: "The total number of MessageSent events that reached max retries",
-	})
-	MessageProcessedEventsIndexingErrors = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	MessageProcessedEventsIndexingErrors = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "message_processed_events_indexing_errors_ops_total",
 		Help: "The total number of errors indexing MessageProcessed events",
-	})
-	MessageStatusChangedEventsIndexed = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	MessageStatusChangedEventsIndexed = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "message_status_changed_events_indexed_ops_total",
 		Help: "The total number of MessageStatusChanged indexed events",
-	})
-	MessageStatusChangedEventsIndexingErrors = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	MessageStatusChangedEventsIndexingErrors = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "message_status_changed_events_indexing_errors_ops_total",
 		Help: "The total number of errors indexing MessageStatusChanged events",
-	})
-	ChainDataSyncedEventsIndexingErrors = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	ChainDataSyncedEventsIndexingErrors = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "chain_data_synced_events_indexing_errors_ops_total",
 		Help: "The total number of errors indexing ChainDataSynced events",
-	})
-	UnprofitableMessagesDetected = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	UnprofitableMessagesDetected = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "unprofitable_messages_detected",
 		Help: "The total number of messages deemed unprofitable",
-	})
-	BlocksProcessed = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	BlocksProcessed = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "blocks_processed_ops_total",
 		Help: "The total number of processed blocks",
-	})
-	BridgeMessageNotSent = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	BridgeMessageNotSent = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "bridge_message_not_sent_opt_total",
 		Help: "The total number of times a bridge message has not been sent but has been processed",
-	})
-	BridgePaused = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	BridgePaused = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "bridge_paused_ops_total",
 		Help: "The total number of times the bridge has been paused",
-	})
-	BridgePausedErrors = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	BridgePausedErrors = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "bridge_paused_errors_ops_total",
 		Help: "The total number of times the bridge has encountered an error while attempting to have been paused",
-	})
-	RetriableEvents = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	RetriableEvents = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "events_processed_retriable_status_ops_total",
 		Help: "The total number of processed events that ended up in Retriable status",
-	})
-	DoneEvents = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	DoneEvents = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "events_processed_done_status_ops_total",
 		Help: "The total number of processed events that ended up in Done status",
-	})
-	ErrorEvents = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	ErrorEvents = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "events_processed_error_ops_total",
 		Help: "The total number of processed events that failed due to an error",
-	})
-	MessagesNotReceivedOnDestChain = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	MessagesNotReceivedOnDestChain = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "messages_not_received_on_dest_chain_opts_total",
 		Help: "The total number of messages that were not received on the destination chain",
-	})
-	ProfitableMessageAfterTransacting = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	ProfitableMessageAfterTransacting = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "profitable_message_after_transacting_ops_total",
 		Help: "The total number of processed events that ended up profitable",
-	})
-	UnprofitableMessageAfterTransacting = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	UnprofitableMessageAfterTransacting = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "unprofitable_message_after_transacting_ops_total",
 		Help: "The total number of processed events that ended up unprofitable",
-	})
-	MessageSentEventsAfterRetryErrorCount = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	MessageSentEventsAfterRetryErrorCount = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "message_sent_events_after_retry_error_count",
 		Help: "The total number of errors logged for MessageSent events after retries",
-	})
-	MessageStatusChangedEventsAfterRetryErrorCount = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	MessageStatusChangedEventsAfterRetryErrorCount = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "message_status_changed_events_after_retry_error_count",
 		Help: "The total number of errors logged for MessageStatusChanged events after retries",
-	})
-	ChainDataSyncedEventsAfterRetryErrorCount = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	ChainDataSyncedEventsAfterRetryErrorCount = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "chain_data_synced_events_after_retry_error_count",
 		Help: "The total number of errors logged for ChainDataSynced events after retries",
-	})
-	MessageProcessedEventsAfterRetryErrorCount = promauto.NewCounter(prometheus.CounterOpts{
+	}, []string{"route"})
+	MessageProcessedEventsAfterRetryErrorCount = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "message_processed_events_after_retry_error_count",
 		Help: "The total number of errors logged for MessageProcessed events after retries",
-	})
-	RelayerKeyBalanceGauge = promauto.NewGauge(prometheus.GaugeOpts{
+	}, []string{"route"})
+	RelayerKeyBalanceGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "relayer_key_balance",
 		Help: "Current balance of the relayer key",
-	})
+	}, []string{"route"})
+	SignerBalanceGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "relayer_signer_balance",
+		Help: "Current balance of each processor key",
+	}, []string{"route", "signer"})
+	SignerPendingTxsGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "relayer_signer_pending_txs",
+		Help: "Number of processMessage transactions in flight for each processor key",
+	}, []string{"destChainId", "signer"})
+	SignerGasSpent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "relayer_signer_gas_spent_total",
+		Help: "Total ETH spent on gas by each processor key",
+	}, []string{"route", "signer"})
+	SignerTxsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "relayer_signer_txs_sent_ops_total",
+		Help: "Total processMessage transactions sent by each processor key, by result",
+	}, []string{"route", "signer", "result"})
+	MessageProcessingErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "relayer_message_processing_errors_ops_total",
+		Help: "Total errors processing messages, by error class and the queue action taken",
+	}, []string{"route", "class", "action"})
+	SchedulerMessages = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "relayer_scheduler_messages",
+		Help: "Number of messages held by the processor scheduler, by state",
+	}, []string{"route", "state"})
+	SchedulerParkedMessagesReleased = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "relayer_scheduler_parked_messages_released_ops_total",
+		Help: "Total parked unprofitable messages released after the dest chain base fee dropped",
+	}, []string{"route"})
+	ProofCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "relayer_proof_cache_lookups_ops_total",
+		Help: "Total storage proof cache lookups, by result",
+	}, []string{"result"})
+	EthGetProofCalls = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "relayer_eth_get_proof_calls_ops_total",
+		Help: "Total eth_getProof calls made to generate signal proofs",
+	})
+	RouteUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "relayer_route_up",
+		Help: "Whether each route of the routes file was started",
+	}, []string{"route"})
+	ProcessorInFlightMessages = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "relayer_processor_in_flight_messages",
+		Help: "Number of queue messages being processed",
+	}, []string{"route"})
 )
@@ -11,6 +11,7 @@ import (
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/db"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/queue"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/queue/rabbitmq"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/routes"
 	"github.com/urfave/cli/v2"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -59,6 +60,31 @@ type Config struct {
 
 	SrcTxmgrConfigs  *txmgr.CLIConfig
 	DestTxmgrConfigs *txmgr.CLIConfig
+
+	// RouteName labels the metrics of the watchdog, it defaults to "<srcChainId>-<destChainId>"
+	RouteName string
+}
+
+// ForRoute returns a copy of the config watching the given route of a routes file.
+func (c *Config) ForRoute(r routes.Route) *Config {
+	cfg := *c
+
+	cfg.RouteName = r.Name
+	cfg.SrcRPCUrl = r.SrcRPCUrl
+	cfg.DestRPCUrl = r.DestRPCUrl
+	cfg.SrcBridgeAddress = r.SrcBridgeAddress
+	cfg.DestBridgeAddress = r.DestBridgeAddress
+	cfg.EnableTaikoL2 = r.EnableTaikoL2
+
+	srcTxmgrConfigs := *c.SrcTxmgrConfigs
+	srcTxmgrConfigs.L1RPCURL = r.SrcRPCUrl
+	cfg.SrcTxmgrConfigs = &srcTxmgrConfigs
+
+	destTxmgrConfigs := *c.DestTxmgrConfigs
+	destTxmgrConfigs.L1RPCURL = r.DestRPCUrl
+	cfg.DestTxmgrConfigs = &destTxmgrConfigs
+
+	return &cfg
 }
 
 // NewConfigFromCliContext creates a new config instance from command line flags.
@@ -70,6 +96,10 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		return nil, fmt.Errorf("invalid watchdogPrivateKey: %w", err)
 	}
 
+	if err := flags.CheckRequired(c, flags.SrcRPCUrl, flags.DestRPCUrl); err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		WatchdogPrivateKey:      watchdogPrivateKey,
 		DestBridgeAddress:       common.HexToAddress(c.String(flags.DestBridgeAddress.Name)),
@@ -26,6 +26,7 @@ import (
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/encoding"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/queue"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/repo"
+	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/routes"
 	"github.com/taikoxyz/taiko-mono/packages/relayer/pkg/utils"
 	"github.com/urfave/cli/v2"
 )
@@ -75,6 +76,9 @@ type Watchdog struct {
 	srcChainId  *big.Int
 	destChainId *big.Int
 
+	// route labels the watchdog metrics
+	route string
+
 	srcTxmgr  txmgr.TxManager
 	destTxmgr txmgr.TxManager
 
@@ -90,6 +94,16 @@ func (w *Watchdog) InitFromCli(ctx context.Context, c *cli.Context) error {
 	return InitFromConfig(ctx, w, cfg)
 }
 
+// InitFromRoute inits a new Watchdog for one route of a routes file.
+func (w *Watchdog) InitFromRoute(ctx context.Context, c *cli.Context, route routes.Route) error {
+	cfg, err := NewConfigFromCliContext(c)
+	if err != nil {
+		return err
+	}
+
+	return InitFromConfig(ctx, w, cfg.ForRoute(route))
+}
+
 // nolint: funlen
 func InitFromConfig(ctx context.Context, w *Watchdog, cfg *Config) error {
 	db, err := cfg.OpenDBFunc()
@@ -180,6 +194,11 @@ func InitFromConfig(ctx context.Context, w *Watchdog, cfg *Config) error {
 	w.srcChainId = srcChainID
 	w.destChainId = destChainID
 
+	w.route = cfg.RouteName
+	if w.route == "" {
+		w.route = routes.DefaultName(srcChainID, destChainID)
+	}
+
 	w.confTimeoutInSeconds = int64(cfg.ConfirmationsTimeout)
 	w.confirmations = cfg.Confirmations
 
@@ -314,7 +333,7 @@ func (w *Watchdog) checkMessage(ctx context.Context, msg queue.Message) error {
 	slog.Warn("dest bridge did not send this message", "msgId", msgBody.Message.Id)
 
 	// we should alert based on this metric
-	relayer.BridgeMessageNotSent.Inc()
+	relayer.BridgeMessageNotSent.WithLabelValues(w.route).Inc()
 
 	pauseReceipt, err := w.pauseBridge(ctx, w.srcBridge, w.cfg.SrcBridgeAddress, w.srcTxmgr)
 	if err != nil {
@@ -330,13 +349,13 @@ func (w *Watchdog) checkMessage(ctx context.Context, msg queue.Message) error {
 		if pauseReceipt.Status != types.ReceiptStatusSuccessful {
 			slog.Error("Error pausing bridge", "bridgeAddress", w.cfg.SrcBridgeAddress)
 
-			relayer.BridgePausedErrors.Inc()
+			relayer.BridgePausedErrors.WithLabelValues(w.route).Inc()
 
 			return err
 		}
 	}
 
-	relayer.BridgePaused.Inc()
+	relayer.BridgePaused.WithLabelValues(w.route).Inc()
 
 	pauseReceipt, err = w.pauseBridge(ctx, w.destBridge, w.cfg.DestBridgeAddress, w.destTxmgr)
 	if err != nil {
@@ -352,13 +371,13 @@ func (w *Watchdog) checkMessage(ctx context.Context, msg queue.Message) error {
 		if pauseReceipt.Status != types.ReceiptStatusSuccessful {
 			slog.Error("Error pausing bridge", "bridgeAddress", w.cfg.DestBridgeAddress)
 
-			relayer.BridgePausedErrors.Inc()
+			relayer.BridgePausedErrors.WithLabelValues(w.route).Inc()
 
 			return err
 		}
 	}
 
-	relayer.BridgePaused.Inc()
+	relayer.BridgePaused.WithLabelValues(w.route).Inc()
 
 	return nil
 }
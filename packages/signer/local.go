@@ -0,0 +1,87 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// LocalSigner signs with a private key held in the process.
+type LocalSigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewLocalSigner creates a new signer with the given private key.
+func NewLocalSigner(key *ecdsa.PrivateKey) *LocalSigner {
+	return &LocalSigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// NewKeystoreSigner creates a new signer with the private key decrypted from the given encrypted keystore
+// file, by the password read from the given password file.
+func NewKeystoreSigner(keystoreFile string, passwordFile string) (*LocalSigner, error) {
+	keyJSON, err := os.ReadFile(keystoreFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore file: %w", err)
+	}
+
+	var password string
+	if passwordFile != "" {
+		content, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keystore password file: %w", err)
+		}
+		password = strings.TrimRight(string(content), "\r\n")
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore file %s: %w", keystoreFile, err)
+	}
+
+	return NewLocalSigner(key.PrivateKey), nil
+}
+
+// PrivateKey returns the private key of the signer.
+func (s *LocalSigner) PrivateKey() *ecdsa.PrivateKey {
+	return s.key
+}
+
+// Address implements the Signer interface.
+func (s *LocalSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTransaction implements the Signer interface.
+func (s *LocalSigner) SignTransaction(
+	_ context.Context,
+	chainID *big.Int,
+	tx *types.Transaction,
+) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+// SignData implements the Signer interface.
+func (s *LocalSigner) SignData(_ context.Context, data []byte) ([]byte, error) {
+	return crypto.Sign(accounts.TextHash(data), s.key)
+}
+
+// SignHash implements the HashSigner interface.
+func (s *LocalSigner) SignHash(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.key)
+}
+
+// ConfigureTxmgr implements the Signer interface.
+func (s *LocalSigner) ConfigureTxmgr(cfg *txmgr.CLIConfig) {
+	cfg.PrivateKey = common.Bytes2Hex(crypto.FromECDSA(s.key))
+}
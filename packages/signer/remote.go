@@ -0,0 +1,189 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RemoteSigner signs with a remote signer speaking the Web3Signer JSON-RPC protocol, i.e. `eth_signTransaction`
+// and `eth_sign`, so that the private key never lives in the process.
+type RemoteSigner struct {
+	client   *rpc.Client
+	endpoint string
+	address  common.Address
+}
+
+// TransactionArgs is the argument of the `eth_signTransaction` call.
+type TransactionArgs struct {
+	From                 common.Address    `json:"from"`
+	To                   *common.Address   `json:"to,omitempty"`
+	Gas                  hexutil.Uint64    `json:"gas"`
+	GasPrice             *hexutil.Big      `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big      `json:"value"`
+	Nonce                hexutil.Uint64    `json:"nonce"`
+	Data                 hexutil.Bytes     `json:"data"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+	ChainID              *hexutil.Big      `json:"chainId"`
+	BlobFeeCap           *hexutil.Big      `json:"maxFeePerBlobGas,omitempty"`
+	BlobHashes           []common.Hash     `json:"blobVersionedHashes,omitempty"`
+}
+
+// NewRemoteSigner creates a new signer with the account of the given address in the remote signer at the given
+// endpoint.
+func NewRemoteSigner(ctx context.Context, endpoint string, address common.Address) (*RemoteSigner, error) {
+	if address == (common.Address{}) {
+		return nil, fmt.Errorf("no account address configured for the remote signer %s", endpoint)
+	}
+
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial remote signer: %w", err)
+	}
+
+	return &RemoteSigner{client: client, endpoint: endpoint, address: address}, nil
+}
+
+// Address implements the Signer interface.
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTransaction implements the Signer interface.
+func (s *RemoteSigner) SignTransaction(
+	ctx context.Context,
+	chainID *big.Int,
+	tx *types.Transaction,
+) (*types.Transaction, error) {
+	args := &TransactionArgs{
+		From:       s.address,
+		To:         tx.To(),
+		Gas:        hexutil.Uint64(tx.Gas()),
+		Value:      (*hexutil.Big)(tx.Value()),
+		Nonce:      hexutil.Uint64(tx.Nonce()),
+		Data:       tx.Data(),
+		ChainID:    (*hexutil.Big)(chainID),
+		BlobHashes: tx.BlobHashes(),
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.AccessListTxType:
+		accessList := tx.AccessList()
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+		args.AccessList = &accessList
+	default:
+		accessList := tx.AccessList()
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		args.AccessList = &accessList
+		if tx.Type() == types.BlobTxType {
+			args.BlobFeeCap = (*hexutil.Big)(tx.BlobGasFeeCap())
+		}
+	}
+
+	var raw hexutil.Bytes
+	if err := s.client.CallContext(ctx, &raw, "eth_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("failed to sign transaction by remote signer: %w", err)
+	}
+
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction signed by remote signer: %w", err)
+	}
+
+	// Never trust the remote signer to sign what has been asked for.
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction signature from remote signer: %w", err)
+	}
+	if sender != s.address {
+		return nil, fmt.Errorf("remote signer signed by %s, expected %s", sender, s.address)
+	}
+	if !sameTransaction(signed, tx) || signed.ChainId().Cmp(chainID) != 0 {
+		return nil, fmt.Errorf("remote signer signed a different transaction: %s", signed.Hash())
+	}
+
+	// The blob sidecar is not part of the signed payload, attach it again.
+	if sidecar := tx.BlobTxSidecar(); sidecar != nil {
+		signed = signed.WithBlobTxSidecar(sidecar)
+	}
+
+	return signed, nil
+}
+
+// SignData implements the Signer interface.
+func (s *RemoteSigner) SignData(ctx context.Context, data []byte) ([]byte, error) {
+	var sig hexutil.Bytes
+	if err := s.client.CallContext(ctx, &sig, "eth_sign", s.address, hexutil.Bytes(data)); err != nil {
+		return nil, fmt.Errorf("failed to sign data by remote signer: %w", err)
+	}
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length from remote signer: %d", len(sig))
+	}
+
+	// Remote signers return V as 27 or 28, convert it to the format of crypto.Sign.
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	return sig, nil
+}
+
+// ConfigureTxmgr implements the Signer interface.
+func (s *RemoteSigner) ConfigureTxmgr(cfg *txmgr.CLIConfig) {
+	cfg.PrivateKey = ""
+	cfg.SignerCLIConfig.Endpoint = s.endpoint
+	cfg.SignerCLIConfig.Address = s.address.Hex()
+}
+
+// sameTransaction checks whether the given transactions have the same payload, regardless of their signatures
+// and chain IDs.
+func sameTransaction(a, b *types.Transaction) bool {
+	return a.Type() == b.Type() &&
+		a.Nonce() == b.Nonce() &&
+		a.Gas() == b.Gas() &&
+		a.GasPrice().Cmp(b.GasPrice()) == 0 &&
+		a.GasTipCap().Cmp(b.GasTipCap()) == 0 &&
+		a.GasFeeCap().Cmp(b.GasFeeCap()) == 0 &&
+		sameBig(a.BlobGasFeeCap(), b.BlobGasFeeCap()) &&
+		sameRecipient(a.To(), b.To()) &&
+		a.Value().Cmp(b.Value()) == 0 &&
+		bytes.Equal(a.Data(), b.Data()) &&
+		sameAccessList(a.AccessList(), b.AccessList()) &&
+		slices.Equal(a.BlobHashes(), b.BlobHashes())
+}
+
+// sameBig checks whether the given optional numbers are the same.
+func sameBig(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
+// sameAccessList checks whether the given access lists are the same, an empty list is the same as none.
+func sameAccessList(a, b types.AccessList) bool {
+	return slices.EqualFunc(a, b, func(x, y types.AccessTuple) bool {
+		return x.Address == y.Address && slices.Equal(x.StorageKeys, y.StorageKeys)
+	})
+}
+
+// sameRecipient checks whether the given transaction recipients are the same.
+func sameRecipient(a, b *common.Address) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,79 @@
+// Package signer implements the signers of the L1 transactions and the messages sent by the clients, which
+// keep their private keys either in an encrypted keystore file, or in a remote signer, so that no raw private
+// key has to be passed on the command line.
+package signer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	ErrNoSigner        = errors.New("no private key, keystore file or remote signer configured")
+	ErrMultipleSigners = errors.New("only one of private key, keystore file and remote signer can be configured")
+)
+
+// Signer signs the L1 transactions and the messages of an account.
+type Signer interface {
+	// Address returns the address of the account.
+	Address() common.Address
+	// SignTransaction signs the given transaction for the given chain.
+	SignTransaction(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error)
+	// SignData signs the given data as an EIP-191 personal message, i.e. the way `eth_sign` does, and returns
+	// the signature in the [R || S || V] format, where V is 0 or 1.
+	SignData(ctx context.Context, data []byte) ([]byte, error)
+	// ConfigureTxmgr configures the given transaction manager config to send its transactions from the account
+	// of this signer, the transaction managers created by NewTxManager then sign them by SignTransaction.
+	ConfigureTxmgr(cfg *txmgr.CLIConfig)
+}
+
+// HashSigner is implemented by the signers which hold their private keys in the process, and thus can sign
+// raw hashes as well, which remote signers refuse to do.
+type HashSigner interface {
+	// SignHash signs the given 32 bytes hash, the way crypto.Sign does.
+	SignHash(hash []byte) ([]byte, error)
+}
+
+// Config is the config of a signer, exactly one of its sources must be set.
+type Config struct {
+	PrivateKey           string // Hex encoded private key
+	KeystoreFile         string
+	KeystorePasswordFile string
+	RemoteEndpoint       string // Endpoint of a Web3Signer compatible remote signer
+	RemoteAddress        common.Address
+}
+
+// New creates a new signer from the given config.
+func New(ctx context.Context, cfg *Config) (Signer, error) {
+	var sources int
+	for _, set := range []bool{cfg.PrivateKey != "", cfg.KeystoreFile != "", cfg.RemoteEndpoint != ""} {
+		if set {
+			sources++
+		}
+	}
+	switch {
+	case sources == 0:
+		return nil, ErrNoSigner
+	case sources > 1:
+		return nil, ErrMultipleSigners
+	}
+
+	switch {
+	case cfg.KeystoreFile != "":
+		return NewKeystoreSigner(cfg.KeystoreFile, cfg.KeystorePasswordFile)
+	case cfg.RemoteEndpoint != "":
+		return NewRemoteSigner(ctx, cfg.RemoteEndpoint, cfg.RemoteAddress)
+	default:
+		key, err := crypto.ToECDSA(common.FromHex(cfg.PrivateKey))
+		if err != nil {
+			return nil, err
+		}
+		return NewLocalSigner(key), nil
+	}
+}
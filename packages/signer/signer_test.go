@@ -0,0 +1,221 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var testChainID = big.NewInt(167)
+
+// standInSigner is a local stand-in of a Web3Signer compatible remote signer, which tampers with the
+// transactions to sign if tamper is set.
+type standInSigner struct {
+	key    *ecdsa.PrivateKey
+	tamper func(tx *types.DynamicFeeTx)
+}
+
+// SignTransaction implements `eth_signTransaction`.
+func (s *standInSigner) SignTransaction(args TransactionArgs) (hexutil.Bytes, error) {
+	inner := &types.DynamicFeeTx{
+		ChainID:   args.ChainID.ToInt(),
+		Nonce:     uint64(args.Nonce),
+		GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+		GasFeeCap: args.MaxFeePerGas.ToInt(),
+		Gas:       uint64(args.Gas),
+		To:        args.To,
+		Value:     args.Value.ToInt(),
+		Data:      args.Data,
+	}
+	if args.AccessList != nil {
+		inner.AccessList = *args.AccessList
+	}
+	if s.tamper != nil {
+		s.tamper(inner)
+	}
+	signed, err := types.SignTx(types.NewTx(inner), types.LatestSignerForChainID(inner.ChainID), s.key)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
+
+// Sign implements `eth_sign`.
+func (s *standInSigner) Sign(_ common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	sig, err := crypto.Sign(accounts.TextHash(data), s.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+func newTestTx() *types.Transaction {
+	to := common.HexToAddress("0x1670000000000000000000000000000000010001")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   testChainID,
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(3),
+		Data:      []byte{0x01},
+	})
+}
+
+func newStandInServer(t *testing.T, key *ecdsa.PrivateKey, tamper func(tx *types.DynamicFeeTx)) string {
+	server := rpc.NewServer()
+	require.Nil(t, server.RegisterName("eth", &standInSigner{key: key, tamper: tamper}))
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+
+	return httpServer.URL
+}
+
+func requireSignerWorks(t *testing.T, s Signer, address common.Address) {
+	require.Equal(t, address, s.Address())
+
+	signed, err := s.SignTransaction(context.Background(), testChainID, newTestTx())
+	require.Nil(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(testChainID), signed)
+	require.Nil(t, err)
+	require.Equal(t, address, sender)
+
+	data := []byte("taiko")
+	sig, err := s.SignData(context.Background(), data)
+	require.Nil(t, err)
+	pubKey, err := crypto.SigToPub(accounts.TextHash(data), sig)
+	require.Nil(t, err)
+	require.Equal(t, address, crypto.PubkeyToAddress(*pubKey))
+}
+
+func TestLocalSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	s, err := New(context.Background(), &Config{PrivateKey: common.Bytes2Hex(crypto.FromECDSA(key))})
+	require.Nil(t, err)
+	requireSignerWorks(t, s, crypto.PubkeyToAddress(key.PublicKey))
+
+	hash := crypto.Keccak256([]byte("taiko"))
+	sig, err := s.(HashSigner).SignHash(hash)
+	require.Nil(t, err)
+	pubKey, err := crypto.SigToPub(hash, sig)
+	require.Nil(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(*pubKey))
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	dir := t.TempDir()
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(key, "password")
+	require.Nil(t, err)
+
+	passwordFile := filepath.Join(dir, "password")
+	require.Nil(t, os.WriteFile(passwordFile, []byte("password\n"), 0600))
+
+	s, err := New(context.Background(), &Config{KeystoreFile: account.URL.Path, KeystorePasswordFile: passwordFile})
+	require.Nil(t, err)
+	requireSignerWorks(t, s, account.Address)
+
+	// Wrong password
+	require.Nil(t, os.WriteFile(passwordFile, []byte("wrong"), 0600))
+	_, err = New(context.Background(), &Config{KeystoreFile: account.URL.Path, KeystorePasswordFile: passwordFile})
+	require.ErrorIs(t, err, keystore.ErrDecrypt)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	s, err := New(context.Background(), &Config{
+		RemoteEndpoint: newStandInServer(t, key, nil),
+		RemoteAddress:  address,
+	})
+	require.Nil(t, err)
+	requireSignerWorks(t, s, address)
+
+	// The remote signer signs by another account.
+	s, err = NewRemoteSigner(context.Background(), newStandInServer(t, key, nil), common.HexToAddress("0x01"))
+	require.Nil(t, err)
+	_, err = s.SignTransaction(context.Background(), testChainID, newTestTx())
+	require.ErrorContains(t, err, "expected")
+}
+
+func TestRemoteSignerTampered(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	for name, tamper := range map[string]func(tx *types.DynamicFeeTx){
+		"nonce":      func(tx *types.DynamicFeeTx) { tx.Nonce++ },
+		"tip cap":    func(tx *types.DynamicFeeTx) { tx.GasTipCap = big.NewInt(100) },
+		"fee cap":    func(tx *types.DynamicFeeTx) { tx.GasFeeCap = big.NewInt(100) },
+		"chain ID":   func(tx *types.DynamicFeeTx) { tx.ChainID = big.NewInt(1) },
+		"data":       func(tx *types.DynamicFeeTx) { tx.Data = []byte{0x02} },
+		"recipient":  func(tx *types.DynamicFeeTx) { tx.To = &address },
+		"accessList": func(tx *types.DynamicFeeTx) { tx.AccessList = types.AccessList{{Address: address}} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, err := NewRemoteSigner(context.Background(), newStandInServer(t, key, tamper), address)
+			require.Nil(t, err)
+			_, err = s.SignTransaction(context.Background(), testChainID, newTestTx())
+			require.NotNil(t, err)
+		})
+	}
+}
+
+func TestSameTransaction(t *testing.T) {
+	tx := newTestTx()
+	require.True(t, sameTransaction(tx, newTestTx()))
+
+	// An empty access list is the same as none.
+	inner := &types.DynamicFeeTx{
+		ChainID:    testChainID,
+		Nonce:      tx.Nonce(),
+		GasTipCap:  tx.GasTipCap(),
+		GasFeeCap:  tx.GasFeeCap(),
+		Gas:        tx.Gas(),
+		To:         tx.To(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: types.AccessList{},
+	}
+	require.True(t, sameTransaction(tx, types.NewTx(inner)))
+
+	inner.AccessList = types.AccessList{{Address: common.HexToAddress("0x01"), StorageKeys: []common.Hash{{}}}}
+	require.False(t, sameTransaction(tx, types.NewTx(inner)))
+}
+
+func TestNewSignerInvalidConfig(t *testing.T) {
+	_, err := New(context.Background(), &Config{})
+	require.ErrorIs(t, err, ErrNoSigner)
+
+	_, err = New(context.Background(), &Config{PrivateKey: "0x01", KeystoreFile: "keystore.json"})
+	require.ErrorIs(t, err, ErrMultipleSigners)
+
+	_, err = New(context.Background(), &Config{RemoteEndpoint: "http://localhost:9000"})
+	require.ErrorContains(t, err, "no account address")
+}
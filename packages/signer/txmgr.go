@@ -0,0 +1,43 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// NewTxManager creates a new transaction manager with the given config, which signs all its transactions
+// by the given signer, so that the transactions signed remotely are always checked by SignTransaction.
+func NewTxManager(
+	name string,
+	l log.Logger,
+	m metrics.TxMetricer,
+	cfg txmgr.CLIConfig,
+	s Signer,
+) (*txmgr.SimpleTxManager, error) {
+	if s == nil {
+		return nil, ErrNoSigner
+	}
+	s.ConfigureTxmgr(&cfg)
+
+	conf, err := txmgr.NewConfig(cfg, l)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID := conf.ChainID
+	conf.From = s.Address()
+	conf.Signer = func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if from != s.Address() {
+			return nil, fmt.Errorf("transaction from %s can't be signed by %s", from, s.Address())
+		}
+		return s.SignTransaction(ctx, chainID, tx)
+	}
+
+	return txmgr.NewSimpleTxManagerFromConfig(name, l, m, conf)
+}
@@ -10,13 +10,15 @@ import (
 )
 
 var (
-	commonCategory   = "COMMON"
-	metricsCategory  = "METRICS"
-	loggingCategory  = "LOGGING"
-	driverCategory   = "DRIVER"
-	proposerCategory = "PROPOSER"
-	proverCategory   = "PROVER"
-	txmgrCategory    = "TX_MANAGER"
+	commonCategory     = "COMMON"
+	metricsCategory    = "METRICS"
+	loggingCategory    = "LOGGING"
+	driverCategory     = "DRIVER"
+	proposerCategory   = "PROPOSER"
+	proverCategory     = "PROVER"
+	txmgrCategory      = "TX_MANAGER"
+	replayCategory     = "REPLAY"
+	mockProverCategory = "MOCK_PROVER"
 )
 
 // Required flags used by all client software.
@@ -148,6 +150,13 @@ var (
 		Value:    12 * time.Second,
 		EnvVars:  []string{"RPC_TIMEOUT"},
 	}
+	L1Finality = &cli.StringFlag{
+		Name: "l1.finality",
+		Usage: "L1 finality mode used when deriving or proving L2 blocks from L1, " +
+			"one of `head`, `safe`, `finalized` or a number of confirmations to the L1 head",
+		Category: commonCategory,
+		EnvVars:  []string{"L1_FINALITY"},
+	}
 	ProverSetAddress = &cli.StringFlag{
 		Name:     "proverSet",
 		Usage:    "ProverSet contract `address`",
@@ -155,6 +164,22 @@ var (
 		Category: commonCategory,
 		EnvVars:  []string{"PROVER_SET"},
 	}
+	// Config file
+	ConfigFile = &cli.StringFlag{
+		Name: "config.file",
+		Usage: "Path to a YAML or TOML config `file`, whose keys are the flag names, values set on the command line " +
+			"or by environment variables take precedence, the runtime-safe subset of it is reloaded when the file " +
+			"changes or on SIGHUP",
+		Category: commonCategory,
+		EnvVars:  []string{"CONFIG_FILE"},
+	}
+	ConfigReloadInterval = &cli.DurationFlag{
+		Name:     "config.reloadInterval",
+		Usage:    "Interval of checking the config file for changes, 0 to reload it on SIGHUP only",
+		Category: commonCategory,
+		Value:    10 * time.Second,
+		EnvVars:  []string{"CONFIG_RELOAD_INTERVAL"},
+	}
 )
 
 // CommonFlags All common flags.
@@ -174,6 +199,8 @@ var CommonFlags = []cli.Flag{
 	BackOffRetryInterval,
 	RPCTimeout,
 	L1PrivateEndpoint,
+	ConfigFile,
+	ConfigReloadInterval,
 }
 
 // MergeFlags merges the given flag slices.
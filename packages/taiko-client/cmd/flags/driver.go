@@ -31,6 +31,13 @@ var (
 		Category: driverCategory,
 		EnvVars:  []string{"P2P_CHECK_POINT_SYNC_URL"},
 	}
+	CheckPointSyncURLs = &cli.StringSliceFlag{
+		Name: "p2p.checkPointSyncUrls",
+		Usage: "HTTP RPC endpoints of more synced L2 execution engine nodes, the checkpoint block " +
+			"must be served by a majority of all checkpoint nodes",
+		Category: driverCategory,
+		EnvVars:  []string{"P2P_CHECK_POINT_SYNC_URLS"},
+	}
 	// Chain syncer specific flag
 	MaxExponent = &cli.Uint64Flag{
 		Name: "syncer.maxExponent",
@@ -40,6 +47,14 @@ var (
 		Category: driverCategory,
 		EnvVars:  []string{"SYNCER_MAX_EXPONENT"},
 	}
+	PrefetchDepth = &cli.Uint64Flag{
+		Name: "syncer.prefetchDepth",
+		Usage: "Number of proposals whose transactions lists are fetched from L1 ahead of their insertion, " +
+			"0 means that the proposals are fetched one by one",
+		Value:    8,
+		Category: driverCategory,
+		EnvVars:  []string{"SYNCER_PREFETCH_DEPTH"},
+	}
 	// blob server endpoint
 	BlobServerEndpoint = &cli.StringFlag{
 		Name:     "blob.server",
@@ -80,6 +95,27 @@ var (
 		Value:    false,
 		EnvVars:  []string{"PRECONFIRMATION_SERVER_SIGNATURE_CHECK"},
 	}
+	// Derivation export
+	DerivationExportDataDir = &cli.StringFlag{
+		Name: "derivationExport.dataDir",
+		Usage: "Directory of the local store where the derivation record of each inserted batch is saved, " +
+			"empty means that the records are not exported",
+		Category: driverCategory,
+		EnvVars:  []string{"DERIVATION_EXPORT_DATA_DIR"},
+	}
+	DerivationExportServerPort = &cli.Uint64Flag{
+		Name:     "derivationExport.serverPort",
+		Usage:    "HTTP port of the derivation records server, 0 means that the server is disabled",
+		Category: driverCategory,
+		Value:    0,
+		EnvVars:  []string{"DERIVATION_EXPORT_SERVER_PORT"},
+	}
+	DerivationExportJWTSecret = &cli.StringFlag{
+		Name:     "derivationExport.jwtSecret",
+		Usage:    "Path to a JWT secret used to authenticate the requests to the derivation records server",
+		Category: driverCategory,
+		EnvVars:  []string{"DERIVATION_EXPORT_JWT_SECRET"},
+	}
 )
 
 // DriverFlags All driver flags.
@@ -88,14 +124,20 @@ var DriverFlags = MergeFlags(CommonFlags, []cli.Flag{
 	L2WSEndpoint,
 	L2AuthEndpoint,
 	JWTSecret,
+	L1Finality,
 	P2PSync,
 	P2PSyncTimeout,
 	CheckPointSyncURL,
+	CheckPointSyncURLs,
 	MaxExponent,
+	PrefetchDepth,
 	BlobServerEndpoint,
 	SocialScanEndpoint,
 	PreconfBlockServerPort,
 	PreconfBlockServerJWTSecret,
 	PreconfBlockServerCORSOrigins,
 	PreconfBlockServerCheckSig,
+	DerivationExportDataDir,
+	DerivationExportServerPort,
+	DerivationExportJWTSecret,
 }, p2pFlags.P2PFlags("PRECONFIRMATION"))
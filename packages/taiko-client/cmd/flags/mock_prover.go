@@ -0,0 +1,64 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Optional flags used by the mock prover.
+var (
+	MockProverPort = &cli.Uint64Flag{
+		Name:     "mockProver.port",
+		Usage:    "Port of the mock proving backend HTTP server",
+		Category: mockProverCategory,
+		Value:    9090,
+		EnvVars:  []string{"MOCK_PROVER_PORT"},
+	}
+	MockProverProofTypes = &cli.StringSliceFlag{
+		Name:     "mockProver.proofTypes",
+		Usage:    "Proof types supported by the mock proving backend, all proof types are supported if not set",
+		Category: mockProverCategory,
+		EnvVars:  []string{"MOCK_PROVER_PROOF_TYPES"},
+	}
+	MockProverLatency = &cli.DurationFlag{
+		Name:     "mockProver.latency",
+		Usage:    "How long a proving task stays in progress before its proof is done",
+		Category: mockProverCategory,
+		Value:    5 * time.Second,
+		EnvVars:  []string{"MOCK_PROVER_LATENCY"},
+	}
+	MockProverFailureRate = &cli.Float64Flag{
+		Name:     "mockProver.failureRate",
+		Usage:    "Probability of a proving task to fail, between 0 and 1",
+		Category: mockProverCategory,
+		EnvVars:  []string{"MOCK_PROVER_FAILURE_RATE"},
+	}
+	MockProverErrorRate = &cli.Float64Flag{
+		Name:     "mockProver.errorRate",
+		Usage:    "Probability of a request to fail with an internal server error, between 0 and 1",
+		Category: mockProverCategory,
+		EnvVars:  []string{"MOCK_PROVER_ERROR_RATE"},
+	}
+	MockProverToken = &cli.StringFlag{
+		Name:     "mockProver.token",
+		Usage:    "Bearer token required by the mock proving backend, should match --prover.producer.token",
+		Category: mockProverCategory,
+		EnvVars:  []string{"MOCK_PROVER_TOKEN"},
+	}
+)
+
+// MockProverFlags All mock prover flags.
+var MockProverFlags = []cli.Flag{
+	Verbosity,
+	LogJSON,
+	MetricsEnabled,
+	MetricsAddr,
+	MetricsPort,
+	MockProverPort,
+	MockProverProofTypes,
+	MockProverLatency,
+	MockProverFailureRate,
+	MockProverErrorRate,
+	MockProverToken,
+}
@@ -7,9 +7,9 @@ import (
 // Required flags used by proposer.
 var (
 	L1ProposerPrivKey = &cli.StringFlag{
-		Name:     "l1.proposerPrivKey",
-		Usage:    "Private key of the L1 proposer, who will send TaikoL1.proposeBlock transactions",
-		Required: true,
+		Name: "l1.proposerPrivKey",
+		Usage: "Private key of the L1 proposer, who will send TaikoL1.proposeBlock transactions, " +
+			"required unless a keystore file or a remote signer is set",
 		Category: proposerCategory,
 		EnvVars:  []string{"L1_PROPOSER_PRIV_KEY"},
 	}
@@ -24,6 +24,31 @@ var (
 
 // Optional flags used by proposer.
 var (
+	L1ProposerKeystore = &cli.StringFlag{
+		Name:     "l1.proposerKeystore",
+		Usage:    "Encrypted keystore file of the L1 proposer, instead of its raw private key",
+		Category: proposerCategory,
+		EnvVars:  []string{"L1_PROPOSER_KEYSTORE"},
+	}
+	L1ProposerKeystorePassword = &cli.StringFlag{
+		Name:     "l1.proposerKeystorePassword",
+		Usage:    "Path to the file containing the password of the L1 proposer keystore file",
+		Category: proposerCategory,
+		EnvVars:  []string{"L1_PROPOSER_KEYSTORE_PASSWORD"},
+	}
+	L1ProposerRemoteSigner = &cli.StringFlag{
+		Name: "l1.proposerRemoteSigner",
+		Usage: "Endpoint of the Web3Signer compatible remote signer which signs for the L1 proposer, " +
+			"instead of its raw private key",
+		Category: proposerCategory,
+		EnvVars:  []string{"L1_PROPOSER_REMOTE_SIGNER"},
+	}
+	L1ProposerRemoteSignerAddress = &cli.StringFlag{
+		Name:     "l1.proposerRemoteSignerAddress",
+		Usage:    "Address of the L1 proposer account in the remote signer",
+		Category: proposerCategory,
+		EnvVars:  []string{"L1_PROPOSER_REMOTE_SIGNER_ADDRESS"},
+	}
 	// Proposing epoch related.
 	ProposeInterval = &cli.DurationFlag{
 		Name:     "epoch.interval",
@@ -119,6 +144,10 @@ var ProposerFlags = MergeFlags(CommonFlags, []cli.Flag{
 	JWTSecret,
 	TaikoTokenAddress,
 	L1ProposerPrivKey,
+	L1ProposerKeystore,
+	L1ProposerKeystorePassword,
+	L1ProposerRemoteSigner,
+	L1ProposerRemoteSignerAddress,
 	L2SuggestedFeeRecipient,
 	ProposeInterval,
 	TxPoolLocals,
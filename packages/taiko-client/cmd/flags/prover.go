@@ -11,9 +11,9 @@ import (
 // Required flags used by prover.
 var (
 	L1ProverPrivKey = &cli.StringFlag{
-		Name:     "l1.proverPrivKey",
-		Usage:    "Private key of L1 prover, who will send TaikoL1.proveBlock transactions",
-		Required: true,
+		Name: "l1.proverPrivKey",
+		Usage: "Private key of L1 prover, who will send TaikoL1.proveBlock transactions, " +
+			"required unless a keystore file or a remote signer is set",
 		Category: proverCategory,
 		EnvVars:  []string{"L1_PROVER_PRIV_KEY"},
 	}
@@ -21,6 +21,31 @@ var (
 
 // Optional flags used by prover.
 var (
+	L1ProverKeystore = &cli.StringFlag{
+		Name:     "l1.proverKeystore",
+		Usage:    "Encrypted keystore file of L1 prover, instead of its raw private key",
+		Category: proverCategory,
+		EnvVars:  []string{"L1_PROVER_KEYSTORE"},
+	}
+	L1ProverKeystorePassword = &cli.StringFlag{
+		Name:     "l1.proverKeystorePassword",
+		Usage:    "Path to the file containing the password of L1 prover keystore file",
+		Category: proverCategory,
+		EnvVars:  []string{"L1_PROVER_KEYSTORE_PASSWORD"},
+	}
+	L1ProverRemoteSigner = &cli.StringFlag{
+		Name: "l1.proverRemoteSigner",
+		Usage: "Endpoint of the Web3Signer compatible remote signer which signs for L1 prover, " +
+			"instead of its raw private key",
+		Category: proverCategory,
+		EnvVars:  []string{"L1_PROVER_REMOTE_SIGNER"},
+	}
+	L1ProverRemoteSignerAddress = &cli.StringFlag{
+		Name:     "l1.proverRemoteSignerAddress",
+		Usage:    "Address of L1 prover account in the remote signer",
+		Category: proverCategory,
+		EnvVars:  []string{"L1_PROVER_REMOTE_SIGNER_ADDRESS"},
+	}
 	RaikoHostEndpoint = &cli.StringFlag{
 		Name:     "raiko.host",
 		Usage:    "RPC endpoint of a Raiko host service",
@@ -75,6 +100,14 @@ var (
 		Value:    false,
 		EnvVars:  []string{"MODE_CONTESTER"},
 	}
+	ContesterSubmitConflictingProof = &cli.BoolFlag{
+		Name: "mode.contester.submitConflictingProof",
+		Usage: "Whether to prove an invalid proven Pacaya batch again with the local transition in contester mode, " +
+			"which makes the protocol pause on the conflicting proofs",
+		Category: proverCategory,
+		Value:    false,
+		EnvVars:  []string{"MODE_CONTESTER_SUBMIT_CONFLICTING_PROOF"},
+	}
 	// HTTP server related.
 	ProverHTTPServerPort = &cli.Uint64Flag{
 		Name:     "prover.port",
@@ -132,6 +165,32 @@ var (
 		Category: proverCategory,
 		EnvVars:  []string{"GUARDIAN_SUBMISSION_DELAY"},
 	}
+	GuardianApprovalPort = &cli.Uint64Flag{
+		Name: "guardian.approval.port",
+		Usage: "HTTP port of the guardian proof approval server, if set, guardian proofs are only submitted " +
+			"after being approved through it, 0 means disabled",
+		Category: proverCategory,
+		EnvVars:  []string{"GUARDIAN_APPROVAL_PORT"},
+	}
+	GuardianApprovalJWTSecret = &cli.StringFlag{
+		Name:     "guardian.approval.jwtSecret",
+		Usage:    "Path to a JWT secret to use for the guardian proof approval server",
+		Category: proverCategory,
+		EnvVars:  []string{"GUARDIAN_APPROVAL_JWT_SECRET"},
+	}
+	GuardianApprovalTimeout = &cli.DurationFlag{
+		Name:     "guardian.approval.timeout",
+		Usage:    "Time to wait for a guardian proof to be approved or rejected, 0 means waiting forever",
+		Category: proverCategory,
+		EnvVars:  []string{"GUARDIAN_APPROVAL_TIMEOUT"},
+	}
+	GuardianApprovalAutoApprove = &cli.BoolFlag{
+		Name:     "guardian.approval.autoApprove",
+		Usage:    "Whether to approve the guardian proofs not decided before guardian.approval.timeout, or reject them",
+		Value:    false,
+		Category: proverCategory,
+		EnvVars:  []string{"GUARDIAN_APPROVAL_AUTO_APPROVE"},
+	}
 	EnableLivenessBondProof = &cli.BoolFlag{
 		Name:     "prover.enableLivenessBondProof",
 		Usage:    "Toggles whether the proof is a dummy proof or returns keccak256(RETURN_LIVENESS_BOND) as proof",
@@ -153,8 +212,9 @@ var (
 	}
 	// Confirmations specific flag
 	BlockConfirmations = &cli.Uint64Flag{
-		Name:     "prover.blockConfirmations",
-		Usage:    "Confirmations to the latest L1 block before submitting a proof for a L2 block",
+		Name: "prover.blockConfirmations",
+		Usage: "Confirmations to the latest L1 block before submitting a proof for a L2 block, " +
+			"ignored if l1.finality is set",
 		Value:    6,
 		Category: proverCategory,
 		EnvVars:  []string{"PROVER_BLOCK_CONFIRMATIONS"},
@@ -184,6 +244,111 @@ var (
 		Value:    30 * time.Minute,
 		EnvVars:  []string{"PROVER_FORCE_BATCH_PROVING_INTERVAL"},
 	}
+	// Generic proving backend related flags
+	ProofProducerEndpoint = &cli.StringFlag{
+		Name: "prover.producer.endpoint",
+		Usage: "Endpoint of a proving backend implementing the proof producer protocol, which proves " +
+			"the Pacaya batches instead of Raiko if set",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_PRODUCER_ENDPOINT"},
+	}
+	ProofProducerProofType = &cli.StringFlag{
+		Name: "prover.producer.proofType",
+		Usage: "Proof type requested from the proving backend, if no compose proof types are set. " +
+			"The proofs are submitted to the sub-verifier of this proof type, e.g. --prover.compose.sgxVerifier",
+		Category: proverCategory,
+		Value:    "sgx",
+		EnvVars:  []string{"PROVER_PRODUCER_PROOF_TYPE"},
+	}
+	ProofProducerToken = &cli.StringFlag{
+		Name:     "prover.producer.token",
+		Usage:    "Bearer token of the proving backend",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_PRODUCER_TOKEN"},
+	}
+	// Proof request scheduler related flags
+	SchedulerMaxConcurrency = &cli.Uint64Flag{
+		Name: "prover.scheduler.maxConcurrency",
+		Usage: "Maximum number of concurrent proof requests of each proof producer, the pending requests are " +
+			"scheduled by their proving window deadlines and expected rewards, 0 means no limit",
+		Category: proverCategory,
+		Value:    0,
+		EnvVars:  []string{"PROVER_SCHEDULER_MAX_CONCURRENCY"},
+	}
+	SchedulerProducerConcurrency = &cli.StringSliceFlag{
+		Name: "prover.scheduler.producerConcurrency",
+		Usage: "Maximum number of concurrent proof requests of the given proof producers, " +
+			"overriding prover.scheduler.maxConcurrency, e.g. sgx=4,sp1=1",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_SCHEDULER_PRODUCER_CONCURRENCY"},
+	}
+	// Proving policy related flags
+	ProvingPolicyFile = &cli.StringFlag{
+		Name: "prover.policy.file",
+		Usage: "YAML file of the policy deciding which blocks and batches to prove, which replaces " +
+			"prover.proveUnassignedBlocks for the proposals without any submitted proof if set",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_POLICY_FILE"},
+	}
+	ProvingPolicyReloadInterval = &cli.DurationFlag{
+		Name:     "prover.policy.reloadInterval",
+		Usage:    "Interval to check the proving policy file for changes and reload it",
+		Category: proverCategory,
+		Value:    10 * time.Second,
+		EnvVars:  []string{"PROVER_POLICY_RELOAD_INTERVAL"},
+	}
+	// Proving cost accounting related flags
+	AccountingDataDir = &cli.StringFlag{
+		Name: "prover.accounting.dataDir",
+		Usage: "Directory to persist the costs and rewards of the proven blocks and batches, " +
+			"the summary report is served on prover.port if set",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_ACCOUNTING_DATA_DIR"},
+	}
+	AccountingJWTSecret = &cli.StringFlag{
+		Name:     "prover.accounting.jwtSecret",
+		Usage:    "Path to a JWT secret to use for the proving cost report endpoints, required if the data dir is set",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_ACCOUNTING_JWT_SECRET"},
+	}
+	// Compose proof related flags
+	ComposeProofTypes = &cli.StringSliceFlag{
+		Name: "prover.compose.proofTypes",
+		Usage: "Proof types (sgx, risc0, sp1) combined into a proof for the compose verifier, " +
+			"this flag only works post Pacaya fork",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_COMPOSE_PROOF_TYPES"},
+	}
+	ComposeRequiredProofTypes = &cli.StringSliceFlag{
+		Name:     "prover.compose.requiredProofTypes",
+		Usage:    "Proof types which are always needed by the compose verifier, e.g. sgx for a SgxAndZkVerifier",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_COMPOSE_REQUIRED_PROOF_TYPES"},
+	}
+	ComposeQuorum = &cli.Uint64Flag{
+		Name:     "prover.compose.quorum",
+		Usage:    "Number of sub-proofs needed by the compose verifier, all the compose proof types if not set",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_COMPOSE_QUORUM"},
+	}
+	SGXVerifierAddress = &cli.StringFlag{
+		Name:     "prover.compose.sgxVerifier",
+		Usage:    "Address of the SGX sub-verifier of the compose verifier",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_COMPOSE_SGX_VERIFIER"},
+	}
+	RISC0VerifierAddress = &cli.StringFlag{
+		Name:     "prover.compose.risc0Verifier",
+		Usage:    "Address of the RISC0 sub-verifier of the compose verifier",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_COMPOSE_RISC0_VERIFIER"},
+	}
+	SP1VerifierAddress = &cli.StringFlag{
+		Name:     "prover.compose.sp1Verifier",
+		Usage:    "Address of the SP1 sub-verifier of the compose verifier",
+		Category: proverCategory,
+		EnvVars:  []string{"PROVER_COMPOSE_SP1_VERIFIER"},
+	}
 )
 
 // ProverFlags All prover flags.
@@ -193,15 +358,24 @@ var ProverFlags = MergeFlags(CommonFlags, []cli.Flag{
 	RaikoHostEndpoint,
 	RaikoJWTPath,
 	L1ProverPrivKey,
+	L1ProverKeystore,
+	L1ProverKeystorePassword,
+	L1ProverRemoteSigner,
+	L1ProverRemoteSignerAddress,
 	StartingBlockID,
 	Dummy,
 	GuardianProverMinority,
 	GuardianProverMajority,
 	GuardianProofSubmissionDelay,
+	GuardianApprovalPort,
+	GuardianApprovalJWTSecret,
+	GuardianApprovalTimeout,
+	GuardianApprovalAutoApprove,
 	GuardianProverHealthCheckServerEndpoint,
 	Graffiti,
 	ProveUnassignedBlocks,
 	ContesterMode,
+	ContesterSubmitConflictingProof,
 	ProverHTTPServerPort,
 	MaxExpiry,
 	TaikoTokenAddress,
@@ -209,9 +383,25 @@ var ProverFlags = MergeFlags(CommonFlags, []cli.Flag{
 	L1NodeVersion,
 	L2NodeVersion,
 	BlockConfirmations,
+	L1Finality,
 	RaikoRequestTimeout,
 	RaikoZKVMHostEndpoint,
 	SGXBatchSize,
 	ZKVMBatchSize,
 	ForceBatchProvingInterval,
+	ProofProducerEndpoint,
+	ProofProducerProofType,
+	ProofProducerToken,
+	SchedulerMaxConcurrency,
+	SchedulerProducerConcurrency,
+	ProvingPolicyFile,
+	ProvingPolicyReloadInterval,
+	AccountingDataDir,
+	AccountingJWTSecret,
+	ComposeProofTypes,
+	ComposeRequiredProofTypes,
+	ComposeQuorum,
+	SGXVerifierAddress,
+	RISC0VerifierAddress,
+	SP1VerifierAddress,
 }, TxmgrFlags)
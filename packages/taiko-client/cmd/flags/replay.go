@@ -0,0 +1,33 @@
+package flags
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+// Required flags used by replay.
+var (
+	ReplayL1Start = &cli.Uint64Flag{
+		Name:     "replay.l1Start",
+		Usage:    "First L1 block of the range whose proposals are replayed",
+		Required: true,
+		Category: replayCategory,
+		EnvVars:  []string{"REPLAY_L1_START"},
+	}
+	ReplayL1End = &cli.Uint64Flag{
+		Name:     "replay.l1End",
+		Usage:    "Last L1 block of the range whose proposals are replayed",
+		Required: true,
+		Category: replayCategory,
+		EnvVars:  []string{"REPLAY_L1_END"},
+	}
+)
+
+// ReplayFlags All replay flags.
+var ReplayFlags = MergeFlags(CommonFlags, []cli.Flag{
+	L1BeaconEndpoint,
+	L2WSEndpoint,
+	BlobServerEndpoint,
+	SocialScanEndpoint,
+	ReplayL1Start,
+	ReplayL1End,
+})
@@ -9,9 +9,11 @@ import (
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/cmd/flags"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/cmd/utils"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/driver"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/replay"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/version"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/proposer"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_producer/protocol"
 )
 
 func main() {
@@ -48,6 +50,20 @@ func main() {
 			Description: "Taiko prover software",
 			Action:      utils.SubcommandAction(new(prover.Prover)),
 		},
+		{
+			Name:        "replay",
+			Flags:       flags.ReplayFlags,
+			Usage:       "Replays the driver's derivation of an L1 block range against the L2 chain",
+			Description: "Taiko L2 derivation replay and verification tool",
+			Action:      utils.OneshotAction(new(replay.Replayer)),
+		},
+		{
+			Name:        "mock-prover",
+			Flags:       flags.MockProverFlags,
+			Usage:       "Starts a mock proving backend implementing the proof producer protocol",
+			Description: "Taiko mock proving backend, for testing the prover without Raiko",
+			Action:      utils.SubcommandAction(new(protocol.MockProver)),
+		},
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -0,0 +1,309 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/cmd/flags"
+)
+
+// ReloadableApplication is a client software which can apply a subset of its flags at runtime, when
+// they are changed in the config file.
+type ReloadableApplication interface {
+	SubcommandApplication
+	// ReloadableFlags returns the flags which are safe to change at runtime.
+	ReloadableFlags() []cli.Flag
+	// Reload validates the given reloadable flags and applies them, nothing is applied if they are invalid.
+	Reload(c *cli.Context) error
+}
+
+// configReloader loads the config file of a client software at startup, and reloads it at runtime. The keys
+// of a config file are the flag names, a flag set on the command line or by an environment variable takes
+// precedence over the config file.
+type configReloader struct {
+	c          *cli.Context
+	app        SubcommandApplication
+	path       string
+	modTime    time.Time
+	overridden map[string]bool
+	applied    map[string][]string
+}
+
+// loadConfigFile applies the config file set by the `config.file` flag to the given context, and returns
+// the reloader of it, or nil if no config file is set.
+//
+// NOTE: the required flags are checked before the config file is loaded, so they must still be set on the
+// command line or by environment variables.
+func loadConfigFile(c *cli.Context, app SubcommandApplication) (*configReloader, error) {
+	if !c.IsSet(flags.ConfigFile.Name) {
+		return nil, nil
+	}
+
+	r := &configReloader{
+		c:          c,
+		app:        app,
+		path:       c.String(flags.ConfigFile.Name),
+		overridden: make(map[string]bool),
+	}
+
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	values, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range c.Command.Flags {
+		if name := f.Names()[0]; c.IsSet(name) {
+			r.overridden[name] = true
+		}
+	}
+
+	for name, value := range values {
+		if r.overridden[name] {
+			continue
+		}
+		for _, v := range value {
+			if err := c.Set(name, v); err != nil {
+				return nil, fmt.Errorf("invalid value of %s in config file: %w", name, err)
+			}
+		}
+	}
+
+	r.modTime = info.ModTime()
+	r.applied = values
+
+	return r, nil
+}
+
+// read reads the config file, and returns the values of the flags in it, keyed by the flag names.
+func (r *configReloader) read() (map[string][]string, error) {
+	content, err := os.ReadFile(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	raw := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(r.path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &raw)
+	case ".toml":
+		err = toml.Unmarshal(content, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported config file format: %s", r.path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", r.path, err)
+	}
+
+	values := make(map[string][]string, len(raw))
+	for key, v := range raw {
+		f := r.lookupFlag(key)
+		if f == nil {
+			return nil, fmt.Errorf("unknown flag in config file: %s", key)
+		}
+		value, err := configValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %s in config file: %w", key, err)
+		}
+		values[f.Names()[0]] = value
+	}
+
+	return values, nil
+}
+
+// lookupFlag returns the flag of the command with the given name or alias.
+func (r *configReloader) lookupFlag(name string) cli.Flag {
+	for _, f := range r.c.Command.Flags {
+		if slices.Contains(f.Names(), name) {
+			return f
+		}
+	}
+	return nil
+}
+
+// configValue converts the given config file value to the flag values.
+func configValue(v interface{}) ([]string, error) {
+	switch v := v.(type) {
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			value, err := configValue(item)
+			if err != nil {
+				return nil, err
+			}
+			if len(value) != 1 {
+				return nil, errors.New("nested list")
+			}
+			values = append(values, value[0])
+		}
+		return values, nil
+	case map[string]interface{}:
+		return nil, errors.New("nested table")
+	case float64:
+		// Avoid the exponent format, which can not be parsed as an integer.
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}, nil
+	case nil:
+		return []string{""}, nil
+	default:
+		return []string{fmt.Sprint(v)}, nil
+	}
+}
+
+// Reload reloads the config file if it has been modified since the last load, or if forced. The changes are
+// applied only if all of them are safe to apply at runtime, otherwise the whole reload is rejected.
+func (r *configReloader) Reload(force bool) error {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if !force && info.ModTime().Equal(r.modTime) {
+		return nil
+	}
+	// Do not load the same file again, even if it is rejected.
+	r.modTime = info.ModTime()
+
+	values, err := r.read()
+	if err != nil {
+		return err
+	}
+
+	reloadable := make(map[string]cli.Flag)
+	if app, ok := r.app.(ReloadableApplication); ok {
+		for _, f := range app.ReloadableFlags() {
+			reloadable[f.Names()[0]] = f
+		}
+	}
+
+	var changed, unsafe []string
+	for _, name := range configKeys(r.applied, values) {
+		if slices.Equal(r.applied[name], values[name]) {
+			continue
+		}
+		if r.overridden[name] {
+			log.Warn("Ignored config file change of a flag overridden on the command line", "flag", name)
+			continue
+		}
+		if _, ok := reloadable[name]; !ok {
+			unsafe = append(unsafe, name)
+			continue
+		}
+		changed = append(changed, name)
+	}
+	if len(unsafe) != 0 {
+		return fmt.Errorf("flags can not be changed at runtime, restart is required: %s", strings.Join(unsafe, ", "))
+	}
+	if len(changed) == 0 {
+		r.applied = values
+		return nil
+	}
+
+	// Build a context with all reloadable flags, the removed ones fall back to their default values.
+	set := flag.NewFlagSet(r.c.Command.Name, flag.ContinueOnError)
+	for _, f := range reloadable {
+		if err := f.Apply(set); err != nil {
+			return err
+		}
+	}
+	for name, f := range reloadable {
+		value := values[name]
+		if r.overridden[name] {
+			if _, ok := f.(*cli.StringSliceFlag); ok {
+				value = r.c.StringSlice(name)
+			} else {
+				value = []string{fmt.Sprint(r.c.Value(name))}
+			}
+		}
+		for _, v := range value {
+			if err := set.Set(name, v); err != nil {
+				return fmt.Errorf("invalid value of %s in config file: %w", name, err)
+			}
+		}
+	}
+
+	if err := r.app.(ReloadableApplication).Reload(cli.NewContext(r.c.App, set, nil)); err != nil {
+		return fmt.Errorf("failed to apply config file: %w", err)
+	}
+
+	for _, name := range changed {
+		log.Info(
+			"Config file change applied",
+			"flag", name,
+			"old", configValueString(r.applied, name),
+			"new", configValueString(values, name),
+		)
+	}
+	r.applied = values
+
+	return nil
+}
+
+// Watch reloads the config file periodically and on SIGHUP, until the given context is canceled.
+func (r *configReloader) Watch(ctx context.Context) {
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	defer signal.Stop(hupCh)
+
+	var tickCh <-chan time.Time
+	if interval := r.c.Duration(flags.ConfigReloadInterval.Name); interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tickCh = ticker.C
+	}
+
+	for {
+		var force bool
+		select {
+		case <-ctx.Done():
+			return
+		case <-hupCh:
+			force = true
+		case <-tickCh:
+		}
+
+		if err := r.Reload(force); err != nil {
+			log.Error("Rejected config file reload, keep using the current config", "path", r.path, "error", err)
+		}
+	}
+}
+
+// configKeys returns the sorted keys of the given config file values.
+func configKeys(groups ...map[string][]string) []string {
+	var keys []string
+	for _, values := range groups {
+		for key := range values {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// configValueString returns the printable config file value of the given flag.
+func configValueString(values map[string][]string, name string) string {
+	value, ok := values[name]
+	if !ok {
+		return "<default>"
+	}
+	return strings.Join(value, ",")
+}
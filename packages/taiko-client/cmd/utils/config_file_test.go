@@ -0,0 +1,287 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/cmd/flags"
+)
+
+var (
+	testGraffiti = &cli.StringFlag{Name: "test.graffiti", EnvVars: []string{"TEST_GRAFFITI"}}
+	testMinTip   = &cli.Float64Flag{Name: "test.minTip", EnvVars: []string{"TEST_MIN_TIP"}}
+	testInterval = &cli.DurationFlag{Name: "test.interval", Value: time.Second}
+	testL1       = &cli.StringFlag{Name: "test.l1"}
+	testBatch    = &cli.Uint64Flag{Name: "test.batchSize", Aliases: []string{"test.batch"}}
+	testPeers    = &cli.StringSliceFlag{Name: "test.peers"}
+)
+
+// reloadableApp records the reloaded flags, and rejects an empty graffiti.
+type reloadableApp struct {
+	graffiti string
+	minTip   float64
+	interval time.Duration
+	reloads  int
+}
+
+func (a *reloadableApp) InitFromCli(context.Context, *cli.Context) error { return nil }
+func (a *reloadableApp) Name() string                                    { return "test" }
+func (a *reloadableApp) Start() error                                    { return nil }
+func (a *reloadableApp) Close(context.Context)                           {}
+
+func (a *reloadableApp) ReloadableFlags() []cli.Flag {
+	return []cli.Flag{testGraffiti, testMinTip, testInterval}
+}
+
+func (a *reloadableApp) Reload(c *cli.Context) error {
+	if c.String(testGraffiti.Name) == "" {
+		return errors.New("empty graffiti")
+	}
+	a.graffiti = c.String(testGraffiti.Name)
+	a.minTip = c.Float64(testMinTip.Name)
+	a.interval = c.Duration(testInterval.Name)
+	a.reloads++
+	return nil
+}
+
+// runWithConfigFile runs a command with the given arguments, loads the config file of it and calls the
+// given function with the command context and the config file reloader.
+func runWithConfigFile(
+	app SubcommandApplication,
+	fn func(*cli.Context, *configReloader),
+	args ...string,
+) error {
+	cliApp := cli.NewApp()
+	cliApp.Commands = []*cli.Command{{
+		Name: "test",
+		Flags: []cli.Flag{
+			flags.ConfigFile,
+			flags.ConfigReloadInterval,
+			testGraffiti,
+			testMinTip,
+			testInterval,
+			testL1,
+			testBatch,
+			testPeers,
+		},
+		Action: func(c *cli.Context) error {
+			r, err := loadConfigFile(c, app)
+			if err != nil {
+				return err
+			}
+			fn(c, r)
+			return nil
+		},
+	}}
+
+	return cliApp.Run(append([]string{"TestConfigFile", "test"}, args...))
+}
+
+func writeConfigFile(t *testing.T, path string, content string) {
+	require.Nil(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestConfigFileNotSet(t *testing.T) {
+	require.Nil(t, runWithConfigFile(new(reloadableApp), func(_ *cli.Context, r *configReloader) {
+		require.Nil(t, r)
+	}))
+}
+
+func TestConfigFilePrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, `
+test.graffiti: file
+test.minTip: 1.5
+test.l1: ws://file
+test.batch: 8
+`)
+	t.Setenv("TEST_MIN_TIP", "2.5")
+
+	app := new(reloadableApp)
+	require.Nil(t, runWithConfigFile(app, func(c *cli.Context, r *configReloader) {
+		require.NotNil(t, r)
+		require.Equal(t, "cli", c.String(testGraffiti.Name))
+		require.Equal(t, 2.5, c.Float64(testMinTip.Name))
+		require.Equal(t, "ws://file", c.String(testL1.Name))
+		require.Equal(t, uint64(8), c.Uint64(testBatch.Name))
+		require.Equal(t, time.Second, c.Duration(testInterval.Name))
+
+		// A change of a flag set on the command line or by an environment variable is ignored.
+		writeConfigFile(t, path, `
+test.graffiti: changed
+test.minTip: 3.5
+test.l1: ws://file
+test.batch: 8
+test.interval: 1m
+`)
+		require.Nil(t, r.Reload(true))
+		require.Equal(t, 1, app.reloads)
+		require.Equal(t, "cli", app.graffiti)
+		require.Equal(t, 2.5, app.minTip)
+		require.Equal(t, time.Minute, app.interval)
+	}, "--"+flags.ConfigFile.Name, path, "--"+testGraffiti.Name, "cli"))
+}
+
+func TestConfigFileRejectUnsafeChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, `
+test.graffiti: before
+test.l1: ws://before
+`)
+
+	app := new(reloadableApp)
+	require.Nil(t, runWithConfigFile(app, func(c *cli.Context, r *configReloader) {
+		// A flag which is not reloadable can't be changed, and nothing else is applied either.
+		writeConfigFile(t, path, `
+test.graffiti: after
+test.l1: ws://after
+`)
+		require.ErrorContains(t, r.Reload(true), "restart is required: test.l1")
+		require.Zero(t, app.reloads)
+		require.Equal(t, []string{"ws://before"}, r.applied[testL1.Name])
+
+		// Nor can a new one be added.
+		writeConfigFile(t, path, `
+test.graffiti: after
+test.l1: ws://before
+test.batchSize: 4
+`)
+		require.ErrorContains(t, r.Reload(true), "restart is required: test.batchSize")
+		require.Zero(t, app.reloads)
+
+		// A reloadable change rejected by the application isn't applied.
+		writeConfigFile(t, path, `
+test.graffiti: ""
+test.l1: ws://before
+`)
+		require.ErrorContains(t, r.Reload(true), "empty graffiti")
+		require.Zero(t, app.reloads)
+		require.Equal(t, []string{"before"}, r.applied[testGraffiti.Name])
+
+		// An unknown flag is rejected.
+		writeConfigFile(t, path, `
+test.graffiti: after
+test.unknown: 1
+`)
+		require.ErrorContains(t, r.Reload(true), "unknown flag in config file: test.unknown")
+
+		// The file is not reloaded if it is not modified, unless forced.
+		writeConfigFile(t, path, `
+test.graffiti: after
+test.l1: ws://before
+`)
+		require.Nil(t, r.Reload(true))
+		require.Equal(t, 1, app.reloads)
+		require.Equal(t, "after", app.graffiti)
+		require.Nil(t, r.Reload(false))
+		require.Equal(t, 1, app.reloads)
+	}, "--"+flags.ConfigFile.Name, path))
+}
+
+func TestConfigFileNotReloadable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfigFile(t, path, "test.graffiti: before\n")
+
+	require.Nil(t, runWithConfigFile(nil, func(c *cli.Context, r *configReloader) {
+		require.Equal(t, "before", c.String(testGraffiti.Name))
+
+		writeConfigFile(t, path, "test.graffiti: after\n")
+		require.ErrorContains(t, r.Reload(true), "restart is required: test.graffiti")
+	}, "--"+flags.ConfigFile.Name, path))
+}
+
+func TestConfigFileRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		before  string
+		after   string
+		invalid string
+	}{
+		{
+			"config.yaml",
+			`
+test.graffiti: before
+test.minTip: 1.5
+test.interval: 30s
+test.l1: ws://l1
+test.batchSize: 1000000
+test.peers:
+  - peer1
+  - peer2
+`,
+			`
+test.graffiti: after
+test.minTip: 0.25
+test.interval: 2m
+test.l1: ws://l1
+test.batchSize: 1000000
+test.peers: [peer1, peer2]
+`,
+			"test.graffiti: [\n",
+		},
+		{
+			"config.toml",
+			`
+"test.graffiti" = "before"
+"test.minTip" = 1.5
+"test.interval" = "30s"
+"test.l1" = "ws://l1"
+"test.batchSize" = 1000000
+"test.peers" = ["peer1", "peer2"]
+`,
+			`
+"test.graffiti" = "after"
+"test.minTip" = 0.25
+"test.interval" = "2m"
+"test.l1" = "ws://l1"
+"test.batchSize" = 1000000
+"test.peers" = ["peer1", "peer2"]
+`,
+			"\"test.graffiti\" = {\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tc.name)
+			writeConfigFile(t, path, tc.before)
+
+			app := new(reloadableApp)
+			require.Nil(t, runWithConfigFile(app, func(c *cli.Context, r *configReloader) {
+				require.Equal(t, "before", c.String(testGraffiti.Name))
+				require.Equal(t, 1.5, c.Float64(testMinTip.Name))
+				require.Equal(t, 30*time.Second, c.Duration(testInterval.Name))
+				require.Equal(t, "ws://l1", c.String(testL1.Name))
+				require.Equal(t, uint64(1000000), c.Uint64(testBatch.Name))
+				require.Equal(t, []string{"peer1", "peer2"}, c.StringSlice(testPeers.Name))
+
+				writeConfigFile(t, path, tc.after)
+				require.Nil(t, r.Reload(true))
+				require.Equal(t, 1, app.reloads)
+				require.Equal(t, "after", app.graffiti)
+				require.Equal(t, 0.25, app.minTip)
+				require.Equal(t, 2*time.Minute, app.interval)
+
+				writeConfigFile(t, path, tc.invalid)
+				require.ErrorContains(t, r.Reload(true), "failed to parse config file")
+				require.Equal(t, 1, app.reloads)
+			}, "--"+flags.ConfigFile.Name, path))
+		})
+	}
+}
+
+func TestConfigFileUnsupportedFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeConfigFile(t, path, "{}")
+
+	require.ErrorContains(
+		t,
+		runWithConfigFile(nil, func(*cli.Context, *configReloader) {}, "--"+flags.ConfigFile.Name, path),
+		"unsupported config file format",
+	)
+}
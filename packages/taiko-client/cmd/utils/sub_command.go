@@ -22,6 +22,11 @@ type SubcommandApplication interface {
 
 func SubcommandAction(app SubcommandApplication) cli.ActionFunc {
 	return func(c *cli.Context) error {
+		configReloader, err := loadConfigFile(c, app)
+		if err != nil {
+			return err
+		}
+
 		logger.InitLogger(c)
 
 		ctx, ctxClose := context.WithCancel(context.Background())
@@ -43,6 +48,11 @@ func SubcommandAction(app SubcommandApplication) cli.ActionFunc {
 			return err
 		}
 
+		if configReloader != nil {
+			log.Info("Watching config file", "path", configReloader.path)
+			go configReloader.Watch(ctx)
+		}
+
 		defer func() {
 			ctxClose()
 			app.Close(ctx)
@@ -61,3 +71,33 @@ func SubcommandAction(app SubcommandApplication) cli.ActionFunc {
 		return nil
 	}
 }
+
+// OneshotApplication is a client software which runs once and then exits, instead of running
+// until it is interrupted.
+type OneshotApplication interface {
+	InitFromCli(context.Context, *cli.Context) error
+	Name() string
+	Run(context.Context) error
+}
+
+// OneshotAction returns the action of the subcommand running the given oneshot application.
+func OneshotAction(app OneshotApplication) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if _, err := loadConfigFile(c, nil); err != nil {
+			return err
+		}
+
+		logger.InitLogger(c)
+
+		ctx, ctxClose := context.WithCancel(context.Background())
+		defer ctxClose()
+
+		if err := app.InitFromCli(ctx, c); err != nil {
+			return err
+		}
+
+		log.Info("Running Taiko client application", "name", app.Name())
+
+		return app.Run(ctx)
+	}
+}
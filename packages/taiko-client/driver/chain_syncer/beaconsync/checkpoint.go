@@ -0,0 +1,155 @@
+package beaconsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	pacayaBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
+)
+
+var (
+	// ErrCheckpointMismatch is returned when the majority of the checkpoint nodes serve a block
+	// different from the last verified block in TaikoInbox.
+	ErrCheckpointMismatch = errors.New("checkpoint block mismatches the last verified block in TaikoInbox")
+	// errNoCheckpointMajority is returned when no block is served by the majority of the
+	// checkpoint nodes, for example when some of them are unavailable or not synced yet.
+	errNoCheckpointMajority = errors.New("no checkpoint block agreed by the majority of the checkpoint nodes")
+)
+
+// VerifiedCheckpoint fetches the last verified block in TaikoInbox from the checkpoint nodes, and
+// returns its header once the majority of the checkpoint nodes agree on it, and its hash matches
+// the one stored in TaikoInbox, or in TaikoL1 before the Pacaya fork. A nil header is returned if
+// no block has been verified yet.
+func (s *Syncer) VerifiedCheckpoint(ctx context.Context) (*types.Header, error) {
+	blockID, blockHash, err := lastVerifiedBlock(ctx, s.rpc)
+	if err != nil {
+		return nil, err
+	}
+
+	if blockID == 0 {
+		return nil, nil
+	}
+
+	headers := fetchCheckpointHeaders(ctx, s.rpc.L2CheckPoints, new(big.Int).SetUint64(blockID))
+
+	header, err := majorityCheckpoint(headers, blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify checkpoint block %d: %w", blockID, err)
+	}
+
+	return header, nil
+}
+
+// lastVerifiedBlockReader reads the last verified block from the protocol contracts.
+type lastVerifiedBlockReader interface {
+	GetLastVerifiedTransitionPacaya(ctx context.Context) (*struct {
+		BatchId uint64                                    //nolint:stylecheck
+		BlockId uint64                                    //nolint:stylecheck
+		Ts      pacayaBindings.ITaikoInboxTransitionState //nolint:stylecheck
+	}, error)
+	GetLastVerifiedBlockOntake(ctx context.Context) (*struct {
+		BlockId    uint64 //nolint:stylecheck
+		BlockHash  [32]byte
+		StateRoot  [32]byte
+		VerifiedAt uint64
+	}, error)
+}
+
+// lastVerifiedBlock returns the ID and hash of the last verified block in TaikoInbox, falling back
+// to TaikoL1 when the Pacaya fork is not activated yet.
+func lastVerifiedBlock(ctx context.Context, reader lastVerifiedBlockReader) (uint64, common.Hash, error) {
+	transition, err := reader.GetLastVerifiedTransitionPacaya(ctx)
+	if err != nil {
+		blockInfo, ontakeErr := reader.GetLastVerifiedBlockOntake(ctx)
+		if ontakeErr != nil {
+			return 0, common.Hash{}, fmt.Errorf(
+				"failed to get last verified block: %w",
+				errors.Join(err, ontakeErr),
+			)
+		}
+
+		return blockInfo.BlockId, blockInfo.BlockHash, nil
+	}
+
+	return transition.BlockId, transition.Ts.BlockHash, nil
+}
+
+// fetchCheckpointHeaders fetches the header of the given block from all checkpoint nodes, a nil
+// header is returned for the nodes which failed to serve it.
+func fetchCheckpointHeaders(ctx context.Context, nodes []*rpc.EthClient, blockID *big.Int) []*types.Header {
+	var (
+		headers = make([]*types.Header, len(nodes))
+		wg      sync.WaitGroup
+	)
+
+	for i, node := range nodes {
+		wg.Add(1)
+
+		go func(i int, node *rpc.EthClient) {
+			defer wg.Done()
+
+			header, err := node.HeaderByNumber(ctx, blockID)
+			if err != nil {
+				log.Warn("Failed to fetch checkpoint block header", "node", i, "blockID", blockID, "error", err)
+				return
+			}
+
+			headers[i] = header
+		}(i, node)
+	}
+
+	wg.Wait()
+
+	return headers
+}
+
+// majorityCheckpoint returns the header served by the majority of the checkpoint nodes, if its hash
+// is the expected one. Nil headers are nodes which failed to serve the block, they count against
+// the majority.
+func majorityCheckpoint(headers []*types.Header, expected common.Hash) (*types.Header, error) {
+	var (
+		votes  = make(map[common.Hash]int)
+		byHash = make(map[common.Hash]*types.Header)
+	)
+
+	for i, header := range headers {
+		if header == nil {
+			continue
+		}
+
+		hash := header.Hash()
+		if hash != expected {
+			log.Warn("Checkpoint node serves an unverified block", "node", i, "hash", hash, "expected", expected)
+		}
+
+		votes[hash]++
+		byHash[hash] = header
+	}
+
+	for hash, count := range votes {
+		if count*2 <= len(headers) {
+			continue
+		}
+
+		if hash != expected {
+			return nil, fmt.Errorf("%w: checkpoint nodes serve %s, expected %s", ErrCheckpointMismatch, hash, expected)
+		}
+
+		return byHash[hash], nil
+	}
+
+	return nil, fmt.Errorf(
+		"%w: %d/%d nodes serve the verified block",
+		errNoCheckpointMajority,
+		votes[expected],
+		len(headers),
+	)
+}
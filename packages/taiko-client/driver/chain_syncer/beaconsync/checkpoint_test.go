@@ -0,0 +1,117 @@
+package beaconsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	pacayaBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
+)
+
+var errNotActivated = errors.New("execution reverted")
+
+// fakeLastVerifiedBlockReader serves the last verified block of a Pacaya or Ontake protocol, a nil
+// block is a contract call which fails.
+type fakeLastVerifiedBlockReader struct {
+	pacaya *pacayaBindings.ITaikoInboxTransitionState
+	ontake *types.Header
+}
+
+func (r *fakeLastVerifiedBlockReader) GetLastVerifiedTransitionPacaya(context.Context) (*struct {
+	BatchId uint64                                    //nolint:stylecheck
+	BlockId uint64                                    //nolint:stylecheck
+	Ts      pacayaBindings.ITaikoInboxTransitionState //nolint:stylecheck
+}, error) {
+	if r.pacaya == nil {
+		return nil, errNotActivated
+	}
+
+	return &struct {
+		BatchId uint64                                    //nolint:stylecheck
+		BlockId uint64                                    //nolint:stylecheck
+		Ts      pacayaBindings.ITaikoInboxTransitionState //nolint:stylecheck
+	}{BatchId: 1, BlockId: 20, Ts: *r.pacaya}, nil
+}
+
+func (r *fakeLastVerifiedBlockReader) GetLastVerifiedBlockOntake(context.Context) (*struct {
+	BlockId    uint64 //nolint:stylecheck
+	BlockHash  [32]byte
+	StateRoot  [32]byte
+	VerifiedAt uint64
+}, error) {
+	if r.ontake == nil {
+		return nil, errNotActivated
+	}
+
+	return &struct {
+		BlockId    uint64 //nolint:stylecheck
+		BlockHash  [32]byte
+		StateRoot  [32]byte
+		VerifiedAt uint64
+	}{BlockId: r.ontake.Number.Uint64(), BlockHash: r.ontake.Hash()}, nil
+}
+
+func TestLastVerifiedBlock(t *testing.T) {
+	var (
+		pacaya = &pacayaBindings.ITaikoInboxTransitionState{BlockHash: common.HexToHash("0x01")}
+		ontake = &types.Header{Number: common.Big2}
+	)
+
+	// After the Pacaya fork, the last verified transition in TaikoInbox is used.
+	blockID, blockHash, err := lastVerifiedBlock(
+		context.Background(),
+		&fakeLastVerifiedBlockReader{pacaya: pacaya, ontake: ontake},
+	)
+	require.Nil(t, err)
+	require.Equal(t, uint64(20), blockID)
+	require.Equal(t, common.HexToHash("0x01"), blockHash)
+
+	// Before the Pacaya fork, it falls back to the last verified block in TaikoL1.
+	blockID, blockHash, err = lastVerifiedBlock(context.Background(), &fakeLastVerifiedBlockReader{ontake: ontake})
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), blockID)
+	require.Equal(t, ontake.Hash(), blockHash)
+
+	// Both contracts fail.
+	_, _, err = lastVerifiedBlock(context.Background(), &fakeLastVerifiedBlockReader{})
+	require.ErrorIs(t, err, errNotActivated)
+}
+
+func TestMajorityCheckpoint(t *testing.T) {
+	var (
+		verified = &types.Header{Number: common.Big1, Extra: []byte("verified")}
+		forged   = &types.Header{Number: common.Big1, Extra: []byte("forged")}
+	)
+
+	// All nodes agree on the verified block.
+	header, err := majorityCheckpoint([]*types.Header{verified, verified, verified}, verified.Hash())
+	require.Nil(t, err)
+	require.Equal(t, verified.Hash(), header.Hash())
+
+	// A single node serves another block.
+	header, err = majorityCheckpoint([]*types.Header{verified, forged, verified}, verified.Hash())
+	require.Nil(t, err)
+	require.Equal(t, verified.Hash(), header.Hash())
+
+	// The majority of the nodes serve another block.
+	_, err = majorityCheckpoint([]*types.Header{forged, forged, verified}, verified.Hash())
+	require.ErrorIs(t, err, ErrCheckpointMismatch)
+
+	_, err = majorityCheckpoint([]*types.Header{forged}, verified.Hash())
+	require.ErrorIs(t, err, ErrCheckpointMismatch)
+
+	// The majority of the nodes are unavailable.
+	_, err = majorityCheckpoint([]*types.Header{verified, nil, nil}, verified.Hash())
+	require.ErrorIs(t, err, errNoCheckpointMajority)
+
+	// No node agrees with another.
+	_, err = majorityCheckpoint([]*types.Header{verified, forged}, verified.Hash())
+	require.ErrorIs(t, err, errNoCheckpointMajority)
+
+	_, err = majorityCheckpoint(nil, verified.Hash())
+	require.ErrorIs(t, err, errNoCheckpointMajority)
+}
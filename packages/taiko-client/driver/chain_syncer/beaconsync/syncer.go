@@ -3,9 +3,9 @@ package beaconsync
 import (
 	"context"
 	"fmt"
-	"math/big"
 
 	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/encoding"
@@ -32,11 +32,14 @@ func NewSyncer(
 	return &Syncer{ctx, rpc, state, progressTracker}
 }
 
-// TriggerBeaconSync triggers the L2 execution engine to start performing a beacon sync, if the
-// latest verified block has changed.
-func (s *Syncer) TriggerBeaconSync(blockID uint64) error {
+// TriggerBeaconSync triggers the L2 execution engine to start performing a beacon sync to the given
+// checkpoint block, if the latest verified block has changed. The header must have been verified
+// with VerifiedCheckpoint.
+func (s *Syncer) TriggerBeaconSync(header *types.Header) error {
+	blockID := header.Number.Uint64()
+
 	// If we don't need to trigger another beacon sync, just return.
-	needResync, err := s.progressTracker.NeedReSync(new(big.Int).SetUint64(blockID))
+	needResync, err := s.progressTracker.NeedReSync(header.Number)
 	if err != nil {
 		return err
 	}
@@ -52,10 +55,7 @@ func (s *Syncer) TriggerBeaconSync(blockID uint64) error {
 		)
 	}
 
-	headPayload, err := s.getBlockPayload(s.ctx, blockID)
-	if err != nil {
-		return err
-	}
+	headPayload := encoding.ToExecutableData(header)
 
 	status, err := s.rpc.L2Engine.NewPayload(s.ctx, headPayload)
 	if err != nil {
@@ -77,7 +77,7 @@ func (s *Syncer) TriggerBeaconSync(blockID uint64) error {
 	}
 
 	// Update sync status.
-	s.progressTracker.UpdateMeta(new(big.Int).SetUint64(blockID), headPayload.BlockHash)
+	s.progressTracker.UpdateMeta(header.Number, headPayload.BlockHash)
 
 	log.Info(
 		"⛓️ Beacon sync triggered",
@@ -87,16 +87,3 @@ func (s *Syncer) TriggerBeaconSync(blockID uint64) error {
 
 	return nil
 }
-
-// getBlockPayload fetches the block's header, and converts it to an Engine API executable data,
-// which will be used to let the node start beacon syncing.
-func (s *Syncer) getBlockPayload(ctx context.Context, blockID uint64) (*engine.ExecutableData, error) {
-	header, err := s.rpc.L2CheckPoint.HeaderByNumber(s.ctx, new(big.Int).SetUint64(blockID))
-	if err != nil {
-		return nil, err
-	}
-
-	log.Info("Block header to sync retrieved", "hash", header.Hash())
-
-	return encoding.ToExecutableData(header), nil
-}
@@ -101,10 +101,15 @@ func createPayloadAndSetHead(
 		}
 	}
 
+	safeBlockHash, finalizedBlockHash := forkchoiceSafeAndFinalized(
+		meta.L1Finality,
+		payload.BlockHash,
+		lastVerifiedBlockHash,
+	)
 	fc := &engine.ForkchoiceStateV1{
 		HeadBlockHash:      payload.BlockHash,
-		SafeBlockHash:      lastVerifiedBlockHash,
-		FinalizedBlockHash: lastVerifiedBlockHash,
+		SafeBlockHash:      safeBlockHash,
+		FinalizedBlockHash: finalizedBlockHash,
 	}
 
 	// Update the fork choice
@@ -119,6 +124,24 @@ func createPayloadAndSetHead(
 	return payload, nil
 }
 
+// forkchoiceSafeAndFinalized returns the safe and finalized L2 block hashes used to set a newly inserted
+// head block, if the head block is derived from the L1 `safe` / `finalized` block, it is safe / finalized
+// itself, otherwise the last verified block is used for both.
+func forkchoiceSafeAndFinalized(
+	l1Finality *rpc.L1FinalityMode,
+	headBlockHash common.Hash,
+	lastVerifiedBlockHash common.Hash,
+) (common.Hash, common.Hash) {
+	switch {
+	case l1Finality.IsFinalized():
+		return headBlockHash, headBlockHash
+	case l1Finality.IsSafe():
+		return headBlockHash, lastVerifiedBlockHash
+	default:
+		return lastVerifiedBlockHash, lastVerifiedBlockHash
+	}
+}
+
 // createExecutionPayloads creates a new execution payloads through
 // Engine APIs.
 func createExecutionPayloads(
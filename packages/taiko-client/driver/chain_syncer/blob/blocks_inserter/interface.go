@@ -11,18 +11,38 @@ import (
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
 	pacayaBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
 	eventIterator "github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/chain_iterator/event_iterator"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
 )
 
 // Inserter is an interface that defines the method to insert blocks to the L2 execution engine.
 type Inserter interface {
+	// FetchTxList fetches the transactions list of the given proposal from L1, and decompresses it,
+	// the returned list is never nil.
+	FetchTxList(
+		ctx context.Context,
+		metadata metadata.TaikoProposalMetaData,
+		proposingTx *types.Transaction,
+	) (types.Transactions, error)
+	// InsertBlocks inserts the blocks of the given proposal, if txs is nil, the transactions list
+	// is fetched from L1 through FetchTxList.
 	InsertBlocks(
 		ctx context.Context,
 		metadata metadata.TaikoProposalMetaData,
 		proposingTx *types.Transaction,
+		txs types.Transactions,
 		endIter eventIterator.EndBlockProposedEventIterFunc,
 	) error
 }
 
+// DerivedBlock is an L2 block derived from a proposal, before it is inserted to the L2 execution engine.
+type DerivedBlock struct {
+	ID        *big.Int
+	Timestamp uint64
+	BaseFee   *big.Int
+	AnchorTx  *types.Transaction
+	Txs       types.Transactions
+}
+
 // createExecutionPayloadsMetaData is a struct that contains all the necessary metadata
 // for creating a new execution payloads.
 type createExecutionPayloadsMetaData struct {
@@ -47,4 +67,5 @@ type createPayloadAndSetHeadMetaData struct {
 	AnchorBlockHash common.Hash
 	BaseFeeConfig   *pacayaBindings.LibSharedDataBaseFeeConfig
 	Parent          *types.Header
+	L1Finality      *rpc.L1FinalityMode // L1 finality mode the block is derived under, nil for preconfirmation blocks
 }
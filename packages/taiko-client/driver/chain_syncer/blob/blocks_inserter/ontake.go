@@ -30,6 +30,7 @@ type BlocksInserterOntake struct {
 	anchorConstructor  *anchorTxConstructor.AnchorTxConstructor // TaikoL2.anchor transactions constructor
 	calldataFetcher    txlistFetcher.TxListFetcher
 	blobFetcher        txlistFetcher.TxListFetcher
+	l1Finality         *rpc.L1FinalityMode // L1 finality mode the blocks are derived under
 }
 
 // NewBlocksInserterOntake creates a new BlocksInserterOntake instance.
@@ -41,6 +42,7 @@ func NewBlocksInserterOntake(
 	anchorConstructor *anchorTxConstructor.AnchorTxConstructor,
 	calldataFetcher txlistFetcher.TxListFetcher,
 	blobFetcher txlistFetcher.TxListFetcher,
+	l1Finality *rpc.L1FinalityMode,
 ) *BlocksInserterOntake {
 	return &BlocksInserterOntake{
 		rpc:                rpc,
@@ -50,14 +52,56 @@ func NewBlocksInserterOntake(
 		anchorConstructor:  anchorConstructor,
 		calldataFetcher:    calldataFetcher,
 		blobFetcher:        blobFetcher,
+		l1Finality:         l1Finality,
 	}
 }
 
+// FetchTxList fetches the transactions list of the given Ontake block, and decompresses it.
+func (i *BlocksInserterOntake) FetchTxList(
+	ctx context.Context,
+	metadata metadata.TaikoProposalMetaData,
+	proposingTx *types.Transaction,
+) (types.Transactions, error) {
+	if metadata.IsPacaya() {
+		return nil, fmt.Errorf("metadata is not for Ontake fork")
+	}
+
+	var (
+		meta        = metadata.Ontake()
+		txListBytes []byte
+		err         error
+	)
+
+	// Fetch transactions list.
+	if meta.GetBlobUsed() {
+		if txListBytes, err = i.blobFetcher.FetchOntake(ctx, proposingTx, meta); err != nil {
+			return nil, fmt.Errorf("failed to fetch tx list from blob: %w", err)
+		}
+	} else {
+		if txListBytes, err = i.calldataFetcher.FetchOntake(ctx, proposingTx, meta); err != nil {
+			return nil, fmt.Errorf("failed to fetch tx list from calldata: %w", err)
+		}
+	}
+
+	txs := i.txListDecompressor.TryDecompress(
+		i.rpc.L2.ChainID,
+		txListBytes,
+		meta.GetBlobUsed(),
+		false,
+	)
+	if txs == nil {
+		txs = types.Transactions{}
+	}
+
+	return txs, nil
+}
+
 // InsertBlocks inserts a new Ontake block to the L2 execution engine.
 func (i *BlocksInserterOntake) InsertBlocks(
 	ctx context.Context,
 	metadata metadata.TaikoProposalMetaData,
 	proposingTx *types.Transaction,
+	txs types.Transactions,
 	endIter eventIterator.EndBlockProposedEventIterFunc,
 ) error {
 	if metadata.IsPacaya() {
@@ -66,10 +110,9 @@ func (i *BlocksInserterOntake) InsertBlocks(
 	// Fetch the L2 parent block, if the node is just finished a P2P sync, we simply use the tracker's
 	// last synced verified block as the parent, otherwise, we fetch the parent block from L2 EE.
 	var (
-		meta        = metadata.Ontake()
-		parent      *types.Header
-		txListBytes []byte
-		err         error
+		meta   = metadata.Ontake()
+		parent *types.Header
+		err    error
 	)
 	if i.progressTracker.Triggered() {
 		// Already synced through beacon sync, just skip this event.
@@ -93,24 +136,14 @@ func (i *BlocksInserterOntake) InsertBlocks(
 		"beaconSyncTriggered", i.progressTracker.Triggered(),
 	)
 
-	// Fetch transactions list.
-	if meta.GetBlobUsed() {
-		if txListBytes, err = i.blobFetcher.FetchOntake(ctx, proposingTx, meta); err != nil {
-			return fmt.Errorf("failed to fetch tx list from blob: %w", err)
-		}
-	} else {
-		if txListBytes, err = i.calldataFetcher.FetchOntake(ctx, proposingTx, meta); err != nil {
-			return fmt.Errorf("failed to fetch tx list from calldata: %w", err)
+	// Fetch transactions list, if it has not been prefetched.
+	if txs == nil {
+		if txs, err = i.FetchTxList(ctx, metadata, proposingTx); err != nil {
+			return err
 		}
 	}
 
-	baseFee, err := i.rpc.CalculateBaseFee(
-		ctx,
-		parent,
-		false,
-		(*pacayaBindings.LibSharedDataBaseFeeConfig)(meta.GetBaseFeeConfig()),
-		meta.GetTimestamp(),
-	)
+	block, err := i.DeriveBlock(ctx, meta, parent, txs)
 	if err != nil {
 		return err
 	}
@@ -118,28 +151,10 @@ func (i *BlocksInserterOntake) InsertBlocks(
 	log.Info(
 		"L2 baseFee",
 		"blockID", meta.GetBlockID(),
-		"baseFee", utils.WeiToGWei(baseFee),
+		"baseFee", utils.WeiToGWei(block.BaseFee),
 		"parentGasUsed", parent.GasUsed,
 	)
 
-	// Assemble a TaikoL2.anchorV2 transaction
-	anchorBlockHeader, err := i.rpc.L1.HeaderByHash(ctx, meta.GetAnchorBlockHash())
-	if err != nil {
-		return fmt.Errorf("failed to fetch anchor block: %w", err)
-	}
-	anchorTx, err := i.anchorConstructor.AssembleAnchorV2Tx(
-		ctx,
-		new(big.Int).SetUint64(meta.GetAnchorBlockID()),
-		anchorBlockHeader.Root,
-		parent.GasUsed,
-		meta.GetBaseFeeConfig(),
-		new(big.Int).Add(parent.Number, common.Big1),
-		baseFee,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create TaikoL2.anchorV2 transaction: %w", err)
-	}
-
 	// Decompress the transactions list and try to insert a new head block to L2 EE.
 	payloadData, err := createPayloadAndSetHead(
 		ctx,
@@ -153,27 +168,23 @@ func (i *BlocksInserterOntake) InsertBlocks(
 				Difficulty:            meta.GetDifficulty(),
 				Timestamp:             meta.GetTimestamp(),
 				ParentHash:            parent.Hash(),
-				BaseFee:               baseFee,
+				BaseFee:               block.BaseFee,
 				L1Origin: &rawdb.L1Origin{
 					BlockID:       meta.GetBlockID(),
 					L2BlockHash:   common.Hash{}, // Will be set by taiko-geth.
 					L1BlockHeight: meta.GetRawBlockHeight(),
 					L1BlockHash:   meta.GetRawBlockHash(),
 				},
-				Txs: i.txListDecompressor.TryDecompress(
-					i.rpc.L2.ChainID,
-					txListBytes,
-					meta.GetBlobUsed(),
-					false,
-				),
+				Txs:         txs,
 				Withdrawals: make([]*types.Withdrawal, 0),
 			},
 			AnchorBlockID:   new(big.Int).SetUint64(meta.GetAnchorBlockID()),
 			AnchorBlockHash: meta.GetAnchorBlockHash(),
 			BaseFeeConfig:   (*pacayaBindings.LibSharedDataBaseFeeConfig)(meta.GetBaseFeeConfig()),
 			Parent:          parent,
+			L1Finality:      i.l1Finality,
 		},
-		anchorTx,
+		block.AnchorTx,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert new head to L2 execution engine: %w", err)
@@ -192,3 +203,49 @@ func (i *BlocksInserterOntake) InsertBlocks(
 
 	return nil
 }
+
+// DeriveBlock derives the block of the given Ontake proposal on top of the given parent, without
+// inserting it.
+func (i *BlocksInserterOntake) DeriveBlock(
+	ctx context.Context,
+	meta metadata.TaikoBlockMetaDataOntake,
+	parent *types.Header,
+	txs types.Transactions,
+) (*DerivedBlock, error) {
+	baseFee, err := i.rpc.CalculateBaseFee(
+		ctx,
+		parent,
+		false,
+		(*pacayaBindings.LibSharedDataBaseFeeConfig)(meta.GetBaseFeeConfig()),
+		meta.GetTimestamp(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Assemble a TaikoL2.anchorV2 transaction
+	anchorBlockHeader, err := i.rpc.L1.HeaderByHash(ctx, meta.GetAnchorBlockHash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch anchor block: %w", err)
+	}
+	anchorTx, err := i.anchorConstructor.AssembleAnchorV2Tx(
+		ctx,
+		new(big.Int).SetUint64(meta.GetAnchorBlockID()),
+		anchorBlockHeader.Root,
+		parent.GasUsed,
+		meta.GetBaseFeeConfig(),
+		new(big.Int).Add(parent.Number, common.Big1),
+		baseFee,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TaikoL2.anchorV2 transaction: %w", err)
+	}
+
+	return &DerivedBlock{
+		ID:        meta.GetBlockID(),
+		Timestamp: meta.GetTimestamp(),
+		BaseFee:   baseFee,
+		AnchorTx:  anchorTx,
+		Txs:       txs,
+	}, nil
+}
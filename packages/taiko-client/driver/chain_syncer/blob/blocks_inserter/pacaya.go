@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -17,6 +18,7 @@ import (
 	pacayaBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
 	anchorTxConstructor "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/anchor_tx_constructor"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/chain_syncer/beaconsync"
+	derivationExport "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/derivation_export"
 	preconfblocks "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/preconf_blocks"
 	txListDecompressor "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/txlist_decompressor"
 	txlistFetcher "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/txlist_fetcher"
@@ -34,6 +36,8 @@ type BlocksInserterPacaya struct {
 	anchorConstructor  *anchorTxConstructor.AnchorTxConstructor // TaikoL2.anchor transactions constructor
 	calldataFetcher    txlistFetcher.TxListFetcher
 	blobFetcher        txlistFetcher.TxListFetcher
+	l1Finality         *rpc.L1FinalityMode     // L1 finality mode the blocks are derived under
+	exportStore        *derivationExport.Store // Optional store of the batches derivation records
 	mutex              sync.Mutex
 }
 
@@ -46,6 +50,8 @@ func NewBlocksInserterPacaya(
 	anchorConstructor *anchorTxConstructor.AnchorTxConstructor,
 	calldataFetcher txlistFetcher.TxListFetcher,
 	blobFetcher txlistFetcher.TxListFetcher,
+	l1Finality *rpc.L1FinalityMode,
+	exportStore *derivationExport.Store,
 ) *BlocksInserterPacaya {
 	return &BlocksInserterPacaya{
 		rpc:                rpc,
@@ -55,51 +61,84 @@ func NewBlocksInserterPacaya(
 		anchorConstructor:  anchorConstructor,
 		calldataFetcher:    calldataFetcher,
 		blobFetcher:        blobFetcher,
+		l1Finality:         l1Finality,
+		exportStore:        exportStore,
 	}
 }
 
-// InsertBlocks inserts new Pacaya blocks to the L2 execution engine.
-func (i *BlocksInserterPacaya) InsertBlocks(
+// FetchTxList fetches the transactions list of the given Pacaya batch, and decompresses it.
+func (i *BlocksInserterPacaya) FetchTxList(
 	ctx context.Context,
 	metadata metadata.TaikoProposalMetaData,
 	proposingTx *types.Transaction,
-	endIter eventIterator.EndBlockProposedEventIterFunc,
-) (err error) {
+) (types.Transactions, error) {
 	if !metadata.IsPacaya() {
-		return fmt.Errorf("metadata is not for Pacaya fork")
+		return nil, fmt.Errorf("metadata is not for Pacaya fork")
 	}
-	i.mutex.Lock()
-	defer i.mutex.Unlock()
 
 	var (
 		meta        = metadata.Pacaya()
 		txListBytes []byte
+		err         error
 	)
 
 	// Fetch transactions list.
 	if len(meta.GetBlobHashes()) != 0 {
 		if txListBytes, err = i.blobFetcher.FetchPacaya(ctx, proposingTx, meta); err != nil {
-			return fmt.Errorf("failed to fetch tx list from blob: %w", err)
+			return nil, fmt.Errorf("failed to fetch tx list from blob: %w", err)
 		}
 	} else {
 		if txListBytes, err = i.calldataFetcher.FetchPacaya(ctx, proposingTx, meta); err != nil {
-			return fmt.Errorf("failed to fetch tx list from calldata: %w", err)
+			return nil, fmt.Errorf("failed to fetch tx list from calldata: %w", err)
+		}
+	}
+
+	txs := i.txListDecompressor.TryDecompress(
+		i.rpc.L2.ChainID,
+		txListBytes,
+		len(meta.GetBlobHashes()) != 0,
+		true,
+	)
+	if txs == nil {
+		txs = types.Transactions{}
+	}
+
+	return txs, nil
+}
+
+// InsertBlocks inserts new Pacaya blocks to the L2 execution engine.
+func (i *BlocksInserterPacaya) InsertBlocks(
+	ctx context.Context,
+	metadata metadata.TaikoProposalMetaData,
+	proposingTx *types.Transaction,
+	allTxs types.Transactions,
+	endIter eventIterator.EndBlockProposedEventIterFunc,
+) (err error) {
+	if !metadata.IsPacaya() {
+		return fmt.Errorf("metadata is not for Pacaya fork")
+	}
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	// Fetch transactions list, if it has not been prefetched.
+	if allTxs == nil {
+		if allTxs, err = i.FetchTxList(ctx, metadata, proposingTx); err != nil {
+			return err
 		}
 	}
 
 	var (
-		allTxs = i.txListDecompressor.TryDecompress(
-			i.rpc.L2.ChainID,
-			txListBytes,
-			len(meta.GetBlobHashes()) != 0,
-			true,
-		)
+		meta            = metadata.Pacaya()
 		parent          *types.Header
 		lastPayloadData *engine.ExecutableData
-		txListCursor    = 0
+		record          *derivationExport.Record
 	)
 
-	for j, blockInfo := range meta.GetBlocks() {
+	if i.exportStore != nil {
+		record = derivationExport.NewRecord(meta)
+	}
+
+	for j := range meta.GetBlocks() {
 		// Fetch the L2 parent block, if the node is just finished a P2P sync, we simply use the tracker's
 		// last synced verified block as the parent, otherwise, we fetch the parent block from L2 EE.
 		if i.progressTracker.Triggered() {
@@ -138,60 +177,23 @@ func (i *BlocksInserterPacaya) InsertBlocks(
 			"beaconSyncTriggered", i.progressTracker.Triggered(),
 		)
 
-		blockID := new(big.Int).SetUint64(parent.Number.Uint64() + 1)
-		difficulty, err := encoding.CalculatePacayaDifficulty(blockID)
-		if err != nil {
-			return fmt.Errorf("failed to calculate difficulty: %w", err)
-		}
-		timestamp := meta.GetLastBlockTimestamp()
-		for i := len(meta.GetBlocks()) - 1; i >= 0; i-- {
-			timestamp = timestamp - uint64(meta.GetBlocks()[i].TimeShift)
-		}
-
-		baseFee, err := i.rpc.CalculateBaseFee(
-			ctx,
-			parent,
-			true,
-			meta.GetBaseFeeConfig(),
-			timestamp,
-		)
+		block, err := i.DeriveBlock(ctx, meta, j, parent, allTxs)
 		if err != nil {
 			return err
 		}
 
 		log.Info(
 			"L2 baseFee",
-			"blockID", blockID,
-			"baseFee", utils.WeiToGWei(baseFee),
+			"blockID", block.ID,
+			"baseFee", utils.WeiToGWei(block.BaseFee),
 			"parentGasUsed", parent.GasUsed,
 			"batchID", meta.GetBatchID(),
 			"indexInBatch", j,
 		)
 
-		// Assemble a TaikoAnchor.anchorV3 transaction
-		anchorBlockHeader, err := i.rpc.L1.HeaderByHash(ctx, meta.GetAnchorBlockHash())
+		difficulty, err := encoding.CalculatePacayaDifficulty(block.ID)
 		if err != nil {
-			return fmt.Errorf("failed to fetch anchor block: %w", err)
-		}
-		anchorTx, err := i.anchorConstructor.AssembleAnchorV3Tx(
-			ctx,
-			new(big.Int).SetUint64(meta.GetAnchorBlockID()),
-			anchorBlockHeader.Root,
-			meta.GetAnchorInput(),
-			parent.GasUsed,
-			meta.GetBaseFeeConfig(),
-			meta.GetSignalSlots(),
-			new(big.Int).Add(parent.Number, common.Big1),
-			baseFee,
-		)
-		if err != nil {
-			return fmt.Errorf("failed to create TaikoAnchor.anchorV3 transaction: %w", err)
-		}
-
-		// Get transactions in the block.
-		txs := types.Transactions{}
-		if txListCursor+int(blockInfo.NumTransactions) <= len(allTxs) {
-			txs = allTxs[txListCursor : txListCursor+int(blockInfo.NumTransactions)]
+			return fmt.Errorf("failed to calculate difficulty: %w", err)
 		}
 
 		// Decompress the transactions list and try to insert a new head block to L2 EE.
@@ -200,29 +202,30 @@ func (i *BlocksInserterPacaya) InsertBlocks(
 			i.rpc,
 			&createPayloadAndSetHeadMetaData{
 				createExecutionPayloadsMetaData: &createExecutionPayloadsMetaData{
-					BlockID:               blockID,
+					BlockID:               block.ID,
 					ExtraData:             meta.GetExtraData(),
 					SuggestedFeeRecipient: meta.GetCoinbase(),
 					GasLimit:              uint64(meta.GetGasLimit()),
 					Difficulty:            common.BytesToHash(difficulty),
-					Timestamp:             timestamp,
+					Timestamp:             block.Timestamp,
 					ParentHash:            parent.Hash(),
 					L1Origin: &rawdb.L1Origin{
-						BlockID:       blockID,
+						BlockID:       block.ID,
 						L2BlockHash:   common.Hash{}, // Will be set by taiko-geth.
 						L1BlockHeight: meta.GetRawBlockHeight(),
 						L1BlockHash:   meta.GetRawBlockHash(),
 					},
-					Txs:         txs,
+					Txs:         block.Txs,
 					Withdrawals: make([]*types.Withdrawal, 0),
-					BaseFee:     baseFee,
+					BaseFee:     block.BaseFee,
 				},
 				AnchorBlockID:   new(big.Int).SetUint64(meta.GetAnchorBlockID()),
 				AnchorBlockHash: meta.GetAnchorBlockHash(),
 				BaseFeeConfig:   meta.GetBaseFeeConfig(),
 				Parent:          parent,
+				L1Finality:      i.l1Finality,
 			},
-			anchorTx,
+			block.AnchorTx,
 		); err != nil {
 			return fmt.Errorf("failed to insert new head to L2 execution engine: %w", err)
 		}
@@ -231,7 +234,7 @@ func (i *BlocksInserterPacaya) InsertBlocks(
 
 		log.Info(
 			"🔗 New L2 block inserted",
-			"blockID", blockID,
+			"blockID", block.ID,
 			"hash", lastPayloadData.BlockHash,
 			"transactions", len(lastPayloadData.Transactions),
 			"timestamp", lastPayloadData.Timestamp,
@@ -241,12 +244,100 @@ func (i *BlocksInserterPacaya) InsertBlocks(
 			"indexInBatch", j,
 		)
 
-		txListCursor += int(blockInfo.NumTransactions)
+		if record != nil {
+			record.Blocks = append(record.Blocks, &derivationExport.BlockRecord{
+				BlockID:      block.ID.Uint64(),
+				Hash:         lastPayloadData.BlockHash,
+				Timestamp:    block.Timestamp,
+				BaseFee:      (*hexutil.Big)(block.BaseFee),
+				AnchorTx:     block.AnchorTx,
+				Transactions: block.Txs,
+			})
+		}
+	}
+
+	// Failing to export the derivation record should not stop the chain syncing.
+	if record != nil {
+		if err := i.exportStore.Put(record); err != nil {
+			log.Warn("Failed to export batch derivation record", "batchID", meta.GetBatchID(), "error", err)
+		}
 	}
 
 	return nil
 }
 
+// DeriveBlock derives the block at the given index of the given Pacaya batch on top of the given parent,
+// without inserting it. allTxs is the transactions list of the whole batch.
+func (i *BlocksInserterPacaya) DeriveBlock(
+	ctx context.Context,
+	meta metadata.TaikoBatchMetaDataPacaya,
+	index int,
+	parent *types.Header,
+	allTxs types.Transactions,
+) (*DerivedBlock, error) {
+	blockID := new(big.Int).SetUint64(parent.Number.Uint64() + 1)
+
+	timestamp := meta.GetLastBlockTimestamp()
+	for i := len(meta.GetBlocks()) - 1; i >= 0; i-- {
+		timestamp = timestamp - uint64(meta.GetBlocks()[i].TimeShift)
+	}
+
+	baseFee, err := i.rpc.CalculateBaseFee(
+		ctx,
+		parent,
+		true,
+		meta.GetBaseFeeConfig(),
+		timestamp,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Assemble a TaikoAnchor.anchorV3 transaction
+	anchorBlockHeader, err := i.rpc.L1.HeaderByHash(ctx, meta.GetAnchorBlockHash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch anchor block: %w", err)
+	}
+	anchorTx, err := i.anchorConstructor.AssembleAnchorV3Tx(
+		ctx,
+		new(big.Int).SetUint64(meta.GetAnchorBlockID()),
+		anchorBlockHeader.Root,
+		meta.GetAnchorInput(),
+		parent.GasUsed,
+		meta.GetBaseFeeConfig(),
+		meta.GetSignalSlots(),
+		blockID,
+		baseFee,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TaikoAnchor.anchorV3 transaction: %w", err)
+	}
+
+	return &DerivedBlock{
+		ID:        blockID,
+		Timestamp: timestamp,
+		BaseFee:   baseFee,
+		AnchorTx:  anchorTx,
+		Txs:       batchBlockTxs(meta, index, allTxs),
+	}, nil
+}
+
+// batchBlockTxs returns the transactions of the block at the given index of the given batch, the
+// block gets no transactions if the batch transactions list is shorter than declared.
+func batchBlockTxs(meta metadata.TaikoBatchMetaDataPacaya, index int, allTxs types.Transactions) types.Transactions {
+	txListCursor := 0
+	for _, blockInfo := range meta.GetBlocks()[:index] {
+		txListCursor += int(blockInfo.NumTransactions)
+	}
+
+	numTxs := int(meta.GetBlocks()[index].NumTransactions)
+	if txListCursor+numTxs > len(allTxs) {
+		return types.Transactions{}
+	}
+
+	return allTxs[txListCursor : txListCursor+numTxs]
+}
+
 // InsertPreconfBlockFromTransactionsBatch inserts a preconf block from transactions batch.
 func (i *BlocksInserterPacaya) InsertPreconfBlockFromTransactionsBatch(
 	ctx context.Context,
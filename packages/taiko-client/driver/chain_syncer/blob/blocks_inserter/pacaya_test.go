@@ -0,0 +1,36 @@
+package blocksinserter
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
+	pacayaBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
+)
+
+func TestBatchBlockTxs(t *testing.T) {
+	var (
+		txs  = types.Transactions{}
+		meta = &metadata.TaikoDataBlockMetadataPacaya{
+			ITaikoInboxBatchInfo: pacayaBindings.ITaikoInboxBatchInfo{
+				Blocks: []pacayaBindings.ITaikoInboxBlockParams{
+					{NumTransactions: 2},
+					{NumTransactions: 0},
+					{NumTransactions: 1},
+					{NumTransactions: 3},
+				},
+			},
+		}
+	)
+	for nonce := uint64(0); nonce < 4; nonce++ {
+		txs = append(txs, types.NewTx(&types.LegacyTx{Nonce: nonce}))
+	}
+
+	require.Equal(t, txs[0:2], batchBlockTxs(meta, 0, txs))
+	require.Empty(t, batchBlockTxs(meta, 1, txs))
+	require.Equal(t, txs[2:3], batchBlockTxs(meta, 2, txs))
+	// The transactions list is shorter than declared.
+	require.Empty(t, batchBlockTxs(meta, 3, txs))
+}
@@ -0,0 +1,107 @@
+package blob
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/metrics"
+)
+
+// prefetchFunc fetches the proposing transaction and the decompressed transactions list of a proposal.
+type prefetchFunc func(
+	ctx context.Context,
+	meta metadata.TaikoProposalMetaData,
+) (*types.Transaction, types.Transactions, error)
+
+// prefetchedProposal is a proposal whose proposing transaction and transactions list are being
+// fetched ahead of its insertion.
+type prefetchedProposal struct {
+	meta        metadata.TaikoProposalMetaData
+	done        chan struct{}
+	proposingTx *types.Transaction
+	txs         types.Transactions
+	err         error
+}
+
+// prefetcher fetches the data of the next proposals concurrently, while they are still handed out
+// strictly in the order they were pushed. At most depth + 1 proposals are fetched at the same time.
+type prefetcher struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	depth   uint64
+	fetch   prefetchFunc
+	pending []*prefetchedProposal
+	wg      sync.WaitGroup
+}
+
+// newPrefetcher creates a new prefetcher instance, a zero depth means no proposal is fetched ahead
+// of the one being inserted.
+func newPrefetcher(ctx context.Context, depth uint64, fetch prefetchFunc) *prefetcher {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &prefetcher{ctx: ctx, cancel: cancel, depth: depth, fetch: fetch}
+}
+
+// push enqueues a proposal, and starts fetching its data if fetch is true.
+func (p *prefetcher) push(meta metadata.TaikoProposalMetaData, fetch bool) {
+	proposal := &prefetchedProposal{meta: meta, done: make(chan struct{})}
+	p.pending = append(p.pending, proposal)
+	metrics.DriverPrefetchDepthGauge.Set(float64(len(p.pending)))
+
+	if !fetch {
+		close(proposal.done)
+		return
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer close(proposal.done)
+
+		proposal.proposingTx, proposal.txs, proposal.err = p.fetch(p.ctx, meta)
+		if proposal.err != nil {
+			metrics.DriverPrefetchFailedCounter.Inc()
+		}
+	}()
+}
+
+// full returns true if there are more proposals enqueued than the prefetch depth.
+func (p *prefetcher) full() bool {
+	return uint64(len(p.pending)) > p.depth
+}
+
+// empty returns true if there is no enqueued proposal.
+func (p *prefetcher) empty() bool {
+	return len(p.pending) == 0
+}
+
+// pop waits until the data of the oldest enqueued proposal is fetched, and dequeues it.
+func (p *prefetcher) pop() *prefetchedProposal {
+	proposal := p.pending[0]
+	p.pending = p.pending[1:]
+	metrics.DriverPrefetchDepthGauge.Set(float64(len(p.pending)))
+
+	select {
+	case <-proposal.done:
+	default:
+		start := time.Now()
+		<-proposal.done
+		metrics.DriverPrefetchStallCounter.Add(time.Since(start).Seconds())
+	}
+
+	return proposal
+}
+
+// close cancels the ongoing fetches and drops all enqueued proposals, it should also be called once
+// the L1 chain has been reorged, since the enqueued proposals might have been reorged out.
+func (p *prefetcher) close() {
+	p.cancel()
+	p.wg.Wait()
+
+	p.pending = nil
+	metrics.DriverPrefetchDepthGauge.Set(0)
+}
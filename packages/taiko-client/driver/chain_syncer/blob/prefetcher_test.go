@@ -0,0 +1,102 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
+	ontakeBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/ontake"
+)
+
+func newTestMeta(id uint64) metadata.TaikoProposalMetaData {
+	return &metadata.TaikoDataBlockMetadataOntake{TaikoDataBlockMetadataV2: ontakeBindings.TaikoDataBlockMetadataV2{Id: id}}
+}
+
+func TestPrefetcherKeepsOrder(t *testing.T) {
+	p := newPrefetcher(
+		context.Background(),
+		3,
+		func(_ context.Context, meta metadata.TaikoProposalMetaData) (*types.Transaction, types.Transactions, error) {
+			// The earlier proposals take longer to fetch.
+			time.Sleep(time.Duration(10-meta.Ontake().GetBlockID().Uint64()) * time.Millisecond)
+			if meta.Ontake().GetBlockID().Uint64() == 2 {
+				return nil, nil, errors.New("fetch failed")
+			}
+
+			return types.NewTx(&types.LegacyTx{Nonce: meta.Ontake().GetBlockID().Uint64()}), types.Transactions{}, nil
+		},
+	)
+	defer p.close()
+
+	var popped []*prefetchedProposal
+	for id := uint64(1); id <= 6; id++ {
+		p.push(newTestMeta(id), id != 4)
+		for p.full() {
+			popped = append(popped, p.pop())
+		}
+	}
+	require.Len(t, popped, 3)
+
+	for !p.empty() {
+		popped = append(popped, p.pop())
+	}
+	require.Len(t, popped, 6)
+
+	for i, proposal := range popped {
+		id := uint64(i + 1)
+		require.Equal(t, id, proposal.meta.Ontake().GetBlockID().Uint64())
+
+		switch id {
+		case 2:
+			require.Error(t, proposal.err)
+		case 4:
+			require.Nil(t, proposal.err)
+			require.Nil(t, proposal.proposingTx)
+			require.Nil(t, proposal.txs)
+		default:
+			require.Nil(t, proposal.err)
+			require.Equal(t, id, proposal.proposingTx.Nonce())
+			require.NotNil(t, proposal.txs)
+		}
+	}
+}
+
+func TestPrefetcherZeroDepth(t *testing.T) {
+	p := newPrefetcher(
+		context.Background(),
+		0,
+		func(context.Context, metadata.TaikoProposalMetaData) (*types.Transaction, types.Transactions, error) {
+			return nil, types.Transactions{}, nil
+		},
+	)
+	defer p.close()
+
+	require.True(t, p.empty())
+	p.push(newTestMeta(1), true)
+	require.True(t, p.full())
+	require.NotNil(t, p.pop())
+	require.True(t, p.empty())
+}
+
+func TestPrefetcherClose(t *testing.T) {
+	p := newPrefetcher(
+		context.Background(),
+		2,
+		func(ctx context.Context, _ metadata.TaikoProposalMetaData) (*types.Transaction, types.Transactions, error) {
+			<-ctx.Done()
+			return nil, nil, ctx.Err()
+		},
+	)
+
+	p.push(newTestMeta(1), true)
+	p.push(newTestMeta(2), true)
+	require.False(t, p.full())
+
+	p.close()
+	require.True(t, p.empty())
+}
@@ -9,11 +9,13 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/chain_syncer/beaconsync"
 	blocksInserter "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/chain_syncer/blob/blocks_inserter"
+	derivationExport "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/derivation_export"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/state"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/metrics"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
@@ -37,6 +39,7 @@ type Syncer struct {
 	blocksInserterOntake blocksInserter.Inserter // Ontake blocks inserter
 	blocksInserterPacaya blocksInserter.Inserter // Pacaya blocks inserter
 
+	prefetchDepth       uint64 // Number of proposals fetched ahead of the one being inserted
 	lastInsertedBlockID *big.Int
 	reorgDetectedFlag   bool
 }
@@ -48,8 +51,10 @@ func NewSyncer(
 	state *state.State,
 	progressTracker *beaconsync.SyncProgressTracker,
 	maxRetrieveExponent uint64,
+	prefetchDepth uint64,
 	blobServerEndpoint *url.URL,
 	socialScanEndpoint *url.URL,
+	exportStore *derivationExport.Store,
 ) (*Syncer, error) {
 	constructor, err := anchorTxConstructor.New(client)
 	if err != nil {
@@ -83,6 +88,7 @@ func NewSyncer(
 		state:              state,
 		progressTracker:    progressTracker,
 		txListDecompressor: txListDecompressor,
+		prefetchDepth:      prefetchDepth,
 		blocksInserterOntake: blocksInserter.NewBlocksInserterOntake(
 			client,
 			progressTracker,
@@ -91,6 +97,7 @@ func NewSyncer(
 			constructor,
 			txListFetcherCalldata,
 			txListFetcherBlob,
+			state.L1Finality(),
 		),
 		blocksInserterPacaya: blocksInserter.NewBlocksInserterPacaya(
 			client,
@@ -100,6 +107,8 @@ func NewSyncer(
 			constructor,
 			txListFetcherCalldata,
 			txListFetcherBlob,
+			state.L1Finality(),
+			exportStore,
 		),
 	}, nil
 }
@@ -130,6 +139,18 @@ func (s *Syncer) processL1Blocks(ctx context.Context) error {
 		l1End          = s.state.GetL1Head()
 		startL1Current = s.state.GetL1Current()
 	)
+	// Unless following the L1 head, the L1 sync cursor can be ahead of the final L1 block, e.g. after the
+	// L1 finality mode changes, then just wait for the final L1 block to catch up.
+	if !s.state.L1Finality().IsHead() && startL1Current.Number.Cmp(l1End.Number) > 0 {
+		log.Debug(
+			"L1 sync cursor is ahead of the final L1 block",
+			"l1Current", startL1Current.Number,
+			"l1Final", l1End.Number,
+			"mode", s.state.L1Finality(),
+		)
+		return nil
+	}
+
 	// If there is a L1 reorg, sometimes this will happen.
 	if startL1Current.Number.Uint64() >= l1End.Number.Uint64() && startL1Current.Hash() != l1End.Hash() {
 		newL1Current, err := s.rpc.L1.HeaderByNumber(ctx, new(big.Int).Sub(l1End.Number, common.Big1))
@@ -150,13 +171,23 @@ func (s *Syncer) processL1Blocks(ctx context.Context) error {
 		s.lastInsertedBlockID = nil
 	}
 
+	// The proposals are fetched ahead of their insertion by the prefetcher, and then inserted one by one.
+	p := newPrefetcher(ctx, s.prefetchDepth, s.prefetchProposal)
+	defer p.close()
+
 	iter, err := eventIterator.NewBlockProposedIterator(ctx, &eventIterator.BlockProposedIteratorConfig{
-		Client:               s.rpc.L1,
-		TaikoL1:              s.rpc.OntakeClients.TaikoL1,
-		TaikoInbox:           s.rpc.PacayaClients.TaikoInbox,
-		StartHeight:          s.state.GetL1Current().Number,
-		EndHeight:            l1End.Number,
-		OnBlockProposedEvent: s.onBlockProposed,
+		Client:      s.rpc.L1,
+		TaikoL1:     s.rpc.OntakeClients.TaikoL1,
+		TaikoInbox:  s.rpc.PacayaClients.TaikoInbox,
+		StartHeight: s.state.GetL1Current().Number,
+		EndHeight:   l1End.Number,
+		OnBlockProposedEvent: func(
+			ctx context.Context,
+			meta metadata.TaikoProposalMetaData,
+			endIter eventIterator.EndBlockProposedEventIterFunc,
+		) error {
+			return s.enqueueProposal(ctx, p, meta, endIter)
+		},
 	})
 	if err != nil {
 		return err
@@ -166,6 +197,13 @@ func (s *Syncer) processL1Blocks(ctx context.Context) error {
 		return err
 	}
 
+	// Insert the proposals still in the prefetcher, the iteration has already finished here.
+	for !p.empty() && !s.reorgDetectedFlag {
+		if err := s.insertProposal(ctx, p.pop(), func() {}); err != nil {
+			return err
+		}
+	}
+
 	// If there is a L1 reorg, we don't update the L1Current cursor.
 	if !s.reorgDetectedFlag {
 		s.state.SetL1Current(l1End)
@@ -175,24 +213,95 @@ func (s *Syncer) processL1Blocks(ctx context.Context) error {
 	return nil
 }
 
-// OnBlockProposed is a `BlockProposed` event callback which responsible for
+// enqueueProposal is a `BlockProposed` event callback which enqueues the proposal to the prefetcher,
+// and then inserts the enqueued proposals which are out of the prefetch window, in order.
+func (s *Syncer) enqueueProposal(
+	ctx context.Context,
+	p *prefetcher,
+	meta metadata.TaikoProposalMetaData,
+	endIter eventIterator.EndBlockProposedEventIterFunc,
+) error {
+	p.push(meta, s.needFetch(meta))
+
+	for p.full() && !s.reorgDetectedFlag {
+		if err := s.insertProposal(ctx, p.pop(), endIter); err != nil {
+			return err
+		}
+	}
+
+	// The enqueued proposals might have been reorged out, drop them.
+	if s.reorgDetectedFlag {
+		p.close()
+	}
+
+	return nil
+}
+
+// needFetch checks whether the data of the given proposal should be prefetched, the genesis proposal
+// and the already inserted or beacon synced proposals are skipped when inserting.
+func (s *Syncer) needFetch(meta metadata.TaikoProposalMetaData) bool {
+	lastBlockID, _ := lastBlockIDAndTimestamp(meta)
+
+	if lastBlockID.Cmp(common.Big0) == 0 {
+		return false
+	}
+	if s.lastInsertedBlockID != nil && lastBlockID.Cmp(s.lastInsertedBlockID) <= 0 {
+		return false
+	}
+	if s.progressTracker.Triggered() &&
+		s.progressTracker.LastSyncedBlockID() != nil &&
+		lastBlockID.Cmp(s.progressTracker.LastSyncedBlockID()) <= 0 {
+		return false
+	}
+
+	return true
+}
+
+// prefetchProposal fetches the original TaikoL1.proposeBlockV2 / TaikoInbox.proposeBatch transaction,
+// and the decompressed transactions list of the given proposal.
+func (s *Syncer) prefetchProposal(
+	ctx context.Context,
+	meta metadata.TaikoProposalMetaData,
+) (*types.Transaction, types.Transactions, error) {
+	tx, err := s.rpc.L1.TransactionInBlock(ctx, meta.GetRawBlockHash(), meta.GetTxIndex())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch original proposing transaction: %w", err)
+	}
+
+	inserter := s.blocksInserterOntake
+	if meta.IsPacaya() {
+		inserter = s.blocksInserterPacaya
+	}
+
+	txs, err := inserter.FetchTxList(ctx, meta, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tx, txs, nil
+}
+
+// onBlockProposed is a `BlockProposed` event callback which responsible for
 // inserting the proposed block one by one to the L2 execution engine.
 func (s *Syncer) onBlockProposed(
 	ctx context.Context,
 	meta metadata.TaikoProposalMetaData,
 	endIter eventIterator.EndBlockProposedEventIterFunc,
+) error {
+	return s.insertProposal(ctx, &prefetchedProposal{meta: meta}, endIter)
+}
+
+// insertProposal inserts the blocks of the given proposal to the L2 execution engine, using the
+// prefetched data if there is any.
+func (s *Syncer) insertProposal(
+	ctx context.Context,
+	proposal *prefetchedProposal,
+	endIter eventIterator.EndBlockProposedEventIterFunc,
 ) error {
 	var (
-		lastBlockID *big.Int
-		timestamp   uint64
+		meta                   = proposal.meta
+		lastBlockID, timestamp = lastBlockIDAndTimestamp(meta)
 	)
-	if meta.IsPacaya() {
-		lastBlockID = new(big.Int).SetUint64(meta.Pacaya().GetLastBlockID())
-		timestamp = meta.Pacaya().GetLastBlockTimestamp()
-	} else {
-		lastBlockID = meta.Ontake().GetBlockID()
-		timestamp = meta.Ontake().GetTimestamp()
-	}
 
 	// We simply ignore the genesis block's `BlockProposedV2` / `BatchesProposed` event.
 	if lastBlockID.Cmp(common.Big0) == 0 {
@@ -247,14 +356,23 @@ func (s *Syncer) onBlockProposed(
 		time.Sleep(time.Until(time.Unix(int64(timestamp), 0)))
 	}
 
-	// Fetch the original TaikoL1.proposeBlockV2 / TaikoInbox.proposeBatch transaction.
-	tx, err := s.rpc.L1.TransactionInBlock(
-		ctx,
-		meta.GetRawBlockHash(),
-		meta.GetTxIndex(),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to fetch original TaikoL1.proposeBlockV2 transaction: %w", err)
+	tx, txs := proposal.proposingTx, proposal.txs
+	if proposal.err != nil {
+		log.Warn("Failed to prefetch proposal, fetching it again", "lastBlockID", lastBlockID, "error", proposal.err)
+		tx, txs = nil, nil
+	}
+
+	// Fetch the original TaikoL1.proposeBlockV2 / TaikoInbox.proposeBatch transaction, if it has not
+	// been prefetched.
+	if tx == nil {
+		var err error
+		if tx, err = s.rpc.L1.TransactionInBlock(
+			ctx,
+			meta.GetRawBlockHash(),
+			meta.GetTxIndex(),
+		); err != nil {
+			return fmt.Errorf("failed to fetch original TaikoL1.proposeBlockV2 transaction: %w", err)
+		}
 	}
 
 	// Insert new blocks to L2 EE's chain.
@@ -268,7 +386,7 @@ func (s *Syncer) onBlockProposed(
 			"lastTimestamp", meta.Pacaya().GetLastBlockTimestamp(),
 			"blocks", len(meta.Pacaya().GetBlocks()),
 		)
-		if err := s.blocksInserterPacaya.InsertBlocks(ctx, meta, tx, endIter); err != nil {
+		if err := s.blocksInserterPacaya.InsertBlocks(ctx, meta, tx, txs, endIter); err != nil {
 			return err
 		}
 	} else {
@@ -279,7 +397,7 @@ func (s *Syncer) onBlockProposed(
 			"blockID", meta.Ontake().GetBlockID(),
 			"coinbase", meta.Ontake().GetCoinbase(),
 		)
-		if err := s.blocksInserterOntake.InsertBlocks(ctx, meta, tx, endIter); err != nil {
+		if err := s.blocksInserterOntake.InsertBlocks(ctx, meta, tx, txs, endIter); err != nil {
 			return err
 		}
 	}
@@ -294,6 +412,15 @@ func (s *Syncer) onBlockProposed(
 	return nil
 }
 
+// lastBlockIDAndTimestamp returns the ID and the timestamp of the last block in the given proposal.
+func lastBlockIDAndTimestamp(meta metadata.TaikoProposalMetaData) (*big.Int, uint64) {
+	if meta.IsPacaya() {
+		return new(big.Int).SetUint64(meta.Pacaya().GetLastBlockID()), meta.Pacaya().GetLastBlockTimestamp()
+	}
+
+	return meta.Ontake().GetBlockID(), meta.Ontake().GetTimestamp()
+}
+
 // checkLastVerifiedBlockMismatch checks if there is a mismatch between protocol's last verified block hash and
 // the corresponding L2 EE block hash.
 func (s *Syncer) checkLastVerifiedBlockMismatch(ctx context.Context) (*rpc.ReorgCheckResult, error) {
@@ -37,7 +37,7 @@ type BlobSyncerTestSuite struct {
 func (s *BlobSyncerTestSuite) SetupTest() {
 	s.ClientTestSuite.SetupTest()
 
-	state2, err := state.New(context.Background(), s.RPCClient)
+	state2, err := state.New(context.Background(), s.RPCClient, nil)
 	s.Nil(err)
 
 	syncer, err := NewSyncer(
@@ -46,8 +46,10 @@ func (s *BlobSyncerTestSuite) SetupTest() {
 		state2,
 		beaconsync.NewSyncProgressTracker(s.RPCClient.L2, 1*time.Hour),
 		0,
+		4,
 		s.BlobServer.URL(),
 		nil,
+		nil,
 	)
 	s.Nil(err)
 	s.s = syncer
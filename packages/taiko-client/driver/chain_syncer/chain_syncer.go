@@ -6,10 +6,12 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/chain_syncer/beaconsync"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/chain_syncer/blob"
+	derivationExport "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/derivation_export"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/state"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
 )
@@ -41,8 +43,10 @@ func New(
 	p2pSync bool,
 	p2pSyncTimeout time.Duration,
 	maxRetrieveExponent uint64,
+	prefetchDepth uint64,
 	blobServerEndpoint *url.URL,
 	socialScanEndpoint *url.URL,
+	exportStore *derivationExport.Store,
 ) (*L2ChainSyncer, error) {
 	tracker := beaconsync.NewSyncProgressTracker(rpc.L2, p2pSyncTimeout)
 	go tracker.Track(ctx)
@@ -54,8 +58,10 @@ func New(
 		state,
 		tracker,
 		maxRetrieveExponent,
+		prefetchDepth,
 		blobServerEndpoint,
 		socialScanEndpoint,
+		exportStore,
 	)
 	if err != nil {
 		return nil, err
@@ -74,7 +80,7 @@ func New(
 
 // Sync performs a sync operation to L2 execution engine's local chain.
 func (s *L2ChainSyncer) Sync() error {
-	blockIDToSync, needNewBeaconSyncTriggered, err := s.needNewBeaconSyncTriggered()
+	headerToSync, needNewBeaconSyncTriggered, err := s.needNewBeaconSyncTriggered()
 	if err != nil {
 		return err
 	}
@@ -83,7 +89,7 @@ func (s *L2ChainSyncer) Sync() error {
 	// `P2PSync` flag is set, try triggering a beacon sync in L2 execution engine to catch up the
 	// head.
 	if needNewBeaconSyncTriggered {
-		if err := s.beaconSyncer.TriggerBeaconSync(blockIDToSync); err != nil {
+		if err := s.beaconSyncer.TriggerBeaconSync(headerToSync); err != nil {
 			return fmt.Errorf("trigger beacon sync error: %w", err)
 		}
 
@@ -175,28 +181,49 @@ func (s *L2ChainSyncer) AheadOfHeadToSync(heightToSync uint64) bool {
 // another new beacon sync, the following conditions should be met:
 // 1. The `--p2p.sync` flag is set.
 // 2. The protocol's (last verified) block head is not zero.
-// 3. The L2 execution engine's chain is behind of the protocol's (latest verified) block head.
-// 4. The L2 execution engine's chain has met a sync timeout issue.
-func (s *L2ChainSyncer) needNewBeaconSyncTriggered() (uint64, bool, error) {
+// 3. The checkpoint nodes serve the protocol's (last verified) block head.
+// 4. The L2 execution engine's chain is behind of the protocol's (latest verified) block head.
+// 5. The L2 execution engine's chain has met a sync timeout issue.
+func (s *L2ChainSyncer) needNewBeaconSyncTriggered() (*types.Header, bool, error) {
 	// If the flag is not set or there was a finished beacon sync, we simply return false.
 	if !s.p2pSync || s.progressTracker.Finished() {
-		return 0, false, nil
+		return nil, false, nil
 	}
 
-	blockID, err := s.rpc.L2CheckPoint.BlockNumber(s.ctx)
+	header, err := s.beaconSyncer.VerifiedCheckpoint(s.ctx)
 	if err != nil {
-		return 0, false, err
+		return nil, false, err
 	}
 
 	// If the protocol's block head is zero, we simply return false.
-	if blockID == 0 {
-		return 0, false, nil
+	if header == nil {
+		return nil, false, nil
 	}
 
-	return blockID, !s.AheadOfHeadToSync(blockID) &&
+	return header, !s.AheadOfHeadToSync(header.Number.Uint64()) &&
 		!s.progressTracker.OutOfSync(), nil
 }
 
+// VerifyCheckpoint checks that the checkpoint nodes serve the last verified block in TaikoInbox,
+// it returns beaconsync.ErrCheckpointMismatch if the majority of them serve another block. It is a
+// no-op when P2P sync is disabled.
+func (s *L2ChainSyncer) VerifyCheckpoint(ctx context.Context) error {
+	if !s.p2pSync {
+		return nil
+	}
+
+	header, err := s.beaconSyncer.VerifiedCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	if header != nil {
+		log.Info("Checkpoint block verified", "blockID", header.Number, "hash", header.Hash())
+	}
+
+	return nil
+}
+
 // BeaconSyncer returns the inner beacon syncer.
 func (s *L2ChainSyncer) BeaconSyncer() *beaconsync.Syncer {
 	return s.beaconSyncer
@@ -29,7 +29,7 @@ type ChainSyncerTestSuite struct {
 func (s *ChainSyncerTestSuite) SetupTest() {
 	s.ClientTestSuite.SetupTest()
 
-	state, err := state.New(context.Background(), s.RPCClient)
+	state, err := state.New(context.Background(), s.RPCClient, nil)
 	s.Nil(err)
 
 	syncer, err := New(
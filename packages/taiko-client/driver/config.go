@@ -27,13 +27,18 @@ type Config struct {
 	P2PSync                       bool
 	P2PSyncTimeout                time.Duration
 	RetryInterval                 time.Duration
+	L1Finality                    *rpc.L1FinalityMode
 	MaxExponent                   uint64
+	PrefetchDepth                 uint64
 	BlobServerEndpoint            *url.URL
 	SocialScanEndpoint            *url.URL
 	PreconfBlockServerPort        uint64
 	PreconfBlockServerJWTSecret   []byte
 	PreconfBlockServerCORSOrigins string
 	PreconfBlockServerCheckSig    bool
+	DerivationExportDataDir       string
+	DerivationExportServerPort    uint64
+	DerivationExportJWTSecret     []byte
 	P2PConfigs                    *p2p.Config
 	P2PSignerConfigs              p2p.SignerSetup
 }
@@ -47,14 +52,24 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 	}
 
 	var (
-		p2pSync      = c.Bool(flags.P2PSync.Name)
-		l2CheckPoint = c.String(flags.CheckPointSyncURL.Name)
+		p2pSync       = c.Bool(flags.P2PSync.Name)
+		l2CheckPoint  = c.String(flags.CheckPointSyncURL.Name)
+		l2CheckPoints = c.StringSlice(flags.CheckPointSyncURLs.Name)
 	)
 
+	if len(l2CheckPoint) == 0 && len(l2CheckPoints) != 0 {
+		l2CheckPoint, l2CheckPoints = l2CheckPoints[0], l2CheckPoints[1:]
+	}
+
 	if p2pSync && len(l2CheckPoint) == 0 {
 		return nil, errors.New("empty L2 check point URL")
 	}
 
+	l1Finality, err := rpc.ParseL1FinalityMode(c.String(flags.L1Finality.Name))
+	if err != nil {
+		return nil, err
+	}
+
 	var beaconEndpoint string
 	if c.IsSet(flags.L1BeaconEndpoint.Name) {
 		beaconEndpoint = c.String(flags.L1BeaconEndpoint.Name)
@@ -91,6 +106,22 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		}
 	}
 
+	var (
+		derivationExportDataDir    = c.String(flags.DerivationExportDataDir.Name)
+		derivationExportServerPort = c.Uint64(flags.DerivationExportServerPort.Name)
+		derivationExportJWTSecret  []byte
+	)
+	if derivationExportServerPort > 0 {
+		if derivationExportDataDir == "" {
+			return nil, errors.New("empty derivation export data directory")
+		}
+		if derivationExportJWTSecret, err = jwt.ParseSecretFromFile(
+			c.String(flags.DerivationExportJWTSecret.Name),
+		); err != nil {
+			return nil, fmt.Errorf("invalid derivation export JWT secret file: %w", err)
+		}
+	}
+
 	// Check P2P network flags and create the P2P configurations.
 	var (
 		clientConfig = &rpc.ClientConfig{
@@ -98,6 +129,7 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 			L1BeaconEndpoint: beaconEndpoint,
 			L2Endpoint:       c.String(flags.L2WSEndpoint.Name),
 			L2CheckPoint:     l2CheckPoint,
+			L2CheckPoints:    l2CheckPoints,
 			TaikoL1Address:   common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
 			TaikoL2Address:   common.HexToAddress(c.String(flags.TaikoL2Address.Name)),
 			L2EngineEndpoint: c.String(flags.L2AuthEndpoint.Name),
@@ -134,13 +166,18 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		RetryInterval:                 c.Duration(flags.BackOffRetryInterval.Name),
 		P2PSync:                       p2pSync,
 		P2PSyncTimeout:                c.Duration(flags.P2PSyncTimeout.Name),
+		L1Finality:                    l1Finality,
 		MaxExponent:                   c.Uint64(flags.MaxExponent.Name),
+		PrefetchDepth:                 c.Uint64(flags.PrefetchDepth.Name),
 		BlobServerEndpoint:            blobServerEndpoint,
 		SocialScanEndpoint:            socialScanEndpoint,
 		PreconfBlockServerPort:        c.Uint64(flags.PreconfBlockServerPort.Name),
 		PreconfBlockServerJWTSecret:   preconfBlockServerJWTSecret,
 		PreconfBlockServerCORSOrigins: c.String(flags.PreconfBlockServerCORSOrigins.Name),
 		PreconfBlockServerCheckSig:    c.Bool(flags.PreconfBlockServerCheckSig.Name),
+		DerivationExportDataDir:       derivationExportDataDir,
+		DerivationExportServerPort:    derivationExportServerPort,
+		DerivationExportJWTSecret:     derivationExportJWTSecret,
 		P2PConfigs:                    p2pConfigs,
 		P2PSignerConfigs:              signerConfigs,
 	}, nil
@@ -0,0 +1,84 @@
+package derivationexport
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
+	pacayaBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
+)
+
+// Record is the derivation record of a Pacaya batch, it contains everything needed to reconstruct
+// the L2 blocks of the batch, without fetching and decoding its blobs again.
+type Record struct {
+	BatchID         uint64                                     `json:"batchID"`
+	L1Height        uint64                                     `json:"l1Height"`
+	L1Hash          common.Hash                                `json:"l1Hash"`
+	ProposingTxHash common.Hash                                `json:"proposingTxHash"`
+	Proposer        common.Address                             `json:"proposer"`
+	Coinbase        common.Address                             `json:"coinbase"`
+	GasLimit        uint32                                     `json:"gasLimit"`
+	ExtraData       hexutil.Bytes                              `json:"extraData"`
+	BlobHashes      []common.Hash                              `json:"blobHashes"`
+	AnchorBlockID   uint64                                     `json:"anchorBlockID"`
+	AnchorBlockHash common.Hash                                `json:"anchorBlockHash"`
+	AnchorInput     common.Hash                                `json:"anchorInput"`
+	SignalSlots     []common.Hash                              `json:"signalSlots"`
+	BaseFeeConfig   *pacayaBindings.LibSharedDataBaseFeeConfig `json:"baseFeeConfig"`
+	Blocks          []*BlockRecord                             `json:"blocks"`
+}
+
+// BlockRecord is the derivation record of an L2 block in a batch.
+type BlockRecord struct {
+	BlockID      uint64             `json:"blockID"`
+	Hash         common.Hash        `json:"hash"`
+	Timestamp    uint64             `json:"timestamp"`
+	BaseFee      *hexutil.Big       `json:"baseFee"`
+	AnchorTx     *types.Transaction `json:"anchorTx"`
+	Transactions types.Transactions `json:"transactions"`
+}
+
+// NewRecord creates a new derivation record with the given batch metadata, the blocks should be
+// appended once they are derived.
+func NewRecord(meta metadata.TaikoBatchMetaDataPacaya) *Record {
+	signalSlots := make([]common.Hash, len(meta.GetSignalSlots()))
+	for i, slot := range meta.GetSignalSlots() {
+		signalSlots[i] = slot
+	}
+
+	return &Record{
+		BatchID:         meta.GetBatchID().Uint64(),
+		L1Height:        meta.GetRawBlockHeight().Uint64(),
+		L1Hash:          meta.GetRawBlockHash(),
+		ProposingTxHash: meta.GetTxHash(),
+		Proposer:        meta.GetProposer(),
+		Coinbase:        meta.GetCoinbase(),
+		GasLimit:        meta.GetGasLimit(),
+		ExtraData:       meta.GetExtraData(),
+		BlobHashes:      meta.GetBlobHashes(),
+		AnchorBlockID:   meta.GetAnchorBlockID(),
+		AnchorBlockHash: meta.GetAnchorBlockHash(),
+		AnchorInput:     meta.GetAnchorInput(),
+		SignalSlots:     signalSlots,
+		BaseFeeConfig:   meta.GetBaseFeeConfig(),
+	}
+}
+
+// FirstBlockID returns the ID of the first block in the batch.
+func (r *Record) FirstBlockID() uint64 {
+	if len(r.Blocks) == 0 {
+		return 0
+	}
+
+	return r.Blocks[0].BlockID
+}
+
+// LastBlockID returns the ID of the last block in the batch.
+func (r *Record) LastBlockID() uint64 {
+	if len(r.Blocks) == 0 {
+		return 0
+	}
+
+	return r.Blocks[len(r.Blocks)-1].BlockID
+}
@@ -0,0 +1,120 @@
+package derivationexport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// Server serves the derivation records in the store over HTTP/JSON, all record routes require a
+// JWT signed with the configured secret.
+type Server struct {
+	echo  *echo.Echo
+	store *Store
+}
+
+// NewServer creates a new derivation export server instance.
+func NewServer(store *Store, jwtSecret []byte) (*Server, error) {
+	if len(jwtSecret) == 0 {
+		return nil, errors.New("empty JWT secret for derivation export server")
+	}
+
+	server := &Server{echo: echo.New(), store: store}
+
+	server.echo.HideBanner = true
+	server.echo.Use(middleware.RequestID())
+	server.echo.Use(middleware.Recover())
+
+	server.echo.GET("/healthz", server.HealthCheck)
+
+	records := server.echo.Group("", echojwt.JWT(jwtSecret))
+	records.GET("/batches/:batchID", server.GetBatch)
+	records.GET("/blocks/:blockID", server.GetBlock)
+	records.GET("/l1Blocks/:l1Height", server.GetL1Block)
+
+	return server, nil
+}
+
+// Start starts the HTTP server.
+func (s *Server) Start(port uint64) error {
+	return s.echo.Start(fmt.Sprintf(":%v", port))
+}
+
+// Shutdown shuts down the HTTP server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.echo.Shutdown(ctx)
+}
+
+// HealthCheck is the endpoints for probes.
+func (s *Server) HealthCheck(c echo.Context) error {
+	return c.NoContent(http.StatusOK)
+}
+
+// GetBatch returns the derivation record of the given batch.
+func (s *Server) GetBatch(c echo.Context) error {
+	batchID, err := strconv.ParseUint(c.Param("batchID"), 10, 64)
+	if err != nil {
+		return s.returnError(c, http.StatusBadRequest, err)
+	}
+
+	record, err := s.store.GetByBatchID(batchID)
+	if err != nil {
+		return s.returnStoreError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, record)
+}
+
+// GetBlock returns the derivation record of the batch which contains the given L2 block.
+func (s *Server) GetBlock(c echo.Context) error {
+	blockID, err := strconv.ParseUint(c.Param("blockID"), 10, 64)
+	if err != nil {
+		return s.returnError(c, http.StatusBadRequest, err)
+	}
+
+	record, err := s.store.GetByBlockID(blockID)
+	if err != nil {
+		return s.returnStoreError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, record)
+}
+
+// GetL1Block returns the derivation records of the batches proposed in the given L1 block.
+func (s *Server) GetL1Block(c echo.Context) error {
+	l1Height, err := strconv.ParseUint(c.Param("l1Height"), 10, 64)
+	if err != nil {
+		return s.returnError(c, http.StatusBadRequest, err)
+	}
+
+	records, err := s.store.GetByL1Height(l1Height)
+	if err != nil {
+		return s.returnStoreError(c, err)
+	}
+
+	if records == nil {
+		records = []*Record{}
+	}
+
+	return c.JSON(http.StatusOK, records)
+}
+
+// returnStoreError writes the given store error to the response.
+func (s *Server) returnStoreError(c echo.Context, err error) error {
+	if errors.Is(err, ErrNotFound) {
+		return s.returnError(c, http.StatusNotFound, err)
+	}
+
+	return s.returnError(c, http.StatusInternalServerError, err)
+}
+
+// returnError writes the given error to the response.
+func (s *Server) returnError(c echo.Context, statusCode int, err error) error {
+	return c.JSON(statusCode, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,77 @@
+package derivationexport
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/stretchr/testify/require"
+)
+
+var testJWTSecret = []byte("derivation-export-test-secret")
+
+// newTestJWT creates a HS256 JWT signed with the given secret.
+func newTestJWT(t *testing.T, secret []byte) string {
+	var (
+		header  = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+		payload = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"test"}`))
+		mac     = hmac.New(sha256.New, secret)
+	)
+	_, err := mac.Write([]byte(header + "." + payload))
+	require.Nil(t, err)
+
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func request(t *testing.T, s *Server, path string, secret []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if secret != nil {
+		req.Header.Set("Authorization", "Bearer "+newTestJWT(t, secret))
+	}
+
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestServer(t *testing.T) {
+	_, err := NewServer(NewStore(memorydb.New()), nil)
+	require.NotNil(t, err)
+
+	store := NewStore(memorydb.New())
+	defer store.Close()
+	require.Nil(t, store.Put(newTestRecord(t, 1, 100, 1, 3)))
+
+	s, err := NewServer(store, testJWTSecret)
+	require.Nil(t, err)
+
+	require.Equal(t, http.StatusOK, request(t, s, "/healthz", nil).Code)
+	require.Equal(t, http.StatusUnauthorized, request(t, s, "/batches/1", []byte("wrong secret")).Code)
+
+	rec := request(t, s, "/batches/1", testJWTSecret)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var r Record
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &r))
+	require.Equal(t, uint64(1), r.BatchID)
+	require.Len(t, r.Blocks, 3)
+
+	rec = request(t, s, "/blocks/2", testJWTSecret)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var records []*Record
+	rec = request(t, s, "/l1Blocks/100", testJWTSecret)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &records))
+	require.Len(t, records, 1)
+
+	require.Equal(t, http.StatusNotFound, request(t, s, "/batches/2", testJWTSecret).Code)
+	require.Equal(t, http.StatusNotFound, request(t, s, "/blocks/4", testJWTSecret).Code)
+	require.Equal(t, http.StatusBadRequest, request(t, s, "/batches/abc", testJWTSecret).Code)
+}
@@ -0,0 +1,172 @@
+package derivationexport
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+)
+
+var (
+	// ErrNotFound is returned when there is no derivation record for the given key.
+	ErrNotFound = errors.New("derivation record not found")
+
+	batchPrefix   = []byte("b") // batchPrefix + batchID -> record
+	blockPrefix   = []byte("k") // blockPrefix + last block ID of the batch -> batchID
+	l1BlockPrefix = []byte("l") // l1BlockPrefix + L1 height + batchID -> batchID
+)
+
+const (
+	storeCache   = 16 // MB
+	storeHandles = 16
+)
+
+// Store persists the derivation records of the batches, indexed by batch ID, L2 block ID and
+// proposing L1 block height.
+type Store struct {
+	db    ethdb.KeyValueStore
+	mutex sync.Mutex
+}
+
+// OpenStore opens (or creates) a LevelDB backed store in the given directory.
+func OpenStore(dir string) (*Store, error) {
+	db, err := leveldb.New(dir, storeCache, storeHandles, "derivation/", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open derivation store: %w", err)
+	}
+
+	return NewStore(db), nil
+}
+
+// NewStore creates a new store on top of the given key-value database.
+func NewStore(db ethdb.KeyValueStore) *Store {
+	return &Store{db: db}
+}
+
+// Put saves the given record, replacing the record previously saved for the same batch, for
+// example when the batch is derived again after an L1 reorg.
+func (s *Store) Put(r *Record) error {
+	value, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	batch := s.db.NewBatch()
+
+	old, err := s.GetByBatchID(r.BatchID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if old != nil {
+		if err := batch.Delete(blockKey(old.LastBlockID())); err != nil {
+			return err
+		}
+		if err := batch.Delete(l1BlockKey(old.L1Height, old.BatchID)); err != nil {
+			return err
+		}
+	}
+
+	if err := batch.Put(batchKey(r.BatchID), value); err != nil {
+		return err
+	}
+	if err := batch.Put(blockKey(r.LastBlockID()), encodeUint64(r.BatchID)); err != nil {
+		return err
+	}
+	if err := batch.Put(l1BlockKey(r.L1Height, r.BatchID), encodeUint64(r.BatchID)); err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
+// GetByBatchID returns the record of the given batch.
+func (s *Store) GetByBatchID(batchID uint64) (*Record, error) {
+	has, err := s.db.Has(batchKey(batchID))
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrNotFound
+	}
+
+	value, err := s.db.Get(batchKey(batchID))
+	if err != nil {
+		return nil, err
+	}
+
+	var r Record
+	if err := json.Unmarshal(value, &r); err != nil {
+		return nil, fmt.Errorf("failed to decode derivation record of batch %d: %w", batchID, err)
+	}
+
+	return &r, nil
+}
+
+// GetByBlockID returns the record of the batch which contains the given L2 block.
+func (s *Store) GetByBlockID(blockID uint64) (*Record, error) {
+	// The first batch whose last block is not before the given block.
+	it := s.db.NewIterator(blockPrefix, encodeUint64(blockID))
+	defer it.Release()
+
+	if !it.Next() {
+		return nil, ErrNotFound
+	}
+
+	r, err := s.GetByBatchID(binary.BigEndian.Uint64(it.Value()))
+	if err != nil {
+		return nil, err
+	}
+
+	if r.FirstBlockID() > blockID {
+		return nil, ErrNotFound
+	}
+
+	return r, nil
+}
+
+// GetByL1Height returns the records of the batches proposed in the given L1 block.
+func (s *Store) GetByL1Height(l1Height uint64) ([]*Record, error) {
+	it := s.db.NewIterator(append(append([]byte{}, l1BlockPrefix...), encodeUint64(l1Height)...), nil)
+	defer it.Release()
+
+	var records []*Record
+	for it.Next() {
+		r, err := s.GetByBatchID(binary.BigEndian.Uint64(it.Value()))
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, r)
+	}
+
+	return records, it.Error()
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// encodeUint64 encodes the given number as big endian, so that the keys are sorted by number.
+func encodeUint64(n uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, n)
+}
+
+func batchKey(batchID uint64) []byte {
+	return append(append([]byte{}, batchPrefix...), encodeUint64(batchID)...)
+}
+
+func blockKey(blockID uint64) []byte {
+	return append(append([]byte{}, blockPrefix...), encodeUint64(blockID)...)
+}
+
+func l1BlockKey(l1Height, batchID uint64) []byte {
+	return append(append(append([]byte{}, l1BlockPrefix...), encodeUint64(l1Height)...), encodeUint64(batchID)...)
+}
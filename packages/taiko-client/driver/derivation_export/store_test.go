@@ -0,0 +1,104 @@
+package derivationexport
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRecord(t *testing.T, batchID, l1Height, firstBlockID, lastBlockID uint64) *Record {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+
+	r := &Record{BatchID: batchID, L1Height: l1Height, L1Hash: common.BigToHash(new(big.Int).SetUint64(l1Height))}
+	for id := firstBlockID; id <= lastBlockID; id++ {
+		anchorTx, err := types.SignNewTx(key, types.LatestSignerForChainID(common.Big1), &types.DynamicFeeTx{
+			ChainID:   common.Big1,
+			Nonce:     id,
+			GasTipCap: common.Big0,
+			GasFeeCap: common.Big1,
+			Gas:       1_000_000,
+			Value:     common.Big0,
+		})
+		require.Nil(t, err)
+
+		r.Blocks = append(r.Blocks, &BlockRecord{
+			BlockID:      id,
+			BaseFee:      (*hexutil.Big)(common.Big1),
+			AnchorTx:     anchorTx,
+			Transactions: types.Transactions{},
+		})
+	}
+
+	return r
+}
+
+func TestStore(t *testing.T) {
+	s := NewStore(memorydb.New())
+	defer s.Close()
+
+	require.Nil(t, s.Put(newTestRecord(t, 1, 100, 1, 3)))
+	require.Nil(t, s.Put(newTestRecord(t, 2, 100, 4, 4)))
+	require.Nil(t, s.Put(newTestRecord(t, 3, 102, 5, 8)))
+
+	// By batch ID.
+	r, err := s.GetByBatchID(2)
+	require.Nil(t, err)
+	require.Equal(t, uint64(4), r.FirstBlockID())
+	require.Equal(t, uint64(4), r.Blocks[0].AnchorTx.Nonce())
+
+	_, err = s.GetByBatchID(4)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// By block ID.
+	for blockID, batchID := range map[uint64]uint64{1: 1, 3: 1, 4: 2, 5: 3, 7: 3, 8: 3} {
+		r, err := s.GetByBlockID(blockID)
+		require.Nil(t, err)
+		require.Equal(t, batchID, r.BatchID)
+	}
+
+	_, err = s.GetByBlockID(9)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// By L1 height.
+	records, err := s.GetByL1Height(100)
+	require.Nil(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, uint64(1), records[0].BatchID)
+	require.Equal(t, uint64(2), records[1].BatchID)
+
+	records, err = s.GetByL1Height(101)
+	require.Nil(t, err)
+	require.Empty(t, records)
+}
+
+func TestStoreReplace(t *testing.T) {
+	s := NewStore(memorydb.New())
+	defer s.Close()
+
+	require.Nil(t, s.Put(newTestRecord(t, 1, 100, 1, 4)))
+
+	// The batch is derived again after an L1 reorg, with less blocks.
+	require.Nil(t, s.Put(newTestRecord(t, 1, 101, 1, 2)))
+
+	records, err := s.GetByL1Height(100)
+	require.Nil(t, err)
+	require.Empty(t, records)
+
+	records, err = s.GetByL1Height(101)
+	require.Nil(t, err)
+	require.Len(t, records, 1)
+
+	r, err := s.GetByBlockID(2)
+	require.Nil(t, err)
+	require.Equal(t, uint64(101), r.L1Height)
+
+	_, err = s.GetByBlockID(3)
+	require.ErrorIs(t, err, ErrNotFound)
+}
@@ -2,7 +2,10 @@ package driver
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
+	"net/http"
 	"sync"
 	"time"
 
@@ -18,7 +21,10 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
 
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/cmd/flags"
 	chainSyncer "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/chain_syncer"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/chain_syncer/beaconsync"
+	derivationExport "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/derivation_export"
 	preconfBlocks "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/preconf_blocks"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/state"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/metrics"
@@ -38,6 +44,8 @@ type Driver struct {
 	rpc                *rpc.Client
 	l2ChainSyncer      *chainSyncer.L2ChainSyncer
 	preconfBlockServer *preconfBlocks.PreconfBlockAPIServer
+	exportStore        *derivationExport.Store
+	exportServer       *derivationExport.Server
 	state              *state.State
 	chainConfig        *config.ChainConfig
 	protocolConfig     config.ProtocolConfigs
@@ -50,6 +58,8 @@ type Driver struct {
 	p2pSigner p2p.Signer
 	p2pSetup  p2p.SetupP2P
 
+	reloadCh chan func(*Config)
+
 	ctx context.Context
 	wg  sync.WaitGroup
 }
@@ -67,6 +77,7 @@ func (d *Driver) InitFromCli(ctx context.Context, c *cli.Context) error {
 // InitFromConfig initializes the driver instance based on the given configurations.
 func (d *Driver) InitFromConfig(ctx context.Context, cfg *Config) (err error) {
 	d.l1HeadCh = make(chan *types.Header, 1024)
+	d.reloadCh = make(chan func(*Config))
 	d.ctx = ctx
 	d.Config = cfg
 
@@ -74,7 +85,7 @@ func (d *Driver) InitFromConfig(ctx context.Context, cfg *Config) (err error) {
 		return err
 	}
 
-	if d.state, err = state.New(d.ctx, d.rpc); err != nil {
+	if d.state, err = state.New(d.ctx, d.rpc, cfg.L1Finality); err != nil {
 		return err
 	}
 
@@ -87,6 +98,18 @@ func (d *Driver) InitFromConfig(ctx context.Context, cfg *Config) (err error) {
 		log.Warn("P2P syncing verified blocks enabled, but no connected peer found in L2 execution engine")
 	}
 
+	if cfg.DerivationExportDataDir != "" {
+		if d.exportStore, err = derivationExport.OpenStore(cfg.DerivationExportDataDir); err != nil {
+			return err
+		}
+
+		if cfg.DerivationExportServerPort > 0 {
+			if d.exportServer, err = derivationExport.NewServer(d.exportStore, cfg.DerivationExportJWTSecret); err != nil {
+				return err
+			}
+		}
+	}
+
 	if d.l2ChainSyncer, err = chainSyncer.New(
 		d.ctx,
 		d.rpc,
@@ -94,12 +117,24 @@ func (d *Driver) InitFromConfig(ctx context.Context, cfg *Config) (err error) {
 		cfg.P2PSync,
 		cfg.P2PSyncTimeout,
 		cfg.MaxExponent,
+		cfg.PrefetchDepth,
 		cfg.BlobServerEndpoint,
 		cfg.SocialScanEndpoint,
+		d.exportStore,
 	); err != nil {
 		return err
 	}
 
+	// Refuse to start if the checkpoint nodes serve a chain which is not the one verified on L1, other
+	// errors are retried when syncing.
+	if err := d.l2ChainSyncer.VerifyCheckpoint(d.ctx); err != nil {
+		if errors.Is(err, beaconsync.ErrCheckpointMismatch) {
+			return err
+		}
+
+		log.Warn("Failed to verify checkpoint block", "error", err)
+	}
+
 	d.l1HeadSub = d.state.SubL1HeadsFeed(d.l1HeadCh)
 	d.chainConfig = config.NewChainConfig(
 		d.rpc.L2.ChainID,
@@ -168,6 +203,15 @@ func (d *Driver) Start() error {
 		}()
 	}
 
+	// Start the derivation records server if it is enabled.
+	if d.exportServer != nil {
+		go func() {
+			if err := d.exportServer.Start(d.DerivationExportServerPort); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Crit("Failed to start derivation export server", "error", err)
+			}
+		}()
+	}
+
 	if d.p2pNode != nil && d.p2pNode.Dv5Udp() != nil {
 		go d.p2pNode.DiscoveryProcess(
 			d.ctx,
@@ -190,7 +234,18 @@ func (d *Driver) Close(_ context.Context) {
 			log.Error("Failed to shutdown preconfirmation block server", "error", err)
 		}
 	}
+	// Close the derivation records server and store if they are enabled.
+	if d.exportServer != nil {
+		if err := d.exportServer.Shutdown(d.ctx); err != nil {
+			log.Error("Failed to shutdown derivation export server", "error", err)
+		}
+	}
 	d.wg.Wait()
+	if d.exportStore != nil {
+		if err := d.exportStore.Close(); err != nil {
+			log.Error("Failed to close derivation export store", "error", err)
+		}
+	}
 }
 
 // eventLoop starts the main loop of a L2 execution engine's driver.
@@ -229,10 +284,35 @@ func (d *Driver) eventLoop() {
 			doSyncWithBackoff()
 		case <-d.l1HeadCh:
 			reqSync()
+		case reload := <-d.reloadCh:
+			reload(d.Config)
 		}
 	}
 }
 
+// ReloadableFlags implements the ReloadableApplication interface.
+func (d *Driver) ReloadableFlags() []cli.Flag {
+	return []cli.Flag{
+		flags.BackOffRetryInterval,
+	}
+}
+
+// Reload implements the ReloadableApplication interface, the new retry interval is applied from the next
+// synchronising operation.
+func (d *Driver) Reload(c *cli.Context) error {
+	retryInterval := c.Duration(flags.BackOffRetryInterval.Name)
+	if retryInterval <= 0 {
+		return fmt.Errorf("invalid backoff retry interval: %s", retryInterval)
+	}
+
+	select {
+	case <-d.ctx.Done():
+		return d.ctx.Err()
+	case d.reloadCh <- func(cfg *Config) { cfg.RetryInterval = retryInterval }:
+		return nil
+	}
+}
+
 // doSync fetches all `BlockProposed` events emitted from local
 // L1 sync cursor to the L1 head, and then applies all corresponding
 // L2 blocks into node's local blockchain.
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"compress/zlib"
 	"context"
+	"flag"
 	"fmt"
 	"math/big"
 	"net/url"
@@ -22,9 +23,11 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/go-resty/resty/v2"
 	"github.com/stretchr/testify/suite"
+	"github.com/urfave/cli/v2"
 
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/encoding"
 	pacayaBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/cmd/flags"
 	preconfblocks "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/preconf_blocks"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/testutils"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/jwt"
@@ -298,6 +301,24 @@ func (s *DriverTestSuite) TestStartClose() {
 	s.d.Close(s.d.ctx)
 }
 
+func (s *DriverTestSuite) TestReload() {
+	reload := func(retryInterval string) error {
+		set := flag.NewFlagSet("driver", flag.ContinueOnError)
+		s.Nil(flags.BackOffRetryInterval.Apply(set))
+		s.Nil(set.Set(flags.BackOffRetryInterval.Name, retryInterval))
+		return s.d.Reload(cli.NewContext(nil, set, nil))
+	}
+
+	s.Nil(s.d.Start())
+	s.NotNil(reload("0s"))
+	s.Nil(reload("3s"))
+
+	s.cancel()
+	s.d.Close(s.d.ctx)
+	s.Equal(3*time.Second, s.d.RetryInterval)
+	s.ErrorIs(reload("5s"), context.Canceled)
+}
+
 func (s *DriverTestSuite) TestL1Current() {
 	// propose and insert a block
 	s.ProposeAndInsertEmptyBlocks(s.p, s.d.ChainSyncer().BlobSyncer())
@@ -0,0 +1,77 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/cmd/flags"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
+)
+
+// Config contains the configurations to initialize a derivation replayer.
+type Config struct {
+	*rpc.ClientConfig
+	BlobServerEndpoint *url.URL
+	SocialScanEndpoint *url.URL
+	L1Start            uint64
+	L1End              uint64
+}
+
+// NewConfigFromCliContext creates a new config instance from
+// the command line inputs.
+func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
+	var (
+		l1Start = c.Uint64(flags.ReplayL1Start.Name)
+		l1End   = c.Uint64(flags.ReplayL1End.Name)
+		err     error
+	)
+	if l1End < l1Start {
+		return nil, fmt.Errorf("invalid L1 range: start %d is after end %d", l1Start, l1End)
+	}
+
+	var beaconEndpoint string
+	if c.IsSet(flags.L1BeaconEndpoint.Name) {
+		beaconEndpoint = c.String(flags.L1BeaconEndpoint.Name)
+	}
+
+	var blobServerEndpoint *url.URL
+	if c.IsSet(flags.BlobServerEndpoint.Name) {
+		if blobServerEndpoint, err = url.Parse(
+			c.String(flags.BlobServerEndpoint.Name),
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	var socialScanEndpoint *url.URL
+	if c.IsSet(flags.SocialScanEndpoint.Name) {
+		if socialScanEndpoint, err = url.Parse(
+			c.String(flags.SocialScanEndpoint.Name),
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if beaconEndpoint == "" && blobServerEndpoint == nil && socialScanEndpoint == nil {
+		return nil, errors.New("empty L1 beacon endpoint, blob server and Social Scan endpoint")
+	}
+
+	return &Config{
+		ClientConfig: &rpc.ClientConfig{
+			L1Endpoint:       c.String(flags.L1WSEndpoint.Name),
+			L1BeaconEndpoint: beaconEndpoint,
+			L2Endpoint:       c.String(flags.L2WSEndpoint.Name),
+			TaikoL1Address:   common.HexToAddress(c.String(flags.TaikoL1Address.Name)),
+			TaikoL2Address:   common.HexToAddress(c.String(flags.TaikoL2Address.Name)),
+			Timeout:          c.Duration(flags.RPCTimeout.Name),
+		},
+		BlobServerEndpoint: blobServerEndpoint,
+		SocialScanEndpoint: socialScanEndpoint,
+		L1Start:            l1Start,
+		L1End:              l1End,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package replay
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	blocksInserter "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/chain_syncer/blob/blocks_inserter"
+)
+
+// Divergence describes the first difference found between a derived block and the existing L2 block.
+type Divergence struct {
+	L1Height *big.Int
+	BlockID  *big.Int
+	Field    string
+	Derived  string
+	Existing string
+}
+
+// String implements the fmt.Stringer interface.
+func (d *Divergence) String() string {
+	return fmt.Sprintf(
+		"block %v (proposed in L1 block %v): %s differs, derived %s, existing %s",
+		d.BlockID,
+		d.L1Height,
+		d.Field,
+		d.Derived,
+		d.Existing,
+	)
+}
+
+// droppedTxChecker reports whether the execution engine could have dropped the given derived
+// transaction as invalid when it built the existing L2 block, at the given index of its transactions.
+type droppedTxChecker func(tx *types.Transaction, index int) (bool, error)
+
+// diffBlock compares the derived block with the existing L2 block, it returns nil if they match.
+// The execution engine drops the invalid transactions of a proposal, so the existing transactions
+// list only needs to be an ordered subset of the derived one, as long as every derived transaction
+// missing from the existing block can have been dropped as invalid.
+func diffBlock(
+	derived *blocksInserter.DerivedBlock,
+	existing *types.Block,
+	canDrop droppedTxChecker,
+) (*Divergence, error) {
+	divergence := func(field string, derivedValue, existingValue interface{}) *Divergence {
+		return &Divergence{
+			BlockID:  derived.ID,
+			Field:    field,
+			Derived:  fmt.Sprintf("%v", derivedValue),
+			Existing: fmt.Sprintf("%v", existingValue),
+		}
+	}
+
+	if existing == nil {
+		return divergence("block", "present", "missing"), nil
+	}
+
+	if derived.Timestamp != existing.Time() {
+		return divergence("timestamp", derived.Timestamp, existing.Time()), nil
+	}
+
+	if existing.BaseFee() == nil || derived.BaseFee.Cmp(existing.BaseFee()) != 0 {
+		return divergence("baseFee", derived.BaseFee, existing.BaseFee()), nil
+	}
+
+	txs := existing.Transactions()
+	if len(txs) == 0 {
+		return divergence("anchor", derived.AnchorTx.Hash(), "none"), nil
+	}
+
+	if derived.AnchorTx.Hash() != txs[0].Hash() {
+		return divergence("anchor", derived.AnchorTx.Hash(), txs[0].Hash()), nil
+	}
+
+	// checkDropped checks that the derived transactions skipped before the existing transaction at the
+	// given index have been dropped as invalid.
+	checkDropped := func(skipped types.Transactions, index int) (*Divergence, error) {
+		for _, tx := range skipped {
+			dropped, err := canDrop(tx, index)
+			if err != nil {
+				return nil, err
+			}
+
+			if dropped {
+				continue
+			}
+
+			if index < len(txs) {
+				return divergence(fmt.Sprintf("transactions[%d]", index), tx.Hash(), txs[index].Hash()), nil
+			}
+
+			return divergence(fmt.Sprintf("transactions[%d]", index), tx.Hash(), "none"), nil
+		}
+
+		return nil, nil
+	}
+
+	cursor := 0
+	for i, tx := range txs[1:] {
+		next := cursor
+		for next < len(derived.Txs) && derived.Txs[next].Hash() != tx.Hash() {
+			next++
+		}
+
+		if next == len(derived.Txs) {
+			return divergence(fmt.Sprintf("transactions[%d]", i+1), "none", tx.Hash()), nil
+		}
+
+		if d, err := checkDropped(derived.Txs[cursor:next], i+1); d != nil || err != nil {
+			return d, err
+		}
+
+		cursor = next + 1
+	}
+
+	if d, err := checkDropped(derived.Txs[cursor:], len(txs)); d != nil || err != nil {
+		return d, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,111 @@
+package replay
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	blocksInserter "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/chain_syncer/blob/blocks_inserter"
+)
+
+func newTestTx(nonce uint64) *types.Transaction {
+	return types.NewTx(&types.LegacyTx{Nonce: nonce, GasPrice: common.Big1, Gas: 21_000})
+}
+
+func newTestBlock(timestamp uint64, baseFee *big.Int, txs ...*types.Transaction) *types.Block {
+	return types.NewBlockWithHeader(&types.Header{
+		Number:  common.Big1,
+		Time:    timestamp,
+		BaseFee: baseFee,
+	}).WithBody(types.Body{Transactions: txs})
+}
+
+// dropTxs returns a checker which reports the given transactions as droppable.
+func dropTxs(txs ...*types.Transaction) droppedTxChecker {
+	return func(tx *types.Transaction, _ int) (bool, error) {
+		for _, dropped := range txs {
+			if dropped.Hash() == tx.Hash() {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+func TestDiffBlock(t *testing.T) {
+	var (
+		anchorTx = newTestTx(0)
+		txs      = types.Transactions{newTestTx(1), newTestTx(2), newTestTx(3)}
+		derived  = &blocksInserter.DerivedBlock{
+			ID:        common.Big1,
+			Timestamp: 100,
+			BaseFee:   big.NewInt(10),
+			AnchorTx:  anchorTx,
+			Txs:       txs,
+		}
+		diff = func(existing *types.Block, canDrop droppedTxChecker) *Divergence {
+			divergence, err := diffBlock(derived, existing, canDrop)
+			require.Nil(t, err)
+			return divergence
+		}
+	)
+
+	// Matching blocks.
+	require.Nil(t, diff(newTestBlock(100, big.NewInt(10), anchorTx, txs[0], txs[1], txs[2]), dropTxs()))
+
+	// The execution engine dropped invalid transactions.
+	require.Nil(t, diff(newTestBlock(100, big.NewInt(10), anchorTx, txs[0], txs[2]), dropTxs(txs[1])))
+	require.Nil(t, diff(newTestBlock(100, big.NewInt(10), anchorTx), dropTxs(txs...)))
+
+	// Missing block.
+	require.Equal(t, "block", diff(nil, dropTxs()).Field)
+
+	// Timestamp mismatch.
+	require.Equal(t, "timestamp", diff(newTestBlock(101, big.NewInt(10), anchorTx), dropTxs()).Field)
+
+	// Base fee mismatch.
+	require.Equal(t, "baseFee", diff(newTestBlock(100, big.NewInt(11), anchorTx), dropTxs()).Field)
+	require.Equal(t, "baseFee", diff(newTestBlock(100, nil, anchorTx), dropTxs()).Field)
+
+	// Anchor transaction mismatch.
+	require.Equal(t, "anchor", diff(newTestBlock(100, big.NewInt(10)), dropTxs()).Field)
+	require.Equal(t, "anchor", diff(newTestBlock(100, big.NewInt(10), txs[0]), dropTxs()).Field)
+
+	// Transactions list mismatch.
+	divergence := diff(newTestBlock(100, big.NewInt(10), anchorTx, txs[1], txs[0]), dropTxs(txs...))
+	require.NotNil(t, divergence)
+	require.Equal(t, "transactions[2]", divergence.Field)
+	require.Equal(t, txs[0].Hash().String(), divergence.Existing)
+
+	divergence = diff(newTestBlock(100, big.NewInt(10), anchorTx, txs[0], newTestTx(4)), dropTxs(txs...))
+	require.NotNil(t, divergence)
+	require.Equal(t, "transactions[2]", divergence.Field)
+
+	// Derived transactions missing from the existing block, which can't have been dropped.
+	divergence = diff(newTestBlock(100, big.NewInt(10), anchorTx), dropTxs())
+	require.NotNil(t, divergence)
+	require.Equal(t, "transactions[1]", divergence.Field)
+	require.Equal(t, txs[0].Hash().String(), divergence.Derived)
+	require.Equal(t, "none", divergence.Existing)
+
+	divergence = diff(newTestBlock(100, big.NewInt(10), anchorTx, txs[0], txs[2]), dropTxs())
+	require.NotNil(t, divergence)
+	require.Equal(t, "transactions[2]", divergence.Field)
+	require.Equal(t, txs[1].Hash().String(), divergence.Derived)
+	require.Equal(t, txs[2].Hash().String(), divergence.Existing)
+
+	divergence = diff(newTestBlock(100, big.NewInt(10), anchorTx, txs[0], txs[1]), dropTxs())
+	require.NotNil(t, divergence)
+	require.Equal(t, "transactions[3]", divergence.Field)
+	require.Equal(t, txs[2].Hash().String(), divergence.Derived)
+
+	// The checker fails.
+	_, err := diffBlock(derived, newTestBlock(100, big.NewInt(10), anchorTx), func(*types.Transaction, int) (bool, error) {
+		return false, errors.New("checker failed")
+	})
+	require.ErrorContains(t, err, "checker failed")
+}
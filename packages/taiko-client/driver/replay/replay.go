@@ -0,0 +1,353 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
+	anchorTxConstructor "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/anchor_tx_constructor"
+	blocksInserter "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/chain_syncer/blob/blocks_inserter"
+	txListDecompressor "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/txlist_decompressor"
+	txlistFetcher "github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/txlist_fetcher"
+	eventIterator "github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/chain_iterator/event_iterator"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
+)
+
+// ErrDivergence is returned when a block derived from L1 differs from the existing L2 block.
+var ErrDivergence = errors.New("derived L2 block diverges from the existing one")
+
+// Replayer replays the driver's derivation of the proposals in an L1 block range, without inserting
+// anything into the L2 execution engine, and compares the derived blocks with the existing L2 blocks.
+type Replayer struct {
+	cfg *Config
+	rpc *rpc.Client
+
+	// The blocks inserters of the chain syncer, only used to derive the blocks without inserting them.
+	blocksInserterOntake *blocksInserter.BlocksInserterOntake
+	blocksInserterPacaya *blocksInserter.BlocksInserterPacaya
+
+	proposals  uint64
+	blocks     uint64
+	divergence *Divergence
+}
+
+// InitFromCli initializes the given replayer instance based on the command line flags.
+func (r *Replayer) InitFromCli(ctx context.Context, c *cli.Context) error {
+	cfg, err := NewConfigFromCliContext(c)
+	if err != nil {
+		return err
+	}
+
+	return r.InitFromConfig(ctx, cfg)
+}
+
+// InitFromConfig initializes the replayer instance based on the given configurations.
+func (r *Replayer) InitFromConfig(ctx context.Context, cfg *Config) (err error) {
+	r.cfg = cfg
+
+	if r.rpc, err = rpc.NewClient(ctx, cfg.ClientConfig); err != nil {
+		return err
+	}
+
+	anchorConstructor, err := anchorTxConstructor.New(r.rpc)
+	if err != nil {
+		return fmt.Errorf("failed to initialize anchor constructor: %w", err)
+	}
+
+	protocolConfigs, err := r.rpc.GetProtocolConfigs(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return err
+	}
+
+	var (
+		blobDataSource = rpc.NewBlobDataSource(ctx, r.rpc, cfg.BlobServerEndpoint, cfg.SocialScanEndpoint)
+		decompressor   = txListDecompressor.NewTxListDecompressor(
+			uint64(protocolConfigs.BlockMaxGasLimit()),
+			rpc.BlockMaxTxListBytes,
+			r.rpc.L2.ChainID,
+		)
+		txListFetcherBlob     = txlistFetcher.NewBlobTxListFetcher(r.rpc.L1Beacon, blobDataSource)
+		txListFetcherCalldata = txlistFetcher.NewCalldataFetch(r.rpc)
+	)
+
+	r.blocksInserterOntake = blocksInserter.NewBlocksInserterOntake(
+		r.rpc,
+		nil,
+		blobDataSource,
+		decompressor,
+		anchorConstructor,
+		txListFetcherCalldata,
+		txListFetcherBlob,
+		nil,
+	)
+	r.blocksInserterPacaya = blocksInserter.NewBlocksInserterPacaya(
+		r.rpc,
+		nil,
+		blobDataSource,
+		decompressor,
+		anchorConstructor,
+		txListFetcherCalldata,
+		txListFetcherBlob,
+		nil,
+		nil,
+	)
+
+	return nil
+}
+
+// Name returns the application name.
+func (r *Replayer) Name() string {
+	return "replay"
+}
+
+// Run replays all proposals in the configured L1 block range, it returns ErrDivergence once the first
+// derived block which differs from the existing L2 block is found.
+func (r *Replayer) Run(ctx context.Context) error {
+	iter, err := eventIterator.NewBlockProposedIterator(ctx, &eventIterator.BlockProposedIteratorConfig{
+		Client:               r.rpc.L1,
+		TaikoL1:              r.rpc.OntakeClients.TaikoL1,
+		TaikoInbox:           r.rpc.PacayaClients.TaikoInbox,
+		StartHeight:          new(big.Int).SetUint64(r.cfg.L1Start),
+		EndHeight:            new(big.Int).SetUint64(r.cfg.L1End),
+		OnBlockProposedEvent: r.onBlockProposed,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := iter.Iter(); err != nil {
+		return err
+	}
+
+	if r.divergence != nil {
+		return fmt.Errorf("%w: %s", ErrDivergence, r.divergence)
+	}
+
+	log.Info(
+		"All derived blocks match the existing L2 blocks",
+		"l1Start", r.cfg.L1Start,
+		"l1End", r.cfg.L1End,
+		"proposals", r.proposals,
+		"blocks", r.blocks,
+	)
+
+	return nil
+}
+
+// onBlockProposed is a `BlockProposed` event callback which derives the blocks of the proposal, and
+// compares them with the existing L2 blocks.
+func (r *Replayer) onBlockProposed(
+	ctx context.Context,
+	meta metadata.TaikoProposalMetaData,
+	endIter eventIterator.EndBlockProposedEventIterFunc,
+) error {
+	// We simply ignore the genesis block's `BlockProposedV2` / `BatchesProposed` event.
+	if (meta.IsPacaya() && meta.Pacaya().GetLastBlockID() == 0) ||
+		(!meta.IsPacaya() && meta.Ontake().GetBlockID().Sign() == 0) {
+		return nil
+	}
+
+	// Fetch the original TaikoL1.proposeBlockV2 / TaikoInbox.proposeBatch transaction.
+	tx, err := r.rpc.L1.TransactionInBlock(ctx, meta.GetRawBlockHash(), meta.GetTxIndex())
+	if err != nil {
+		return fmt.Errorf("failed to fetch original proposing transaction: %w", err)
+	}
+
+	var (
+		blocks     []*blocksInserter.DerivedBlock
+		divergence *Divergence
+	)
+	if meta.IsPacaya() {
+		blocks, divergence, err = r.derivePacaya(ctx, meta, tx)
+	} else {
+		blocks, divergence, err = r.deriveOntake(ctx, meta, tx)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.proposals++
+
+	if divergence != nil {
+		r.onDivergence(meta, divergence, endIter)
+		return nil
+	}
+
+	for _, derived := range blocks {
+		existing, err := r.rpc.L2.BlockByNumber(ctx, derived.ID)
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return fmt.Errorf("failed to fetch L2 block %d: %w", derived.ID, err)
+		}
+
+		divergence, err := diffBlock(derived, existing, r.droppedTxChecker(ctx, existing))
+		if err != nil {
+			return fmt.Errorf("failed to compare L2 block %d: %w", derived.ID, err)
+		}
+
+		if divergence != nil {
+			r.onDivergence(meta, divergence, endIter)
+			return nil
+		}
+
+		r.blocks++
+		log.Debug("Derived block matches the existing L2 block", "blockID", derived.ID, "hash", existing.Hash())
+	}
+
+	log.Info(
+		"Proposal replayed",
+		"l1Height", meta.GetRawBlockHeight(),
+		"blocks", len(blocks),
+		"lastBlockID", blocks[len(blocks)-1].ID,
+	)
+
+	return nil
+}
+
+// onDivergence records the given divergence of a block proposed in the given proposal, and ends the
+// iteration.
+func (r *Replayer) onDivergence(
+	meta metadata.TaikoProposalMetaData,
+	divergence *Divergence,
+	endIter eventIterator.EndBlockProposedEventIterFunc,
+) {
+	divergence.L1Height = meta.GetRawBlockHeight()
+	r.divergence = divergence
+	log.Error("Derived block diverges from the existing L2 block", "divergence", divergence)
+	endIter()
+}
+
+// droppedTxChecker returns a checker which reports whether a derived transaction missing from the given
+// existing L2 block can have been dropped as invalid by the execution engine. Only the state before and
+// after the existing block is available, so a transaction is considered droppable as soon as it could
+// have been invalid at its position in the block.
+func (r *Replayer) droppedTxChecker(ctx context.Context, existing *types.Block) droppedTxChecker {
+	signer := types.LatestSignerForChainID(r.rpc.L2.ChainID)
+
+	return func(tx *types.Transaction, index int) (bool, error) {
+		sender, err := types.Sender(signer, tx)
+		if err != nil {
+			return true, nil
+		}
+
+		if tx.Gas() > existing.GasLimit()-existing.GasUsed() || tx.GasFeeCap().Cmp(existing.BaseFee()) < 0 {
+			return true, nil
+		}
+
+		// At its position, the transaction must use the sender's nonce before the block, plus the number
+		// of the sender's transactions included before it.
+		nonce, err := r.rpc.L2.NonceAt(ctx, sender, new(big.Int).Sub(existing.Number(), common.Big1))
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch the nonce of %s: %w", sender, err)
+		}
+		for _, included := range existing.Transactions()[1:index] {
+			if from, err := types.Sender(signer, included); err == nil && from == sender {
+				nonce++
+			}
+		}
+		if tx.Nonce() != nonce {
+			return true, nil
+		}
+
+		// The sender's balance changes within the block, so the transaction is considered droppable if
+		// it costs more than the sender's balance either before or after the block.
+		for _, number := range []*big.Int{new(big.Int).Sub(existing.Number(), common.Big1), existing.Number()} {
+			balance, err := r.rpc.L2.BalanceAt(ctx, sender, number)
+			if err != nil {
+				return false, fmt.Errorf("failed to fetch the balance of %s: %w", sender, err)
+			}
+			if balance.Cmp(tx.Cost()) < 0 {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+}
+
+// parentHeader fetches the existing L2 parent block of the block with the given ID, it returns a
+// divergence if the parent block does not exist.
+func (r *Replayer) parentHeader(ctx context.Context, blockID *big.Int) (*types.Header, *Divergence, error) {
+	parent, err := r.rpc.L2.HeaderByNumber(ctx, new(big.Int).Sub(blockID, common.Big1))
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, &Divergence{
+				BlockID:  blockID,
+				Field:    "parent",
+				Derived:  "present",
+				Existing: "missing",
+			}, nil
+		}
+
+		return nil, nil, fmt.Errorf("failed to fetch L2 parent block: %w", err)
+	}
+
+	return parent, nil, nil
+}
+
+// deriveOntake derives the block of an Ontake proposal on top of the existing L2 parent block, as the
+// chain syncer would.
+func (r *Replayer) deriveOntake(
+	ctx context.Context,
+	meta metadata.TaikoProposalMetaData,
+	tx *types.Transaction,
+) ([]*blocksInserter.DerivedBlock, *Divergence, error) {
+	txs, err := r.blocksInserterOntake.FetchTxList(ctx, meta, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parent, divergence, err := r.parentHeader(ctx, meta.Ontake().GetBlockID())
+	if err != nil || divergence != nil {
+		return nil, divergence, err
+	}
+
+	block, err := r.blocksInserterOntake.DeriveBlock(ctx, meta.Ontake(), parent, txs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []*blocksInserter.DerivedBlock{block}, nil, nil
+}
+
+// derivePacaya derives the blocks of a Pacaya batch on top of the existing L2 parent blocks, as the
+// chain syncer would.
+func (r *Replayer) derivePacaya(
+	ctx context.Context,
+	meta metadata.TaikoProposalMetaData,
+	tx *types.Transaction,
+) ([]*blocksInserter.DerivedBlock, *Divergence, error) {
+	allTxs, err := r.blocksInserterPacaya.FetchTxList(ctx, meta, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		batch        = meta.Pacaya()
+		firstBlockID = batch.GetLastBlockID() + 1 - uint64(len(batch.GetBlocks()))
+		blocks       = make([]*blocksInserter.DerivedBlock, 0, len(batch.GetBlocks()))
+	)
+	for j := range batch.GetBlocks() {
+		parent, divergence, err := r.parentHeader(ctx, new(big.Int).SetUint64(firstBlockID+uint64(j)))
+		if err != nil || divergence != nil {
+			return nil, divergence, err
+		}
+
+		block, err := r.blocksInserterPacaya.DeriveBlock(ctx, batch, j, parent, allTxs)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil, nil
+}
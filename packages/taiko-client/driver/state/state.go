@@ -23,7 +23,7 @@ type State struct {
 	// Feeds
 	l1HeadsFeed event.Feed // L1 new heads notification feed
 
-	l1Head    atomic.Value // Latest known L1 head
+	l1Head    atomic.Value // Latest known L1 head, under the L1 finality mode
 	l2Head    atomic.Value // Current L2 execution engine's local chain head
 	l1Current atomic.Value // Current L1 block sync cursor
 
@@ -32,15 +32,18 @@ type State struct {
 	OnTakeForkHeight *big.Int
 	PacayaForkHeight *big.Int
 
+	// L1 finality mode, which decides the L1 head used for derivation
+	l1Finality *rpc.L1FinalityMode
+
 	// RPC clients
 	rpc *rpc.Client
 
 	wg sync.WaitGroup
 }
 
-// New creates a new driver state instance.
-func New(ctx context.Context, rpc *rpc.Client) (*State, error) {
-	s := &State{rpc: rpc}
+// New creates a new driver state instance, a nil L1 finality mode follows the L1 head.
+func New(ctx context.Context, rpc *rpc.Client, l1Finality *rpc.L1FinalityMode) (*State, error) {
+	s := &State{rpc: rpc, l1Finality: l1Finality}
 
 	if err := s.init(ctx); err != nil {
 		return nil, err
@@ -76,7 +79,8 @@ func (s *State) init(ctx context.Context) error {
 	s.l1Current.Store(latestL2KnownL1Header)
 
 	// L1 head
-	l1Head, err := s.rpc.L1.HeaderByNumber(ctx, nil)
+	log.Info("L1 finality mode", "mode", s.l1Finality)
+	l1Head, err := s.l1Finality.Header(ctx, s.rpc.L1)
 	if err != nil {
 		return err
 	}
@@ -162,14 +166,33 @@ func (s *State) eventLoop(ctx context.Context) {
 				"lastVerifiedBlockHash", common.Hash(e.BlockHash),
 			)
 		case newHead := <-l1HeadCh:
-			s.setL1Head(newHead)
-			s.l1HeadsFeed.Send(newHead)
+			s.onL1Head(ctx, newHead)
 		case newHead := <-l2HeadCh:
 			s.setL2Head(newHead)
 		}
 	}
 }
 
+// onL1Head updates the L1 head used for derivation when a new L1 head is received, and notifies
+// the subscribers if it changed.
+func (s *State) onL1Head(ctx context.Context, newHead *types.Header) {
+	if !s.l1Finality.IsHead() {
+		finalHead, err := s.l1Finality.Header(ctx, s.rpc.L1)
+		if err != nil {
+			log.Warn("Failed to fetch the final L1 head", "mode", s.l1Finality, "error", err)
+			return
+		}
+
+		if finalHead.Hash() == s.GetL1Head().Hash() {
+			return
+		}
+		newHead = finalHead
+	}
+
+	s.setL1Head(newHead)
+	s.l1HeadsFeed.Send(newHead)
+}
+
 // setL1Head sets the L1 head concurrent safely.
 func (s *State) setL1Head(l1Head *types.Header) {
 	if l1Head == nil {
@@ -188,6 +211,11 @@ func (s *State) GetL1Head() *types.Header {
 	return s.l1Head.Load().(*types.Header)
 }
 
+// L1Finality returns the L1 finality mode used for derivation.
+func (s *State) L1Finality() *rpc.L1FinalityMode {
+	return s.l1Finality
+}
+
 // setL2Head sets the L2 head concurrent safely.
 func (s *State) setL2Head(l2Head *types.Header) {
 	if l2Head == nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/testutils"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/utils"
 )
 
@@ -22,7 +23,7 @@ type DriverStateTestSuite struct {
 func (s *DriverStateTestSuite) SetupTest() {
 	s.ClientTestSuite.SetupTest()
 	s.ctx, s.cancel = context.WithCancel(context.Background())
-	state, err := New(s.ctx, s.RPCClient)
+	state, err := New(s.ctx, s.RPCClient, nil)
 	s.Nil(err)
 	s.s = state
 }
@@ -38,6 +39,18 @@ func (s *DriverStateTestSuite) TestGetL1Head() {
 	s.NotNil(l1Head)
 }
 
+func (s *DriverStateTestSuite) TestOnL1HeadWithL1Finality() {
+	state, err := New(s.ctx, s.RPCClient, &rpc.L1FinalityMode{Confirmations: 1})
+	s.Nil(err)
+
+	l1Head, err := s.RPCClient.L1.HeaderByNumber(s.ctx, nil)
+	s.Nil(err)
+	s.Less(state.GetL1Head().Number.Uint64(), l1Head.Number.Uint64())
+
+	state.onL1Head(s.ctx, l1Head)
+	s.GreaterOrEqual(state.GetL1Head().Number.Uint64(), l1Head.Number.Uint64()-1)
+}
+
 func (s *DriverStateTestSuite) TestClose() {
 	s.cancel()
 	s.NotPanics(s.s.Close)
@@ -59,7 +72,7 @@ func (s *DriverStateTestSuite) TestSubL1HeadsFeed() {
 func (s *DriverStateTestSuite) TestNewDriverContextErr() {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
-	state, err := New(ctx, s.RPCClient)
+	state, err := New(ctx, s.RPCClient, nil)
 	s.Nil(state)
 	s.ErrorContains(err, "context canceled")
 }
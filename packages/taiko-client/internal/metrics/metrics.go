@@ -25,6 +25,9 @@ var (
 	DriverL1CurrentHeightGauge  = factory.NewGauge(prometheus.GaugeOpts{Name: "driver_l1Current_height"})
 	DriverL2HeadIDGauge         = factory.NewGauge(prometheus.GaugeOpts{Name: "driver_l2Head_id"})
 	DriverL2VerifiedHeightGauge = factory.NewGauge(prometheus.GaugeOpts{Name: "driver_l2Verified_id"})
+	DriverPrefetchDepthGauge    = factory.NewGauge(prometheus.GaugeOpts{Name: "driver_prefetch_depth"})
+	DriverPrefetchStallCounter  = factory.NewCounter(prometheus.CounterOpts{Name: "driver_prefetch_stall_seconds"})
+	DriverPrefetchFailedCounter = factory.NewCounter(prometheus.CounterOpts{Name: "driver_prefetch_failed"})
 
 	// Proposer
 	ProposerProposeEpochCounter    = factory.NewCounter(prometheus.CounterOpts{Name: "proposer_epoch"})
@@ -56,6 +59,9 @@ var (
 	ProverAggregationSubmissionErrorCounter = factory.NewCounter(prometheus.CounterOpts{
 		Name: "prover_proof_aggregation_submission_error",
 	})
+	ProverInvalidTransitionDetectedCounter = factory.NewCounter(prometheus.CounterOpts{
+		Name: "prover_invalid_transition_detected",
+	})
 	ProverSGXAggregationGenerationTime = factory.NewGauge(prometheus.GaugeOpts{
 		Name: "prover_proof_sgx_aggregation_generation_time",
 	})
@@ -95,6 +101,49 @@ var (
 	ProverSubmissionRevertedCounter = factory.NewCounter(prometheus.CounterOpts{
 		Name: "prover_proof_submission_reverted",
 	})
+	ProverGuardianApprovalPendingGauge = factory.NewGauge(prometheus.GaugeOpts{
+		Name: "prover_guardian_approval_pending",
+	})
+	ProverGuardianApprovalDecisionCounter = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "prover_guardian_approval_decision",
+	}, []string{"status"})
+	ProverSchedulerQueuedGauge = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_scheduler_queued",
+	}, []string{"producer"})
+	ProverSchedulerRunningGauge = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "prover_scheduler_running",
+	}, []string{"producer"})
+	ProverSchedulerNextDeadlineGauge = factory.NewGauge(prometheus.GaugeOpts{
+		Name: "prover_scheduler_next_deadline_seconds",
+	})
+	ProverSchedulerHopelessCounter = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "prover_scheduler_hopeless",
+	}, []string{"producer", "state"})
+	ProverPolicyDecisionCounter = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "prover_policy_decision",
+	}, []string{"action", "rule"})
+	// Proving cost accounting, labeled by fork, tier and proof producer, all the amounts are in wei.
+	ProverAccountingProofsCounter = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "prover_accounting_proofs",
+	}, []string{"fork", "tier", "producer"})
+	ProverAccountingVerifiedCounter = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "prover_accounting_verified",
+	}, []string{"fork", "tier", "producer"})
+	ProverAccountingGasFeeCounter = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "prover_accounting_gas_fee",
+	}, []string{"fork", "tier", "producer"})
+	ProverAccountingBlobFeeCounter = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "prover_accounting_blob_fee",
+	}, []string{"fork", "tier", "producer"})
+	ProverAccountingBondLockedCounter = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "prover_accounting_bond_locked",
+	}, []string{"fork", "tier", "producer"})
+	ProverAccountingBondReturnedCounter = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "prover_accounting_bond_returned",
+	}, []string{"fork", "tier", "producer"})
+	ProverAccountingRewardCounter = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "prover_accounting_reward",
+	}, []string{"fork", "tier", "producer"})
 
 	// TxManager
 	TxMgrMetrics   = txmgrMetrics.MakeTxMetrics("client", factory)
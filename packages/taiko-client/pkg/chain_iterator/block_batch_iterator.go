@@ -57,6 +57,7 @@ type BlockBatchIterator struct {
 	reorgRewindDepth   uint64
 	retryInterval      time.Duration
 	blockConfirmations *uint64
+	l1Finality         *rpc.L1FinalityMode
 }
 
 // BlockBatchIteratorConfig represents the configs of a block batch iterator.
@@ -69,6 +70,7 @@ type BlockBatchIteratorConfig struct {
 	ReorgRewindDepth      *uint64
 	RetryInterval         time.Duration
 	BlockConfirmations    *uint64
+	L1Finality            *rpc.L1FinalityMode // If set, BlockConfirmations is ignored
 }
 
 // NewBlockBatchIterator creates a new block batch iterator instance.
@@ -106,6 +108,7 @@ func NewBlockBatchIterator(ctx context.Context, cfg *BlockBatchIteratorConfig) (
 		onBlocks:           cfg.OnBlocks,
 		current:            startHeader,
 		blockConfirmations: cfg.BlockConfirmations,
+		l1Finality:         cfg.L1Finality,
 	}
 
 	if cfg.MaxBlocksReadPerEpoch != nil {
@@ -199,6 +202,12 @@ func (i *BlockBatchIterator) iter() (err error) {
 
 	if i.endHeight != nil {
 		destHeight = *i.endHeight
+	} else if i.l1Finality != nil {
+		finalHeader, err := i.l1Finality.Header(i.ctx, i.client)
+		if err != nil {
+			return err
+		}
+		destHeight = finalHeader.Number.Uint64()
 	} else {
 		destHeight, err = i.client.BlockNumber(i.ctx)
 		if err != nil {
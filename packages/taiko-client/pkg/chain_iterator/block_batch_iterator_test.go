@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/testutils"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
 )
 
 type BlockBatchIteratorTestSuite struct {
@@ -113,6 +114,40 @@ func (s *BlockBatchIteratorTestSuite) TestIterWithLessThanConfirmations() {
 	s.Equal(headHeight, lastEnd)
 }
 
+func (s *BlockBatchIteratorTestSuite) TestIterWithL1Finality() {
+	var maxBlocksReadPerEpoch uint64 = 2
+	var blockConfirmations uint64 = 2
+
+	headHeight, err := s.RPCClient.L1.BlockNumber(context.Background())
+	s.Nil(err)
+	s.Greater(headHeight, blockConfirmations)
+
+	lastEnd := common.Big0
+
+	iter, err := NewBlockBatchIterator(context.Background(), &BlockBatchIteratorConfig{
+		Client:                s.RPCClient.L1,
+		MaxBlocksReadPerEpoch: &maxBlocksReadPerEpoch,
+		StartHeight:           common.Big0,
+		// The L1 finality mode takes precedence over the block confirmations.
+		BlockConfirmations: &headHeight,
+		L1Finality:         &rpc.L1FinalityMode{Confirmations: blockConfirmations},
+		OnBlocks: func(
+			_ context.Context,
+			start, end *types.Header,
+			_ UpdateCurrentFunc,
+			_ EndIterFunc,
+		) error {
+			s.Equal(lastEnd.Uint64(), start.Number.Uint64())
+			lastEnd = end.Number
+			return nil
+		},
+	})
+
+	s.Nil(err)
+	s.Nil(iter.Iter())
+	s.GreaterOrEqual(lastEnd.Uint64(), headHeight-blockConfirmations)
+}
+
 func (s *BlockBatchIteratorTestSuite) TestIterEndFunc() {
 	var maxBlocksReadPerEpoch uint64 = 2
 
@@ -48,6 +48,7 @@ type BlockProposedIteratorConfig struct {
 	EndHeight             *big.Int
 	OnBlockProposedEvent  OnBlockProposedEvent
 	BlockConfirmations    *uint64
+	L1Finality            *rpc.L1FinalityMode
 }
 
 // NewBlockProposedIterator creates a new instance of BlockProposed event iterator.
@@ -69,6 +70,7 @@ func NewBlockProposedIterator(ctx context.Context, cfg *BlockProposedIteratorCon
 		StartHeight:           cfg.StartHeight,
 		EndHeight:             cfg.EndHeight,
 		BlockConfirmations:    cfg.BlockConfirmations,
+		L1Finality:            cfg.L1Finality,
 		OnBlocks: assembleBlockProposedIteratorCallback(
 			cfg.Client,
 			cfg.TaikoL1,
@@ -1,21 +1,18 @@
 package flags
 
 import (
-	"crypto/ecdsa"
-
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli/v2"
 
+	"github.com/taikoxyz/taiko-mono/packages/signer"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/cmd/flags"
 )
 
-// InitTxmgrConfigsFromCli initializes the transaction manager configs from the command line flags.
-func InitTxmgrConfigsFromCli(l1Endpoint string, privateKey *ecdsa.PrivateKey, c *cli.Context) *txmgr.CLIConfig {
-	return &txmgr.CLIConfig{
+// InitTxmgrConfigsFromCli initializes the transaction manager configs from the command line flags, the
+// transactions are signed by the given signer.
+func InitTxmgrConfigsFromCli(l1Endpoint string, s signer.Signer, c *cli.Context) *txmgr.CLIConfig {
+	cfg := &txmgr.CLIConfig{
 		L1RPCURL:                  l1Endpoint,
-		PrivateKey:                common.Bytes2Hex(crypto.FromECDSA(privateKey)),
 		NumConfirmations:          c.Uint64(flags.NumConfirmations.Name),
 		SafeAbortNonceTooLowCount: c.Uint64(flags.SafeAbortNonceTooLowCount.Name),
 		FeeLimitMultiplier:        c.Uint64(flags.FeeLimitMultiplier.Name),
@@ -28,4 +25,7 @@ func InitTxmgrConfigsFromCli(l1Endpoint string, privateKey *ecdsa.PrivateKey, c
 		TxSendTimeout:             c.Duration(flags.TxSendTimeout.Name),
 		TxNotInMempoolTimeout:     c.Duration(flags.TxNotInMempoolTimeout.Name),
 	}
+	s.ConfigureTxmgr(cfg)
+
+	return cfg
 }
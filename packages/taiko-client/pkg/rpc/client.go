@@ -53,6 +53,8 @@ type Client struct {
 	L1           *EthClient
 	L2           *EthClient
 	L2CheckPoint *EthClient
+	// All checkpoint nodes, including L2CheckPoint
+	L2CheckPoints []*EthClient
 	// Geth Engine API clients
 	L2Engine *EngineClient
 	// Beacon clients
@@ -70,6 +72,7 @@ type ClientConfig struct {
 	L2Endpoint                    string
 	L1BeaconEndpoint              string
 	L2CheckPoint                  string
+	L2CheckPoints                 []string
 	TaikoL1Address                common.Address
 	TaikoL2Address                common.Address
 	TaikoTokenAddress             common.Address
@@ -88,6 +91,7 @@ func NewClient(ctx context.Context, cfg *ClientConfig) (*Client, error) {
 		l2Client       *EthClient
 		l1BeaconClient *BeaconClient
 		l2CheckPoint   *EthClient
+		l2CheckPoints  []*EthClient
 		err            error
 	)
 
@@ -120,6 +124,17 @@ func NewClient(ctx context.Context, cfg *ClientConfig) (*Client, error) {
 				log.Error("Failed to connect to L2 checkpoint endpoint, retrying", "endpoint", cfg.L2CheckPoint, "err", err)
 				return err
 			}
+
+			l2CheckPoints = []*EthClient{l2CheckPoint}
+			for _, endpoint := range cfg.L2CheckPoints {
+				client, err := NewEthClient(ctxWithTimeout, endpoint, cfg.Timeout)
+				if err != nil {
+					log.Error("Failed to connect to L2 checkpoint endpoint, retrying", "endpoint", endpoint, "err", err)
+					return err
+				}
+
+				l2CheckPoints = append(l2CheckPoints, client)
+			}
 		}
 
 		return nil
@@ -138,11 +153,12 @@ func NewClient(ctx context.Context, cfg *ClientConfig) (*Client, error) {
 	}
 
 	c := &Client{
-		L1:           l1Client,
-		L1Beacon:     l1BeaconClient,
-		L2:           l2Client,
-		L2CheckPoint: l2CheckPoint,
-		L2Engine:     l2AuthClient,
+		L1:            l1Client,
+		L1Beacon:      l1BeaconClient,
+		L2:            l2Client,
+		L2CheckPoint:  l2CheckPoint,
+		L2CheckPoints: l2CheckPoints,
+		L2Engine:      l2AuthClient,
 	}
 
 	// Initialize all smart contract clients.
@@ -0,0 +1,100 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// L1 finality tags, besides them, a number of confirmations can also be used as a finality mode.
+const (
+	FinalityHead      = "head"
+	FinalitySafe      = "safe"
+	FinalityFinalized = "finalized"
+)
+
+// L1FinalityMode decides which L1 block is treated as the tip of the L1 chain, when deriving
+// or proving L2 blocks from L1: the L1 head, the L1 head minus N confirmations, or the L1
+// block tagged as `safe` / `finalized` by the L1 node.
+type L1FinalityMode struct {
+	Tag           string
+	Confirmations uint64
+}
+
+// ParseL1FinalityMode parses the given finality mode, which should be one of `head`, `safe`,
+// `finalized` or a number of confirmations.
+func ParseL1FinalityMode(mode string) (*L1FinalityMode, error) {
+	switch mode {
+	case "", FinalityHead:
+		return &L1FinalityMode{Tag: FinalityHead}, nil
+	case FinalitySafe, FinalityFinalized:
+		return &L1FinalityMode{Tag: mode}, nil
+	}
+
+	confirmations, err := strconv.ParseUint(mode, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid L1 finality mode: %s", mode)
+	}
+	if confirmations == 0 {
+		return &L1FinalityMode{Tag: FinalityHead}, nil
+	}
+
+	return &L1FinalityMode{Confirmations: confirmations}, nil
+}
+
+// IsHead returns true if the given mode follows the L1 head directly, a nil mode is treated
+// as the L1 head.
+func (m *L1FinalityMode) IsHead() bool {
+	return m == nil || m.Tag == FinalityHead
+}
+
+// IsSafe returns true if the given mode follows the L1 `safe` block.
+func (m *L1FinalityMode) IsSafe() bool {
+	return m != nil && m.Tag == FinalitySafe
+}
+
+// IsFinalized returns true if the given mode follows the L1 `finalized` block.
+func (m *L1FinalityMode) IsFinalized() bool {
+	return m != nil && m.Tag == FinalityFinalized
+}
+
+// String implements the fmt.Stringer interface.
+func (m *L1FinalityMode) String() string {
+	if m.IsHead() {
+		return FinalityHead
+	}
+	if m.Tag != "" {
+		return m.Tag
+	}
+
+	return fmt.Sprintf("%d confirmations", m.Confirmations)
+}
+
+// Header fetches the L1 block header which is treated as the tip of the L1 chain under the
+// given finality mode.
+func (m *L1FinalityMode) Header(ctx context.Context, client *EthClient) (*types.Header, error) {
+	switch {
+	case m.IsHead():
+		return client.HeaderByNumber(ctx, nil)
+	case m.IsSafe():
+		return client.HeaderByNumber(ctx, big.NewInt(int64(rpc.SafeBlockNumber)))
+	case m.IsFinalized():
+		return client.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	}
+
+	head, err := client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var height uint64
+	if head > m.Confirmations {
+		height = head - m.Confirmations
+	}
+
+	return client.HeaderByNumber(ctx, new(big.Int).SetUint64(height))
+}
@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseL1FinalityMode(t *testing.T) {
+	for _, mode := range []string{"", "head", "0"} {
+		m, err := ParseL1FinalityMode(mode)
+		require.Nil(t, err)
+		require.True(t, m.IsHead())
+	}
+
+	m, err := ParseL1FinalityMode("safe")
+	require.Nil(t, err)
+	require.True(t, m.IsSafe())
+	require.Equal(t, "safe", m.String())
+
+	m, err = ParseL1FinalityMode("finalized")
+	require.Nil(t, err)
+	require.True(t, m.IsFinalized())
+
+	m, err = ParseL1FinalityMode("12")
+	require.Nil(t, err)
+	require.False(t, m.IsHead())
+	require.Equal(t, uint64(12), m.Confirmations)
+	require.Equal(t, "12 confirmations", m.String())
+
+	_, err = ParseL1FinalityMode("latest")
+	require.ErrorContains(t, err, "invalid L1 finality mode")
+
+	require.True(t, (*L1FinalityMode)(nil).IsHead())
+}
+
+func TestL1FinalityModeHeader(t *testing.T) {
+	client := newTestClient(t)
+
+	head, err := client.L1.BlockNumber(context.Background())
+	require.Nil(t, err)
+
+	header, err := (&L1FinalityMode{Confirmations: head + 1}).Header(context.Background(), client.L1)
+	require.Nil(t, err)
+	require.Zero(t, header.Number.Uint64())
+
+	header, err = (&L1FinalityMode{Tag: FinalityHead}).Header(context.Background(), client.L1)
+	require.Nil(t, err)
+	require.GreaterOrEqual(t, header.Number.Uint64(), head)
+}
@@ -286,6 +286,27 @@ func (c *Client) GetBatchByID(ctx context.Context, batchID *big.Int) (*pacayaBin
 	return &batch, nil
 }
 
+// GetTransitionByIDPacaya fetches the transition of the given batch by its ID from the Pacaya protocol.
+func (c *Client) GetTransitionByIDPacaya(
+	ctx context.Context,
+	batchID *big.Int,
+	transitionID *big.Int,
+) (*pacayaBindings.ITaikoInboxTransitionState, error) {
+	ctxWithTimeout, cancel := CtxWithTimeoutOrDefault(ctx, defaultTimeout)
+	defer cancel()
+
+	ts, err := c.PacayaClients.TaikoInbox.GetTransitionById(
+		&bind.CallOpts{Context: ctxWithTimeout},
+		batchID.Uint64(),
+		transitionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transition by ID: %w", err)
+	}
+
+	return &ts, nil
+}
+
 // L2ParentByCurrentBlockID fetches the block header from L2 execution engine with the largest block id that
 // smaller than the given `blockId`.
 func (c *Client) L2ParentByCurrentBlockID(ctx context.Context, blockID *big.Int) (*types.Header, error) {
@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli/v2"
 
+	"github.com/taikoxyz/taiko-mono/packages/signer"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/cmd/flags"
 	pkgFlags "github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/flags"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/jwt"
@@ -22,6 +23,7 @@ import (
 type Config struct {
 	*rpc.ClientConfig
 	L1ProposerPrivKey          *ecdsa.PrivateKey
+	L1ProposerSigner           signer.Signer
 	L2SuggestedFeeRecipient    common.Address
 	ProposeInterval            time.Duration
 	LocalAddresses             []common.Address
@@ -48,9 +50,22 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		return nil, fmt.Errorf("invalid JWT secret file: %w", err)
 	}
 
-	l1ProposerPrivKey, err := crypto.ToECDSA(common.FromHex(c.String(flags.L1ProposerPrivKey.Name)))
+	var l1ProposerPrivKey *ecdsa.PrivateKey
+	if c.IsSet(flags.L1ProposerPrivKey.Name) {
+		if l1ProposerPrivKey, err = crypto.ToECDSA(common.FromHex(c.String(flags.L1ProposerPrivKey.Name))); err != nil {
+			return nil, fmt.Errorf("invalid L1 proposer private key: %w", err)
+		}
+	}
+
+	l1ProposerSigner, err := signer.New(c.Context, &signer.Config{
+		PrivateKey:           c.String(flags.L1ProposerPrivKey.Name),
+		KeystoreFile:         c.String(flags.L1ProposerKeystore.Name),
+		KeystorePasswordFile: c.String(flags.L1ProposerKeystorePassword.Name),
+		RemoteEndpoint:       c.String(flags.L1ProposerRemoteSigner.Name),
+		RemoteAddress:        common.HexToAddress(c.String(flags.L1ProposerRemoteSignerAddress.Name)),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("invalid L1 proposer private key: %w", err)
+		return nil, fmt.Errorf("invalid L1 proposer signer: %w", err)
 	}
 
 	l2SuggestedFeeRecipient := c.String(flags.L2SuggestedFeeRecipient.Name)
@@ -91,6 +106,7 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 			ProverSetAddress:  common.HexToAddress(c.String(flags.ProverSetAddress.Name)),
 		},
 		L1ProposerPrivKey:          l1ProposerPrivKey,
+		L1ProposerSigner:           l1ProposerSigner,
 		L2SuggestedFeeRecipient:    common.HexToAddress(l2SuggestedFeeRecipient),
 		ProposeInterval:            c.Duration(flags.ProposeInterval.Name),
 		LocalAddresses:             localAddresses,
@@ -107,12 +123,12 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		RevertProtectionEnabled:    c.Bool(flags.RevertProtectionEnabled.Name),
 		TxmgrConfigs: pkgFlags.InitTxmgrConfigsFromCli(
 			c.String(flags.L1WSEndpoint.Name),
-			l1ProposerPrivKey,
+			l1ProposerSigner,
 			c,
 		),
 		PrivateTxmgrConfigs: pkgFlags.InitTxmgrConfigsFromCli(
 			c.String(flags.L1PrivateEndpoint.Name),
-			l1ProposerPrivKey,
+			l1ProposerSigner,
 			c,
 		),
 	}, nil
@@ -13,12 +13,13 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/urfave/cli/v2"
 
+	"github.com/taikoxyz/taiko-mono/packages/signer"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/cmd/flags"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/metrics"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/testutils"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/config"
@@ -53,6 +54,9 @@ type Proposer struct {
 
 	txmgrSelector *utils.TxMgrSelector
 
+	// Config changes reloaded at runtime, applied between epochs
+	reloadCh chan func(*Config)
+
 	ctx context.Context
 	wg  sync.WaitGroup
 }
@@ -73,10 +77,14 @@ func (p *Proposer) InitFromConfig(
 	txMgr *txmgr.SimpleTxManager,
 	privateTxMgr *txmgr.SimpleTxManager,
 ) (err error) {
-	p.proposerAddress = crypto.PubkeyToAddress(cfg.L1ProposerPrivKey.PublicKey)
+	if cfg.L1ProposerSigner == nil {
+		cfg.L1ProposerSigner = signer.NewLocalSigner(cfg.L1ProposerPrivKey)
+	}
+	p.proposerAddress = cfg.L1ProposerSigner.Address()
 	p.ctx = ctx
 	p.Config = cfg
 	p.lastProposedAt = time.Now()
+	p.reloadCh = make(chan func(*Config))
 
 	// RPC clients
 	if p.rpc, err = rpc.NewClient(p.ctx, cfg.ClientConfig); err != nil {
@@ -90,22 +98,24 @@ func (p *Proposer) InitFromConfig(
 	log.Info("Protocol configs", "configs", p.protocolConfigs)
 
 	if txMgr == nil {
-		if txMgr, err = txmgr.NewSimpleTxManager(
+		if txMgr, err = signer.NewTxManager(
 			"proposer",
 			log.Root(),
 			&metrics.TxMgrMetrics,
 			*cfg.TxmgrConfigs,
+			cfg.L1ProposerSigner,
 		); err != nil {
 			return err
 		}
 	}
 
 	if privateTxMgr == nil && cfg.PrivateTxmgrConfigs != nil && len(cfg.PrivateTxmgrConfigs.L1RPCURL) > 0 {
-		if privateTxMgr, err = txmgr.NewSimpleTxManager(
+		if privateTxMgr, err = signer.NewTxManager(
 			"privateMempoolProposer",
 			log.Root(),
 			&metrics.TxMgrMetrics,
 			*cfg.PrivateTxmgrConfigs,
+			cfg.L1ProposerSigner,
 		); err != nil {
 			return err
 		}
@@ -119,7 +129,6 @@ func (p *Proposer) InitFromConfig(
 	)
 	p.txBuilder = builder.NewBuilderWithFallback(
 		p.rpc,
-		p.L1ProposerPrivKey,
 		cfg.L2SuggestedFeeRecipient,
 		cfg.TaikoL1Address,
 		cfg.ProverSetAddress,
@@ -154,6 +163,8 @@ func (p *Proposer) eventLoop() {
 		select {
 		case <-p.ctx.Done():
 			return
+		case reload := <-p.reloadCh:
+			reload(p.Config)
 		// proposing interval timer has been reached
 		case <-p.proposingTimer.C:
 			metrics.ProposerProposeEpochCounter.Add(1)
@@ -173,6 +184,50 @@ func (p *Proposer) Close(_ context.Context) {
 	p.wg.Wait()
 }
 
+// ReloadableFlags implements the ReloadableApplication interface.
+func (p *Proposer) ReloadableFlags() []cli.Flag {
+	return []cli.Flag{
+		flags.ProposeInterval,
+		flags.MinGasUsed,
+		flags.MinTxListBytes,
+		flags.MinTip,
+		flags.MinProposingInternal,
+		flags.AllowZeroInterval,
+		flags.MaxProposedTxListsPerEpoch,
+	}
+}
+
+// Reload implements the ReloadableApplication interface, the new epoch configurations are applied before
+// the next epoch starts.
+func (p *Proposer) Reload(c *cli.Context) error {
+	minTip, err := utils.GWeiToWei(c.Float64(flags.MinTip.Name))
+	if err != nil {
+		return err
+	}
+
+	maxProposedTxListsPerEpoch := c.Uint64(flags.MaxProposedTxListsPerEpoch.Name)
+	if maxProposedTxListsPerEpoch > 2 {
+		return fmt.Errorf("max proposed tx lists per epoch should not exceed 2, got: %d", maxProposedTxListsPerEpoch)
+	}
+
+	reload := func(cfg *Config) {
+		cfg.ProposeInterval = c.Duration(flags.ProposeInterval.Name)
+		cfg.MinGasUsed = c.Uint64(flags.MinGasUsed.Name)
+		cfg.MinTxListBytes = c.Uint64(flags.MinTxListBytes.Name)
+		cfg.MinTip = minTip.Uint64()
+		cfg.MinProposingInternal = c.Duration(flags.MinProposingInternal.Name)
+		cfg.AllowZeroInterval = c.Uint64(flags.AllowZeroInterval.Name)
+		cfg.MaxProposedTxListsPerEpoch = maxProposedTxListsPerEpoch
+	}
+
+	select {
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	case p.reloadCh <- reload:
+		return nil
+	}
+}
+
 // fetchPoolContent fetches the transaction pool content from L2 execution engine.
 func (p *Proposer) fetchPoolContent(filterPoolContent bool) ([]types.Transactions, error) {
 	var (
@@ -39,7 +39,7 @@ type ProposerTestSuite struct {
 func (s *ProposerTestSuite) SetupTest() {
 	s.ClientTestSuite.SetupTest()
 
-	state2, err := state.New(context.Background(), s.RPCClient)
+	state2, err := state.New(context.Background(), s.RPCClient, nil)
 	s.Nil(err)
 
 	syncer, err := blob.NewSyncer(
@@ -48,8 +48,10 @@ func (s *ProposerTestSuite) SetupTest() {
 		state2,
 		beaconsync.NewSyncProgressTracker(s.RPCClient.L2, 1*time.Hour),
 		0,
+		0,
 		s.BlobServer.URL(),
 		nil,
+		nil,
 	)
 	s.Nil(err)
 	s.s = syncer
@@ -2,7 +2,6 @@ package builder
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 
@@ -24,7 +23,6 @@ import (
 // bytes saved in blob.
 type BlobTransactionBuilder struct {
 	rpc                     *rpc.Client
-	proposerPrivateKey      *ecdsa.PrivateKey
 	taikoL1Address          common.Address
 	proverSetAddress        common.Address
 	l2SuggestedFeeRecipient common.Address
@@ -36,7 +34,6 @@ type BlobTransactionBuilder struct {
 // NewBlobTransactionBuilder creates a new BlobTransactionBuilder instance based on giving configurations.
 func NewBlobTransactionBuilder(
 	rpc *rpc.Client,
-	proposerPrivateKey *ecdsa.PrivateKey,
 	taikoL1Address common.Address,
 	proverSetAddress common.Address,
 	l2SuggestedFeeRecipient common.Address,
@@ -46,7 +43,6 @@ func NewBlobTransactionBuilder(
 ) *BlobTransactionBuilder {
 	return &BlobTransactionBuilder{
 		rpc,
-		proposerPrivateKey,
 		taikoL1Address,
 		proverSetAddress,
 		l2SuggestedFeeRecipient,
@@ -2,7 +2,6 @@ package builder
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 
@@ -22,7 +21,6 @@ import (
 // bytes saved in calldata.
 type CalldataTransactionBuilder struct {
 	rpc                     *rpc.Client
-	proposerPrivateKey      *ecdsa.PrivateKey
 	l2SuggestedFeeRecipient common.Address
 	taikoL1Address          common.Address
 	proverSetAddress        common.Address
@@ -34,7 +32,6 @@ type CalldataTransactionBuilder struct {
 // NewCalldataTransactionBuilder creates a new CalldataTransactionBuilder instance based on giving configurations.
 func NewCalldataTransactionBuilder(
 	rpc *rpc.Client,
-	proposerPrivateKey *ecdsa.PrivateKey,
 	l2SuggestedFeeRecipient common.Address,
 	taikoL1Address common.Address,
 	proverSetAddress common.Address,
@@ -44,7 +41,6 @@ func NewCalldataTransactionBuilder(
 ) *CalldataTransactionBuilder {
 	return &CalldataTransactionBuilder{
 		rpc,
-		proposerPrivateKey,
 		l2SuggestedFeeRecipient,
 		taikoL1Address,
 		proverSetAddress,
@@ -7,7 +7,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/testutils"
@@ -24,9 +23,6 @@ type TransactionBuilderTestSuite struct {
 func (s *TransactionBuilderTestSuite) SetupTest() {
 	s.ClientTestSuite.SetupTest()
 
-	l1ProposerPrivKey, err := crypto.ToECDSA(common.FromHex(os.Getenv("L1_PROPOSER_PRIVATE_KEY")))
-	s.Nil(err)
-
 	chainConfig := config.NewChainConfig(
 		s.RPCClient.L2.ChainID,
 		s.RPCClient.OntakeClients.ForkHeight,
@@ -35,7 +31,6 @@ func (s *TransactionBuilderTestSuite) SetupTest() {
 
 	s.calldataTxBuilder = NewCalldataTransactionBuilder(
 		s.RPCClient,
-		l1ProposerPrivKey,
 		common.HexToAddress(os.Getenv("TAIKO_ANCHOR")),
 		common.HexToAddress(os.Getenv("TAIKO_INBOX")),
 		common.Address{},
@@ -45,7 +40,6 @@ func (s *TransactionBuilderTestSuite) SetupTest() {
 	)
 	s.blobTxBuiler = NewBlobTransactionBuilder(
 		s.RPCClient,
-		l1ProposerPrivKey,
 		common.HexToAddress(os.Getenv("TAIKO_INBOX")),
 		common.Address{},
 		common.HexToAddress(os.Getenv("TAIKO_ANCHOR")),
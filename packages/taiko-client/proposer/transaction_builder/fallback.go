@@ -2,7 +2,6 @@ package builder
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 
@@ -33,7 +32,6 @@ type TxBuilderWithFallback struct {
 // NewBuilderWithFallback creates a new TxBuilderWithFallback instance.
 func NewBuilderWithFallback(
 	rpc *rpc.Client,
-	proposerPrivateKey *ecdsa.PrivateKey,
 	l2SuggestedFeeRecipient common.Address,
 	taikoL1Address common.Address,
 	proverSetAddress common.Address,
@@ -53,7 +51,6 @@ func NewBuilderWithFallback(
 	if blobAllowed {
 		builder.blobTransactionBuilder = NewBlobTransactionBuilder(
 			rpc,
-			proposerPrivateKey,
 			taikoL1Address,
 			proverSetAddress,
 			l2SuggestedFeeRecipient,
@@ -65,7 +62,6 @@ func NewBuilderWithFallback(
 
 	builder.calldataTransactionBuilder = NewCalldataTransactionBuilder(
 		rpc,
-		proposerPrivateKey,
 		l2SuggestedFeeRecipient,
 		taikoL1Address,
 		proverSetAddress,
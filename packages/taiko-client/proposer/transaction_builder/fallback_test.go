@@ -118,7 +118,6 @@ func (s *TransactionBuilderTestSuite) newTestBuilderWithFallback(
 
 	return NewBuilderWithFallback(
 		s.RPCClient,
-		l1ProposerPrivKey,
 		common.HexToAddress(os.Getenv("TAIKO_ANCHOR")),
 		common.HexToAddress(os.Getenv("TAIKO_INBOX")),
 		common.Address{},
@@ -0,0 +1,252 @@
+package accounting
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/metrics"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
+)
+
+// Accountant records what proving the proposals costs and earns this prover: the proof request durations,
+// the L1 fees of the proof submission transactions, the locked and returned bonds, and the rewards
+// received on verification. Accounting never interrupts proving, so failures are only logged, and all
+// the methods are no-ops on a nil accountant.
+type Accountant struct {
+	rpc           *rpc.Client
+	store         *Store
+	provers       []common.Address
+	validityBonds map[uint16]*big.Int
+}
+
+// New creates a new accountant instance, the given provers are the addresses which can be recorded as
+// the prover of a transition on chain, i.e. the transaction sender and the prover set.
+func New(
+	cli *rpc.Client,
+	store *Store,
+	tiers []*rpc.TierProviderTierWithID,
+	provers ...common.Address,
+) *Accountant {
+	validityBonds := make(map[uint16]*big.Int, len(tiers))
+	for _, tier := range tiers {
+		validityBonds[tier.ID] = tier.ValidityBond
+	}
+
+	return &Accountant{
+		rpc:           cli,
+		store:         store,
+		provers:       slices.DeleteFunc(provers, func(a common.Address) bool { return a == rpc.ZeroAddress }),
+		validityBonds: validityBonds,
+	}
+}
+
+// Store returns the store of the accounting records.
+func (a *Accountant) Store() *Store {
+	return a.store
+}
+
+// ProofGenerated records that a proof of the given proposal has been generated by the given producer.
+func (a *Accountant) ProofGenerated(
+	id *big.Int,
+	pacaya bool,
+	tier uint16,
+	producer string,
+	duration time.Duration,
+) {
+	if a == nil {
+		return
+	}
+
+	r, err := a.store.Update(id.Uint64(), pacaya, func(r *Record) {
+		r.Tier = tier
+		r.Producer = producer
+		r.RequestDuration = duration
+	})
+	if err != nil {
+		log.Warn("Failed to record generated proof", "id", id, "pacaya", pacaya, "error", err)
+		return
+	}
+
+	metrics.ProverAccountingProofsCounter.WithLabelValues(labels(r)...).Inc()
+}
+
+// ProofsSubmitted records the L1 fees paid by the given proof submission transaction, which are split
+// evenly among all the proposals proven by it and added to the fees of the former submissions, and the
+// bonds locked by the proofs. A reverted transaction only adds its fees, since it proves nothing.
+func (a *Accountant) ProofsSubmitted(ids []*big.Int, pacaya bool, tier uint16, receipt *types.Receipt) {
+	if a == nil || len(ids) == 0 {
+		return
+	}
+
+	var (
+		gasFee  = new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), bigOrZero(receipt.EffectiveGasPrice))
+		blobFee = new(big.Int).Mul(new(big.Int).SetUint64(receipt.BlobGasUsed), bigOrZero(receipt.BlobGasPrice))
+		// There is no validity bond after Pacaya fork.
+		bondLocked = new(big.Int)
+		reverted   = receipt.Status != types.ReceiptStatusSuccessful
+	)
+	if !pacaya && !reverted {
+		bondLocked = bigOrZero(a.validityBonds[tier])
+	}
+
+	for i, id := range ids {
+		r, err := a.store.Update(id.Uint64(), pacaya, func(r *Record) {
+			r.GasFee = new(big.Int).Add(r.GasFee, split(gasFee, len(ids), i))
+			r.BlobFee = new(big.Int).Add(r.BlobFee, split(blobFee, len(ids), i))
+			if reverted {
+				return
+			}
+			r.Tier = tier
+			r.ProvingTxHash = receipt.TxHash
+			r.BondLocked = new(big.Int).Set(bondLocked)
+			r.Submitted = true
+		})
+		if err != nil {
+			log.Warn("Failed to record submitted proof", "id", id, "pacaya", pacaya, "error", err)
+			continue
+		}
+
+		l := labels(r)
+		metrics.ProverAccountingGasFeeCounter.WithLabelValues(l...).Add(toFloat(split(gasFee, len(ids), i)))
+		metrics.ProverAccountingBlobFeeCounter.WithLabelValues(l...).Add(toFloat(split(blobFee, len(ids), i)))
+		metrics.ProverAccountingBondLockedCounter.WithLabelValues(l...).Add(toFloat(bondLocked))
+	}
+}
+
+// BlockVerified records the verification of the given block before Pacaya fork, the validity bond is
+// returned if the verified transition was proven by this prover, and lost otherwise.
+func (a *Accountant) BlockVerified(blockID *big.Int, prover common.Address) {
+	if a == nil {
+		return
+	}
+
+	a.verified(blockID.Uint64(), false, func(r *Record) {
+		if a.isProver(prover) {
+			r.BondReturned = new(big.Int).Set(r.BondLocked)
+		}
+	})
+}
+
+// BatchesVerified records the verification of all the pending batches up to the given batch. The
+// liveness bond locked by the proposer is returned if the batch was proven by this prover within the
+// proving window, otherwise this prover is rewarded with half of it.
+func (a *Accountant) BatchesVerified(ctx context.Context, lastBatchID uint64) {
+	if a == nil {
+		return
+	}
+
+	ids, err := a.store.Pending(true, lastBatchID)
+	if err != nil {
+		log.Warn("Failed to fetch pending batches", "lastBatchID", lastBatchID, "error", err)
+		return
+	}
+
+	for _, id := range ids {
+		batchID := new(big.Int).SetUint64(id)
+
+		batch, err := a.rpc.GetBatchByID(ctx, batchID)
+		if err != nil {
+			log.Warn("Failed to fetch verified batch", "batchID", id, "error", err)
+			continue
+		}
+		if batch.VerifiedTransitionId == nil || batch.VerifiedTransitionId.Sign() == 0 {
+			continue
+		}
+
+		ts, err := a.rpc.GetTransitionByIDPacaya(ctx, batchID, batch.VerifiedTransitionId)
+		if err != nil {
+			log.Warn("Failed to fetch verified transition", "batchID", id, "error", err)
+			continue
+		}
+
+		a.verified(id, true, func(r *Record) {
+			if !a.isProver(ts.Prover) {
+				return
+			}
+			if ts.InProvingWindow {
+				r.BondLocked = new(big.Int).Set(batch.LivenessBond)
+				r.BondReturned = new(big.Int).Set(batch.LivenessBond)
+			} else {
+				r.Reward = new(big.Int).Div(batch.LivenessBond, common.Big2)
+			}
+		})
+	}
+}
+
+// verified marks the given submitted proposal as verified after applying the given update.
+func (a *Accountant) verified(id uint64, pacaya bool, update func(r *Record)) {
+	old, err := a.store.Get(id, pacaya)
+	if err != nil || !old.Submitted || old.Verified {
+		return
+	}
+
+	r, err := a.store.Update(id, pacaya, func(r *Record) {
+		update(r)
+		r.Verified = true
+	})
+	if err != nil {
+		log.Warn("Failed to record verified proposal", "id", id, "pacaya", pacaya, "error", err)
+		return
+	}
+
+	var (
+		l            = labels(r)
+		lockedChange = new(big.Int).Sub(r.BondLocked, old.BondLocked)
+	)
+	metrics.ProverAccountingVerifiedCounter.WithLabelValues(l...).Inc()
+	metrics.ProverAccountingBondLockedCounter.WithLabelValues(l...).Add(toFloat(lockedChange))
+	metrics.ProverAccountingBondReturnedCounter.WithLabelValues(l...).Add(toFloat(r.BondReturned))
+	metrics.ProverAccountingRewardCounter.WithLabelValues(l...).Add(toFloat(r.Reward))
+
+	log.Info(
+		"Proposal proving accounted",
+		"id", id,
+		"fork", r.Fork(),
+		"tier", r.Tier,
+		"producer", r.Producer,
+		"cost", r.Cost(),
+		"profit", r.Profit(),
+	)
+}
+
+// isProver checks whether the given address is one of the addresses of this prover.
+func (a *Accountant) isProver(address common.Address) bool {
+	return slices.Contains(a.provers, address)
+}
+
+// labels returns the metrics labels of the given record.
+func labels(r *Record) []string {
+	return []string{r.Fork(), strconv.Itoa(int(r.Tier)), r.Producer}
+}
+
+// split returns the share of the i-th of n proposals in the given total, the remainder goes to the first one.
+func split(total *big.Int, n int, i int) *big.Int {
+	share, remainder := new(big.Int).DivMod(total, big.NewInt(int64(n)), new(big.Int))
+	if i == 0 {
+		share.Add(share, remainder)
+	}
+
+	return share
+}
+
+// bigOrZero returns the given number, or zero if it is nil.
+func bigOrZero(n *big.Int) *big.Int {
+	if n == nil {
+		return new(big.Int)
+	}
+
+	return n
+}
+
+// toFloat converts the given amount to float64 for metrics.
+func toFloat(n *big.Int) float64 {
+	f, _ := new(big.Float).SetInt(n).Float64()
+	return f
+}
@@ -0,0 +1,138 @@
+package accounting
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/stretchr/testify/require"
+
+	ontakeBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/ontake"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
+)
+
+var (
+	testProver = common.HexToAddress("0x1")
+	testOther  = common.HexToAddress("0x2")
+)
+
+func newTestAccountant() *Accountant {
+	tier := &rpc.TierProviderTierWithID{
+		ID:                200,
+		ITierProviderTier: ontakeBindings.ITierProviderTier{ValidityBond: big.NewInt(1000)},
+	}
+
+	return New(nil, NewStore(memorydb.New()), []*rpc.TierProviderTierWithID{tier}, testProver, rpc.ZeroAddress)
+}
+
+func TestAccountantOntake(t *testing.T) {
+	a := newTestAccountant()
+	defer a.Store().Close()
+
+	for _, id := range []int64{1, 2, 3} {
+		a.ProofGenerated(big.NewInt(id), false, 200, "sgx", time.Duration(id)*time.Second)
+	}
+
+	// The fees of the aggregated proof are split among all the proven blocks.
+	a.ProofsSubmitted([]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}, false, 200, &types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		TxHash:            common.HexToHash("0x1"),
+		GasUsed:           100,
+		EffectiveGasPrice: big.NewInt(10),
+		BlobGasUsed:       0,
+	})
+
+	r, err := a.Store().Get(1, false)
+	require.Nil(t, err)
+	require.True(t, r.Submitted)
+	require.Equal(t, big.NewInt(334), r.GasFee)
+	require.Equal(t, big.NewInt(1000), r.BondLocked)
+
+	r, err = a.Store().Get(2, false)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(333), r.GasFee)
+
+	// The validity bond is returned if the verified transition is proven by this prover.
+	a.BlockVerified(big.NewInt(1), testProver)
+	a.BlockVerified(big.NewInt(2), testOther)
+	// Blocks not proven by this prover are ignored.
+	a.BlockVerified(big.NewInt(4), testProver)
+
+	r, err = a.Store().Get(1, false)
+	require.Nil(t, err)
+	require.True(t, r.Verified)
+	require.Equal(t, big.NewInt(-334), r.Profit())
+
+	r, err = a.Store().Get(2, false)
+	require.Nil(t, err)
+	require.True(t, r.Verified)
+	require.Equal(t, big.NewInt(-1333), r.Profit())
+
+	_, err = a.Store().Get(4, false)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	records, err := a.Store().All()
+	require.Nil(t, err)
+
+	summaries := Summarize(records)
+	require.Len(t, summaries, 1)
+	require.Equal(t, "ontake", summaries[0].Fork)
+	require.Equal(t, uint16(200), summaries[0].Tier)
+	require.Equal(t, "sgx", summaries[0].Producer)
+	require.Equal(t, uint64(3), summaries[0].Proofs)
+	require.Equal(t, uint64(3), summaries[0].Submitted)
+	require.Equal(t, uint64(2), summaries[0].Verified)
+	require.Equal(t, 2*time.Second, summaries[0].AverageRequestDuration)
+	require.Equal(t, big.NewInt(1000), summaries[0].GasFee)
+	require.Equal(t, big.NewInt(-1667), summaries[0].Profit)
+}
+
+func TestAccountantReverted(t *testing.T) {
+	a := newTestAccountant()
+	defer a.Store().Close()
+
+	a.ProofGenerated(common.Big1, false, 200, "sgx", time.Second)
+
+	// The fees of a reverted submission are recorded, but the proof is neither submitted nor locks a bond.
+	a.ProofsSubmitted([]*big.Int{common.Big1}, false, 200, &types.Receipt{
+		Status:            types.ReceiptStatusFailed,
+		TxHash:            common.HexToHash("0x1"),
+		GasUsed:           100,
+		EffectiveGasPrice: big.NewInt(10),
+	})
+
+	r, err := a.Store().Get(1, false)
+	require.Nil(t, err)
+	require.False(t, r.Submitted)
+	require.Equal(t, common.Hash{}, r.ProvingTxHash)
+	require.Equal(t, big.NewInt(1000), r.GasFee)
+	require.Equal(t, big.NewInt(0), r.BondLocked)
+
+	// A later successful submission adds its fees to the reverted one.
+	a.ProofsSubmitted([]*big.Int{common.Big1}, false, 200, &types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		TxHash:            common.HexToHash("0x2"),
+		GasUsed:           100,
+		EffectiveGasPrice: big.NewInt(10),
+	})
+
+	r, err = a.Store().Get(1, false)
+	require.Nil(t, err)
+	require.True(t, r.Submitted)
+	require.Equal(t, common.HexToHash("0x2"), r.ProvingTxHash)
+	require.Equal(t, big.NewInt(2000), r.GasFee)
+	require.Equal(t, big.NewInt(1000), r.BondLocked)
+	require.Equal(t, big.NewInt(-3000), r.Profit())
+}
+
+func TestAccountantNil(t *testing.T) {
+	var a *Accountant
+	require.NotPanics(t, func() {
+		a.ProofGenerated(common.Big1, true, 0, "sgx", time.Second)
+		a.ProofsSubmitted([]*big.Int{common.Big1}, true, 0, &types.Receipt{})
+		a.BlockVerified(common.Big1, testProver)
+	})
+}
@@ -0,0 +1,62 @@
+package accounting
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Record is the accounting record of a proposal proven by this prover, i.e. a block before Pacaya fork,
+// or a batch after it. All the amounts are in wei.
+type Record struct {
+	ID              uint64        `json:"id"`
+	Pacaya          bool          `json:"pacaya"`
+	Tier            uint16        `json:"tier"`
+	Producer        string        `json:"producer"`
+	RequestDuration time.Duration `json:"requestDuration"`
+	ProvingTxHash   common.Hash   `json:"provingTxHash"`
+	GasFee          *big.Int      `json:"gasFee"`
+	BlobFee         *big.Int      `json:"blobFee"`
+	BondLocked      *big.Int      `json:"bondLocked"`
+	BondReturned    *big.Int      `json:"bondReturned"`
+	Reward          *big.Int      `json:"reward"`
+	Submitted       bool          `json:"submitted"`
+	Verified        bool          `json:"verified"`
+}
+
+// newRecord creates a new empty record for the given proposal.
+func newRecord(id uint64, pacaya bool) *Record {
+	return &Record{
+		ID:           id,
+		Pacaya:       pacaya,
+		GasFee:       new(big.Int),
+		BlobFee:      new(big.Int),
+		BondLocked:   new(big.Int),
+		BondReturned: new(big.Int),
+		Reward:       new(big.Int),
+	}
+}
+
+// Cost returns the L1 fees paid for proving the proposal.
+func (r *Record) Cost() *big.Int {
+	return new(big.Int).Add(r.GasFee, r.BlobFee)
+}
+
+// Profit returns the rewards and returned bonds minus the locked bonds and the L1 fees, it is negative
+// when proving the proposal is not profitable.
+func (r *Record) Profit() *big.Int {
+	profit := new(big.Int).Add(r.Reward, r.BondReturned)
+	profit.Sub(profit, r.BondLocked)
+
+	return profit.Sub(profit, r.Cost())
+}
+
+// Fork returns the name of the protocol fork of the proposal.
+func (r *Record) Fork() string {
+	if r.Pacaya {
+		return "pacaya"
+	}
+
+	return "ontake"
+}
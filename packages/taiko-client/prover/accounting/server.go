@@ -0,0 +1,95 @@
+package accounting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// Server serves the proving cost report over HTTP/JSON. All the report endpoints are authenticated by the
+// given JWT secret.
+type Server struct {
+	echo  *echo.Echo
+	store *Store
+}
+
+// NewServer creates a new accounting report server instance.
+func NewServer(store *Store, jwtSecret []byte) (*Server, error) {
+	if len(jwtSecret) == 0 {
+		return nil, errors.New("empty JWT secret for proving cost report server")
+	}
+
+	server := &Server{echo: echo.New(), store: store}
+
+	server.echo.HideBanner = true
+	server.echo.Use(middleware.RequestID())
+	server.echo.Use(middleware.Recover())
+
+	server.echo.GET("/healthz", server.HealthCheck)
+
+	report := server.echo.Group("", echojwt.JWT(jwtSecret))
+	report.GET("/summary", server.GetSummary)
+	report.GET("/:fork/:id", server.GetRecord)
+
+	return server, nil
+}
+
+// Start starts the HTTP server.
+func (s *Server) Start(port uint64) error {
+	return s.echo.Start(fmt.Sprintf(":%v", port))
+}
+
+// Shutdown shuts down the HTTP server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.echo.Shutdown(ctx)
+}
+
+// HealthCheck is the endpoints for probes.
+func (s *Server) HealthCheck(c echo.Context) error {
+	return c.NoContent(http.StatusOK)
+}
+
+// GetSummary returns the accounting summaries of all the proven proposals, grouped by fork, tier and
+// proof producer.
+func (s *Server) GetSummary(c echo.Context) error {
+	records, err := s.store.All()
+	if err != nil {
+		return s.returnError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, Summarize(records))
+}
+
+// GetRecord returns the accounting record of the given block (`/ontake/:id`) or batch (`/pacaya/:id`).
+func (s *Server) GetRecord(c echo.Context) error {
+	fork := c.Param("fork")
+	if fork != "ontake" && fork != "pacaya" {
+		return s.returnError(c, http.StatusNotFound, fmt.Errorf("unknown fork: %s", fork))
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return s.returnError(c, http.StatusBadRequest, err)
+	}
+
+	record, err := s.store.Get(id, fork == "pacaya")
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return s.returnError(c, http.StatusNotFound, err)
+		}
+		return s.returnError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, record)
+}
+
+// returnError writes the given error to the response.
+func (s *Server) returnError(c echo.Context, statusCode int, err error) error {
+	return c.JSON(statusCode, map[string]string{"error": err.Error()})
+}
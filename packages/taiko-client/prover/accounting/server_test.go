@@ -0,0 +1,78 @@
+package accounting
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/stretchr/testify/require"
+)
+
+var testJWTSecret = []byte("accounting-test-secret")
+
+// newTestJWT creates a HS256 JWT signed with the given secret.
+func newTestJWT(t *testing.T, secret []byte) string {
+	var (
+		header  = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+		payload = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"test"}`))
+		mac     = hmac.New(sha256.New, secret)
+	)
+	_, err := mac.Write([]byte(header + "." + payload))
+	require.Nil(t, err)
+
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func request(t *testing.T, s *Server, path string, secret []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if secret != nil {
+		req.Header.Set("Authorization", "Bearer "+newTestJWT(t, secret))
+	}
+
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestServer(t *testing.T) {
+	_, err := NewServer(NewStore(memorydb.New()), nil)
+	require.NotNil(t, err)
+
+	a := newTestAccountant()
+	defer a.Store().Close()
+	a.ProofGenerated(common.Big1, true, 0, "sgx", time.Second)
+
+	s, err := NewServer(a.Store(), testJWTSecret)
+	require.Nil(t, err)
+
+	require.Equal(t, http.StatusOK, request(t, s, "/healthz", nil).Code)
+	require.Equal(t, http.StatusUnauthorized, request(t, s, "/summary", nil).Code)
+	require.Equal(t, http.StatusUnauthorized, request(t, s, "/pacaya/1", []byte("wrong secret")).Code)
+
+	rec := request(t, s, "/pacaya/1", testJWTSecret)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var r Record
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &r))
+	require.Equal(t, uint64(1), r.ID)
+	require.Equal(t, "sgx", r.Producer)
+
+	rec = request(t, s, "/summary", testJWTSecret)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var summaries []*Summary
+	require.Nil(t, json.Unmarshal(rec.Body.Bytes(), &summaries))
+	require.Len(t, summaries, 1)
+
+	require.Equal(t, http.StatusNotFound, request(t, s, "/ontake/1", testJWTSecret).Code)
+	require.Equal(t, http.StatusNotFound, request(t, s, "/unknown/1", testJWTSecret).Code)
+	require.Equal(t, http.StatusBadRequest, request(t, s, "/pacaya/abc", testJWTSecret).Code)
+}
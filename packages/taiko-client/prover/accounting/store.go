@@ -0,0 +1,171 @@
+package accounting
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+)
+
+var (
+	// ErrNotFound is returned when there is no accounting record for the given proposal.
+	ErrNotFound = errors.New("accounting record not found")
+
+	ontakePrefix  = []byte("o") // ontakePrefix + blockID -> record
+	pacayaPrefix  = []byte("p") // pacayaPrefix + batchID -> record
+	pendingPrefix = []byte("u") // pendingPrefix + fork prefix + ID -> nil, for the submitted but unverified proposals
+)
+
+const (
+	storeCache   = 16 // MB
+	storeHandles = 16
+)
+
+// Store persists the accounting records of the proven proposals.
+type Store struct {
+	db    ethdb.KeyValueStore
+	mutex sync.Mutex
+}
+
+// OpenStore opens (or creates) a LevelDB backed store in the given directory.
+func OpenStore(dir string) (*Store, error) {
+	db, err := leveldb.New(dir, storeCache, storeHandles, "accounting/", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open accounting store: %w", err)
+	}
+
+	return NewStore(db), nil
+}
+
+// NewStore creates a new store on top of the given key-value database.
+func NewStore(db ethdb.KeyValueStore) *Store {
+	return &Store{db: db}
+}
+
+// Get returns the record of the given proposal.
+func (s *Store) Get(id uint64, pacaya bool) (*Record, error) {
+	has, err := s.db.Has(recordKey(id, pacaya))
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrNotFound
+	}
+
+	value, err := s.db.Get(recordKey(id, pacaya))
+	if err != nil {
+		return nil, err
+	}
+
+	r := newRecord(id, pacaya)
+	if err := json.Unmarshal(value, r); err != nil {
+		return nil, fmt.Errorf("failed to decode accounting record of proposal %d: %w", id, err)
+	}
+
+	return r, nil
+}
+
+// Update applies the given function to the record of the given proposal, a new record is created if
+// there is none yet, and saves the result.
+func (s *Store) Update(id uint64, pacaya bool, update func(r *Record)) (*Record, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	r, err := s.Get(id, pacaya)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		r = newRecord(id, pacaya)
+	}
+
+	update(r)
+
+	value, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := s.db.NewBatch()
+	if err := batch.Put(recordKey(id, pacaya), value); err != nil {
+		return nil, err
+	}
+	if r.Submitted && !r.Verified {
+		err = batch.Put(pendingKey(id, pacaya), []byte{})
+	} else {
+		err = batch.Delete(pendingKey(id, pacaya))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r, batch.Write()
+}
+
+// Pending returns the IDs of the submitted but not yet verified proposals, up to the given ID.
+func (s *Store) Pending(pacaya bool, maxID uint64) ([]uint64, error) {
+	it := s.db.NewIterator(append(append([]byte{}, pendingPrefix...), forkPrefix(pacaya)...), nil)
+	defer it.Release()
+
+	var ids []uint64
+	for it.Next() {
+		id := binary.BigEndian.Uint64(it.Key()[len(pendingPrefix)+1:])
+		if id > maxID {
+			break
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, it.Error()
+}
+
+// All returns all the records in the store, sorted by fork and ID.
+func (s *Store) All() ([]*Record, error) {
+	var records []*Record
+	for _, prefix := range [][]byte{ontakePrefix, pacayaPrefix} {
+		it := s.db.NewIterator(prefix, nil)
+		for it.Next() {
+			r := new(Record)
+			if err := json.Unmarshal(it.Value(), r); err != nil {
+				it.Release()
+				return nil, fmt.Errorf("failed to decode accounting record: %w", err)
+			}
+
+			records = append(records, r)
+		}
+		it.Release()
+
+		if err := it.Error(); err != nil {
+			return nil, err
+		}
+	}
+
+	return records, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// forkPrefix returns the key prefix of the records of the given fork.
+func forkPrefix(pacaya bool) []byte {
+	if pacaya {
+		return pacayaPrefix
+	}
+
+	return ontakePrefix
+}
+
+func recordKey(id uint64, pacaya bool) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte{}, forkPrefix(pacaya)...), id)
+}
+
+func pendingKey(id uint64, pacaya bool) []byte {
+	return binary.BigEndian.AppendUint64(append(append([]byte{}, pendingPrefix...), forkPrefix(pacaya)...), id)
+}
@@ -0,0 +1,71 @@
+package accounting
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	s := NewStore(memorydb.New())
+	defer s.Close()
+
+	_, err := s.Get(1, false)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Blocks and batches with the same ID are different proposals.
+	_, err = s.Update(1, false, func(r *Record) { r.Producer = "sgx" })
+	require.Nil(t, err)
+	_, err = s.Update(1, true, func(r *Record) { r.Producer = "sp1" })
+	require.Nil(t, err)
+
+	r, err := s.Get(1, false)
+	require.Nil(t, err)
+	require.Equal(t, "sgx", r.Producer)
+	require.Zero(t, r.GasFee.Sign())
+
+	r, err = s.Update(1, true, func(r *Record) { r.GasFee = big.NewInt(100) })
+	require.Nil(t, err)
+	require.Equal(t, "sp1", r.Producer)
+
+	r, err = s.Get(1, true)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(100), r.GasFee)
+
+	records, err := s.All()
+	require.Nil(t, err)
+	require.Len(t, records, 2)
+	require.False(t, records[0].Pacaya)
+	require.True(t, records[1].Pacaya)
+}
+
+func TestStorePending(t *testing.T) {
+	s := NewStore(memorydb.New())
+	defer s.Close()
+
+	for _, id := range []uint64{1, 2, 3, 10} {
+		_, err := s.Update(id, true, func(r *Record) { r.Submitted = true })
+		require.Nil(t, err)
+	}
+	_, err := s.Update(4, true, func(r *Record) {})
+	require.Nil(t, err)
+	_, err = s.Update(5, false, func(r *Record) { r.Submitted = true })
+	require.Nil(t, err)
+
+	ids, err := s.Pending(true, 5)
+	require.Nil(t, err)
+	require.Equal(t, []uint64{1, 2, 3}, ids)
+
+	_, err = s.Update(2, true, func(r *Record) { r.Verified = true })
+	require.Nil(t, err)
+
+	ids, err = s.Pending(true, 10)
+	require.Nil(t, err)
+	require.Equal(t, []uint64{1, 3, 10}, ids)
+
+	ids, err = s.Pending(false, 10)
+	require.Nil(t, err)
+	require.Equal(t, []uint64{5}, ids)
+}
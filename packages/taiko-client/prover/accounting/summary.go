@@ -0,0 +1,91 @@
+package accounting
+
+import (
+	"math/big"
+	"sort"
+	"time"
+)
+
+// Summary is the accounting summary of the proposals proven with the same fork, tier and proof producer.
+// All the amounts are in wei.
+type Summary struct {
+	Fork                   string        `json:"fork"`
+	Tier                   uint16        `json:"tier"`
+	Producer               string        `json:"producer"`
+	Proofs                 uint64        `json:"proofs"`
+	Submitted              uint64        `json:"submitted"`
+	Verified               uint64        `json:"verified"`
+	AverageRequestDuration time.Duration `json:"averageRequestDuration"`
+	GasFee                 *big.Int      `json:"gasFee"`
+	BlobFee                *big.Int      `json:"blobFee"`
+	BondLocked             *big.Int      `json:"bondLocked"`
+	BondReturned           *big.Int      `json:"bondReturned"`
+	Reward                 *big.Int      `json:"reward"`
+	Profit                 *big.Int      `json:"profit"`
+}
+
+// Summarize groups the given records by fork, tier and proof producer, and sums them up. Only the
+// verified proposals are taken into account for the profit, since the bonds of the others are not
+// settled yet.
+func Summarize(records []*Record) []*Summary {
+	var (
+		summaries      = make(map[[3]string]*Summary)
+		totalDurations = make(map[[3]string]time.Duration)
+	)
+	for _, r := range records {
+		key := [3]string(labels(r))
+
+		s, ok := summaries[key]
+		if !ok {
+			s = &Summary{
+				Fork:         r.Fork(),
+				Tier:         r.Tier,
+				Producer:     r.Producer,
+				GasFee:       new(big.Int),
+				BlobFee:      new(big.Int),
+				BondLocked:   new(big.Int),
+				BondReturned: new(big.Int),
+				Reward:       new(big.Int),
+				Profit:       new(big.Int),
+			}
+			summaries[key] = s
+		}
+
+		if r.RequestDuration > 0 {
+			s.Proofs++
+			totalDurations[key] += r.RequestDuration
+		}
+		if r.Submitted {
+			s.Submitted++
+			s.GasFee.Add(s.GasFee, r.GasFee)
+			s.BlobFee.Add(s.BlobFee, r.BlobFee)
+		}
+		if r.Verified {
+			s.Verified++
+			s.BondLocked.Add(s.BondLocked, r.BondLocked)
+			s.BondReturned.Add(s.BondReturned, r.BondReturned)
+			s.Reward.Add(s.Reward, r.Reward)
+			s.Profit.Add(s.Profit, r.Profit())
+		}
+	}
+
+	result := make([]*Summary, 0, len(summaries))
+	for key, s := range summaries {
+		if s.Proofs > 0 {
+			s.AverageRequestDuration = totalDurations[key] / time.Duration(s.Proofs)
+		}
+		result = append(result, s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Fork != result[j].Fork {
+			return result[i].Fork < result[j].Fork
+		}
+		if result[i].Tier != result[j].Tier {
+			return result[i].Tier < result[j].Tier
+		}
+		return result[i].Producer < result[j].Producer
+	})
+
+	return result
+}
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"math/big"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -13,10 +16,13 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli/v2"
 
+	"github.com/taikoxyz/taiko-mono/packages/signer"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/cmd/flags"
 	pkgFlags "github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/flags"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/jwt"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/utils"
+	proofProducer "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_producer"
 )
 
 // Config contains the configurations to initialize a Taiko prover.
@@ -29,16 +35,22 @@ type Config struct {
 	TaikoTokenAddress                       common.Address
 	ProverSetAddress                        common.Address
 	L1ProverPrivKey                         *ecdsa.PrivateKey
+	L1ProverSigner                          signer.Signer
 	StartingBlockID                         *big.Int
 	Dummy                                   bool
 	GuardianProverMinorityAddress           common.Address
 	GuardianProverMajorityAddress           common.Address
 	GuardianProofSubmissionDelay            time.Duration
+	GuardianApprovalPort                    uint64
+	GuardianApprovalJWTSecret               []byte
+	GuardianApprovalTimeout                 time.Duration
+	GuardianApprovalAutoApprove             bool
 	Graffiti                                string
 	BackOffMaxRetries                       uint64
 	BackOffRetryInterval                    time.Duration
 	ProveUnassignedBlocks                   bool
 	ContesterMode                           bool
+	ContesterSubmitConflictingProof         bool
 	EnableLivenessBondProof                 bool
 	RPCTimeout                              time.Duration
 	ProveBlockGasLimit                      uint64
@@ -54,11 +66,25 @@ type Config struct {
 	L1NodeVersion                           string
 	L2NodeVersion                           string
 	BlockConfirmations                      uint64
+	L1Finality                              *rpc.L1FinalityMode
 	TxmgrConfigs                            *txmgr.CLIConfig
 	PrivateTxmgrConfigs                     *txmgr.CLIConfig
 	SGXProofBufferSize                      uint64
 	ZKVMProofBufferSize                     uint64
 	ForceBatchProvingInterval               time.Duration
+	ProofProducerEndpoint                   string
+	ProofProducerProofType                  string
+	ProofProducerToken                      string
+	SchedulerMaxConcurrency                 uint64
+	SchedulerProducerConcurrency            map[string]uint64
+	ProvingPolicyFile                       string
+	ProvingPolicyReloadInterval             time.Duration
+	AccountingDataDir                       string
+	AccountingJWTSecret                     []byte
+	ComposeProofTypes                       []string
+	ComposeRequiredProofTypes               []string
+	ComposeQuorum                           uint64
+	ComposeVerifiers                        map[string]common.Address
 }
 
 // NewConfigFromCliContext creates a new config instance from command line flags.
@@ -66,9 +92,24 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 	var (
 		jwtSecret []byte
 	)
-	l1ProverPrivKey, err := crypto.ToECDSA(common.FromHex(c.String(flags.L1ProverPrivKey.Name)))
+	var l1ProverPrivKey *ecdsa.PrivateKey
+	if c.IsSet(flags.L1ProverPrivKey.Name) {
+		key, err := crypto.ToECDSA(common.FromHex(c.String(flags.L1ProverPrivKey.Name)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid L1 prover private key: %w", err)
+		}
+		l1ProverPrivKey = key
+	}
+
+	l1ProverSigner, err := signer.New(c.Context, &signer.Config{
+		PrivateKey:           c.String(flags.L1ProverPrivKey.Name),
+		KeystoreFile:         c.String(flags.L1ProverKeystore.Name),
+		KeystorePasswordFile: c.String(flags.L1ProverKeystorePassword.Name),
+		RemoteEndpoint:       c.String(flags.L1ProverRemoteSigner.Name),
+		RemoteAddress:        common.HexToAddress(c.String(flags.L1ProverRemoteSignerAddress.Name)),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("invalid L1 prover private key: %w", err)
+		return nil, fmt.Errorf("invalid L1 prover signer: %w", err)
 	}
 
 	var startingBlockID *big.Int
@@ -113,7 +154,71 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		}
 	}
 
-	if !c.IsSet(flags.GuardianProverMajority.Name) && !c.IsSet(flags.RaikoHostEndpoint.Name) {
+	// Each compose proof type needs the address of its sub-verifier.
+	var (
+		composeProofTypes         = c.StringSlice(flags.ComposeProofTypes.Name)
+		composeRequiredProofTypes = c.StringSlice(flags.ComposeRequiredProofTypes.Name)
+		composeVerifiers          = map[string]common.Address{
+			proofProducer.ProofTypeSgx:   common.HexToAddress(c.String(flags.SGXVerifierAddress.Name)),
+			proofProducer.ZKProofTypeR0:  common.HexToAddress(c.String(flags.RISC0VerifierAddress.Name)),
+			proofProducer.ZKProofTypeSP1: common.HexToAddress(c.String(flags.SP1VerifierAddress.Name)),
+		}
+	)
+	producerConcurrency := make(map[string]uint64)
+	for _, item := range c.StringSlice(flags.SchedulerProducerConcurrency.Name) {
+		producer, limit, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid proof producer concurrency: %s", item)
+		}
+		if producerConcurrency[producer], err = strconv.ParseUint(limit, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid proof producer concurrency: %s: %w", item, err)
+		}
+	}
+	for _, proofType := range composeProofTypes {
+		verifier, ok := composeVerifiers[proofType]
+		if !ok {
+			return nil, fmt.Errorf("unsupported compose proof type: %s", proofType)
+		}
+		if verifier == (common.Address{}) {
+			return nil, fmt.Errorf("empty compose verifier address for proof type: %s", proofType)
+		}
+	}
+	for _, proofType := range composeRequiredProofTypes {
+		if !slices.Contains(composeProofTypes, proofType) {
+			return nil, fmt.Errorf("required compose proof type is not enabled: %s", proofType)
+		}
+	}
+	// Without compose proof types, the proofs of the proving backend are submitted to the sub-verifier of
+	// their proof type.
+	if c.IsSet(flags.ProofProducerEndpoint.Name) && len(composeProofTypes) == 0 {
+		proofType := c.String(flags.ProofProducerProofType.Name)
+		verifier, ok := composeVerifiers[proofType]
+		if !ok {
+			return nil, fmt.Errorf("unsupported proof producer proof type: %s", proofType)
+		}
+		if verifier == (common.Address{}) {
+			return nil, fmt.Errorf("empty verifier address for proof producer proof type: %s", proofType)
+		}
+	}
+	if c.Uint64(flags.ComposeQuorum.Name) > uint64(len(composeProofTypes)) {
+		return nil, fmt.Errorf(
+			"compose quorum (%d) > compose proof types (%d)",
+			c.Uint64(flags.ComposeQuorum.Name),
+			len(composeProofTypes),
+		)
+	}
+
+	// If no L1 finality mode is set, the prover keeps waiting for the given block confirmations.
+	var l1Finality *rpc.L1FinalityMode
+	if c.IsSet(flags.L1Finality.Name) {
+		if l1Finality, err = rpc.ParseL1FinalityMode(c.String(flags.L1Finality.Name)); err != nil {
+			return nil, err
+		}
+	}
+
+	if !c.IsSet(flags.GuardianProverMajority.Name) &&
+		!c.IsSet(flags.RaikoHostEndpoint.Name) &&
+		!c.IsSet(flags.ProofProducerEndpoint.Name) {
 		return nil, errors.New("empty raiko host endpoint")
 	}
 
@@ -124,6 +229,28 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		}
 	}
 
+	var guardianApprovalJWTSecret []byte
+	if c.Uint64(flags.GuardianApprovalPort.Name) != 0 {
+		if !c.IsSet(flags.GuardianApprovalJWTSecret.Name) {
+			return nil, errors.New("empty guardian proof approval server JWT secret")
+		}
+		if guardianApprovalJWTSecret, err = jwt.ParseSecretFromFile(
+			c.String(flags.GuardianApprovalJWTSecret.Name),
+		); err != nil {
+			return nil, fmt.Errorf("invalid guardian proof approval server JWT secret file: %w", err)
+		}
+	}
+
+	var accountingJWTSecret []byte
+	if c.IsSet(flags.AccountingDataDir.Name) {
+		if !c.IsSet(flags.AccountingJWTSecret.Name) {
+			return nil, errors.New("empty proving cost report JWT secret")
+		}
+		if accountingJWTSecret, err = jwt.ParseSecretFromFile(c.String(flags.AccountingJWTSecret.Name)); err != nil {
+			return nil, fmt.Errorf("invalid proving cost report JWT secret file: %w", err)
+		}
+	}
+
 	return &Config{
 		L1WsEndpoint:                            c.String(flags.L1WSEndpoint.Name),
 		L2WsEndpoint:                            c.String(flags.L2WSEndpoint.Name),
@@ -133,6 +260,7 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		TaikoTokenAddress:                       common.HexToAddress(c.String(flags.TaikoTokenAddress.Name)),
 		ProverSetAddress:                        common.HexToAddress(c.String(flags.ProverSetAddress.Name)),
 		L1ProverPrivKey:                         l1ProverPrivKey,
+		L1ProverSigner:                          l1ProverSigner,
 		RaikoHostEndpoint:                       c.String(flags.RaikoHostEndpoint.Name),
 		RaikoZKVMHostEndpoint:                   c.String(flags.RaikoZKVMHostEndpoint.Name),
 		RaikoJWT:                                common.Bytes2Hex(jwtSecret),
@@ -142,12 +270,17 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		GuardianProverMinorityAddress:           common.HexToAddress(c.String(flags.GuardianProverMinority.Name)),
 		GuardianProverMajorityAddress:           common.HexToAddress(c.String(flags.GuardianProverMajority.Name)),
 		GuardianProofSubmissionDelay:            c.Duration(flags.GuardianProofSubmissionDelay.Name),
+		GuardianApprovalPort:                    c.Uint64(flags.GuardianApprovalPort.Name),
+		GuardianApprovalJWTSecret:               guardianApprovalJWTSecret,
+		GuardianApprovalTimeout:                 c.Duration(flags.GuardianApprovalTimeout.Name),
+		GuardianApprovalAutoApprove:             c.Bool(flags.GuardianApprovalAutoApprove.Name),
 		GuardianProverHealthCheckServerEndpoint: guardianProverHealthCheckServerEndpoint,
 		Graffiti:                                c.String(flags.Graffiti.Name),
 		BackOffMaxRetries:                       c.Uint64(flags.BackOffMaxRetries.Name),
 		BackOffRetryInterval:                    c.Duration(flags.BackOffRetryInterval.Name),
 		ProveUnassignedBlocks:                   c.Bool(flags.ProveUnassignedBlocks.Name),
 		ContesterMode:                           c.Bool(flags.ContesterMode.Name),
+		ContesterSubmitConflictingProof:         c.Bool(flags.ContesterSubmitConflictingProof.Name),
 		EnableLivenessBondProof:                 c.Bool(flags.EnableLivenessBondProof.Name),
 		RPCTimeout:                              c.Duration(flags.RPCTimeout.Name),
 		ProveBlockGasLimit:                      c.Uint64(flags.TxGasLimit.Name),
@@ -157,18 +290,32 @@ func NewConfigFromCliContext(c *cli.Context) (*Config, error) {
 		L1NodeVersion:                           c.String(flags.L1NodeVersion.Name),
 		L2NodeVersion:                           c.String(flags.L2NodeVersion.Name),
 		BlockConfirmations:                      c.Uint64(flags.BlockConfirmations.Name),
+		L1Finality:                              l1Finality,
 		TxmgrConfigs: pkgFlags.InitTxmgrConfigsFromCli(
 			c.String(flags.L1WSEndpoint.Name),
-			l1ProverPrivKey,
+			l1ProverSigner,
 			c,
 		),
 		PrivateTxmgrConfigs: pkgFlags.InitTxmgrConfigsFromCli(
 			c.String(flags.L1PrivateEndpoint.Name),
-			l1ProverPrivKey,
+			l1ProverSigner,
 			c,
 		),
-		SGXProofBufferSize:        c.Uint64(flags.SGXBatchSize.Name),
-		ZKVMProofBufferSize:       c.Uint64(flags.ZKVMBatchSize.Name),
-		ForceBatchProvingInterval: c.Duration(flags.ForceBatchProvingInterval.Name),
+		SGXProofBufferSize:           c.Uint64(flags.SGXBatchSize.Name),
+		ZKVMProofBufferSize:          c.Uint64(flags.ZKVMBatchSize.Name),
+		ForceBatchProvingInterval:    c.Duration(flags.ForceBatchProvingInterval.Name),
+		ProofProducerEndpoint:        c.String(flags.ProofProducerEndpoint.Name),
+		ProofProducerProofType:       c.String(flags.ProofProducerProofType.Name),
+		ProofProducerToken:           c.String(flags.ProofProducerToken.Name),
+		SchedulerMaxConcurrency:      c.Uint64(flags.SchedulerMaxConcurrency.Name),
+		SchedulerProducerConcurrency: producerConcurrency,
+		ProvingPolicyFile:            c.String(flags.ProvingPolicyFile.Name),
+		ProvingPolicyReloadInterval:  c.Duration(flags.ProvingPolicyReloadInterval.Name),
+		AccountingDataDir:            c.String(flags.AccountingDataDir.Name),
+		AccountingJWTSecret:          accountingJWTSecret,
+		ComposeProofTypes:            composeProofTypes,
+		ComposeRequiredProofTypes:    composeRequiredProofTypes,
+		ComposeQuorum:                c.Uint64(flags.ComposeQuorum.Name),
+		ComposeVerifiers:             composeVerifiers,
 	}, nil
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/utils"
 	guardianProverHeartbeater "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/guardian_prover_heartbeater"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/policy"
 	proofProducer "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_producer"
 	state "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/shared_state"
 )
@@ -41,6 +42,7 @@ type BlockProposedEventHandler struct {
 	backOffMaxRetrys      uint64
 	contesterMode         bool
 	proveUnassignedBlocks bool
+	policy                *policy.Engine
 	// Guardian prover related.
 	isGuardian bool
 }
@@ -59,6 +61,7 @@ type NewBlockProposedEventHandlerOps struct {
 	BackOffMaxRetrys      uint64
 	ContesterMode         bool
 	ProveUnassignedBlocks bool
+	Policy                *policy.Engine
 }
 
 // NewBlockProposedEventHandler creates a new BlockProposedEventHandler instance.
@@ -76,6 +79,7 @@ func NewBlockProposedEventHandler(opts *NewBlockProposedEventHandlerOps) *BlockP
 		opts.BackOffMaxRetrys,
 		opts.ContesterMode,
 		opts.ProveUnassignedBlocks,
+		opts.Policy,
 		false,
 	}
 }
@@ -315,6 +319,11 @@ func (h *BlockProposedEventHandler) checkExpirationAndSubmitProofOntake(
 		return fmt.Errorf("failed to check if the proving window is expired: %w", err)
 	}
 
+	// If a proving policy is set, it decides whether to prove this block.
+	if h.policy != nil {
+		return h.proveByPolicy(ctx, meta, windowExpired, timeToExpire)
+	}
+
 	// If the proving window is not expired, we need to check if the current prover is the assigned prover,
 	// if no and the current prover wants to prove unassigned blocks, then we should wait for its expiration.
 	if !windowExpired &&
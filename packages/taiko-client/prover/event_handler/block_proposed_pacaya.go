@@ -165,6 +165,11 @@ func (h *BlockProposedEventHandler) checkExpirationAndSubmitProofPacaya(
 		return fmt.Errorf("failed to check if the proving window is expired: %w", err)
 	}
 
+	// If a proving policy is set, it decides whether to prove this batch.
+	if h.policy != nil {
+		return h.proveByPolicy(ctx, meta, windowExpired, timeToExpire)
+	}
+
 	// If the proving window is not expired, we need to check if the current prover is the assigned prover,
 	// if no and the current prover wants to prove unassigned blocks, then we should wait for its expiration.
 	if !windowExpired &&
@@ -1,26 +1,33 @@
 package handler
 
 import (
+	"context"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
 	ontakeBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/ontake"
 	pacayaBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/metrics"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/accounting"
 )
 
 // BlockVerifiedEventHandler is responsible for handling the BlockVerified event.
 type BlockVerifiedEventHandler struct {
 	guardianProverAddress common.Address
+	accountant            *accounting.Accountant
 }
 
 // NewBlockVerifiedEventHandler creates a new BlockVerifiedEventHandler instance.
-func NewBlockVerifiedEventHandler(guardianProverAddress common.Address) *BlockVerifiedEventHandler {
-	return &BlockVerifiedEventHandler{guardianProverAddress: guardianProverAddress}
+func NewBlockVerifiedEventHandler(
+	guardianProverAddress common.Address,
+	accountant *accounting.Accountant,
+) *BlockVerifiedEventHandler {
+	return &BlockVerifiedEventHandler{guardianProverAddress: guardianProverAddress, accountant: accountant}
 }
 
 // Handle handles the BlockVerified event.
-func (h *BlockVerifiedEventHandler) Handle(e *ontakeBindings.TaikoL1ClientBlockVerifiedV2) {
+func (h *BlockVerifiedEventHandler) Handle(_ context.Context, e *ontakeBindings.TaikoL1ClientBlockVerifiedV2) {
 	metrics.ProverLatestVerifiedIDGauge.Set(float64(e.BlockId.Uint64()))
 
 	log.Info(
@@ -29,10 +36,15 @@ func (h *BlockVerifiedEventHandler) Handle(e *ontakeBindings.TaikoL1ClientBlockV
 		"hash", common.BytesToHash(e.BlockHash[:]),
 		"prover", e.Prover,
 	)
+
+	h.accountant.BlockVerified(e.BlockId, e.Prover)
 }
 
-// Handle handles the BatchVerified event.
-func (h *BlockVerifiedEventHandler) HandlePacaya(e *pacayaBindings.TaikoInboxClientBatchesVerified) {
+// HandlePacaya handles the BatchesVerified event.
+func (h *BlockVerifiedEventHandler) HandlePacaya(
+	ctx context.Context,
+	e *pacayaBindings.TaikoInboxClientBatchesVerified,
+) {
 	metrics.ProverLatestVerifiedIDGauge.Set(float64(e.BatchId))
 
 	log.Info(
@@ -40,4 +52,6 @@ func (h *BlockVerifiedEventHandler) HandlePacaya(e *pacayaBindings.TaikoInboxCli
 		"batchID", e.BatchId,
 		"hash", common.BytesToHash(e.BlockHash[:]),
 	)
+
+	h.accountant.BatchesVerified(ctx, e.BatchId)
 }
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"context"
+
 	"github.com/ethereum/go-ethereum/core/types"
 
 	ontakeBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/ontake"
@@ -11,7 +13,7 @@ func (s *EventHandlerTestSuite) TestBlockVerifiedHandle() {
 	handler := &BlockVerifiedEventHandler{}
 	id := testutils.RandomHash().Big().Uint64()
 	s.NotPanics(func() {
-		handler.Handle(&ontakeBindings.TaikoL1ClientBlockVerifiedV2{
+		handler.Handle(context.Background(), &ontakeBindings.TaikoL1ClientBlockVerifiedV2{
 			BlockId: testutils.RandomHash().Big(),
 			Raw: types.Log{
 				BlockHash:   testutils.RandomHash(),
@@ -5,6 +5,7 @@ import (
 
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
 	ontakeBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/ontake"
+	pacayaBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
 	eventIterator "github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/chain_iterator/event_iterator"
 )
 
@@ -21,14 +22,18 @@ type TransitionContestedHandler interface {
 	Handle(ctx context.Context, event *ontakeBindings.TaikoL1ClientTransitionContestedV2) error
 }
 
-// TransitionProvedHandler is the interface for handling `TaikoL1.TransitionProvedV2` events.
+// TransitionProvedHandler is the interface for handling `TaikoL1.TransitionProvedV2` and
+// `TaikoInbox.BatchesProved` events.
 type TransitionProvedHandler interface {
 	Handle(ctx context.Context, event *ontakeBindings.TaikoL1ClientTransitionProvedV2) error
+	HandlePacaya(ctx context.Context, event *pacayaBindings.TaikoInboxClientBatchesProved) error
 }
 
-// BlockVerifiedHandler is the interface for handling `TaikoL1.BlockVerifiedV2` events.
+// BlockVerifiedHandler is the interface for handling `TaikoL1.BlockVerifiedV2` and
+// `TaikoInbox.BatchesVerified` events.
 type BlockVerifiedHandler interface {
-	Handle(e *ontakeBindings.TaikoL1ClientBlockVerifiedV2)
+	Handle(ctx context.Context, e *ontakeBindings.TaikoL1ClientBlockVerifiedV2)
+	HandlePacaya(ctx context.Context, e *pacayaBindings.TaikoInboxClientBatchesVerified)
 }
 
 // AssignmentExpiredHandler is the interface for handling the proof assignment expiration.
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/metrics"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/utils"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/policy"
+	proofProducer "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_producer"
+)
+
+// proveByPolicy lets the proving policy decide whether to prove the given proposal, which has no proof
+// submitted yet, and then proves it right away, waits for its proving window expiration, or skips it. A proposal
+// of another proposer is never proven before its proving window expiration.
+func (h *BlockProposedEventHandler) proveByPolicy(
+	ctx context.Context,
+	meta metadata.TaikoProposalMetaData,
+	windowExpired bool,
+	timeToExpire time.Duration,
+) error {
+	proposal, err := h.policyProposal(ctx, meta, windowExpired, timeToExpire)
+	if err != nil {
+		return fmt.Errorf("failed to assemble the proposal for proving policy: %w", err)
+	}
+
+	decision := h.policy.Evaluate(proposal)
+
+	log.Info(
+		"Proving policy decision",
+		"id", proposal.ID,
+		"pacaya", proposal.Pacaya,
+		"action", decision.Action,
+		"rule", decision.Rule,
+		"proposer", proposal.Proposer,
+		"ownProposal", proposal.OwnProposal,
+		"blocks", proposal.Blocks,
+		"gasUsed", proposal.GasUsed,
+		"windowExpired", proposal.ProvingWindowExpired,
+		"timeToExpire", proposal.TimeToExpire,
+		"queueDepth", proposal.QueueDepth,
+		"expectedReward", utils.WeiToEther(proposal.ExpectedReward),
+	)
+	metrics.ProverPolicyDecisionCounter.WithLabelValues(string(decision.Action), decision.Rule).Inc()
+
+	// During the proving window, only the assigned prover can prove the proposal, so proving the proposals of
+	// others has to wait for the window expiration.
+	action := decision.Action
+	if action == policy.ActionProve && !windowExpired && !proposal.OwnProposal {
+		log.Info(
+			"Proposal is not provable by current prover before its proving window expiration",
+			"id", proposal.ID,
+			"pacaya", proposal.Pacaya,
+			"proposer", proposal.Proposer,
+			"timeToExpire", timeToExpire,
+		)
+		action = policy.ActionWait
+	}
+
+	switch action {
+	case policy.ActionSkip:
+		return nil
+	case policy.ActionWait:
+		if !windowExpired {
+			time.AfterFunc(
+				// Add another 72 seconds, to ensure one more L1 block will be mined before the proof submission
+				timeToExpire+proofExpirationDelay,
+				func() { h.assignmentExpiredCh <- meta },
+			)
+			return nil
+		}
+	case policy.ActionProve:
+	}
+
+	reqBody := &proofProducer.ProofRequestBody{Meta: meta}
+	if !meta.IsPacaya() {
+		reqBody.Tier = meta.Ontake().GetMinTier()
+		if h.isGuardian {
+			reqBody.Tier = encoding.TierGuardianMinorityID
+		}
+	}
+
+	metrics.ProverProofsAssigned.Add(1)
+
+	h.proofSubmissionCh <- reqBody
+
+	return nil
+}
+
+// policyProposal assembles what the proving policy needs to know about the given proposal.
+func (h *BlockProposedEventHandler) policyProposal(
+	ctx context.Context,
+	meta metadata.TaikoProposalMetaData,
+	windowExpired bool,
+	timeToExpire time.Duration,
+) (*policy.Proposal, error) {
+	proposal := &policy.Proposal{
+		Pacaya:               meta.IsPacaya(),
+		Proposer:             meta.GetProposer(),
+		OwnProposal:          meta.GetProposer() == h.proverAddress || meta.GetProposer() == h.proverSetAddress,
+		ProvingWindowExpired: windowExpired,
+		QueueDepth:           h.sharedState.GetProvingQueueDepth(),
+	}
+	if !windowExpired {
+		proposal.TimeToExpire = timeToExpire
+	}
+
+	var firstBlockID, lastBlockID uint64
+	if meta.IsPacaya() {
+		proposal.ID = meta.Pacaya().GetBatchID()
+		lastBlockID = meta.Pacaya().GetLastBlockID()
+		firstBlockID = lastBlockID - uint64(len(meta.Pacaya().GetBlocks())) + 1
+	} else {
+		proposal.ID = meta.Ontake().GetBlockID()
+		firstBlockID, lastBlockID = proposal.ID.Uint64(), proposal.ID.Uint64()
+	}
+
+	reward, lateReward, err := ExpectedProvingRewards(ctx, h.rpc, meta, proposal.OwnProposal)
+	if err != nil {
+		return nil, err
+	}
+	if windowExpired {
+		proposal.ExpectedReward = lateReward
+	} else {
+		proposal.ExpectedReward = reward
+	}
+
+	for id := firstBlockID; id <= lastBlockID; id++ {
+		header, err := h.rpc.L2.HeaderByNumber(ctx, new(big.Int).SetUint64(id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch L2 block %d: %w", id, err)
+		}
+
+		proposal.Blocks++
+		proposal.GasUsed += header.GasUsed
+	}
+
+	return proposal, nil
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/policy"
+	proofProducer "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_producer"
+	state "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/shared_state"
+)
+
+// newPolicyHandler creates a BlockProposedEventHandler deciding by the given proving policy.
+func (s *EventHandlerTestSuite) newPolicyHandler(
+	data string,
+	proverAddress common.Address,
+) (*BlockProposedEventHandler, chan *proofProducer.ProofRequestBody) {
+	path := filepath.Join(s.T().TempDir(), "policy.yaml")
+	s.Nil(os.WriteFile(path, []byte(data), 0o600))
+
+	engine, err := policy.NewEngine(path, 0)
+	s.Nil(err)
+
+	proofSubmissionCh := make(chan *proofProducer.ProofRequestBody, 1)
+	return NewBlockProposedEventHandler(&NewBlockProposedEventHandlerOps{
+		SharedState:          &state.SharedState{},
+		ProverAddress:        proverAddress,
+		RPC:                  s.RPCClient,
+		ProofGenerationCh:    make(chan *proofProducer.ProofResponse),
+		AssignmentExpiredCh:  make(chan metadata.TaikoProposalMetaData, 1),
+		ProofSubmissionCh:    proofSubmissionCh,
+		ProofContestCh:       make(chan *proofProducer.ContestRequestBody),
+		BackOffRetryInterval: 1 * time.Minute,
+		BackOffMaxRetrys:     5,
+		Policy:               engine,
+	}), proofSubmissionCh
+}
+
+func (s *EventHandlerTestSuite) TestProveByPolicyOwnProposal() {
+	m := s.ProposeAndInsertValidBlock(s.proposer, s.blobSyncer)
+
+	handler, proofSubmissionCh := s.newPolicyHandler("default: prove", m.GetProposer())
+	s.Nil(handler.Handle(context.Background(), m, func() {}))
+	s.Len(proofSubmissionCh, 1)
+}
+
+func (s *EventHandlerTestSuite) TestProveByPolicyOthersProposal() {
+	m := s.ProposeAndInsertValidBlock(s.proposer, s.blobSyncer)
+
+	// The proposal of another proposer waits for its proving window expiration, even if the policy
+	// decides to prove it.
+	handler, proofSubmissionCh := s.newPolicyHandler("default: prove", common.Address{})
+	s.Nil(handler.Handle(context.Background(), m, func() {}))
+	s.Empty(proofSubmissionCh)
+}
+
+func (s *EventHandlerTestSuite) TestProveByPolicySkip() {
+	m := s.ProposeAndInsertValidBlock(s.proposer, s.blobSyncer)
+
+	handler, proofSubmissionCh := s.newPolicyHandler("default: skip", m.GetProposer())
+	s.Nil(handler.Handle(context.Background(), m, func() {}))
+	s.Empty(proofSubmissionCh)
+}
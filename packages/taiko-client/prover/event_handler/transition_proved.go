@@ -23,6 +23,8 @@ type TransitionProvedEventHandler struct {
 	proofSubmissionCh chan<- *proofProducer.ProofRequestBody
 	contesterMode     bool
 	isGuardian        bool
+	// submitConflictingProof is whether to prove an invalid proven Pacaya batch again.
+	submitConflictingProof bool
 }
 
 // NewTransitionProvedEventHandler creates a new TransitionProvedEventHandler instance.
@@ -32,6 +34,7 @@ func NewTransitionProvedEventHandler(
 	proofSubmissionCh chan *proofProducer.ProofRequestBody,
 	contesterMode bool,
 	isGuardian bool,
+	submitConflictingProof bool,
 ) *TransitionProvedEventHandler {
 	return &TransitionProvedEventHandler{
 		rpc,
@@ -39,6 +42,7 @@ func NewTransitionProvedEventHandler(
 		proofSubmissionCh,
 		contesterMode,
 		isGuardian,
+		submitConflictingProof,
 	}
 }
 
@@ -119,7 +123,7 @@ func (h *TransitionProvedEventHandler) Handle(
 	return nil
 }
 
-// Handle implements the TransitionProvedHandler interface.
+// HandlePacaya implements the TransitionProvedHandler interface.
 func (h *TransitionProvedEventHandler) HandlePacaya(
 	ctx context.Context,
 	e *pacayaBindings.TaikoInboxClientBatchesProved,
@@ -132,5 +136,68 @@ func (h *TransitionProvedEventHandler) HandlePacaya(
 	}
 	metrics.ProverReceivedProvenBlockGauge.Set(float64(batch.LastBlockId))
 
+	// If this prover is in contest mode, we check the validity of the proven transitions, since there is
+	// no contesting after Pacaya fork, the invalid ones are reported, and optionally proven again.
+	if !h.contesterMode {
+		return nil
+	}
+
+	// Checking a transition waits for the local L2 node to insert the proven batch, so the checks run in
+	// their own goroutine, to not block the prover event loop while the local L2 node lags behind.
+	go func() {
+		if err := h.checkTransitionsPacaya(ctx, e); err != nil {
+			log.Error("Failed to check proven batch transitions", "batchIDs", e.BatchIds, "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// checkTransitionsPacaya checks the proven transitions of the given event against the local L2 chain,
+// reports the invalid ones, and optionally proves their batches again.
+func (h *TransitionProvedEventHandler) checkTransitionsPacaya(
+	ctx context.Context,
+	e *pacayaBindings.TaikoInboxClientBatchesProved,
+) error {
+	for i, batchID := range e.BatchIds {
+		invalid, err := checkBatchTransitionPacaya(ctx, h.rpc, new(big.Int).SetUint64(batchID), e.Transitions[i])
+		if err != nil {
+			return err
+		}
+		// If the transition is valid, we simply continue.
+		if invalid == nil {
+			continue
+		}
+
+		metrics.ProverInvalidTransitionDetectedCounter.Add(1)
+		log.Error(
+			"Invalid proven batch transition detected",
+			"batchID", batchID,
+			"lastBlockID", invalid.batch.LastBlockId,
+			"verifier", e.Verifier,
+			"provingTx", e.Raw.TxHash,
+			"l1Height", e.Raw.BlockNumber,
+			"parentHash", common.Hash(e.Transitions[i].ParentHash),
+			"blockHash", common.Hash(e.Transitions[i].BlockHash),
+			"stateRoot", common.Hash(e.Transitions[i].StateRoot),
+			"localBlockHash", invalid.lastHeader.Hash(),
+			"localStateRoot", invalid.lastHeader.Root,
+		)
+
+		if !h.submitConflictingProof {
+			continue
+		}
+
+		meta, err := getMetadataFromBatchPacaya(ctx, h.rpc, invalid.batch)
+		if err != nil {
+			return err
+		}
+
+		log.Info("Attempting to prove an invalid proven batch again", "batchID", batchID)
+		go func() {
+			h.proofSubmissionCh <- &proofProducer.ProofRequestBody{Meta: meta}
+		}()
+	}
+
 	return nil
 }
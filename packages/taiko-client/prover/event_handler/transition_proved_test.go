@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"math/big"
 	"os"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	ontakeBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/ontake"
+	pacayaBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/driver"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/chain_syncer/beaconsync"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/driver/chain_syncer/blob"
@@ -52,7 +54,7 @@ func (s *EventHandlerTestSuite) SetupTest() {
 	s.d = d
 
 	// Init calldata syncer
-	testState, err := state.New(context.Background(), s.RPCClient)
+	testState, err := state.New(context.Background(), s.RPCClient, nil)
 	s.Nil(err)
 	s.Nil(testState.ResetL1Current(context.Background(), common.Big0))
 
@@ -63,6 +65,8 @@ func (s *EventHandlerTestSuite) SetupTest() {
 		testState,
 		tracker,
 		0,
+		0,
+		nil,
 		nil,
 		nil,
 	)
@@ -130,6 +134,7 @@ func (s *EventHandlerTestSuite) TestTransitionProvedHandle() {
 		make(chan *proofProducer.ProofRequestBody),
 		true,
 		false,
+		false,
 	)
 	m := s.ProposeAndInsertValidBlock(s.proposer, s.blobSyncer)
 	err := handler.Handle(context.Background(), &ontakeBindings.TaikoL1ClientTransitionProvedV2{
@@ -140,6 +145,49 @@ func (s *EventHandlerTestSuite) TestTransitionProvedHandle() {
 	s.Nil(err)
 }
 
+func (s *EventHandlerTestSuite) TestTransitionProvedHandlePacaya() {
+	proofSubmissionCh := make(chan *proofProducer.ProofRequestBody, 1)
+	handler := NewTransitionProvedEventHandler(
+		s.RPCClient,
+		make(chan *proofProducer.ContestRequestBody),
+		proofSubmissionCh,
+		true,
+		false,
+		true,
+	)
+	m := s.ProposeAndInsertValidBlock(s.proposer, s.blobSyncer)
+	if !m.IsPacaya() {
+		s.T().Skip("Pacaya fork is not activated")
+	}
+
+	proofStatus, err := rpc.GetBatchProofStatus(context.Background(), s.RPCClient, m.Pacaya().GetBatchID())
+	s.Nil(err)
+	lastHeader, err := s.RPCClient.L2.HeaderByNumber(
+		context.Background(),
+		new(big.Int).SetUint64(m.Pacaya().GetLastBlockID()),
+	)
+	s.Nil(err)
+
+	// A valid transition.
+	e := &pacayaBindings.TaikoInboxClientBatchesProved{
+		BatchIds: []uint64{m.Pacaya().GetBatchID().Uint64()},
+		Transitions: []pacayaBindings.ITaikoInboxTransition{{
+			ParentHash: proofStatus.ParentHeader.Hash(),
+			BlockHash:  lastHeader.Hash(),
+			StateRoot:  lastHeader.Root,
+		}},
+	}
+	s.Nil(handler.HandlePacaya(context.Background(), e))
+	s.Never(func() bool { return len(proofSubmissionCh) > 0 }, 3*time.Second, 100*time.Millisecond)
+
+	// An invalid transition is proven again.
+	e.Transitions[0].BlockHash = common.BytesToHash(testutils.RandomBytes(32))
+	s.Nil(handler.HandlePacaya(context.Background(), e))
+
+	req := <-proofSubmissionCh
+	s.Equal(m.Pacaya().GetBatchID(), req.Meta.Pacaya().GetBatchID())
+}
+
 func TestTransitionProvedEventHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(EventHandlerTestSuite))
 }
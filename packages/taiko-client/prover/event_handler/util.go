@@ -7,10 +7,13 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
+	pacayaBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
 	eventIterator "github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/chain_iterator/event_iterator"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
 )
@@ -61,6 +64,108 @@ func isValidProof(
 		l2Header.Root == stateRoot, nil
 }
 
+// invalidTransitionPacaya is a proven Pacaya batch transition which disagrees with the local L2 chain.
+type invalidTransitionPacaya struct {
+	batch      *pacayaBindings.ITaikoInboxBatch
+	lastHeader *types.Header
+}
+
+// checkBatchTransitionPacaya compares the given proven transition with the current L2 node canonical chain,
+// and returns the local batch information if they disagree. A transition with a non-canonical parent is
+// ignored, since it will never be used to verify the batch.
+func checkBatchTransitionPacaya(
+	ctx context.Context,
+	cli *rpc.Client,
+	batchID *big.Int,
+	tran pacayaBindings.ITaikoInboxTransition,
+) (*invalidTransitionPacaya, error) {
+	batch, err := cli.GetBatchByID(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wait for the batch's last block being inserted in the local L2 node.
+	lastHeader, err := cli.WaitL2Header(ctx, new(big.Int).SetUint64(batch.LastBlockId))
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait L2 header (batchID %d): %w", batchID, err)
+	}
+	if lastHeader.Hash() == tran.BlockHash && lastHeader.Root == tran.StateRoot {
+		return nil, nil
+	}
+
+	proofStatus, err := rpc.GetBatchProofStatus(ctx, cli, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if proofStatus.ParentHeader.Hash() != tran.ParentHash {
+		log.Warn(
+			"Proven batch transition with a non-canonical parent",
+			"batchID", batchID,
+			"parentHash", common.Hash(tran.ParentHash),
+			"canonicalParentHash", proofStatus.ParentHeader.Hash(),
+		)
+		return nil, nil
+	}
+
+	return &invalidTransitionPacaya{batch: batch, lastHeader: lastHeader}, nil
+}
+
+// getMetadataFromBatchPacaya fetches the batch meta from the onchain event of the given batch, which is
+// proposed within `maxAnchorHeightOffset` L1 blocks after its anchor block.
+func getMetadataFromBatchPacaya(
+	ctx context.Context,
+	cli *rpc.Client,
+	batch *pacayaBindings.ITaikoInboxBatch,
+) (m metadata.TaikoProposalMetaData, err error) {
+	configs, err := cli.PacayaClients.TaikoInbox.PacayaConfig(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Pacaya protocol configs: %w", err)
+	}
+	l1Head, err := cli.L1.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	callback := func(
+		_ context.Context,
+		meta metadata.TaikoProposalMetaData,
+		end eventIterator.EndBlockProposedEventIterFunc,
+	) error {
+		// Only filter for exact batchID we want.
+		if !meta.IsPacaya() || meta.Pacaya().GetBatchID().Uint64() != batch.BatchId {
+			return nil
+		}
+
+		m = meta
+		end()
+
+		return nil
+	}
+
+	iter, err := eventIterator.NewBlockProposedIterator(ctx, &eventIterator.BlockProposedIteratorConfig{
+		Client:               cli.L1,
+		TaikoL1:              cli.OntakeClients.TaikoL1,
+		TaikoInbox:           cli.PacayaClients.TaikoInbox,
+		StartHeight:          new(big.Int).SetUint64(batch.AnchorBlockId),
+		EndHeight:            new(big.Int).SetUint64(min(batch.AnchorBlockId+configs.MaxAnchorHeightOffset, l1Head)),
+		OnBlockProposedEvent: callback,
+	})
+	if err != nil {
+		log.Error("Failed to start event iterator", "event", "BatchProposed", "error", err)
+		return nil, err
+	}
+
+	if err := iter.Iter(); err != nil {
+		return nil, err
+	}
+
+	if m == nil {
+		return nil, fmt.Errorf("failed to find BatchProposed event for batch %d", batch.BatchId)
+	}
+
+	return m, nil
+}
+
 // getProvingWindowOntake returns the provingWindow of the given tier.
 func getProvingWindowOntake(
 	tier uint16,
@@ -75,6 +180,36 @@ func getProvingWindowOntake(
 	return 0, errTierNotFound
 }
 
+// ExpectedProvingRewards returns the bonds earned or kept by proving the given proposal within its proving
+// window, and after it, ownProposal tells whether the proposer is the prover itself or its prover set.
+func ExpectedProvingRewards(
+	ctx context.Context,
+	cli *rpc.Client,
+	meta metadata.TaikoProposalMetaData,
+	ownProposal bool,
+) (*big.Int, *big.Int, error) {
+	if !meta.IsPacaya() {
+		// The liveness bond of a block is only returned to its assigned prover within the proving window.
+		if ownProposal {
+			return meta.Ontake().GetLivenessBond(), common.Big0, nil
+		}
+		return common.Big0, common.Big0, nil
+	}
+
+	// The liveness bond of a batch is returned to its proposer if it is proven within the proving window,
+	// otherwise half of it rewards the prover.
+	batch, err := cli.GetBatchByID(ctx, meta.Pacaya().GetBatchID())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get batch %d: %w", meta.Pacaya().GetBatchID(), err)
+	}
+	lateReward := new(big.Int).Div(batch.LivenessBond, common.Big2)
+	if ownProposal {
+		return batch.LivenessBond, lateReward, nil
+	}
+
+	return common.Big0, lateReward, nil
+}
+
 // getMetadataFromBlockIDOntake fetches the block meta from the onchain event by the given block id.
 func getMetadataFromBlockIDOntake(
 	ctx context.Context,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"golang.org/x/sync/errgroup"
 )
@@ -27,29 +28,28 @@ func (p *Prover) guardianProverHeartbeatLoop(ctx context.Context) {
 			return
 		case <-ticker.C:
 			var (
-				latestL1Block uint64
-				latestL2Block uint64
-				err           error
-				g             = new(errgroup.Group)
+				latestL1Head *types.Header
+				latestL2Head *types.Header
+				g            = new(errgroup.Group)
 			)
 
-			g.Go(func() error {
-				latestL1Block, err = p.rpc.L1.BlockNumber(ctx)
+			g.Go(func() (err error) {
+				latestL1Head, err = p.rpc.L1.HeaderByNumber(ctx, nil)
 				return err
 			})
-			g.Go(func() error {
-				latestL2Block, err = p.rpc.L2.BlockNumber(ctx)
+			g.Go(func() (err error) {
+				latestL2Head, err = p.rpc.L2.HeaderByNumber(ctx, nil)
 				return err
 			})
 			if err := g.Wait(); err != nil {
-				log.Error("Failed to get latest L1/L2 block number", "error", err)
+				log.Error("Failed to get latest L1/L2 head", "error", err)
 				continue
 			}
 
 			if err := p.guardianProverHeartbeater.SendHeartbeat(
 				ctx,
-				latestL1Block,
-				latestL2Block,
+				latestL1Head,
+				latestL2Head,
 			); err != nil {
 				log.Error("Failed to send guardian prover heartbeat", "error", err)
 			}
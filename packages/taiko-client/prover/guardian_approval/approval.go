@@ -0,0 +1,83 @@
+// Package approval implements the manual approval workflow of the guardian prover, which stages the guardian
+// proofs until they are approved or rejected by a human, or the approval timeout elapses.
+package approval
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Status is the status of a staged approval.
+type Status string
+
+const (
+	StatusPending      Status = "pending"
+	StatusApproved     Status = "approved"
+	StatusRejected     Status = "rejected"
+	StatusAutoApproved Status = "auto_approved"
+	StatusAutoRejected Status = "auto_rejected"
+	StatusCanceled     Status = "canceled"
+)
+
+// Approved checks whether the status allows the guardian proof to be submitted.
+func (s Status) Approved() bool {
+	return s == StatusApproved || s == StatusAutoApproved
+}
+
+// Transition is a transition of an L2 block, either the one the guardian is about to approve, or a competing
+// one already on chain.
+type Transition struct {
+	ParentHash common.Hash    `json:"parentHash"`
+	BlockHash  common.Hash    `json:"blockHash"`
+	StateRoot  common.Hash    `json:"stateRoot"`
+	Prover     common.Address `json:"prover,omitempty"`
+	Contester  common.Address `json:"contester,omitempty"`
+	Tier       uint16         `json:"tier"`
+	Timestamp  uint64         `json:"timestamp,omitempty"`
+}
+
+// Equal checks whether the given transition moves the block from the same parent to the same block hash and
+// state root, regardless of who proves or contests it.
+func (t *Transition) Equal(other *Transition) bool {
+	return t.ParentHash == other.ParentHash && t.BlockHash == other.BlockHash && t.StateRoot == other.StateRoot
+}
+
+// Verification is the result of verifying the transition to approve against the local L2 node.
+type Verification struct {
+	LocalBlockHash common.Hash `json:"localBlockHash"`
+	LocalStateRoot common.Hash `json:"localStateRoot"`
+	Canonical      bool        `json:"canonical"`   // Whether the block is the canonical one of the local L2 node
+	AnchorValid    bool        `json:"anchorValid"` // Whether the anchor transaction of the block is valid
+	Error          string      `json:"error,omitempty"`
+}
+
+// Valid checks whether the transition to approve is verified by the local L2 node.
+func (v *Verification) Valid() bool {
+	return v.Canonical && v.AnchorValid && v.Error == ""
+}
+
+// Approval is a guardian proof staged for approval.
+type Approval struct {
+	BlockID      *big.Int       `json:"blockID"`
+	Coinbase     common.Address `json:"coinbase"`
+	Transition   *Transition    `json:"transition"`
+	Competing    []*Transition  `json:"competing"`
+	Verification *Verification  `json:"verification"`
+	Status       Status         `json:"status"`
+	StagedAt     time.Time      `json:"stagedAt"`
+	Deadline     time.Time      `json:"deadline"` // When the timeout policy applies, zero if none
+	DecidedAt    time.Time      `json:"decidedAt"`
+	DecidedBy    string         `json:"decidedBy,omitempty"`
+	Reason       string         `json:"reason,omitempty"`
+
+	decided chan struct{}
+}
+
+// snapshot returns a shallow copy of the approval, which is safe to read without the lock of its manager.
+func (a *Approval) snapshot() *Approval {
+	c := *a
+	c.decided = nil
+	return &c
+}
@@ -0,0 +1,198 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/metrics"
+)
+
+// maxDecidedApprovals is the number of the decided approvals kept for the dashboard.
+const maxDecidedApprovals = 256
+
+var (
+	ErrNotFound          = errors.New("approval not found")
+	ErrDecided           = errors.New("approval already decided")
+	ErrTransitionPending = errors.New("another transition of the block is pending approval")
+)
+
+// Manager keeps the staged approvals, and applies the decisions made on them.
+type Manager struct {
+	timeout     time.Duration
+	autoApprove bool
+
+	mu        sync.Mutex
+	approvals map[string]*Approval
+	decided   []*Approval // Oldest first
+}
+
+// NewManager creates a new approval manager. If the timeout is not zero, the approvals not decided in time
+// are approved if autoApprove is set, and rejected otherwise.
+func NewManager(timeout time.Duration, autoApprove bool) *Manager {
+	return &Manager{
+		timeout:     timeout,
+		autoApprove: autoApprove,
+		approvals:   make(map[string]*Approval),
+	}
+}
+
+// Stage stages the given approval, and waits until it is decided, or the given context is canceled. If there
+// is a pending approval of the same block and transition already, it waits for that one instead, and if the
+// pending one has another transition, ErrTransitionPending is returned, since its decision does not apply.
+func (m *Manager) Stage(ctx context.Context, a *Approval) (Status, error) {
+	m.mu.Lock()
+	if pending, ok := m.approvals[a.BlockID.String()]; ok && pending.Status == StatusPending {
+		if !pending.Transition.Equal(a.Transition) {
+			m.mu.Unlock()
+			return "", ErrTransitionPending
+		}
+		a = pending
+	} else {
+		a.Status = StatusPending
+		a.StagedAt = time.Now()
+		if m.timeout != 0 {
+			a.Deadline = a.StagedAt.Add(m.timeout)
+		}
+		a.decided = make(chan struct{})
+		m.approvals[a.BlockID.String()] = a
+
+		log.Info(
+			"Guardian proof staged for approval",
+			"blockID", a.BlockID,
+			"blockHash", a.Transition.BlockHash,
+			"competing", len(a.Competing),
+			"verified", a.Verification.Valid(),
+			"deadline", a.Deadline,
+		)
+		metrics.ProverGuardianApprovalPendingGauge.Inc()
+	}
+	m.mu.Unlock()
+
+	var timeoutCh <-chan time.Time
+	if !a.Deadline.IsZero() {
+		timer := time.NewTimer(time.Until(a.Deadline))
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	select {
+	case <-a.decided:
+	case <-timeoutCh:
+		status := StatusAutoRejected
+		if m.autoApprove {
+			status = StatusAutoApproved
+		}
+		// The approval may be decided at the same time, then that decision is kept.
+		if err := m.decide(a, status, "timeout", "approval timeout elapsed"); err != nil && !errors.Is(err, ErrDecided) {
+			return "", err
+		}
+	case <-ctx.Done():
+		if err := m.decide(a, StatusCanceled, "prover", ctx.Err().Error()); err != nil && !errors.Is(err, ErrDecided) {
+			return "", err
+		}
+		return StatusCanceled, ctx.Err()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return a.Status, nil
+}
+
+// Approve approves the pending approval of the given block.
+func (m *Manager) Approve(blockID *big.Int, by string, reason string) (*Approval, error) {
+	return m.decideByID(blockID, StatusApproved, by, reason)
+}
+
+// Reject rejects the pending approval of the given block.
+func (m *Manager) Reject(blockID *big.Int, by string, reason string) (*Approval, error) {
+	return m.decideByID(blockID, StatusRejected, by, reason)
+}
+
+// Get returns the latest approval of the given block.
+func (m *Manager) Get(blockID *big.Int) (*Approval, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, ok := m.approvals[blockID.String()]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return a.snapshot(), nil
+}
+
+// List returns the pending approvals and the recently decided ones, the latest block first.
+func (m *Manager) List() []*Approval {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	approvals := make([]*Approval, 0, len(m.approvals))
+	for _, a := range m.approvals {
+		approvals = append(approvals, a.snapshot())
+	}
+	slices.SortFunc(approvals, func(a, b *Approval) int { return b.BlockID.Cmp(a.BlockID) })
+
+	return approvals
+}
+
+// decideByID applies the given decision to the pending approval of the given block.
+func (m *Manager) decideByID(blockID *big.Int, status Status, by string, reason string) (*Approval, error) {
+	m.mu.Lock()
+	a, ok := m.approvals[blockID.String()]
+	m.mu.Unlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	if err := m.decide(a, status, by, reason); err != nil {
+		return nil, err
+	}
+
+	return m.Get(blockID)
+}
+
+// decide applies the given decision to the given approval, if it is still pending.
+func (m *Manager) decide(a *Approval, status Status, by string, reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if a.Status != StatusPending {
+		return ErrDecided
+	}
+
+	a.Status = status
+	a.DecidedAt = time.Now()
+	a.DecidedBy = by
+	a.Reason = reason
+	close(a.decided)
+
+	log.Info(
+		"Guardian proof approval decided",
+		"blockID", a.BlockID,
+		"status", a.Status,
+		"by", a.DecidedBy,
+		"reason", a.Reason,
+		"waited", a.DecidedAt.Sub(a.StagedAt),
+	)
+	metrics.ProverGuardianApprovalPendingGauge.Dec()
+	metrics.ProverGuardianApprovalDecisionCounter.WithLabelValues(string(status)).Inc()
+
+	// Only keep the latest decided approvals.
+	m.decided = append(m.decided, a)
+	if len(m.decided) > maxDecidedApprovals {
+		oldest := m.decided[0]
+		m.decided = m.decided[1:]
+		if m.approvals[oldest.BlockID.String()] == oldest {
+			delete(m.approvals, oldest.BlockID.String())
+		}
+	}
+
+	return nil
+}
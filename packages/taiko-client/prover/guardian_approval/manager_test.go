@@ -0,0 +1,130 @@
+package approval
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestApproval(id int64) *Approval {
+	return &Approval{BlockID: big.NewInt(id), Transition: &Transition{}, Verification: &Verification{}}
+}
+
+// stageAsync stages the given approval in a new goroutine, and returns the channel of its final status.
+func stageAsync(ctx context.Context, m *Manager, a *Approval) <-chan Status {
+	ch := make(chan Status, 1)
+	go func() {
+		status, _ := m.Stage(ctx, a)
+		ch <- status
+	}()
+	return ch
+}
+
+// waitPending waits until the approval of the given block is pending.
+func waitPending(t *testing.T, m *Manager, id int64) {
+	require.Eventually(t, func() bool {
+		a, err := m.Get(big.NewInt(id))
+		return err == nil && a.Status == StatusPending
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestManagerApproveAndReject(t *testing.T) {
+	m := NewManager(0, false)
+
+	_, err := m.Approve(big.NewInt(1), "test", "")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	approvedCh := stageAsync(context.Background(), m, newTestApproval(1))
+	rejectedCh := stageAsync(context.Background(), m, newTestApproval(2))
+	waitPending(t, m, 1)
+	waitPending(t, m, 2)
+	require.Len(t, m.List(), 2)
+	require.Equal(t, int64(2), m.List()[0].BlockID.Int64())
+
+	a, err := m.Approve(big.NewInt(1), "alice", "looks good")
+	require.Nil(t, err)
+	require.Equal(t, StatusApproved, a.Status)
+	require.Equal(t, "alice", a.DecidedBy)
+	require.Equal(t, StatusApproved, <-approvedCh)
+
+	_, err = m.Reject(big.NewInt(2), "bob", "wrong state root")
+	require.Nil(t, err)
+	require.Equal(t, StatusRejected, <-rejectedCh)
+
+	_, err = m.Approve(big.NewInt(2), "alice", "")
+	require.ErrorIs(t, err, ErrDecided)
+}
+
+func TestManagerTimeout(t *testing.T) {
+	status, err := NewManager(10*time.Millisecond, true).Stage(context.Background(), newTestApproval(1))
+	require.Nil(t, err)
+	require.Equal(t, StatusAutoApproved, status)
+	require.True(t, status.Approved())
+
+	status, err = NewManager(10*time.Millisecond, false).Stage(context.Background(), newTestApproval(1))
+	require.Nil(t, err)
+	require.Equal(t, StatusAutoRejected, status)
+	require.False(t, status.Approved())
+}
+
+func TestManagerStageSameBlock(t *testing.T) {
+	m := NewManager(100*time.Millisecond, true)
+
+	firstCh := stageAsync(context.Background(), m, newTestApproval(1))
+	waitPending(t, m, 1)
+	staged, err := m.Get(big.NewInt(1))
+	require.Nil(t, err)
+
+	// The second one waits for the pending approval of the same block.
+	status, err := m.Stage(context.Background(), newTestApproval(1))
+	require.Nil(t, err)
+	require.Equal(t, StatusAutoApproved, status)
+	require.Equal(t, StatusAutoApproved, <-firstCh)
+
+	decided, err := m.Get(big.NewInt(1))
+	require.Nil(t, err)
+	require.Equal(t, staged.StagedAt, decided.StagedAt)
+	require.Len(t, m.List(), 1)
+}
+
+func TestManagerStageOtherTransition(t *testing.T) {
+	m := NewManager(0, false)
+
+	firstCh := stageAsync(context.Background(), m, newTestApproval(1))
+	waitPending(t, m, 1)
+
+	// The decision on the pending transition does not apply to another transition of the same block.
+	other := newTestApproval(1)
+	other.Transition.BlockHash = common.HexToHash("0x1")
+	_, err := m.Stage(context.Background(), other)
+	require.ErrorIs(t, err, ErrTransitionPending)
+
+	_, err = m.Approve(big.NewInt(1), "alice", "")
+	require.Nil(t, err)
+	require.Equal(t, StatusApproved, <-firstCh)
+
+	// Once the pending one is decided, the other transition is staged for its own decision.
+	otherCh := stageAsync(context.Background(), m, other)
+	waitPending(t, m, 1)
+	a, err := m.Reject(big.NewInt(1), "bob", "")
+	require.Nil(t, err)
+	require.Equal(t, common.HexToHash("0x1"), a.Transition.BlockHash)
+	require.Equal(t, StatusRejected, <-otherCh)
+}
+
+func TestManagerCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := NewManager(0, false)
+
+	ch := stageAsync(ctx, m, newTestApproval(1))
+	waitPending(t, m, 1)
+	cancel()
+
+	require.Equal(t, StatusCanceled, <-ch)
+	_, err := m.Approve(big.NewInt(1), "alice", "")
+	require.ErrorIs(t, err, ErrDecided)
+}
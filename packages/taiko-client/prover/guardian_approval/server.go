@@ -0,0 +1,126 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	echojwt "github.com/labstack/echo-jwt/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// Server serves the staged guardian proof approvals over HTTP/JSON, and takes the decisions on them. All the
+// approval endpoints are authenticated by the given JWT secret.
+type Server struct {
+	echo    *echo.Echo
+	manager *Manager
+}
+
+// decisionRequest is the optional request body of an approval decision.
+type decisionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// NewServer creates a new guardian proof approval server instance.
+func NewServer(manager *Manager, jwtSecret []byte) *Server {
+	server := &Server{echo: echo.New(), manager: manager}
+
+	server.echo.HideBanner = true
+	server.echo.Use(middleware.RequestID())
+	server.echo.Use(middleware.Recover())
+
+	server.echo.GET("/healthz", server.HealthCheck)
+
+	approvals := server.echo.Group("/approvals", echojwt.JWT(jwtSecret))
+	approvals.GET("", server.GetApprovals)
+	approvals.GET("/:id", server.GetApproval)
+	approvals.POST("/:id/approve", server.Approve)
+	approvals.POST("/:id/reject", server.Reject)
+
+	return server
+}
+
+// Start starts the HTTP server.
+func (s *Server) Start(port uint64) error {
+	return s.echo.Start(fmt.Sprintf(":%v", port))
+}
+
+// Shutdown shuts down the HTTP server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.echo.Shutdown(ctx)
+}
+
+// HealthCheck is the endpoints for probes.
+func (s *Server) HealthCheck(c echo.Context) error {
+	return c.NoContent(http.StatusOK)
+}
+
+// GetApprovals returns the pending approvals and the recently decided ones, the latest block first.
+func (s *Server) GetApprovals(c echo.Context) error {
+	return c.JSON(http.StatusOK, s.manager.List())
+}
+
+// GetApproval returns the latest approval of the given block.
+func (s *Server) GetApproval(c echo.Context) error {
+	blockID, ok := new(big.Int).SetString(c.Param("id"), 10)
+	if !ok {
+		return s.returnError(c, http.StatusBadRequest, fmt.Errorf("invalid block ID: %s", c.Param("id")))
+	}
+
+	approval, err := s.manager.Get(blockID)
+	if err != nil {
+		return s.returnError(c, http.StatusNotFound, err)
+	}
+
+	return c.JSON(http.StatusOK, approval)
+}
+
+// Approve approves the pending approval of the given block, so that its guardian proof is submitted.
+func (s *Server) Approve(c echo.Context) error {
+	return s.decide(c, s.manager.Approve)
+}
+
+// Reject rejects the pending approval of the given block, so that its guardian proof is dropped.
+func (s *Server) Reject(c echo.Context) error {
+	return s.decide(c, s.manager.Reject)
+}
+
+// decide applies the decision of the request with the given function.
+func (s *Server) decide(
+	c echo.Context,
+	decide func(blockID *big.Int, by string, reason string) (*Approval, error),
+) error {
+	blockID, ok := new(big.Int).SetString(c.Param("id"), 10)
+	if !ok {
+		return s.returnError(c, http.StatusBadRequest, fmt.Errorf("invalid block ID: %s", c.Param("id")))
+	}
+
+	req := new(decisionRequest)
+	if c.Request().ContentLength != 0 {
+		if err := c.Bind(req); err != nil {
+			return s.returnError(c, http.StatusBadRequest, err)
+		}
+	}
+
+	approval, err := decide(blockID, c.RealIP(), req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNotFound):
+			return s.returnError(c, http.StatusNotFound, err)
+		case errors.Is(err, ErrDecided):
+			return s.returnError(c, http.StatusConflict, err)
+		default:
+			return s.returnError(c, http.StatusInternalServerError, err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, approval)
+}
+
+// returnError writes the given error to the response.
+func (s *Server) returnError(c echo.Context, statusCode int, err error) error {
+	return c.JSON(statusCode, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,90 @@
+package guardianproverheartbeater
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-resty/resty/v2"
+
+	guardianproverauth "github.com/taikoxyz/taiko-mono/packages/guardian-prover-auth"
+)
+
+// heartbeatProtocolResp is the response body of the health check server's protocol endpoint.
+type heartbeatProtocolResp struct {
+	Versions []uint64 `json:"versions"`
+}
+
+// newHeartbeatAuth creates a new heartbeat auth payload for the given protocol version and heads.
+func newHeartbeatAuth(
+	version uint64,
+	l1Head *types.Header,
+	l2Head *types.Header,
+) (*guardianproverauth.HeartbeatAuth, error) {
+	if version == guardianproverauth.HeartbeatVersionLegacy {
+		return &guardianproverauth.HeartbeatAuth{
+			LatestL1Block: l1Head.Number.Uint64(),
+			LatestL2Block: l2Head.Number.Uint64(),
+		}, nil
+	}
+
+	var nonce common.Hash
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate heartbeat nonce: %w", err)
+	}
+
+	return &guardianproverauth.HeartbeatAuth{
+		Version:           version,
+		Timestamp:         uint64(time.Now().Unix()),
+		Nonce:             nonce.Hex(),
+		LatestL1Block:     l1Head.Number.Uint64(),
+		LatestL1BlockHash: l1Head.Hash().Hex(),
+		LatestL2Block:     l2Head.Number.Uint64(),
+		LatestL2BlockHash: l2Head.Hash().Hex(),
+	}, nil
+}
+
+// protocolVersion returns the heartbeat protocol version to use with the health check server,
+// the result is cached until a request to the server fails.
+func (s *GuardianProverHeartBeater) protocolVersion(ctx context.Context) uint64 {
+	if version := s.version.Load(); version != 0 {
+		return version
+	}
+
+	resp, err := resty.New().R().
+		SetContext(ctx).
+		SetHeader("Accept", "application/json").
+		SetResult(&heartbeatProtocolResp{}).
+		Get(fmt.Sprintf("%v/heartbeatProtocol", s.healthCheckServerEndpoint.String()))
+	if err != nil {
+		// Try the latest version, we will negotiate again after the request fails.
+		log.Warn("Failed to negotiate heartbeat protocol version", "error", err)
+		return guardianproverauth.HeartbeatVersion
+	}
+
+	// Servers without the protocol endpoint only support the legacy protocol.
+	version := guardianproverauth.HeartbeatVersionLegacy
+	if resp.StatusCode() != http.StatusNotFound {
+		if !resp.IsSuccess() {
+			log.Warn("Failed to negotiate heartbeat protocol version", "statusCode", resp.StatusCode())
+			return guardianproverauth.HeartbeatVersion
+		}
+
+		for _, v := range resp.Result().(*heartbeatProtocolResp).Versions {
+			if v == guardianproverauth.HeartbeatVersion {
+				version = v
+			}
+		}
+	}
+
+	log.Info("Negotiated guardian prover heartbeat protocol version", "version", version)
+
+	s.version.Store(version)
+
+	return version
+}
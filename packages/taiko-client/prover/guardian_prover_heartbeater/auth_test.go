@@ -0,0 +1,72 @@
+package guardianproverheartbeater
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	guardianproverauth "github.com/taikoxyz/taiko-mono/packages/guardian-prover-auth"
+)
+
+var (
+	testProver = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	testL1Head = &types.Header{Number: big.NewInt(100), Difficulty: common.Big0}
+	testL2Head = &types.Header{Number: big.NewInt(200), Difficulty: common.Big0}
+)
+
+// decodeHeartbeatAuth decodes the auth payload of the given request, the same way the health check
+// server binds it.
+func decodeHeartbeatAuth(t *testing.T, req interface{}) *guardianproverauth.HeartbeatAuth {
+	b, err := json.Marshal(req)
+	require.Nil(t, err)
+
+	auth := new(guardianproverauth.HeartbeatAuth)
+	require.Nil(t, json.Unmarshal(b, auth))
+
+	return auth
+}
+
+func TestNewHeartbeatAuth(t *testing.T) {
+	auth, err := newHeartbeatAuth(guardianproverauth.HeartbeatVersion, testL1Head, testL2Head)
+	require.Nil(t, err)
+	require.False(t, auth.IsLegacy())
+	require.Equal(t, testL1Head.Hash().Hex(), auth.LatestL1BlockHash)
+	require.Equal(t, testL2Head.Hash().Hex(), auth.LatestL2BlockHash)
+
+	other, err := newHeartbeatAuth(guardianproverauth.HeartbeatVersion, testL1Head, testL2Head)
+	require.Nil(t, err)
+	require.NotEqual(t, auth.Nonce, other.Nonce)
+
+	legacy, err := newHeartbeatAuth(guardianproverauth.HeartbeatVersionLegacy, testL1Head, testL2Head)
+	require.Nil(t, err)
+	require.True(t, legacy.IsLegacy())
+	require.Equal(t, uint64(100), legacy.LatestL1Block)
+	require.Equal(t, uint64(200), legacy.LatestL2Block)
+}
+
+func TestRequestsSignedMessages(t *testing.T) {
+	for _, version := range []uint64{guardianproverauth.HeartbeatVersionLegacy, guardianproverauth.HeartbeatVersion} {
+		auth, err := newHeartbeatAuth(version, testL1Head, testL2Head)
+		require.Nil(t, err)
+
+		decoded := decodeHeartbeatAuth(t, &healthCheckReq{HeartbeatAuth: *auth, ProverAddress: testProver.Hex()})
+		require.Equal(t, auth, decoded)
+		require.Equal(
+			t,
+			guardianproverauth.HeartbeatMessage(testProver, auth),
+			guardianproverauth.HeartbeatMessage(testProver, decoded),
+		)
+
+		decoded = decodeHeartbeatAuth(t, &startupReq{HeartbeatAuth: *auth, ProverAddress: testProver.Hex()})
+		require.Equal(t, auth, decoded)
+		require.Equal(
+			t,
+			guardianproverauth.StartupMessage(testProver, "abc123", "v1.0.0", "geth/v1", "taiko-geth/v1", auth),
+			guardianproverauth.StartupMessage(testProver, "abc123", "v1.0.0", "geth/v1", "taiko-geth/v1", decoded),
+		)
+	}
+}
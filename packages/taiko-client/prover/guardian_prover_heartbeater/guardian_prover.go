@@ -2,30 +2,31 @@ package guardianproverheartbeater
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 	"net/url"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/go-resty/resty/v2"
 
+	guardianproverauth "github.com/taikoxyz/taiko-mono/packages/guardian-prover-auth"
+	"github.com/taikoxyz/taiko-mono/packages/signer"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
 )
 
 // healthCheckReq is the request body sent to the health check server when a heartbeat is sent.
 type healthCheckReq struct {
+	guardianproverauth.HeartbeatAuth
 	ProverAddress      string `json:"prover"`
 	HeartBeatSignature []byte `json:"heartBeatSignature"`
-	LatestL1Block      uint64 `json:"latestL1Block"`
-	LatestL2Block      uint64 `json:"latestL2Block"`
 }
 
 // signedBlockReq is the request body sent to the health check server when a block is signed.
 type signedBlockReq struct {
+	Version   uint64         `json:"version,omitempty"`
 	BlockID   uint64         `json:"blockID"`
 	BlockHash string         `json:"blockHash"`
 	Signature []byte         `json:"signature"`
@@ -34,6 +35,7 @@ type signedBlockReq struct {
 
 // startupReq is the request body send to the health check server when the guardian prover starts up.
 type startupReq struct {
+	guardianproverauth.HeartbeatAuth
 	ProverAddress   string `json:"prover"`
 	GuardianVersion string `json:"guardianVersion"`
 	L1NodeVersion   string `json:"l1NodeVersion"`
@@ -44,27 +46,39 @@ type startupReq struct {
 
 // GuardianProverHeartBeater is responsible for signing and sending known blocks to the health check server.
 type GuardianProverHeartBeater struct {
-	privateKey                *ecdsa.PrivateKey
+	signer                    signer.Signer
 	healthCheckServerEndpoint *url.URL
 	rpc                       *rpc.Client
 	proverAddress             common.Address
+	// version is the negotiated heartbeat protocol version, zero if not negotiated yet.
+	version atomic.Uint64
 }
 
 // New creates a new GuardianProverBlockSender instance.
 func New(
-	privateKey *ecdsa.PrivateKey,
+	signer signer.Signer,
 	healthCheckServerEndpoint *url.URL,
 	rpc *rpc.Client,
 	proverAddress common.Address,
 ) *GuardianProverHeartBeater {
 	return &GuardianProverHeartBeater{
-		privateKey:                privateKey,
+		signer:                    signer,
 		healthCheckServerEndpoint: healthCheckServerEndpoint,
 		rpc:                       rpc,
 		proverAddress:             proverAddress,
 	}
 }
 
+// sign signs the given hash. Remote signers never sign raw hashes, so they sign it as an EIP-191 personal
+// message instead, which the health check server accepts as well.
+func (s *GuardianProverHeartBeater) sign(ctx context.Context, hash []byte) ([]byte, error) {
+	if hashSigner, ok := s.signer.(signer.HashSigner); ok {
+		return hashSigner.SignHash(hash)
+	}
+
+	return s.signer.SignData(ctx, hash)
+}
+
 // post sends the given POST request to the health check server.
 func (s *GuardianProverHeartBeater) post(ctx context.Context, route string, req interface{}) error {
 	resp, err := resty.New().R().
@@ -74,10 +88,14 @@ func (s *GuardianProverHeartBeater) post(ctx context.Context, route string, req
 		SetBody(req).
 		Post(fmt.Sprintf("%v/%v", s.healthCheckServerEndpoint.String(), route))
 	if err != nil {
+		s.version.Store(0)
 		return err
 	}
 
 	if !resp.IsSuccess() {
+		// Negotiate the protocol version again, in case the server has been upgraded.
+		s.version.Store(0)
+
 		return fmt.Errorf(
 			"unable to contact health check server endpoint, status code: %v",
 			resp.StatusCode(),
@@ -89,7 +107,9 @@ func (s *GuardianProverHeartBeater) post(ctx context.Context, route string, req
 
 // SignAndSendBlock signs the given block and sends it to the health check server.
 func (s *GuardianProverHeartBeater) SignAndSendBlock(ctx context.Context, blockID *big.Int) error {
-	signed, header, err := s.signBlock(ctx, blockID)
+	version := s.protocolVersion(ctx)
+
+	signed, header, err := s.signBlock(ctx, version, blockID)
 	if err != nil {
 		return nil
 	}
@@ -98,7 +118,7 @@ func (s *GuardianProverHeartBeater) SignAndSendBlock(ctx context.Context, blockI
 		return nil
 	}
 
-	if err := s.sendSignedBlockReq(ctx, signed, header.Hash(), blockID); err != nil {
+	if err := s.sendSignedBlockReq(ctx, version, signed, header.Hash(), blockID); err != nil {
 		return err
 	}
 
@@ -118,20 +138,35 @@ func (s *GuardianProverHeartBeater) SendStartupMessage(
 		return nil
 	}
 
-	sig, err := crypto.Sign(
-		crypto.Keccak256Hash(
-			s.proverAddress.Bytes(),
-			[]byte(revision),
-			[]byte(version),
-			[]byte(l1NodeVersion),
-			[]byte(l2NodeVersion),
-		).Bytes(),
-		s.privateKey)
+	l1Head, err := s.rpc.L1.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	l2Head, err := s.rpc.L2.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	auth, err := newHeartbeatAuth(s.protocolVersion(ctx), l1Head, l2Head)
+	if err != nil {
+		return err
+	}
+
+	sig, err := s.sign(ctx, guardianproverauth.StartupMessage(
+		s.proverAddress,
+		revision,
+		version,
+		l1NodeVersion,
+		l2NodeVersion,
+		auth,
+	))
 	if err != nil {
 		return err
 	}
 
 	if err := s.post(ctx, "startup", &startupReq{
+		HeartbeatAuth:   *auth,
 		Revision:        revision,
 		GuardianVersion: version,
 		L1NodeVersion:   l1NodeVersion,
@@ -146,6 +181,7 @@ func (s *GuardianProverHeartBeater) SendStartupMessage(
 		"Guardian prover successfully sent the startup message",
 		"l1NodeVersion", l1NodeVersion,
 		"l2NodeVersion", l2NodeVersion,
+		"protocolVersion", auth.Version,
 	)
 
 	return nil
@@ -154,6 +190,7 @@ func (s *GuardianProverHeartBeater) SendStartupMessage(
 // sendSignedBlockReq is the actual method that sends the signed block to the health check server.
 func (s *GuardianProverHeartBeater) sendSignedBlockReq(
 	ctx context.Context,
+	version uint64,
 	signed []byte,
 	hash common.Hash,
 	blockID *big.Int,
@@ -164,6 +201,7 @@ func (s *GuardianProverHeartBeater) sendSignedBlockReq(
 	}
 
 	req := &signedBlockReq{
+		Version:   version,
 		BlockID:   blockID.Uint64(),
 		BlockHash: hash.Hex(),
 		Signature: signed,
@@ -179,8 +217,13 @@ func (s *GuardianProverHeartBeater) sendSignedBlockReq(
 	return nil
 }
 
-// signBlock signs the given block and returns the signature and header.
-func (s *GuardianProverHeartBeater) signBlock(ctx context.Context, blockID *big.Int) ([]byte, *types.Header, error) {
+// signBlock signs the given block with the given heartbeat protocol version, and returns the signature
+// and header.
+func (s *GuardianProverHeartBeater) signBlock(
+	ctx context.Context,
+	version uint64,
+	blockID *big.Int,
+) ([]byte, *types.Header, error) {
 	log.Info("Guardian prover signing block", "blockID", blockID.Uint64())
 
 	head, err := s.rpc.L2.BlockNumber(ctx)
@@ -216,7 +259,7 @@ func (s *GuardianProverHeartBeater) signBlock(ctx context.Context, blockID *big.
 		"eventBlockID", blockID.Uint64(),
 	)
 
-	signed, err := crypto.Sign(header.Hash().Bytes(), s.privateKey)
+	signed, err := s.sign(ctx, guardianproverauth.SignedBlockMessage(version, blockID.Uint64(), header.Hash()))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -224,22 +267,26 @@ func (s *GuardianProverHeartBeater) signBlock(ctx context.Context, blockID *big.
 	return signed, header, nil
 }
 
-// SendHeartbeat sends a heartbeat to the health check server.
+// SendHeartbeat sends a heartbeat, signed over the given latest L1 / L2 heads, to the health check server.
 func (s *GuardianProverHeartBeater) SendHeartbeat(
 	ctx context.Context,
-	latestL1Block uint64,
-	latestL2Block uint64,
+	latestL1Head *types.Header,
+	latestL2Head *types.Header,
 ) error {
-	sig, err := crypto.Sign(crypto.Keccak256Hash([]byte("HEART_BEAT")).Bytes(), s.privateKey)
+	auth, err := newHeartbeatAuth(s.protocolVersion(ctx), latestL1Head, latestL2Head)
+	if err != nil {
+		return err
+	}
+
+	sig, err := s.sign(ctx, guardianproverauth.HeartbeatMessage(s.proverAddress, auth))
 	if err != nil {
 		return err
 	}
 
 	req := &healthCheckReq{
+		HeartbeatAuth:      *auth,
 		HeartBeatSignature: sig,
 		ProverAddress:      s.proverAddress.Hex(),
-		LatestL1Block:      latestL1Block,
-		LatestL2Block:      latestL2Block,
 	}
 
 	if err := s.post(ctx, "healthCheck", req); err != nil {
@@ -3,6 +3,8 @@ package guardianproverheartbeater
 import (
 	"context"
 	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // BlockSigner defines an interface that communicates with a central Guardian Prover server, sending signed blocks.
@@ -19,7 +21,7 @@ type BlockSigner interface {
 
 // Heartbeater defines an interface that communicates with a central Guardian Prover server, sending heartbeats.
 type Heartbeater interface {
-	SendHeartbeat(ctx context.Context, latestL1Block uint64, latestL2Block uint64) error
+	SendHeartbeat(ctx context.Context, latestL1Head *types.Header, latestL2Head *types.Header) error
 }
 
 // BlockSenderHeartbeater defines an interface that communicates with a central Guardian Prover server,
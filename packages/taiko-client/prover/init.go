@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"slices"
 
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum"
@@ -98,49 +99,11 @@ func (p *Prover) initProofSubmitters(
 ) (err error) {
 	if len(tiers) > 0 {
 		for _, tier := range p.sharedState.GetTiers() {
-			var (
-				bufferSize = p.cfg.SGXProofBufferSize
-				producer   proofProducer.ProofProducer
-				submitter  proofSubmitter.Submitter
-				err        error
-			)
-			switch tier.ID {
-			case encoding.TierOptimisticID:
-				producer = &proofProducer.OptimisticProofProducer{}
-			case encoding.TierSgxID:
-				producer = &proofProducer.SGXProofProducer{
-					RaikoHostEndpoint:   p.cfg.RaikoHostEndpoint,
-					JWT:                 p.cfg.RaikoJWT,
-					ProofType:           proofProducer.ProofTypeSgx,
-					Dummy:               p.cfg.Dummy,
-					RaikoRequestTimeout: p.cfg.RaikoRequestTimeout,
-				}
-			case encoding.TierZkVMRisc0ID:
-				producer = &proofProducer.ZKvmProofProducer{
-					ZKProofType:         proofProducer.ZKProofTypeR0,
-					RaikoHostEndpoint:   p.cfg.RaikoZKVMHostEndpoint,
-					JWT:                 p.cfg.RaikoJWT,
-					Dummy:               p.cfg.Dummy,
-					RaikoRequestTimeout: p.cfg.RaikoRequestTimeout,
-				}
-				bufferSize = p.cfg.ZKVMProofBufferSize
-			case encoding.TierZkVMSp1ID:
-				producer = &proofProducer.ZKvmProofProducer{
-					ZKProofType:         proofProducer.ZKProofTypeSP1,
-					RaikoHostEndpoint:   p.cfg.RaikoZKVMHostEndpoint,
-					JWT:                 p.cfg.RaikoJWT,
-					Dummy:               p.cfg.Dummy,
-					RaikoRequestTimeout: p.cfg.RaikoRequestTimeout,
-				}
-				bufferSize = p.cfg.ZKVMProofBufferSize
-			case encoding.TierGuardianMinorityID:
-				producer = proofProducer.NewGuardianProofProducer(encoding.TierGuardianMinorityID, p.cfg.EnableLivenessBondProof)
-				bufferSize = 0
-			case encoding.TierGuardianMajorityID:
-				producer = proofProducer.NewGuardianProofProducer(encoding.TierGuardianMajorityID, p.cfg.EnableLivenessBondProof)
-				bufferSize = 0
-			default:
-				return fmt.Errorf("unsupported tier: %d", tier.ID)
+			var submitter proofSubmitter.Submitter
+
+			producer, bufferSize, err := newOntakeProofProducer(p.cfg, tier.ID)
+			if err != nil {
+				return err
 			}
 
 			if submitter, err = proofSubmitter.NewProofSubmitterOntake(
@@ -161,6 +124,8 @@ func (p *Prover) initProofSubmitters(
 				p.cfg.GuardianProofSubmissionDelay,
 				bufferSize,
 				p.cfg.ForceBatchProvingInterval,
+				p.accountant,
+				p.approvals,
 			); err != nil {
 				return err
 			}
@@ -168,9 +133,10 @@ func (p *Prover) initProofSubmitters(
 			p.proofSubmittersOntake = append(p.proofSubmittersOntake, submitter)
 		}
 	}
+	pacayaProducer, pacayaBufferSize := newPacayaProofProducer(p.cfg)
 	if p.proofSubmitterPacaya, err = proofSubmitter.NewProofSubmitterPacaya(
 		p.rpc,
-		&proofProducer.OptimisticProofProducer{},
+		pacayaProducer,
 		p.proofGenerationCh,
 		p.batchProofGenerationCh,
 		p.aggregationNotify,
@@ -181,6 +147,9 @@ func (p *Prover) initProofSubmitters(
 		p.txmgr,
 		p.privateTxmgr,
 		txBuilder,
+		pacayaBufferSize,
+		p.cfg.ForceBatchProvingInterval,
+		p.accountant,
 	); err != nil {
 		return err
 	}
@@ -188,6 +157,124 @@ func (p *Prover) initProofSubmitters(
 	return nil
 }
 
+// newOntakeProofProducer creates the proof producer of the given Ontake tier, and returns it with its proof
+// buffer size.
+func newOntakeProofProducer(cfg *Config, tierID uint16) (proofProducer.ProofProducer, uint64, error) {
+	switch tierID {
+	case encoding.TierOptimisticID:
+		return &proofProducer.OptimisticProofProducer{}, cfg.SGXProofBufferSize, nil
+	case encoding.TierSgxID:
+		return &proofProducer.SGXProofProducer{
+			RaikoHostEndpoint:   cfg.RaikoHostEndpoint,
+			JWT:                 cfg.RaikoJWT,
+			ProofType:           proofProducer.ProofTypeSgx,
+			Dummy:               cfg.Dummy,
+			RaikoRequestTimeout: cfg.RaikoRequestTimeout,
+		}, cfg.SGXProofBufferSize, nil
+	case encoding.TierZkVMRisc0ID:
+		return &proofProducer.ZKvmProofProducer{
+			ZKProofType:         proofProducer.ZKProofTypeR0,
+			RaikoHostEndpoint:   cfg.RaikoZKVMHostEndpoint,
+			JWT:                 cfg.RaikoJWT,
+			Dummy:               cfg.Dummy,
+			RaikoRequestTimeout: cfg.RaikoRequestTimeout,
+		}, cfg.ZKVMProofBufferSize, nil
+	case encoding.TierZkVMSp1ID:
+		return &proofProducer.ZKvmProofProducer{
+			ZKProofType:         proofProducer.ZKProofTypeSP1,
+			RaikoHostEndpoint:   cfg.RaikoZKVMHostEndpoint,
+			JWT:                 cfg.RaikoJWT,
+			Dummy:               cfg.Dummy,
+			RaikoRequestTimeout: cfg.RaikoRequestTimeout,
+		}, cfg.ZKVMProofBufferSize, nil
+	case encoding.TierGuardianMinorityID:
+		return proofProducer.NewGuardianProofProducer(encoding.TierGuardianMinorityID, cfg.EnableLivenessBondProof), 0, nil
+	case encoding.TierGuardianMajorityID:
+		return proofProducer.NewGuardianProofProducer(encoding.TierGuardianMajorityID, cfg.EnableLivenessBondProof), 0, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported tier: %d", tierID)
+	}
+}
+
+// newPacayaProofProducer creates the proof producer for Pacaya batches, and returns it with its proof
+// buffer size. Batches are proven by the optimistic proof producer, unless a proving backend or some compose
+// proof types are set.
+func newPacayaProofProducer(cfg *Config) (proofProducer.ProofProducer, uint64) {
+	if len(cfg.ComposeProofTypes) == 0 {
+		if len(cfg.ProofProducerEndpoint) == 0 {
+			return &proofProducer.OptimisticProofProducer{}, cfg.SGXProofBufferSize
+		}
+
+		// The proofs of the proving backend are submitted to the sub-verifier of their proof type, as the single
+		// sub-proof of the compose verifier.
+		bufferSize := cfg.SGXProofBufferSize
+		if cfg.ProofProducerProofType != proofProducer.ProofTypeSgx {
+			bufferSize = cfg.ZKVMProofBufferSize
+		}
+		return &proofProducer.ComposeProofProducer{
+			SubProducers: []*proofProducer.SubProofProducer{{
+				ProofProducer: newHTTPProofProducer(cfg, cfg.ProofProducerProofType),
+				Name:          cfg.ProofProducerProofType,
+				Verifier:      cfg.ComposeVerifiers[cfg.ProofProducerProofType],
+				Required:      true,
+			}},
+			Quorum: 1,
+		}, bufferSize
+	}
+
+	var (
+		bufferSize = cfg.SGXProofBufferSize
+		producer   = &proofProducer.ComposeProofProducer{Quorum: int(cfg.ComposeQuorum)}
+	)
+	for _, proofType := range cfg.ComposeProofTypes {
+		var subProducer proofProducer.ProofProducer
+		switch {
+		case len(cfg.ProofProducerEndpoint) != 0:
+			subProducer = newHTTPProofProducer(cfg, proofType)
+		case proofType == proofProducer.ProofTypeSgx:
+			subProducer = &proofProducer.SGXProofProducer{
+				RaikoHostEndpoint:   cfg.RaikoHostEndpoint,
+				JWT:                 cfg.RaikoJWT,
+				ProofType:           proofProducer.ProofTypeSgx,
+				Dummy:               cfg.Dummy,
+				RaikoRequestTimeout: cfg.RaikoRequestTimeout,
+			}
+		default:
+			subProducer = &proofProducer.ZKvmProofProducer{
+				ZKProofType:         proofType,
+				RaikoHostEndpoint:   cfg.RaikoZKVMHostEndpoint,
+				JWT:                 cfg.RaikoJWT,
+				Dummy:               cfg.Dummy,
+				RaikoRequestTimeout: cfg.RaikoRequestTimeout,
+			}
+		}
+		// The ZK proofs are the slowest ones, so that their batch size is used.
+		if proofType != proofProducer.ProofTypeSgx {
+			bufferSize = cfg.ZKVMProofBufferSize
+		}
+
+		producer.SubProducers = append(producer.SubProducers, &proofProducer.SubProofProducer{
+			ProofProducer: subProducer,
+			Name:          proofType,
+			Verifier:      cfg.ComposeVerifiers[proofType],
+			Required:      slices.Contains(cfg.ComposeRequiredProofTypes, proofType),
+		})
+	}
+
+	return producer, bufferSize
+}
+
+// newHTTPProofProducer creates a new proof producer of the given proof type, which requests proofs from
+// the configured proving backend.
+func newHTTPProofProducer(cfg *Config, proofType string) *proofProducer.HTTPProofProducer {
+	return &proofProducer.HTTPProofProducer{
+		Endpoint:       cfg.ProofProducerEndpoint,
+		ProofType:      proofType,
+		Token:          cfg.ProofProducerToken,
+		RequestTimeout: cfg.RaikoRequestTimeout,
+	}
+}
+
 // initL1Current initializes prover's L1Current cursor.
 func (p *Prover) initL1Current(startingBlockID *big.Int) error {
 	if err := p.rpc.WaitTillL2ExecutionEngineSynced(p.ctx); err != nil {
@@ -272,6 +359,7 @@ func (p *Prover) initEventHandlers() error {
 		BackOffMaxRetrys:      p.cfg.BackOffMaxRetries,
 		ContesterMode:         p.cfg.ContesterMode,
 		ProveUnassignedBlocks: p.cfg.ProveUnassignedBlocks,
+		Policy:                p.policyEngine,
 	}
 	if p.IsGuardianProver() {
 		p.eventHandlers.blockProposedHandler = handler.NewBlockProposedEventGuardianHandler(
@@ -290,6 +378,7 @@ func (p *Prover) initEventHandlers() error {
 		p.proofSubmissionCh,
 		p.cfg.ContesterMode,
 		p.IsGuardianProver(),
+		p.cfg.ContesterSubmitConflictingProof,
 	)
 	// ------- TransitionContested -------
 	p.eventHandlers.transitionContestedHandler = handler.NewTransitionContestedEventHandler(
@@ -312,10 +401,10 @@ func (p *Prover) initEventHandlers() error {
 	guardianProverAddress, err := p.rpc.GetGuardianProverAddress(p.ctx)
 	if err != nil {
 		log.Debug("Failed to get guardian prover address", "error", encoding.TryParsingCustomError(err))
-		p.eventHandlers.blockVerifiedHandler = handler.NewBlockVerifiedEventHandler(common.Address{})
+		p.eventHandlers.blockVerifiedHandler = handler.NewBlockVerifiedEventHandler(common.Address{}, p.accountant)
 		return nil
 	}
-	p.eventHandlers.blockVerifiedHandler = handler.NewBlockVerifiedEventHandler(guardianProverAddress)
+	p.eventHandlers.blockVerifiedHandler = handler.NewBlockVerifiedEventHandler(guardianProverAddress, p.accountant)
 
 	return nil
 }
@@ -0,0 +1,87 @@
+package policy
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Engine evaluates proposals against the policy file at the given path, and reloads the policy when
+// the file changes, so that it can be tuned without restarting the prover. An invalid file is rejected,
+// and the last valid policy stays in use.
+type Engine struct {
+	path           string
+	reloadInterval time.Duration
+	policy         atomic.Pointer[Policy]
+	modTime        time.Time
+}
+
+// NewEngine creates a new policy engine with the policy file at the given path.
+func NewEngine(path string, reloadInterval time.Duration) (*Engine, error) {
+	e := &Engine{path: path, reloadInterval: reloadInterval}
+	if _, err := e.Reload(); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Evaluate returns the decision of the current policy for the given proposal.
+func (e *Engine) Evaluate(proposal *Proposal) *Decision {
+	return e.policy.Load().Evaluate(proposal)
+}
+
+// Reload loads the policy file again if it has been modified since the last load, and reports whether
+// the policy has been replaced.
+func (e *Engine) Reload() (bool, error) {
+	info, err := os.Stat(e.path)
+	if err != nil {
+		return false, err
+	}
+	if e.policy.Load() != nil && info.ModTime().Equal(e.modTime) {
+		return false, nil
+	}
+
+	policy, err := Load(e.path)
+	if err != nil {
+		// Do not load the same invalid file again.
+		if e.policy.Load() != nil {
+			e.modTime = info.ModTime()
+		}
+		return false, err
+	}
+
+	e.policy.Store(policy)
+	e.modTime = info.ModTime()
+
+	return true, nil
+}
+
+// Watch reloads the policy file periodically, until the given context is canceled.
+func (e *Engine) Watch(ctx context.Context) {
+	if e.reloadInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(e.reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := e.Reload()
+			if err != nil {
+				log.Error("Failed to reload proving policy, keep using the current one", "path", e.path, "error", err)
+				continue
+			}
+			if reloaded {
+				log.Info("Proving policy reloaded", "path", e.path, "rules", len(e.policy.Load().Rules))
+			}
+		}
+	}
+}
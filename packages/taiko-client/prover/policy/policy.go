@@ -0,0 +1,257 @@
+// Package policy implements a declarative, file-based policy deciding which proposals the prover proves.
+//
+// A policy is a YAML file with an ordered list of rules and a default action. Each proposal is matched
+// against the rules in order, and the action of the first rule whose conditions all hold is taken, or
+// the default action if no rule matches. A rule without any condition matches every proposal.
+//
+//	rules:
+//	  - name: own-proposals
+//	    ownProposal: true
+//	    action: prove
+//	  - name: deadline-too-close
+//	    maxTimeToExpire: 2m
+//	    provingWindowExpired: false
+//	    action: skip
+//	  - name: cheap-and-busy
+//	    maxExpectedReward: "0.001"
+//	    minQueueDepth: 8
+//	    action: skip
+//	default: wait
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"gopkg.in/yaml.v3"
+)
+
+// Action is the decision of a policy for a proposal.
+type Action string
+
+const (
+	// ActionProve proves the proposal right away.
+	ActionProve Action = "prove"
+	// ActionWait proves the proposal once its proving window is expired, or right away if it is already.
+	ActionWait Action = "wait"
+	// ActionSkip never proves the proposal.
+	ActionSkip Action = "skip"
+)
+
+// DefaultRuleName is the rule name reported when no rule matches a proposal.
+const DefaultRuleName = "default"
+
+// Proposal is what a policy knows about a proposal, i.e. a block before Pacaya fork, or a batch after it.
+type Proposal struct {
+	ID                   *big.Int
+	Pacaya               bool
+	Proposer             common.Address
+	OwnProposal          bool // Whether the proposer is the prover itself or its prover set
+	Blocks               uint64
+	GasUsed              uint64
+	ProvingWindowExpired bool
+	TimeToExpire         time.Duration
+	QueueDepth           uint64   // Number of the proof requests in progress
+	ExpectedReward       *big.Int // Bond earned or kept by proving the proposal, in wei
+}
+
+// Decision is the action taken for a proposal, and the name of the rule which decided it.
+type Decision struct {
+	Action Action
+	Rule   string
+}
+
+// Rule is a policy rule, all its conditions must hold for the rule to match.
+type Rule struct {
+	Name                 string
+	Action               Action
+	Proposers            []common.Address
+	OwnProposal          *bool
+	ProvingWindowExpired *bool
+	MinBlocks            *uint64
+	MaxBlocks            *uint64
+	MinGasUsed           *uint64
+	MaxGasUsed           *uint64
+	MinTimeToExpire      *time.Duration
+	MaxTimeToExpire      *time.Duration
+	MinQueueDepth        *uint64
+	MaxQueueDepth        *uint64
+	MinExpectedReward    *big.Int
+	MaxExpectedReward    *big.Int
+}
+
+// Policy is an ordered list of rules, with the action taken when none of them matches.
+type Policy struct {
+	Rules   []*Rule
+	Default Action
+}
+
+type policyFile struct {
+	Rules []struct {
+		Name                 string         `yaml:"name"`
+		Action               string         `yaml:"action"`
+		Proposers            []string       `yaml:"proposers"`
+		OwnProposal          *bool          `yaml:"ownProposal"`
+		ProvingWindowExpired *bool          `yaml:"provingWindowExpired"`
+		MinBlocks            *uint64        `yaml:"minBlocks"`
+		MaxBlocks            *uint64        `yaml:"maxBlocks"`
+		MinGasUsed           *uint64        `yaml:"minGasUsed"`
+		MaxGasUsed           *uint64        `yaml:"maxGasUsed"`
+		MinTimeToExpire      *time.Duration `yaml:"minTimeToExpire"`
+		MaxTimeToExpire      *time.Duration `yaml:"maxTimeToExpire"`
+		MinQueueDepth        *uint64        `yaml:"minQueueDepth"`
+		MaxQueueDepth        *uint64        `yaml:"maxQueueDepth"`
+		MinExpectedReward    string         `yaml:"minExpectedReward"`
+		MaxExpectedReward    string         `yaml:"maxExpectedReward"`
+	} `yaml:"rules"`
+	Default string `yaml:"default"`
+}
+
+// Load reads and validates the policy file at the given path.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data)
+}
+
+// Parse parses and validates the given YAML encoded policy.
+func Parse(data []byte) (*Policy, error) {
+	var f policyFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %w", err)
+	}
+
+	p := &Policy{Default: Action(strings.ToLower(f.Default))}
+	if p.Default == "" {
+		return nil, errors.New("empty default action")
+	}
+	if !p.Default.valid() {
+		return nil, fmt.Errorf("invalid default action %q", f.Default)
+	}
+
+	var (
+		names = make(map[string]struct{}, len(f.Rules))
+		err   error
+	)
+	for i, r := range f.Rules {
+		rule := &Rule{
+			Name:                 r.Name,
+			Action:               Action(strings.ToLower(r.Action)),
+			OwnProposal:          r.OwnProposal,
+			ProvingWindowExpired: r.ProvingWindowExpired,
+			MinBlocks:            r.MinBlocks,
+			MaxBlocks:            r.MaxBlocks,
+			MinGasUsed:           r.MinGasUsed,
+			MaxGasUsed:           r.MaxGasUsed,
+			MinTimeToExpire:      r.MinTimeToExpire,
+			MaxTimeToExpire:      r.MaxTimeToExpire,
+			MinQueueDepth:        r.MinQueueDepth,
+			MaxQueueDepth:        r.MaxQueueDepth,
+		}
+
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", i)
+		}
+		if _, ok := names[rule.Name]; ok || rule.Name == DefaultRuleName {
+			return nil, fmt.Errorf("rule %d: duplicate name %q", i, rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+
+		if !rule.Action.valid() {
+			return nil, fmt.Errorf("rule %q: invalid action %q", rule.Name, r.Action)
+		}
+
+		for _, proposer := range r.Proposers {
+			if !common.IsHexAddress(proposer) {
+				return nil, fmt.Errorf("rule %q: invalid proposer %q", rule.Name, proposer)
+			}
+			rule.Proposers = append(rule.Proposers, common.HexToAddress(proposer))
+		}
+
+		if rule.MinExpectedReward, err = parseEther(r.MinExpectedReward); err != nil {
+			return nil, fmt.Errorf("rule %q: invalid minExpectedReward: %w", rule.Name, err)
+		}
+		if rule.MaxExpectedReward, err = parseEther(r.MaxExpectedReward); err != nil {
+			return nil, fmt.Errorf("rule %q: invalid maxExpectedReward: %w", rule.Name, err)
+		}
+
+		p.Rules = append(p.Rules, rule)
+	}
+
+	return p, nil
+}
+
+// Evaluate returns the decision of the policy for the given proposal.
+func (p *Policy) Evaluate(proposal *Proposal) *Decision {
+	for _, rule := range p.Rules {
+		if rule.Match(proposal) {
+			return &Decision{Action: rule.Action, Rule: rule.Name}
+		}
+	}
+
+	return &Decision{Action: p.Default, Rule: DefaultRuleName}
+}
+
+// Match checks whether all the conditions of the rule hold for the given proposal.
+func (r *Rule) Match(p *Proposal) bool {
+	if len(r.Proposers) > 0 && !slices.Contains(r.Proposers, p.Proposer) {
+		return false
+	}
+	if r.OwnProposal != nil && *r.OwnProposal != p.OwnProposal {
+		return false
+	}
+	if r.ProvingWindowExpired != nil && *r.ProvingWindowExpired != p.ProvingWindowExpired {
+		return false
+	}
+
+	expectedReward := p.ExpectedReward
+	if expectedReward == nil {
+		expectedReward = common.Big0
+	}
+
+	return inRange(p.Blocks, r.MinBlocks, r.MaxBlocks) &&
+		inRange(p.GasUsed, r.MinGasUsed, r.MaxGasUsed) &&
+		inRange(p.TimeToExpire, r.MinTimeToExpire, r.MaxTimeToExpire) &&
+		inRange(p.QueueDepth, r.MinQueueDepth, r.MaxQueueDepth) &&
+		(r.MinExpectedReward == nil || expectedReward.Cmp(r.MinExpectedReward) >= 0) &&
+		(r.MaxExpectedReward == nil || expectedReward.Cmp(r.MaxExpectedReward) <= 0)
+}
+
+// valid checks whether the action is a known one.
+func (a Action) valid() bool {
+	return a == ActionProve || a == ActionWait || a == ActionSkip
+}
+
+// inRange checks whether the given value is within the given optional bounds, both inclusive.
+func inRange[T uint64 | time.Duration](value T, minValue *T, maxValue *T) bool {
+	return (minValue == nil || value >= *minValue) && (maxValue == nil || value <= *maxValue)
+}
+
+// parseEther parses an optional non-negative decimal amount in ether, and returns it in wei.
+func parseEther(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	if r.Sign() < 0 {
+		return nil, fmt.Errorf("negative amount %q", s)
+	}
+
+	r.Mul(r, new(big.Rat).SetInt64(params.Ether))
+
+	return new(big.Int).Quo(r.Num(), r.Denom()), nil
+}
@@ -0,0 +1,118 @@
+package policy
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicy = `
+rules:
+  - name: allowlisted
+    proposers: ["0x0000000000000000000000000000000000000001"]
+    action: prove
+  - name: deadline-too-close
+    provingWindowExpired: false
+    maxTimeToExpire: 2m
+    action: skip
+  - name: cheap-and-busy
+    maxExpectedReward: "0.001"
+    minQueueDepth: 8
+    action: skip
+  - minBlocks: 10
+    action: prove
+default: wait
+`
+
+func TestParse(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	require.Nil(t, err)
+	require.Len(t, p.Rules, 4)
+	require.Equal(t, ActionWait, p.Default)
+	require.Equal(t, "rule-3", p.Rules[3].Name)
+	require.Equal(t, 2*time.Minute, *p.Rules[1].MaxTimeToExpire)
+	require.Equal(t, big.NewInt(1_000_000_000_000_000), p.Rules[2].MaxExpectedReward)
+	require.Equal(t, []common.Address{common.BigToAddress(common.Big1)}, p.Rules[0].Proposers)
+}
+
+func TestParseInvalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"empty default":   "rules: []",
+		"invalid default": "default: maybe",
+		"invalid action":  "rules: [{action: later}]\ndefault: wait",
+		"duplicate name":  "rules: [{name: a, action: prove}, {name: a, action: skip}]\ndefault: wait",
+		"reserved name":   "rules: [{name: default, action: prove}]\ndefault: wait",
+		"invalid address": "rules: [{proposers: [0x01], action: prove}]\ndefault: wait",
+		"invalid reward":  "rules: [{minExpectedReward: abc, action: prove}]\ndefault: wait",
+		"negative reward": "rules: [{minExpectedReward: \"-1\", action: prove}]\ndefault: wait",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(data))
+			require.NotNil(t, err)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	require.Nil(t, err)
+
+	for _, tc := range []struct {
+		proposal *Proposal
+		action   Action
+		rule     string
+	}{
+		{
+			&Proposal{Proposer: common.BigToAddress(common.Big1), TimeToExpire: time.Minute},
+			ActionProve,
+			"allowlisted",
+		},
+		{&Proposal{TimeToExpire: time.Minute}, ActionSkip, "deadline-too-close"},
+		{&Proposal{ProvingWindowExpired: true, QueueDepth: 8}, ActionSkip, "cheap-and-busy"},
+		{
+			&Proposal{ProvingWindowExpired: true, QueueDepth: 8, ExpectedReward: big.NewInt(params.Ether)},
+			ActionWait,
+			DefaultRuleName,
+		},
+		{&Proposal{TimeToExpire: time.Hour, Blocks: 10}, ActionProve, "rule-3"},
+		{&Proposal{TimeToExpire: time.Hour, Blocks: 9}, ActionWait, DefaultRuleName},
+	} {
+		decision := p.Evaluate(tc.proposal)
+		require.Equal(t, tc.action, decision.Action)
+		require.Equal(t, tc.rule, decision.Rule)
+	}
+}
+
+func TestEngineReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.Nil(t, os.WriteFile(path, []byte("default: prove"), 0600))
+
+	e, err := NewEngine(path, time.Second)
+	require.Nil(t, err)
+	require.Equal(t, ActionProve, e.Evaluate(&Proposal{}).Action)
+
+	// Not modified.
+	reloaded, err := e.Reload()
+	require.Nil(t, err)
+	require.False(t, reloaded)
+
+	// An invalid policy is rejected, and the current one stays in use.
+	require.Nil(t, os.WriteFile(path, []byte("default: maybe"), 0600))
+	require.Nil(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	_, err = e.Reload()
+	require.NotNil(t, err)
+	require.Equal(t, ActionProve, e.Evaluate(&Proposal{}).Action)
+
+	require.Nil(t, os.WriteFile(path, []byte("default: skip"), 0600))
+	require.Nil(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Minute)))
+	reloaded, err = e.Reload()
+	require.Nil(t, err)
+	require.True(t, reloaded)
+	require.Equal(t, ActionSkip, e.Evaluate(&Proposal{}).Action)
+}
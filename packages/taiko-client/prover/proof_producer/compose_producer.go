@@ -0,0 +1,311 @@
+package producer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
+)
+
+var errNoQuorum = errors.New("not enough sub-proofs for the compose verifier")
+
+// SubProofProducer is a proof producer whose proofs are checked by the given sub-verifier of
+// a compose verifier.
+type SubProofProducer struct {
+	ProofProducer
+	Name     string
+	Verifier common.Address
+	// Required marks a sub-proof which is always needed, e.g. the SGX proof of a SgxAndZkVerifier.
+	Required bool
+}
+
+// ComposeProofProducer requests proofs from several proof producers concurrently, and combines their
+// sub-proofs into the proof checked by a Pacaya compose verifier.
+type ComposeProofProducer struct {
+	SubProducers []*SubProofProducer
+	// Quorum is the number of sub-proofs needed, all the sub producers if not set.
+	Quorum int
+}
+
+// subProofResult is the result of a sub producer request.
+type subProofResult struct {
+	producer *SubProofProducer
+	proof    []byte
+	err      error
+}
+
+// RequestProof implements the ProofProducer interface.
+func (c *ComposeProofProducer) RequestProof(
+	ctx context.Context,
+	opts ProofRequestOptions,
+	batchID *big.Int,
+	meta metadata.TaikoProposalMetaData,
+	requestAt time.Time,
+) (*ProofResponse, error) {
+	if !opts.IsPacaya() {
+		return nil, errors.New("compose proof generation is only supported after Pacaya fork")
+	}
+
+	log.Info(
+		"Request compose proof",
+		"batchID", batchID,
+		"coinbase", meta.GetCoinbase(),
+		"producers", c.names(),
+		"quorum", c.quorum(),
+	)
+
+	proof, err := c.compose(
+		ctx,
+		func(ctx context.Context, p *SubProofProducer) ([]byte, error) {
+			res, err := p.RequestProof(ctx, opts, batchID, meta, requestAt)
+			if err != nil {
+				return nil, err
+			}
+			return res.Proof, nil
+		},
+		func(ctx context.Context, p *SubProofProducer) error { return p.RequestCancel(ctx, opts) },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProofResponse{
+		BlockID:  batchID,
+		Meta:     meta,
+		Proof:    proof,
+		Opts:     opts,
+		Tier:     c.Tier(),
+		Composed: true,
+	}, nil
+}
+
+// Aggregate implements the ProofProducer interface to aggregate a batch of proofs, each sub producer
+// aggregates its own proofs, and the aggregated sub-proofs are then combined.
+func (c *ComposeProofProducer) Aggregate(
+	ctx context.Context,
+	items []*ProofResponse,
+	requestAt time.Time,
+) (*BatchProofs, error) {
+	if len(items) == 0 {
+		return nil, ErrInvalidLength
+	}
+
+	log.Info(
+		"Aggregate compose batch proofs",
+		"batchIDs", aggregationIDs(items),
+		"producers", c.names(),
+		"quorum", c.quorum(),
+	)
+
+	proof, err := c.compose(
+		ctx,
+		func(ctx context.Context, p *SubProofProducer) ([]byte, error) {
+			batchProof, err := p.Aggregate(ctx, items, requestAt)
+			if err != nil {
+				return nil, err
+			}
+			return batchProof.BatchProof, nil
+		},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BatchProofs{
+		ProofResponses: items,
+		BatchProof:     proof,
+		Tier:           c.Tier(),
+		BlockIDs:       aggregationIDs(items),
+		Composed:       true,
+	}, nil
+}
+
+// RequestCancel implements the ProofProducer interface to cancel the proof generating progress
+// of all the sub producers.
+func (c *ComposeProofProducer) RequestCancel(ctx context.Context, opts ProofRequestOptions) error {
+	var errs []error
+	for _, p := range c.SubProducers {
+		if err := p.RequestCancel(ctx, opts); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Tier implements the ProofProducer interface, proof tiers are no longer used after Pacaya fork.
+func (c *ComposeProofProducer) Tier() uint16 {
+	return 0
+}
+
+// compose runs the given request on all the sub producers concurrently, and returns the encoded
+// sub-proofs once the quorum is met. The sub producers which are still generating a proof at that
+// point are canceled. If some sub-proofs are still being generated and the quorum can still be met,
+// ErrProofInProgress is returned so that the caller retries later.
+func (c *ComposeProofProducer) compose(
+	ctx context.Context,
+	request func(context.Context, *SubProofProducer) ([]byte, error),
+	cancel func(context.Context, *SubProofProducer) error,
+) ([]byte, error) {
+	if len(c.SubProducers) == 0 || c.quorum() > len(c.SubProducers) {
+		return nil, fmt.Errorf("invalid compose quorum: %d of %d producers", c.quorum(), len(c.SubProducers))
+	}
+
+	requestCtx, cancelRequests := context.WithCancel(ctx)
+	defer cancelRequests()
+
+	resultCh := make(chan *subProofResult, len(c.SubProducers))
+	for _, p := range c.SubProducers {
+		go func(p *SubProofProducer) {
+			proof, err := request(requestCtx, p)
+			resultCh <- &subProofResult{producer: p, proof: proof, err: err}
+		}(p)
+	}
+
+	var (
+		proved     []*subProofResult
+		inProgress []*SubProofProducer
+		finished   = make(map[*SubProofProducer]bool)
+		errs       []error
+	)
+	for range c.SubProducers {
+		res := <-resultCh
+		finished[res.producer] = true
+
+		switch {
+		case res.err == nil:
+			proved = append(proved, res)
+		case errors.Is(res.err, ErrProofInProgress) || errors.Is(res.err, ErrRetry):
+			inProgress = append(inProgress, res.producer)
+		default:
+			log.Warn("Failed to request sub-proof", "producer", res.producer.Name, "error", res.err)
+			errs = append(errs, fmt.Errorf("%s: %w", res.producer.Name, res.err))
+		}
+
+		if c.quorumMet(proved) {
+			break
+		}
+	}
+
+	if !c.quorumMet(proved) {
+		if c.quorumReachable(proved, inProgress) {
+			return nil, ErrProofInProgress
+		}
+		return nil, fmt.Errorf("%w: %w", errNoQuorum, errors.Join(errs...))
+	}
+
+	// Stop the sub producers whose proofs are no longer needed.
+	cancelRequests()
+	if cancel != nil {
+		for _, p := range c.SubProducers {
+			if finished[p] && !containsProducer(inProgress, p) {
+				continue
+			}
+			if err := cancel(ctx, p); err != nil {
+				log.Warn("Failed to cancel sub-proof request", "producer", p.Name, "error", err)
+			}
+		}
+	}
+
+	return c.encode(proved)
+}
+
+// encode encodes the given sub-proofs for the compose verifier, which only accepts exactly a quorum
+// of sub-proofs, sorted by their verifier addresses.
+func (c *ComposeProofProducer) encode(proved []*subProofResult) ([]byte, error) {
+	var subProofs []encoding.SubProof
+	for _, res := range proved {
+		if res.producer.Required {
+			subProofs = append(subProofs, encoding.SubProof{Verifier: res.producer.Verifier, Proof: res.proof})
+		}
+	}
+	for _, res := range proved {
+		if len(subProofs) >= c.quorum() {
+			break
+		}
+		if !res.producer.Required {
+			subProofs = append(subProofs, encoding.SubProof{Verifier: res.producer.Verifier, Proof: res.proof})
+		}
+	}
+
+	sort.Slice(subProofs, func(i, j int) bool {
+		return bytes.Compare(subProofs[i].Verifier.Bytes(), subProofs[j].Verifier.Bytes()) < 0
+	})
+
+	return encoding.EncodeBatchesSubProofs(subProofs)
+}
+
+// quorumMet returns whether the given sub-proofs are enough for the compose verifier.
+func (c *ComposeProofProducer) quorumMet(proved []*subProofResult) bool {
+	if len(proved) < c.quorum() {
+		return false
+	}
+	for _, p := range c.SubProducers {
+		if p.Required && !containsResult(proved, p) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// quorumReachable returns whether the quorum can still be met once the sub-proofs in progress are generated.
+func (c *ComposeProofProducer) quorumReachable(proved []*subProofResult, inProgress []*SubProofProducer) bool {
+	if len(proved)+len(inProgress) < c.quorum() {
+		return false
+	}
+	for _, p := range c.SubProducers {
+		if p.Required && !containsResult(proved, p) && !containsProducer(inProgress, p) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// quorum returns the number of sub-proofs needed.
+func (c *ComposeProofProducer) quorum() int {
+	if c.Quorum <= 0 {
+		return len(c.SubProducers)
+	}
+	return c.Quorum
+}
+
+// names returns the names of all the sub producers.
+func (c *ComposeProofProducer) names() []string {
+	names := make([]string, len(c.SubProducers))
+	for i, p := range c.SubProducers {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// containsResult returns whether the given results contain one from the given producer.
+func containsResult(results []*subProofResult, p *SubProofProducer) bool {
+	for _, res := range results {
+		if res.producer == p {
+			return true
+		}
+	}
+	return false
+}
+
+// containsProducer returns whether the given producers contain the given producer.
+func containsProducer(producers []*SubProofProducer, p *SubProofProducer) bool {
+	for _, producer := range producers {
+		if producer == p {
+			return true
+		}
+	}
+	return false
+}
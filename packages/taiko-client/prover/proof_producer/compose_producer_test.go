@@ -0,0 +1,146 @@
+package producer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/encoding"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
+)
+
+// testSubProducer is a sub producer returning the given proof or error.
+type testSubProducer struct {
+	OptimisticProofProducer
+	proof    []byte
+	err      error
+	canceled bool
+}
+
+func (t *testSubProducer) RequestProof(
+	_ context.Context,
+	opts ProofRequestOptions,
+	blockID *big.Int,
+	meta metadata.TaikoProposalMetaData,
+	_ time.Time,
+) (*ProofResponse, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return &ProofResponse{BlockID: blockID, Meta: meta, Proof: t.proof, Opts: opts}, nil
+}
+
+func (t *testSubProducer) RequestCancel(_ context.Context, _ ProofRequestOptions) error {
+	t.canceled = true
+	return nil
+}
+
+func newTestSubProducer(name string, verifier byte, required bool, err error) *SubProofProducer {
+	return &SubProofProducer{
+		ProofProducer: &testSubProducer{proof: []byte(name), err: err},
+		Name:          name,
+		Verifier:      common.BytesToAddress([]byte{verifier}),
+		Required:      required,
+	}
+}
+
+func requestComposeProof(producer *ComposeProofProducer) (*ProofResponse, error) {
+	return producer.RequestProof(
+		context.Background(),
+		&ProofRequestOptionsPacaya{},
+		common.Big1,
+		&metadata.TaikoDataBlockMetadataPacaya{},
+		time.Now(),
+	)
+}
+
+func TestComposeRequestProof(t *testing.T) {
+	producer := &ComposeProofProducer{
+		SubProducers: []*SubProofProducer{
+			newTestSubProducer(ZKProofTypeSP1, 3, false, nil),
+			newTestSubProducer(ProofTypeSgx, 1, true, nil),
+		},
+	}
+
+	res, err := requestComposeProof(producer)
+	require.Nil(t, err)
+	require.True(t, res.Composed)
+
+	// Sub-proofs are sorted by their verifier addresses.
+	expected, err := encoding.EncodeBatchesSubProofs([]encoding.SubProof{
+		{Verifier: common.BytesToAddress([]byte{1}), Proof: []byte(ProofTypeSgx)},
+		{Verifier: common.BytesToAddress([]byte{3}), Proof: []byte(ZKProofTypeSP1)},
+	})
+	require.Nil(t, err)
+	require.Equal(t, expected, res.Proof)
+}
+
+func TestComposeRequestProofQuorum(t *testing.T) {
+	failing := newTestSubProducer(ZKProofTypeR0, 2, false, errors.New("raiko error"))
+	producer := &ComposeProofProducer{
+		SubProducers: []*SubProofProducer{
+			newTestSubProducer(ProofTypeSgx, 1, true, nil),
+			failing,
+			newTestSubProducer(ZKProofTypeSP1, 3, false, nil),
+		},
+		Quorum: 2,
+	}
+
+	// A failing sub producer is tolerated as long as the quorum is met.
+	res, err := requestComposeProof(producer)
+	require.Nil(t, err)
+
+	expected, err := encoding.EncodeBatchesSubProofs([]encoding.SubProof{
+		{Verifier: common.BytesToAddress([]byte{1}), Proof: []byte(ProofTypeSgx)},
+		{Verifier: common.BytesToAddress([]byte{3}), Proof: []byte(ZKProofTypeSP1)},
+	})
+	require.Nil(t, err)
+	require.Equal(t, expected, res.Proof)
+
+	// The quorum can no longer be met.
+	producer.SubProducers[2].ProofProducer.(*testSubProducer).err = errors.New("raiko error")
+	_, err = requestComposeProof(producer)
+	require.ErrorIs(t, err, errNoQuorum)
+}
+
+func TestComposeRequestProofRequired(t *testing.T) {
+	sgx := newTestSubProducer(ProofTypeSgx, 1, true, ErrProofInProgress)
+	producer := &ComposeProofProducer{
+		SubProducers: []*SubProofProducer{
+			sgx,
+			newTestSubProducer(ZKProofTypeR0, 2, false, nil),
+			newTestSubProducer(ZKProofTypeSP1, 3, false, nil),
+		},
+		Quorum: 2,
+	}
+
+	// The required sub-proof is still being generated.
+	_, err := requestComposeProof(producer)
+	require.ErrorIs(t, err, ErrProofInProgress)
+
+	// The required sub-proof fails.
+	sgx.ProofProducer.(*testSubProducer).err = errors.New("raiko error")
+	_, err = requestComposeProof(producer)
+	require.ErrorIs(t, err, errNoQuorum)
+}
+
+func TestComposeRequestProofCancel(t *testing.T) {
+	slow := newTestSubProducer(ZKProofTypeR0, 2, false, ErrProofInProgress)
+	producer := &ComposeProofProducer{
+		SubProducers: []*SubProofProducer{
+			newTestSubProducer(ProofTypeSgx, 1, true, nil),
+			slow,
+			newTestSubProducer(ZKProofTypeSP1, 3, false, nil),
+		},
+		Quorum: 2,
+	}
+
+	_, err := requestComposeProof(producer)
+	require.Nil(t, err)
+	require.True(t, slow.ProofProducer.(*testSubProducer).canceled)
+}
@@ -0,0 +1,248 @@
+package producer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_producer/protocol"
+)
+
+// HTTPProofProducer generates proofs through a proving backend implementing the proof producer protocol.
+type HTTPProofProducer struct {
+	Endpoint       string        // Endpoint of the proving backend
+	ProofType      string        // Proof type
+	Token          string        // Bearer token of the proving backend
+	RequestTimeout time.Duration // Timeout of a single request
+	ProofTier      uint16        // Tier of the proofs before Pacaya fork
+}
+
+// RequestProof implements the ProofProducer interface.
+func (h *HTTPProofProducer) RequestProof(
+	ctx context.Context,
+	opts ProofRequestOptions,
+	blockID *big.Int,
+	meta metadata.TaikoProposalMetaData,
+	requestAt time.Time,
+) (*ProofResponse, error) {
+	log.Info(
+		"Request proof from proving backend",
+		"blockID", blockID,
+		"coinbase", meta.GetCoinbase(),
+		"proofType", h.ProofType,
+		"time", time.Since(requestAt),
+	)
+
+	proof, err := h.requestProof(ctx, protocol.RouteProof, &protocol.ProofRequest{
+		ProofType: h.ProofType,
+		Proposals: []*protocol.Proposal{{ID: blockID, Blocks: proposalBlocks(meta)}},
+		Prover:    opts.GetProverAddress(),
+		Graffiti:  opts.GetGraffiti(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Proof generated", "blockID", blockID, "time", time.Since(requestAt), "producer", "HTTPProofProducer")
+
+	return &ProofResponse{
+		BlockID: blockID,
+		Meta:    meta,
+		Proof:   proof,
+		Opts:    opts,
+		Tier:    h.Tier(),
+	}, nil
+}
+
+// Aggregate implements the ProofProducer interface to aggregate a batch of proofs.
+func (h *HTTPProofProducer) Aggregate(
+	ctx context.Context,
+	items []*ProofResponse,
+	requestAt time.Time,
+) (*BatchProofs, error) {
+	if len(items) == 0 {
+		return nil, ErrInvalidLength
+	}
+
+	var (
+		ids       = aggregationIDs(items)
+		proposals = make([]*protocol.Proposal, len(items))
+	)
+	for i, item := range items {
+		proposals[i] = &protocol.Proposal{ID: ids[i], Blocks: proposalBlocks(item.Meta)}
+	}
+
+	log.Info(
+		"Aggregate batch proofs from proving backend",
+		"ids", ids,
+		"proofType", h.ProofType,
+		"time", time.Since(requestAt),
+	)
+
+	batchProof, err := h.requestProof(ctx, protocol.RouteProofAggregate, &protocol.ProofRequest{
+		ProofType: h.ProofType,
+		Proposals: proposals,
+		Prover:    items[0].Opts.GetProverAddress(),
+		Graffiti:  items[0].Opts.GetGraffiti(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Batch proof generated", "ids", ids, "time", time.Since(requestAt), "producer", "HTTPProofProducer")
+
+	return &BatchProofs{
+		ProofResponses: items,
+		BatchProof:     batchProof,
+		Tier:           h.Tier(),
+		BlockIDs:       ids,
+	}, nil
+}
+
+// RequestCancel implements the ProofProducer interface to cancel the proof generating progress.
+func (h *HTTPProofProducer) RequestCancel(
+	ctx context.Context,
+	opts ProofRequestOptions,
+) error {
+	var id *big.Int
+	if opts.IsPacaya() {
+		id = opts.PacayaOptions().BatchID
+	} else {
+		id = opts.OntakeOptions().BlockID
+	}
+
+	res, err := h.send(ctx, protocol.RouteProofCancel, &protocol.ProofRequest{
+		ProofType: h.ProofType,
+		Proposals: []*protocol.Proposal{{ID: id}},
+	})
+	if err != nil {
+		return err
+	}
+
+	if res.Status != protocol.StatusCancelled && res.Status != protocol.StatusNotFound {
+		return fmt.Errorf("failed to cancel requesting proof, status: %s, error: %s", res.Status, res.Error)
+	}
+
+	return nil
+}
+
+// Tier implements the ProofProducer interface.
+func (h *HTTPProofProducer) Tier() uint16 {
+	return h.ProofTier
+}
+
+// Status returns the protocol version and the proof types supported by the proving backend.
+func (h *HTTPProofProducer) Status(ctx context.Context) (*protocol.StatusResponse, error) {
+	ctx, cancel := rpc.CtxWithTimeoutOrDefault(ctx, h.RequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.Endpoint+protocol.RouteStatus, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	status := new(protocol.StatusResponse)
+	if err := h.do(req, status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// requestProof requests a proof from the proving backend, and returns ErrRetry or ErrProofInProgress
+// if the proof is not generated yet.
+func (h *HTTPProofProducer) requestProof(
+	ctx context.Context,
+	route string,
+	reqBody *protocol.ProofRequest,
+) ([]byte, error) {
+	res, err := h.send(ctx, route, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	switch res.Status {
+	case protocol.StatusRegistered:
+		return nil, ErrRetry
+	case protocol.StatusInProgress:
+		return nil, ErrProofInProgress
+	case protocol.StatusDone:
+		if len(res.Proof) == 0 {
+			return nil, errEmptyProof
+		}
+		return res.Proof, nil
+	default:
+		return nil, fmt.Errorf("failed to get proof, status: %s, error: %s", res.Status, res.Error)
+	}
+}
+
+// send sends the given request body to the given route of the proving backend.
+func (h *HTTPProofProducer) send(
+	ctx context.Context,
+	route string,
+	reqBody *protocol.ProofRequest,
+) (*protocol.ProofResponse, error) {
+	ctx, cancel := rpc.CtxWithTimeoutOrDefault(ctx, h.RequestTimeout)
+	defer cancel()
+
+	jsonValue, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("Send request to proving backend", "route", route, "input", string(jsonValue))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.Endpoint+route, bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res := new(protocol.ProofResponse)
+	if err := h.do(req, res); err != nil {
+		return nil, err
+	}
+	if res.Version != protocol.Version {
+		return nil, fmt.Errorf("unsupported proof producer protocol version: %s", res.Version)
+	}
+
+	return res, nil
+}
+
+// do sends the given HTTP request, and decodes the JSON response body into the given output.
+func (h *HTTPProofProducer) do(req *http.Request, output interface{}) error {
+	if len(h.Token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+h.Token)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	resBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	log.Debug("Proving backend output", "url", req.URL, "statusCode", res.StatusCode, "output", string(resBytes))
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to request proving backend, statusCode: %d, output: %s", res.StatusCode, resBytes)
+	}
+	if err := json.Unmarshal(resBytes, output); err != nil {
+		return fmt.Errorf("invalid proving backend output: %w", err)
+	}
+
+	return nil
+}
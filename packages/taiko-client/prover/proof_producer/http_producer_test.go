@@ -0,0 +1,111 @@
+package producer
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_producer/protocol"
+)
+
+func newTestHTTPProducer(t *testing.T, cfg *protocol.MockServerConfig) (*HTTPProofProducer, *protocol.MockServer) {
+	server := protocol.NewMockServer(cfg)
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	return &HTTPProofProducer{
+		Endpoint:       httpServer.URL,
+		ProofType:      ProofTypeSgx,
+		Token:          cfg.Token,
+		RequestTimeout: time.Second,
+	}, server
+}
+
+func TestHTTPRequestProof(t *testing.T) {
+	producer, server := newTestHTTPProducer(t, &protocol.MockServerConfig{
+		ProofTypes:   []string{ProofTypeSgx},
+		ProofLatency: 100 * time.Millisecond,
+		Token:        "token",
+	})
+
+	status, err := producer.Status(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, protocol.Version, status.Version)
+
+	request := func() (*ProofResponse, error) {
+		return producer.RequestProof(
+			context.Background(),
+			&ProofRequestOptionsOntake{BlockID: common.Big32},
+			common.Big32,
+			&metadata.TaikoDataBlockMetadataOntake{},
+			time.Now(),
+		)
+	}
+
+	_, err = request()
+	require.ErrorIs(t, err, ErrRetry)
+	_, err = request()
+	require.ErrorIs(t, err, ErrProofInProgress)
+
+	time.Sleep(100 * time.Millisecond)
+	res, err := request()
+	require.Nil(t, err)
+	require.NotEmpty(t, res.Proof)
+
+	// The same request always gets the same proof.
+	res2, err := request()
+	require.Nil(t, err)
+	require.Equal(t, res.Proof, res2.Proof)
+
+	require.Nil(t, producer.RequestCancel(context.Background(), &ProofRequestOptionsOntake{BlockID: common.Big32}))
+	require.Zero(t, server.Tasks())
+
+	// Requests with an invalid token are rejected.
+	producer.Token = "invalid"
+	_, err = request()
+	require.ErrorContains(t, err, "statusCode: 401")
+}
+
+func TestHTTPRequestProofFailures(t *testing.T) {
+	request := func(producer *HTTPProofProducer) error {
+		_, err := producer.RequestProof(
+			context.Background(),
+			&ProofRequestOptionsOntake{BlockID: common.Big1},
+			common.Big1,
+			&metadata.TaikoDataBlockMetadataOntake{},
+			time.Now(),
+		)
+		return err
+	}
+
+	producer, _ := newTestHTTPProducer(t, &protocol.MockServerConfig{FailureRate: 1})
+	require.ErrorIs(t, request(producer), ErrRetry)
+	require.ErrorContains(t, request(producer), "injected failure")
+
+	producer, _ = newTestHTTPProducer(t, &protocol.MockServerConfig{ErrorRate: 1})
+	require.ErrorContains(t, request(producer), "statusCode: 500")
+
+	producer, _ = newTestHTTPProducer(t, &protocol.MockServerConfig{ProofTypes: []string{ZKProofTypeSP1}})
+	require.ErrorContains(t, request(producer), "unsupported proof type")
+}
+
+func TestHTTPAggregate(t *testing.T) {
+	producer, _ := newTestHTTPProducer(t, &protocol.MockServerConfig{})
+
+	items := []*ProofResponse{
+		{BlockID: common.Big1, Meta: &metadata.TaikoDataBlockMetadataOntake{}, Opts: &ProofRequestOptionsOntake{}},
+		{BlockID: common.Big2, Meta: &metadata.TaikoDataBlockMetadataOntake{}, Opts: &ProofRequestOptionsOntake{}},
+	}
+	_, err := producer.Aggregate(context.Background(), items, time.Now())
+	require.ErrorIs(t, err, ErrRetry)
+
+	batchProof, err := producer.Aggregate(context.Background(), items, time.Now())
+	require.Nil(t, err)
+	require.NotEmpty(t, batchProof.BatchProof)
+	require.Len(t, batchProof.BlockIDs, 2)
+}
@@ -68,6 +68,7 @@ type ProofRequestOptionsPacaya struct {
 	ProverAddress      common.Address
 	ProposeBlockTxHash common.Hash
 	EventL1Hash        common.Hash
+	EventL1Height      *big.Int
 }
 
 // IsPacaya implemenwts the ProofRequestOptions interface.
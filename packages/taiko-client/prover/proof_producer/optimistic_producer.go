@@ -43,15 +43,11 @@ func (o *OptimisticProofProducer) Aggregate(
 	if len(items) == 0 {
 		return nil, ErrInvalidLength
 	}
-	blockIDs := make([]*big.Int, len(items))
-	for i, item := range items {
-		blockIDs[i] = item.Meta.Ontake().GetBlockID()
-	}
 	batchProof, err := o.DummyProofProducer.RequestBatchProofs(items, o.Tier())
 	if err != nil {
 		return nil, err
 	}
-	batchProof.BlockIDs = blockIDs
+	batchProof.BlockIDs = aggregationIDs(items)
 	return batchProof, nil
 }
 
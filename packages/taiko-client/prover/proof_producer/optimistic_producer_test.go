@@ -2,14 +2,17 @@ package producer
 
 import (
 	"context"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
+	pacayaBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/pacaya"
 )
 
 func TestOptimisticRequestProof(t *testing.T) {
@@ -45,3 +48,44 @@ func TestProofCancel(t *testing.T) {
 	)
 	require.Nil(t, err)
 }
+
+func TestOptimisticAggregatePacaya(t *testing.T) {
+	var (
+		producer = &OptimisticProofProducer{}
+		items    []*ProofResponse
+	)
+	for batchID := uint64(1); batchID <= 2; batchID++ {
+		meta := &metadata.TaikoDataBlockMetadataPacaya{
+			ITaikoInboxBatchInfo: pacayaBindings.ITaikoInboxBatchInfo{
+				LastBlockId: batchID * 2,
+				Blocks:      make([]pacayaBindings.ITaikoInboxBlockParams, 2),
+			},
+			ITaikoInboxBatchMetadata: pacayaBindings.ITaikoInboxBatchMetadata{BatchId: batchID},
+			Log:                      types.Log{BlockNumber: 100 + batchID},
+		}
+		res, err := producer.RequestProof(
+			context.Background(),
+			&ProofRequestOptionsPacaya{BatchID: meta.GetBatchID()},
+			meta.GetBatchID(),
+			meta,
+			time.Now(),
+		)
+		require.Nil(t, err)
+		items = append(items, res)
+	}
+
+	batchProof, err := producer.Aggregate(context.Background(), items, time.Now())
+	require.Nil(t, err)
+	require.Equal(t, []*big.Int{common.Big1, common.Big2}, batchProof.BlockIDs)
+	require.NotEmpty(t, batchProof.BatchProof)
+
+	require.Equal(t, [][2]*big.Int{
+		{big.NewInt(1), big.NewInt(101)},
+		{big.NewInt(2), big.NewInt(101)},
+		{big.NewInt(3), big.NewInt(102)},
+		{big.NewInt(4), big.NewInt(102)},
+	}, aggregationBlocks(items))
+
+	_, err = producer.Aggregate(context.Background(), []*ProofResponse{}, time.Now())
+	require.ErrorIs(t, err, ErrInvalidLength)
+}
@@ -3,7 +3,9 @@ package producer
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -38,6 +40,8 @@ type ProofResponse struct {
 	Proof   []byte
 	Opts    ProofRequestOptions
 	Tier    uint16
+	// Composed is set if the proof is already encoded as the sub-proofs of a compose verifier.
+	Composed bool
 }
 
 type BatchProofs struct {
@@ -45,6 +49,8 @@ type BatchProofs struct {
 	BatchProof     []byte
 	Tier           uint16
 	BlockIDs       []*big.Int
+	// Composed is set if the batch proof is already encoded as the sub-proofs of a compose verifier.
+	Composed bool
 }
 
 type ProofProducer interface {
@@ -66,3 +72,82 @@ type ProofProducer interface {
 	) error
 	Tier() uint16
 }
+
+// Name returns a short name of the given proof producer, which is used to tell the proofs of different
+// producers apart in logs, metrics and reports.
+func Name(p ProofProducer) string {
+	switch producer := p.(type) {
+	case *SGXProofProducer:
+		return producer.ProofType
+	case *ZKvmProofProducer:
+		return producer.ZKProofType
+	case *HTTPProofProducer:
+		return "http_" + producer.ProofType
+	case *GuardianProofProducer:
+		return "guardian"
+	case *OptimisticProofProducer:
+		return "optimistic"
+	case *ComposeProofProducer:
+		names := make([]string, len(producer.SubProducers))
+		for i, sub := range producer.SubProducers {
+			names[i] = sub.Name
+		}
+		return "compose_" + strings.Join(names, "_")
+	default:
+		return fmt.Sprintf("%T", p)
+	}
+}
+
+// aggregationIDs returns the IDs of the given proofs to aggregate, which are the block IDs before Pacaya fork,
+// and the batch IDs after it.
+func aggregationIDs(items []*ProofResponse) []*big.Int {
+	ids := make([]*big.Int, len(items))
+	for i, item := range items {
+		if item.Meta.IsPacaya() {
+			ids[i] = item.Meta.Pacaya().GetBatchID()
+		} else {
+			ids[i] = item.Meta.Ontake().GetBlockID()
+		}
+	}
+
+	return ids
+}
+
+// aggregationBlocks returns the `block_numbers` of a Raiko proof aggregation request for the given proofs.
+func aggregationBlocks(items []*ProofResponse) [][2]*big.Int {
+	var blocks [][2]*big.Int
+	for _, item := range items {
+		blocks = append(blocks, proposalBlocks(item.Meta)...)
+	}
+
+	return blocks
+}
+
+// proposalBlocks returns the `block_numbers` of a Raiko proof request for the given proposal, after Pacaya fork,
+// each block of the batch is paired with the batch's L1 inclusion block number.
+func proposalBlocks(meta metadata.TaikoProposalMetaData) [][2]*big.Int {
+	if !meta.IsPacaya() {
+		return [][2]*big.Int{{meta.Ontake().GetBlockID(), nil}}
+	}
+
+	var (
+		blocks       = make([][2]*big.Int, len(meta.Pacaya().GetBlocks()))
+		firstBlockID = meta.Pacaya().GetLastBlockID() - uint64(len(blocks)) + 1
+	)
+	for i := range blocks {
+		blocks[i] = [2]*big.Int{new(big.Int).SetUint64(firstBlockID + uint64(i)), meta.GetRawBlockHeight()}
+	}
+
+	return blocks
+}
+
+// batchBlocks returns the `block_numbers` of a Raiko proof request for the batch of the given Pacaya proof
+// request options, which are the same as the ones of proposalBlocks for the batch metadata.
+func batchBlocks(opts *ProofRequestOptionsPacaya) [][2]*big.Int {
+	blocks := make([][2]*big.Int, len(opts.Headers))
+	for i, header := range opts.Headers {
+		blocks[i] = [2]*big.Int{header.Number, opts.EventL1Height}
+	}
+
+	return blocks
+}
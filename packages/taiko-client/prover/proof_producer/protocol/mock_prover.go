@@ -0,0 +1,67 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/cmd/flags"
+)
+
+// MockProver runs a MockServer as a standalone proving backend, so that a prover started with
+// `--prover.producer.endpoint` can be tested end to end without Raiko.
+type MockProver struct {
+	server *MockServer
+	port   uint64
+}
+
+// InitFromCli initializes the given mock prover instance based on the command line flags.
+func (p *MockProver) InitFromCli(_ context.Context, c *cli.Context) error {
+	failureRate, errorRate := c.Float64(flags.MockProverFailureRate.Name), c.Float64(flags.MockProverErrorRate.Name)
+	if failureRate < 0 || failureRate > 1 {
+		return fmt.Errorf("invalid mock prover failure rate: %v", failureRate)
+	}
+	if errorRate < 0 || errorRate > 1 {
+		return fmt.Errorf("invalid mock prover error rate: %v", errorRate)
+	}
+
+	p.port = c.Uint64(flags.MockProverPort.Name)
+	p.server = NewMockServer(&MockServerConfig{
+		ProofTypes:   c.StringSlice(flags.MockProverProofTypes.Name),
+		ProofLatency: c.Duration(flags.MockProverLatency.Name),
+		FailureRate:  failureRate,
+		ErrorRate:    errorRate,
+		Token:        c.String(flags.MockProverToken.Name),
+	})
+
+	return nil
+}
+
+// Name returns the application name.
+func (p *MockProver) Name() string {
+	return "mock-prover"
+}
+
+// Start starts the HTTP server of the mock proving backend.
+func (p *MockProver) Start() error {
+	go func() {
+		if err := p.server.Start(p.port); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Crit("Failed to start mock proving backend", "error", err)
+		}
+	}()
+
+	log.Info("Mock proving backend started", "port", p.port, "proofTypes", p.server.cfg.ProofTypes)
+
+	return nil
+}
+
+// Close shuts down the HTTP server of the mock proving backend.
+func (p *MockProver) Close(ctx context.Context) {
+	if err := p.server.Shutdown(ctx); err != nil {
+		log.Error("Failed to shut down mock proving backend", "error", err)
+	}
+}
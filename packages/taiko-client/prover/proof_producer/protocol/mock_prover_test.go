@@ -0,0 +1,64 @@
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/cmd/flags"
+)
+
+func runMockProver(args ...string) (*MockProver, error) {
+	var (
+		p   = new(MockProver)
+		app = cli.NewApp()
+	)
+	app.Flags = flags.MockProverFlags
+	app.Action = func(c *cli.Context) error {
+		return p.InitFromCli(context.Background(), c)
+	}
+
+	return p, app.Run(append([]string{"TestMockProver"}, args...))
+}
+
+func TestMockProverInvalidRates(t *testing.T) {
+	_, err := runMockProver("--"+flags.MockProverFailureRate.Name, "1.5")
+	require.ErrorContains(t, err, "invalid mock prover failure rate")
+
+	_, err = runMockProver("--"+flags.MockProverErrorRate.Name, "-1")
+	require.ErrorContains(t, err, "invalid mock prover error rate")
+}
+
+func TestMockProverStartAndClose(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.Nil(t, listener.Close())
+
+	p, err := runMockProver(
+		"--"+flags.MockProverPort.Name, fmt.Sprintf("%d", port),
+		"--"+flags.MockProverProofTypes.Name, "sgx",
+	)
+	require.Nil(t, err)
+	require.Nil(t, p.Start())
+	defer p.Close(context.Background())
+
+	var res *http.Response
+	require.Eventually(t, func() bool {
+		res, err = http.Get(fmt.Sprintf("http://127.0.0.1:%d%s", port, RouteStatus))
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+	defer res.Body.Close()
+
+	status := new(StatusResponse)
+	require.Nil(t, json.NewDecoder(res.Body).Decode(status))
+	require.Equal(t, Version, status.Version)
+	require.Equal(t, []string{"sgx"}, status.ProofTypes)
+}
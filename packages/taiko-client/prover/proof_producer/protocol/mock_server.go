@@ -0,0 +1,189 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// MockServerConfig is the configuration of a MockServer.
+type MockServerConfig struct {
+	// ProofTypes are the supported proof types, all proof types are supported if not set.
+	ProofTypes []string
+	// ProofLatency is how long a proving task stays in progress before its proof is done.
+	ProofLatency time.Duration
+	// FailureRate is the probability of a proving task to fail.
+	FailureRate float64
+	// ErrorRate is the probability of a request to fail with an internal server error.
+	ErrorRate float64
+	// Token is the bearer token required by all the routes, if set.
+	Token string
+}
+
+// mockTask is a proving task of the mock server.
+type mockTask struct {
+	registeredAt time.Time
+	failed       bool
+}
+
+// MockServer is a proving backend implementing the proof producer protocol, which returns deterministic
+// fake proofs after a configurable latency, and injects failures, for testing the prover without Raiko.
+type MockServer struct {
+	echo  *echo.Echo
+	cfg   *MockServerConfig
+	mutex sync.Mutex
+	tasks map[common.Hash]*mockTask
+}
+
+// NewMockServer creates a new mock proving backend instance.
+func NewMockServer(cfg *MockServerConfig) *MockServer {
+	server := &MockServer{echo: echo.New(), cfg: cfg, tasks: make(map[common.Hash]*mockTask)}
+
+	server.echo.HideBanner = true
+	server.echo.Use(middleware.Recover())
+	server.echo.Use(server.injectErrors)
+	if len(cfg.Token) > 0 {
+		server.echo.Use(middleware.KeyAuth(func(key string, _ echo.Context) (bool, error) {
+			return key == cfg.Token, nil
+		}))
+	}
+
+	server.echo.POST(RouteProof, server.RequestProof)
+	server.echo.POST(RouteProofAggregate, server.RequestProof)
+	server.echo.POST(RouteProofCancel, server.CancelProof)
+	server.echo.GET(RouteStatus, server.Status)
+
+	return server
+}
+
+// Start starts the HTTP server.
+func (s *MockServer) Start(port uint64) error {
+	return s.echo.Start(fmt.Sprintf(":%v", port))
+}
+
+// Shutdown shuts down the HTTP server.
+func (s *MockServer) Shutdown(ctx context.Context) error {
+	return s.echo.Shutdown(ctx)
+}
+
+// ServeHTTP implements the http.Handler interface, so that the server can be used with httptest.
+func (s *MockServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.echo.ServeHTTP(w, r)
+}
+
+// Tasks returns the number of the registered proving tasks.
+func (s *MockServer) Tasks() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.tasks)
+}
+
+// RequestProof registers a proving task for the given request, or returns the status of the registered one.
+func (s *MockServer) RequestProof(c echo.Context) error {
+	req, key, err := s.parseRequest(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, &ProofResponse{Version: Version, Error: err.Error()})
+	}
+	if len(s.cfg.ProofTypes) > 0 && !slices.Contains(s.cfg.ProofTypes, req.ProofType) {
+		return c.JSON(http.StatusBadRequest, &ProofResponse{
+			Version: Version,
+			Error:   fmt.Sprintf("unsupported proof type: %s", req.ProofType),
+		})
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	task, ok := s.tasks[key]
+	if !ok {
+		s.tasks[key] = &mockTask{registeredAt: time.Now(), failed: rand.Float64() < s.cfg.FailureRate}
+		return c.JSON(http.StatusOK, &ProofResponse{Version: Version, Status: StatusRegistered})
+	}
+
+	switch {
+	case task.failed:
+		return c.JSON(http.StatusOK, &ProofResponse{Version: Version, Status: StatusFailed, Error: "injected failure"})
+	case time.Since(task.registeredAt) < s.cfg.ProofLatency:
+		return c.JSON(http.StatusOK, &ProofResponse{Version: Version, Status: StatusInProgress})
+	default:
+		return c.JSON(http.StatusOK, &ProofResponse{Version: Version, Status: StatusDone, Proof: key.Bytes()})
+	}
+}
+
+// CancelProof removes the proving task of the given request.
+func (s *MockServer) CancelProof(c echo.Context) error {
+	req, _, err := s.parseRequest(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, &ProofResponse{Version: Version, Error: err.Error()})
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Both the single and the aggregated proving tasks of the given proposals are canceled.
+	status := StatusNotFound
+	for _, route := range []string{RouteProof, RouteProofAggregate} {
+		key := taskKey(route, req)
+		if _, ok := s.tasks[key]; ok {
+			delete(s.tasks, key)
+			status = StatusCancelled
+		}
+	}
+
+	return c.JSON(http.StatusOK, &ProofResponse{Version: Version, Status: status})
+}
+
+// Status returns the protocol version and the supported proof types.
+func (s *MockServer) Status(c echo.Context) error {
+	return c.JSON(http.StatusOK, &StatusResponse{Version: Version, ProofTypes: s.cfg.ProofTypes})
+}
+
+// injectErrors is a middleware which fails requests with the configured error rate.
+func (s *MockServer) injectErrors(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if rand.Float64() < s.cfg.ErrorRate {
+			return echo.NewHTTPError(http.StatusInternalServerError, "injected error")
+		}
+		return next(c)
+	}
+}
+
+// parseRequest parses the given proof request, and returns it with the key of its proving task.
+func (s *MockServer) parseRequest(c echo.Context) (*ProofRequest, common.Hash, error) {
+	req := new(ProofRequest)
+	if err := c.Bind(req); err != nil {
+		return nil, common.Hash{}, err
+	}
+	if len(req.Proposals) == 0 {
+		return nil, common.Hash{}, errors.New("empty proposals")
+	}
+
+	for _, proposal := range req.Proposals {
+		if proposal.ID == nil {
+			return nil, common.Hash{}, errors.New("empty proposal ID")
+		}
+	}
+
+	return req, taskKey(c.Path(), req), nil
+}
+
+// taskKey returns the key of the proving task of the given request, which is also used as its fake proof.
+func taskKey(route string, req *ProofRequest) common.Hash {
+	data := [][]byte{[]byte(route), []byte(req.ProofType)}
+	for _, proposal := range req.Proposals {
+		data = append(data, common.BigToHash(proposal.ID).Bytes())
+	}
+
+	return crypto.Keccak256Hash(data...)
+}
@@ -0,0 +1,81 @@
+// Package protocol defines the HTTP/JSON protocol between the prover and a proving backend, so that
+// backends other than Raiko can be plugged into the prover.
+//
+// All the routes are prefixed with the protocol version, and all the request and response bodies are
+// JSON encoded. If the backend is configured with a token, every request must carry it in an
+// `Authorization: Bearer <token>` header.
+//
+//   - POST /v1/proof: requests a proof for the given proposals. The request is idempotent, the first call
+//     registers a proving task, and the following calls poll its status. A proving task is identified by
+//     its route, proof type and proposal IDs.
+//   - POST /v1/proof/aggregate: requests a single aggregated proof for all the given proposals, it is
+//     polled in the same way.
+//   - POST /v1/proof/cancel: cancels the proving tasks of the given proof type and proposal IDs, the other
+//     fields of the request body are ignored.
+//   - GET /v1/status: returns the protocol version and the proof types supported by the backend.
+//
+// A proving task goes through `registered`, `work_in_progress` and then either `done` with a proof, or
+// `failed` with an error message. A cancellation responds `cancelled`, or `not_found` if there is no such
+// task, and the next proof request registers the task again.
+package protocol
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Version is the current version of the proof producer protocol.
+const Version = "v1"
+
+// Routes of the proof producer protocol.
+const (
+	RouteProof          = "/" + Version + "/proof"
+	RouteProofAggregate = "/" + Version + "/proof/aggregate"
+	RouteProofCancel    = "/" + Version + "/proof/cancel"
+	RouteStatus         = "/" + Version + "/status"
+)
+
+// TaskStatus is the status of a proving task.
+type TaskStatus string
+
+// Statuses of a proving task.
+const (
+	StatusRegistered TaskStatus = "registered"
+	StatusInProgress TaskStatus = "work_in_progress"
+	StatusDone       TaskStatus = "done"
+	StatusFailed     TaskStatus = "failed"
+	StatusCancelled  TaskStatus = "cancelled"
+	StatusNotFound   TaskStatus = "not_found"
+)
+
+// Proposal is a proposal to prove, i.e. a block before Pacaya fork, or a batch after it.
+type Proposal struct {
+	// ID is the block ID before Pacaya fork, and the batch ID after it.
+	ID *big.Int `json:"id"`
+	// Blocks are the L2 block IDs of the proposal, each paired with the L1 block which includes it.
+	Blocks [][2]*big.Int `json:"block_numbers"`
+}
+
+// ProofRequest is the body of the proof, aggregation and cancellation requests.
+type ProofRequest struct {
+	ProofType string         `json:"proof_type"`
+	Proposals []*Proposal    `json:"proposals"`
+	Prover    common.Address `json:"prover"`
+	Graffiti  string         `json:"graffiti"`
+}
+
+// ProofResponse is the body of the proof and aggregation responses.
+type ProofResponse struct {
+	Version string        `json:"version"`
+	Status  TaskStatus    `json:"status"`
+	Proof   hexutil.Bytes `json:"proof,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// StatusResponse is the body of the status response.
+type StatusResponse struct {
+	Version    string   `json:"version"`
+	ProofTypes []string `json:"proof_types"`
+}
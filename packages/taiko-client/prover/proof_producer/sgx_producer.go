@@ -101,7 +101,7 @@ func (s *SGXProofProducer) RequestProof(
 	log.Info(
 		"Request sgx proof from raiko-host service",
 		"blockID", blockID,
-		"coinbase", meta.Ontake().GetCoinbase(),
+		"coinbase", meta.GetCoinbase(),
 		"time", time.Since(requestAt),
 	)
 
@@ -109,7 +109,23 @@ func (s *SGXProofProducer) RequestProof(
 		return s.DummyProofProducer.RequestProof(opts, blockID, meta, s.Tier(), requestAt)
 	}
 
-	proof, err := s.callProverDaemon(ctx, opts, requestAt)
+	var (
+		proof []byte
+		err   error
+	)
+	if meta.IsPacaya() {
+		// A Pacaya batch is proven as a whole, through the Raiko batch proof API.
+		proof, err = s.requestBatchProof(
+			ctx,
+			[]*big.Int{blockID},
+			proposalBlocks(meta),
+			opts.GetProverAddress(),
+			opts.GetGraffiti(),
+			requestAt,
+		)
+	} else {
+		proof, err = s.callProverDaemon(ctx, opts, requestAt)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -142,13 +158,11 @@ func (s *SGXProofProducer) Aggregate(
 		return nil, ErrInvalidLength
 	}
 
-	blockIDs := make([]*big.Int, len(items))
-	for i, item := range items {
-		blockIDs[i] = item.Meta.Ontake().GetBlockID()
-	}
+	blockIDs := aggregationIDs(items)
 	batchProof, err := s.requestBatchProof(
 		ctx,
 		blockIDs,
+		aggregationBlocks(items),
 		items[0].Opts.GetProverAddress(),
 		items[0].Opts.GetGraffiti(),
 		requestAt,
@@ -172,19 +186,34 @@ func (s *SGXProofProducer) RequestCancel(
 	ctx context.Context,
 	opts ProofRequestOptions,
 ) error {
-	if opts.IsPacaya() {
-		return fmt.Errorf("sgx proof cancellation is not supported for Pacaya fork")
-	}
-	reqBody := RaikoRequestProofBody{
-		Type:     s.ProofType,
-		Block:    opts.OntakeOptions().BlockID,
-		Prover:   opts.OntakeOptions().ProverAddress.Hex()[2:],
-		Graffiti: opts.OntakeOptions().Graffiti,
-		SGX: &SGXRequestProofBodyParam{
+	var (
+		reqBody interface{}
+		route   = "/v2/proof/cancel"
+		sgx     = &SGXRequestProofBodyParam{
 			Setup:     false,
 			Bootstrap: false,
 			Prove:     true,
-		},
+		}
+	)
+	if opts.IsPacaya() {
+		// A Pacaya batch proof is canceled through the Raiko batch proof API, with the same body as the
+		// proof request.
+		reqBody = RaikoRequestProofBodyV3{
+			Type:     s.ProofType,
+			Blocks:   batchBlocks(opts.PacayaOptions()),
+			Prover:   opts.GetProverAddress().Hex()[2:],
+			Graffiti: opts.GetGraffiti(),
+			SGX:      sgx,
+		}
+		route = "/v3/proof/cancel"
+	} else {
+		reqBody = RaikoRequestProofBody{
+			Type:     s.ProofType,
+			Block:    opts.OntakeOptions().BlockID,
+			Prover:   opts.OntakeOptions().ProverAddress.Hex()[2:],
+			Graffiti: opts.OntakeOptions().Graffiti,
+			SGX:      sgx,
+		}
 	}
 
 	client := &http.Client{}
@@ -197,7 +226,7 @@ func (s *SGXProofProducer) RequestCancel(
 	req, err := http.NewRequestWithContext(
 		ctx,
 		"POST",
-		s.RaikoHostEndpoint+"/v2/proof/cancel",
+		s.RaikoHostEndpoint+route,
 		bytes.NewBuffer(jsonValue),
 	)
 	if err != nil {
@@ -225,6 +254,7 @@ func (s *SGXProofProducer) RequestCancel(
 func (s *SGXProofProducer) requestBatchProof(
 	ctx context.Context,
 	blockIDs []*big.Int,
+	blocks [][2]*big.Int,
 	proverAddress common.Address,
 	graffiti string,
 	requestAt time.Time,
@@ -236,10 +266,6 @@ func (s *SGXProofProducer) requestBatchProof(
 	ctx, cancel := rpc.CtxWithTimeoutOrDefault(ctx, s.RaikoRequestTimeout)
 	defer cancel()
 
-	blocks := make([][2]*big.Int, len(blockIDs))
-	for i := range blockIDs {
-		blocks[i][0] = blockIDs[i]
-	}
 	reqBody := RaikoRequestProofBodyV3{
 		Type:     s.ProofType,
 		Blocks:   blocks,
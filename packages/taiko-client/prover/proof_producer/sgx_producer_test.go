@@ -2,10 +2,16 @@ package producer
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/require"
 
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/encoding"
@@ -30,3 +36,52 @@ func TestSGXProducerRequestProof(t *testing.T) {
 	require.Equal(t, res.Tier, encoding.TierSgxID)
 	require.NotEmpty(t, res.Proof)
 }
+
+// newTestRaikoServer returns a Raiko host which records the path, authorization and body of the last
+// request, and replies with the given status code.
+func newTestRaikoServer(t *testing.T, statusCode int) (*httptest.Server, *testRaikoRequest) {
+	last := new(testRaikoRequest)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last.path = r.URL.Path
+		last.authorization = r.Header.Get("Authorization")
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&last.body))
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, last
+}
+
+// testRaikoRequest is a request received by the test Raiko host.
+type testRaikoRequest struct {
+	path          string
+	authorization string
+	body          RaikoRequestProofBodyV3
+}
+
+// testPacayaOptions returns the proof request options of a batch with two blocks, proposed in L1 block 100.
+func testPacayaOptions() *ProofRequestOptionsPacaya {
+	return &ProofRequestOptionsPacaya{
+		BatchID:       common.Big1,
+		Headers:       []*types.Header{{Number: big.NewInt(10)}, {Number: big.NewInt(11)}},
+		ProverAddress: common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		EventL1Height: big.NewInt(100),
+	}
+}
+
+func TestSGXProducerRequestCancelPacaya(t *testing.T) {
+	server, last := newTestRaikoServer(t, http.StatusOK)
+	producer := &SGXProofProducer{RaikoHostEndpoint: server.URL, ProofType: ProofTypeSgx, JWT: "jwt"}
+
+	require.Nil(t, producer.RequestCancel(context.Background(), testPacayaOptions()))
+	require.Equal(t, "/v3/proof/cancel", last.path)
+	require.Equal(t, "Bearer "+base64.StdEncoding.EncodeToString([]byte("jwt")), last.authorization)
+	require.Equal(t, ProofTypeSgx, last.body.Type)
+	require.Equal(t, "70997970C51812dc3A010C7d01b50e0d17dc79C8", last.body.Prover)
+	require.Equal(t, [][2]*big.Int{{big.NewInt(10), big.NewInt(100)}, {big.NewInt(11), big.NewInt(100)}}, last.body.Blocks)
+	require.True(t, last.body.SGX.Prove)
+
+	server, _ = newTestRaikoServer(t, http.StatusInternalServerError)
+	producer.RaikoHostEndpoint = server.URL
+	require.ErrorContains(t, producer.RequestCancel(context.Background(), testPacayaOptions()), "statusCode: 500")
+}
@@ -70,14 +70,10 @@ func (s *ZKvmProofProducer) RequestProof(
 	meta metadata.TaikoProposalMetaData,
 	requestAt time.Time,
 ) (*ProofResponse, error) {
-	if meta.IsPacaya() {
-		return nil, fmt.Errorf("zk proof generation is not supported for Pacaya")
-	}
-
 	log.Info(
 		"Request zk proof from raiko-host service",
 		"blockID", blockID,
-		"coinbase", meta.Ontake().GetCoinbase(),
+		"coinbase", meta.GetCoinbase(),
 		"zkType", s.ZKProofType,
 		"time", time.Since(requestAt),
 	)
@@ -86,7 +82,23 @@ func (s *ZKvmProofProducer) RequestProof(
 		return s.DummyProofProducer.RequestProof(opts, blockID, meta, s.Tier(), requestAt)
 	}
 
-	proof, err := s.callProverDaemon(ctx, opts, requestAt)
+	var (
+		proof []byte
+		err   error
+	)
+	if meta.IsPacaya() {
+		// A Pacaya batch is proven as a whole, through the Raiko batch proof API.
+		proof, err = s.requestBatchProof(
+			ctx,
+			[]*big.Int{blockID},
+			proposalBlocks(meta),
+			opts.GetProverAddress(),
+			opts.GetGraffiti(),
+			requestAt,
+		)
+	} else {
+		proof, err = s.callProverDaemon(ctx, opts, requestAt)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -132,13 +144,11 @@ func (s *ZKvmProofProducer) Aggregate(
 		return nil, ErrInvalidLength
 	}
 
-	blockIDs := make([]*big.Int, len(items))
-	for i, item := range items {
-		blockIDs[i] = item.Meta.Ontake().GetBlockID()
-	}
+	blockIDs := aggregationIDs(items)
 	batchProof, err := s.requestBatchProof(
 		ctx,
 		blockIDs,
+		aggregationBlocks(items),
 		items[0].Opts.GetProverAddress(),
 		items[0].Opts.GetGraffiti(),
 		requestAt,
@@ -325,7 +335,7 @@ func (s *ZKvmProofProducer) requestCancel(
 	opts ProofRequestOptions,
 ) error {
 	if opts.IsPacaya() {
-		return fmt.Errorf("proof cancellation is not supported for Pacaya fork")
+		return s.requestBatchCancel(ctx, opts.PacayaOptions())
 	}
 
 	var (
@@ -399,29 +409,54 @@ func (s *ZKvmProofProducer) requestCancel(
 	return nil
 }
 
-// requestBatchProof poll the proof aggregation service to get the aggregated proof.
-func (s *ZKvmProofProducer) requestBatchProof(
-	ctx context.Context,
-	blockIDs []*big.Int,
-	proverAddress common.Address,
-	graffiti string,
-	requestAt time.Time,
-) ([]byte, error) {
-	var (
-		proof []byte
+// requestBatchCancel cancels the batch proof request of the given Pacaya proof request options, through
+// the Raiko batch proof API, with the same body as the proof request.
+func (s *ZKvmProofProducer) requestBatchCancel(ctx context.Context, opts *ProofRequestOptionsPacaya) error {
+	reqBody := s.batchProofBody(batchBlocks(opts), opts.GetProverAddress(), opts.GetGraffiti())
+
+	client := &http.Client{}
+
+	jsonValue, err := json.Marshal(reqBody)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		"POST",
+		s.RaikoHostEndpoint+"/v3/proof/cancel",
+		bytes.NewBuffer(jsonValue),
 	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.JWT) > 0 {
+		req.Header.Set("Authorization", "Bearer "+base64.StdEncoding.EncodeToString([]byte(s.JWT)))
+	}
 
-	ctx, cancel := rpc.CtxWithTimeoutOrDefault(ctx, s.RaikoRequestTimeout)
-	defer cancel()
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
 
-	blocks := make([][2]*big.Int, len(blockIDs))
-	for i := range blockIDs {
-		blocks[i][0] = blockIDs[i]
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to cancel requesting batch proof, statusCode: %d", res.StatusCode)
 	}
-	var reqBody RaikoRequestProofBodyV3
+
+	return nil
+}
+
+// batchProofBody returns the body of a Raiko batch proof request for the given blocks.
+func (s *ZKvmProofProducer) batchProofBody(
+	blocks [][2]*big.Int,
+	proverAddress common.Address,
+	graffiti string,
+) RaikoRequestProofBodyV3 {
 	switch s.ZKProofType {
 	case ZKProofTypeSP1:
-		reqBody = RaikoRequestProofBodyV3{
+		return RaikoRequestProofBodyV3{
 			Type:     s.ZKProofType,
 			Blocks:   blocks,
 			Prover:   proverAddress.Hex()[2:],
@@ -433,7 +468,7 @@ func (s *ZKvmProofProducer) requestBatchProof(
 			},
 		}
 	default:
-		reqBody = RaikoRequestProofBodyV3{
+		return RaikoRequestProofBodyV3{
 			Type:     s.ZKProofType,
 			Blocks:   blocks,
 			Prover:   proverAddress.Hex()[2:],
@@ -446,6 +481,25 @@ func (s *ZKvmProofProducer) requestBatchProof(
 			},
 		}
 	}
+}
+
+// requestBatchProof poll the proof aggregation service to get the aggregated proof.
+func (s *ZKvmProofProducer) requestBatchProof(
+	ctx context.Context,
+	blockIDs []*big.Int,
+	blocks [][2]*big.Int,
+	proverAddress common.Address,
+	graffiti string,
+	requestAt time.Time,
+) ([]byte, error) {
+	var (
+		proof []byte
+	)
+
+	ctx, cancel := rpc.CtxWithTimeoutOrDefault(ctx, s.RaikoRequestTimeout)
+	defer cancel()
+
+	reqBody := s.batchProofBody(blocks, proverAddress, graffiti)
 
 	client := &http.Client{}
 
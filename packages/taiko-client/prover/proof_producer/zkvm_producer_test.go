@@ -0,0 +1,38 @@
+package producer
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZKvmProducerRequestCancelPacaya(t *testing.T) {
+	for _, proofType := range []string{ZKProofTypeR0, ZKProofTypeSP1} {
+		t.Run(proofType, func(t *testing.T) {
+			server, last := newTestRaikoServer(t, http.StatusOK)
+			producer := &ZKvmProofProducer{RaikoHostEndpoint: server.URL, ZKProofType: proofType}
+
+			require.Nil(t, producer.RequestCancel(context.Background(), testPacayaOptions()))
+			require.Equal(t, "/v3/proof/cancel", last.path)
+			require.Empty(t, last.authorization)
+			require.Equal(t, proofType, last.body.Type)
+			require.Equal(
+				t,
+				[][2]*big.Int{{big.NewInt(10), big.NewInt(100)}, {big.NewInt(11), big.NewInt(100)}},
+				last.body.Blocks,
+			)
+			if proofType == ZKProofTypeSP1 {
+				require.Equal(t, RecursionCompressed, last.body.SP1.Recursion)
+			} else {
+				require.True(t, last.body.RISC0.Bonsai)
+			}
+
+			server, _ = newTestRaikoServer(t, http.StatusNotFound)
+			producer.RaikoHostEndpoint = server.URL
+			require.ErrorContains(t, producer.RequestCancel(context.Background(), testPacayaOptions()), "statusCode: 404")
+		})
+	}
+}
@@ -18,6 +18,9 @@ var (
 	proofPollingInterval                     = 10 * time.Second
 	ProofTimeout                             = 3 * time.Hour
 	ErrInvalidProof                          = errors.New("invalid proof found")
+	// ErrProofCanceled is the cause to cancel the context of RequestProof with, so that the proof
+	// generation in the proof producer is canceled as well.
+	ErrProofCanceled = errors.New("proof request canceled")
 )
 
 // Submitter is the interface for submitting proofs of the L2 blocks.
@@ -30,6 +33,9 @@ type Submitter interface {
 	Tier() uint16
 	BufferSize() uint64
 	AggregationEnabled() bool
+	// Reconfigure replaces the proof producer, the graffiti and the proof buffer size at runtime, the
+	// proof requests in progress keep using the former producer.
+	Reconfigure(producer proofProducer.ProofProducer, graffiti string, bufferSize uint64)
 }
 
 // Contester is the interface for contesting proofs of the L2 blocks.
@@ -42,4 +48,6 @@ type Contester interface {
 		meta metadata.TaikoProposalMetaData,
 		tier uint16,
 	) error
+	// SetGraffiti replaces the graffiti of the contests at runtime.
+	SetGraffiti(graffiti string)
 }
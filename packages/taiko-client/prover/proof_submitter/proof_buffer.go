@@ -15,7 +15,7 @@ var (
 
 // ProofBuffer caches all single proof with a fixed size.
 type ProofBuffer struct {
-	MaxLength     uint64
+	maxLength     uint64
 	buffer        []*producer.ProofResponse
 	lastUpdatedAt time.Time
 	isAggregating bool
@@ -27,7 +27,7 @@ func NewProofBuffer(maxLength uint64) *ProofBuffer {
 	return &ProofBuffer{
 		buffer:        make([]*producer.ProofResponse, 0, maxLength),
 		lastUpdatedAt: time.Now(),
-		MaxLength:     maxLength,
+		maxLength:     maxLength,
 	}
 }
 
@@ -36,7 +36,7 @@ func (pb *ProofBuffer) Write(item *producer.ProofResponse) (int, error) {
 	pb.mutex.Lock()
 	defer pb.mutex.Unlock()
 
-	if len(pb.buffer)+1 > int(pb.MaxLength) {
+	if len(pb.buffer)+1 > int(pb.maxLength) {
 		return len(pb.buffer), errBufferOverflow
 	}
 
@@ -80,7 +80,7 @@ func (pb *ProofBuffer) UpdateLastUpdatedAt() {
 	pb.lastUpdatedAt = time.Now()
 }
 
-// ClearItems clears items that has given block ids in the buffer.
+// ClearItems clears items that has given block ids (batch ids after Pacaya fork) in the buffer.
 func (pb *ProofBuffer) ClearItems(blockIDs ...uint64) int {
 	pb.mutex.Lock()
 	defer pb.mutex.Unlock()
@@ -94,7 +94,7 @@ func (pb *ProofBuffer) ClearItems(blockIDs ...uint64) int {
 	clearedCount := 0
 
 	for _, b := range pb.buffer {
-		if !clearMap[b.Meta.Ontake().GetBlockID().Uint64()] {
+		if !clearMap[b.BlockID.Uint64()] {
 			newBuffer = append(newBuffer, b)
 		} else {
 			clearedCount++
@@ -116,7 +116,21 @@ func (pb *ProofBuffer) IsAggregating() bool {
 	return pb.isAggregating
 }
 
+// MaxLength returns the max length of the buffer.
+func (pb *ProofBuffer) MaxLength() uint64 {
+	pb.mutex.RLock()
+	defer pb.mutex.RUnlock()
+	return pb.maxLength
+}
+
+// SetMaxLength updates the max length of the buffer, the items already in the buffer are kept.
+func (pb *ProofBuffer) SetMaxLength(maxLength uint64) {
+	pb.mutex.Lock()
+	defer pb.mutex.Unlock()
+	pb.maxLength = maxLength
+}
+
 // Enabled returns if the buffer is enabled.
 func (pb *ProofBuffer) Enabled() bool {
-	return pb.MaxLength > 1
+	return pb.MaxLength() > 1
 }
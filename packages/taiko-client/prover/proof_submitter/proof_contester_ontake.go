@@ -4,6 +4,7 @@ import (
 	"context"
 	"math/big"
 	"strings"
+	"sync"
 
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -26,6 +27,7 @@ type ProofContesterOntake struct {
 	txBuilder *transaction.ProveBlockTxBuilder
 	sender    *transaction.Sender
 	graffiti  [32]byte
+	mutex     sync.RWMutex
 }
 
 // NewProofContester creates a new ProofContester instance.
@@ -41,7 +43,7 @@ func NewProofContester(
 	return &ProofContesterOntake{
 		rpc:       rpcClient,
 		txBuilder: builder,
-		sender:    transaction.NewSender(rpcClient, txmgr, privateTxmgr, proverSetAddress, gasLimit),
+		sender:    transaction.NewSender(rpcClient, txmgr, privateTxmgr, proverSetAddress, gasLimit, nil),
 		graffiti:  rpc.StringToBytes32(graffiti),
 	}
 }
@@ -113,7 +115,7 @@ func (c *ProofContesterOntake) SubmitContest(
 				ParentHash: header.ParentHash,
 				BlockHash:  header.Hash(),
 				StateRoot:  header.Root,
-				Graffiti:   c.graffiti,
+				Graffiti:   c.currentGraffiti(),
 			},
 			&ontakeBindings.TaikoDataTierProof{
 				Tier: transition.Tier,
@@ -123,3 +125,17 @@ func (c *ProofContesterOntake) SubmitContest(
 		),
 	)
 }
+
+// SetGraffiti implements the Contester interface.
+func (c *ProofContesterOntake) SetGraffiti(graffiti string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.graffiti = rpc.StringToBytes32(graffiti)
+}
+
+// currentGraffiti returns the graffiti of the contests.
+func (c *ProofContesterOntake) currentGraffiti() [32]byte {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.graffiti
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -18,8 +19,10 @@ import (
 	ontakeBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/ontake"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/metrics"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/accounting"
 	validator "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/anchor_tx_validator"
 	handler "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/event_handler"
+	approval "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/guardian_approval"
 	proofProducer "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_producer"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_submitter/transaction"
 )
@@ -43,9 +46,14 @@ type ProofSubmitterOntake struct {
 	// Guardian prover related.
 	isGuardian      bool
 	submissionDelay time.Duration
+	approvals       *approval.Manager
 	// Batch proof related
 	proofBuffer               *ProofBuffer
 	forceBatchProvingInterval time.Duration
+	// Proving cost accounting related
+	accountant *accounting.Accountant
+	// Guards the configurations which can be reconfigured at runtime.
+	mutex sync.RWMutex
 }
 
 // NewProofSubmitterOntake creates a new ProofSubmitter instance.
@@ -67,12 +75,16 @@ func NewProofSubmitterOntake(
 	submissionDelay time.Duration,
 	proofBufferSize uint64,
 	forceBatchProvingInterval time.Duration,
+	accountant *accounting.Accountant,
+	approvals *approval.Manager,
 ) (*ProofSubmitterOntake, error) {
 	anchorValidator, err := validator.New(taikoL2Address, rpcClient.L2.ChainID, rpcClient)
 	if err != nil {
 		return nil, err
 	}
 
+	sender := transaction.NewSender(rpcClient, txmgr, privateTxmgr, proverSetAddress, gasLimit, accountant)
+
 	return &ProofSubmitterOntake{
 		rpc:                       rpcClient,
 		proofProducer:             proofProducer,
@@ -81,7 +93,7 @@ func NewProofSubmitterOntake(
 		aggregationNotify:         aggregationNotify,
 		anchorValidator:           anchorValidator,
 		txBuilder:                 builder,
-		sender:                    transaction.NewSender(rpcClient, txmgr, privateTxmgr, proverSetAddress, gasLimit),
+		sender:                    sender,
 		proverAddress:             txmgr.From(),
 		proverSetAddress:          proverSetAddress,
 		taikoL2Address:            taikoL2Address,
@@ -89,8 +101,10 @@ func NewProofSubmitterOntake(
 		tiers:                     tiers,
 		isGuardian:                isGuardian,
 		submissionDelay:           submissionDelay,
+		approvals:                 approvals,
 		proofBuffer:               NewProofBuffer(proofBufferSize),
 		forceBatchProvingInterval: forceBatchProvingInterval,
+		accountant:                accountant,
 	}, nil
 }
 
@@ -100,6 +114,9 @@ func (s *ProofSubmitterOntake) RequestProof(ctx context.Context, meta metadata.T
 		header *types.Header
 		parent *types.Header
 		err    error
+		// The proof request keeps using the same producer, even if the submitter is reconfigured.
+		producer = s.Producer()
+		graffiti = s.currentGraffiti()
 	)
 
 	if header, err = s.rpc.WaitL2Header(ctx, meta.Ontake().GetBlockID()); err != nil {
@@ -127,7 +144,7 @@ func (s *ProofSubmitterOntake) RequestProof(ctx context.Context, meta metadata.T
 		ParentHash:         header.ParentHash,
 		StateRoot:          header.Root,
 		EventL1Hash:        meta.GetRawBlockHash(),
-		Graffiti:           common.Bytes2Hex(s.graffiti[:]),
+		Graffiti:           common.Bytes2Hex(graffiti[:]),
 		GasUsed:            header.GasUsed,
 		ParentGasUsed:      parent.GasUsed,
 		Compressed:         s.proofBuffer.Enabled(),
@@ -140,14 +157,14 @@ func (s *ProofSubmitterOntake) RequestProof(ctx context.Context, meta metadata.T
 	startTime := time.Now()
 
 	// Send the generated proof.
-	if err := backoff.Retry(
+	err = backoff.Retry(
 		func() error {
 			if ctx.Err() != nil {
 				log.Error("Failed to request proof, context is canceled", "blockID", opts.BlockID, "error", ctx.Err())
 				return nil
 			}
 			// Check if the proof buffer is full.
-			if s.proofBuffer.Enabled() && uint64(s.proofBuffer.Len()) >= s.proofBuffer.MaxLength {
+			if s.proofBuffer.Enabled() && uint64(s.proofBuffer.Len()) >= s.proofBuffer.MaxLength() {
 				log.Warn(
 					"Proof buffer is full now",
 					"blockID", meta.Ontake().GetBlockID(),
@@ -171,7 +188,7 @@ func (s *ProofSubmitterOntake) RequestProof(ctx context.Context, meta metadata.T
 				return nil
 			}
 
-			result, err := s.proofProducer.RequestProof(
+			result, err := producer.RequestProof(
 				ctx,
 				opts,
 				meta.Ontake().GetBlockID(),
@@ -182,13 +199,21 @@ func (s *ProofSubmitterOntake) RequestProof(ctx context.Context, meta metadata.T
 				// If request proof has timed out in retry, let's cancel the proof generating and skip
 				if errors.Is(err, proofProducer.ErrProofInProgress) && time.Since(startTime) >= ProofTimeout {
 					log.Error("Request proof has timed out, start to cancel", "blockID", opts.BlockID)
-					if cancelErr := s.proofProducer.RequestCancel(ctx, opts); cancelErr != nil {
+					if cancelErr := producer.RequestCancel(ctx, opts); cancelErr != nil {
 						log.Error("Failed to request cancellation of proof", "err", cancelErr)
 					}
 					return nil
 				}
 				return fmt.Errorf("failed to request proof (id: %d): %w", meta.Ontake().GetBlockID(), err)
 			}
+			s.accountant.ProofGenerated(
+				meta.Ontake().GetBlockID(),
+				false,
+				result.Tier,
+				proofProducer.Name(producer),
+				time.Since(startTime),
+			)
+
 			if s.proofBuffer.Enabled() {
 				bufferSize, err := s.proofBuffer.Write(result)
 				if err != nil {
@@ -203,13 +228,13 @@ func (s *ProofSubmitterOntake) RequestProof(ctx context.Context, meta metadata.T
 					"Proof generated",
 					"blockID", meta.Ontake().GetBlockID(),
 					"bufferSize", bufferSize,
-					"maxBufferSize", s.proofBuffer.MaxLength,
+					"maxBufferSize", s.proofBuffer.MaxLength(),
 					"bufferIsAggregating", s.proofBuffer.IsAggregating(),
 					"bufferLastUpdatedAt", s.proofBuffer.lastUpdatedAt,
 				)
 				// Check if we need to aggregate proofs.
 				if !s.proofBuffer.IsAggregating() &&
-					(uint64(bufferSize) >= s.proofBuffer.MaxLength ||
+					(uint64(bufferSize) >= s.proofBuffer.MaxLength() ||
 						time.Since(s.proofBuffer.lastUpdatedAt) > s.forceBatchProvingInterval) {
 					s.aggregationNotify <- s.Tier()
 					s.proofBuffer.MarkAggregating()
@@ -221,7 +246,15 @@ func (s *ProofSubmitterOntake) RequestProof(ctx context.Context, meta metadata.T
 			return nil
 		},
 		backoff.WithContext(backoff.NewConstantBackOff(proofPollingInterval), ctx),
-	); err != nil {
+	)
+	if errors.Is(context.Cause(ctx), ErrProofCanceled) {
+		log.Warn("Proof request canceled, start to cancel the proof generation", "blockID", opts.BlockID)
+		if cancelErr := producer.RequestCancel(context.WithoutCancel(ctx), opts); cancelErr != nil {
+			log.Error("Failed to request cancellation of proof", "err", cancelErr)
+		}
+		return nil
+	}
+	if err != nil {
 		log.Error("Request proof error", "error", err)
 		return err
 	}
@@ -287,6 +320,17 @@ func (s *ProofSubmitterOntake) SubmitProof(
 		if proofStatus.IsSubmitted && !proofStatus.Invalid {
 			return nil
 		}
+
+		// Wait for the guardian proof to be approved, if the manual approval is enabled.
+		if s.approvals != nil {
+			approved, err := s.waitForApproval(ctx, proofResponse, proofStatus)
+			if err != nil {
+				return err
+			}
+			if !approved {
+				return nil
+			}
+		}
 	}
 
 	metrics.ProverReceivedProofCounter.Add(1)
@@ -322,7 +366,7 @@ func (s *ProofSubmitterOntake) SubmitProof(
 				ParentHash: proofResponse.Opts.OntakeOptions().ParentHash,
 				BlockHash:  proofResponse.Opts.OntakeOptions().BlockHash,
 				StateRoot:  proofResponse.Opts.OntakeOptions().StateRoot,
-				Graffiti:   s.graffiti,
+				Graffiti:   s.currentGraffiti(),
 			},
 			&ontakeBindings.TaikoDataTierProof{
 				Tier: proofResponse.Tier,
@@ -438,7 +482,7 @@ func (s *ProofSubmitterOntake) BatchSubmitProofs(ctx context.Context, batchProof
 	// Build the TaikoL1.proveBlocks transaction and send it to the L1 node.
 	if err := s.sender.SendBatchProof(
 		ctx,
-		s.txBuilder.BuildProveBlocks(batchProof, s.graffiti),
+		s.txBuilder.BuildProveBlocks(batchProof, s.currentGraffiti()),
 		batchProof,
 	); err != nil {
 		if err.Error() == transaction.ErrUnretryableSubmission.Error() {
@@ -471,7 +515,7 @@ func (s *ProofSubmitterOntake) AggregateProofs(ctx context.Context) error {
 				return nil
 			}
 
-			result, err := s.proofProducer.Aggregate(
+			result, err := s.Producer().Aggregate(
 				ctx,
 				buffer,
 				startTime,
@@ -526,22 +570,120 @@ func (s *ProofSubmitterOntake) getRandomBumpedSubmissionDelay(expiredAt time.Tim
 	return delay - time.Since(expiredAt), nil
 }
 
+// waitForApproval stages the given guardian proof for approval, with the competing transition on chain and
+// the result of verifying it against the local L2 node, and reports whether it is approved.
+func (s *ProofSubmitterOntake) waitForApproval(
+	ctx context.Context,
+	proofResponse *proofProducer.ProofResponse,
+	proofStatus *rpc.BlockProofStatus,
+) (bool, error) {
+	opts := proofResponse.Opts.OntakeOptions()
+	a := &approval.Approval{
+		BlockID:  proofResponse.BlockID,
+		Coinbase: proofResponse.Meta.Ontake().GetCoinbase(),
+		Transition: &approval.Transition{
+			ParentHash: opts.ParentHash,
+			BlockHash:  opts.BlockHash,
+			StateRoot:  opts.StateRoot,
+			Prover:     opts.ProverAddress,
+			Tier:       proofResponse.Tier,
+		},
+		Verification: s.verifyTransition(ctx, opts),
+	}
+	if ts := proofStatus.CurrentTransitionState; ts != nil {
+		a.Competing = append(a.Competing, &approval.Transition{
+			ParentHash: proofStatus.ParentHeader.Hash(),
+			BlockHash:  ts.BlockHash,
+			StateRoot:  ts.StateRoot,
+			Prover:     ts.Prover,
+			Contester:  ts.Contester,
+			Tier:       ts.Tier,
+			Timestamp:  ts.Timestamp,
+		})
+	}
+
+	status, err := s.approvals.Stage(ctx, a)
+	if err != nil {
+		return false, fmt.Errorf("failed to wait for guardian proof approval: %w", err)
+	}
+	if !status.Approved() {
+		log.Warn("Guardian proof not approved, skip submitting", "blockID", proofResponse.BlockID, "status", status)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// verifyTransition verifies the given transition against the local L2 node.
+func (s *ProofSubmitterOntake) verifyTransition(
+	ctx context.Context,
+	opts *proofProducer.ProofRequestOptionsOntake,
+) *approval.Verification {
+	v := new(approval.Verification)
+
+	header, err := s.rpc.L2.HeaderByNumber(ctx, opts.BlockID)
+	if err != nil {
+		v.Error = fmt.Sprintf("failed to get L2 block %d: %v", opts.BlockID, err)
+		return v
+	}
+	v.LocalBlockHash, v.LocalStateRoot = header.Hash(), header.Root
+	v.Canonical = header.Hash() == opts.BlockHash &&
+		header.Root == opts.StateRoot &&
+		header.ParentHash == opts.ParentHash
+
+	block, err := s.rpc.L2.BlockByHash(ctx, header.Hash())
+	if err != nil {
+		v.Error = fmt.Sprintf("failed to get L2 block %s: %v", header.Hash(), err)
+		return v
+	}
+	if block.Transactions().Len() == 0 {
+		v.Error = "no anchor transaction in block"
+		return v
+	}
+	if err := s.anchorValidator.ValidateAnchorTx(block.Transactions()[0]); err != nil {
+		v.Error = fmt.Sprintf("invalid anchor transaction: %v", err)
+		return v
+	}
+	v.AnchorValid = true
+
+	return v
+}
+
 // Producer returns the inner proof producer.
 func (s *ProofSubmitterOntake) Producer() proofProducer.ProofProducer {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	return s.proofProducer
 }
 
 // Tier returns the proof tier of the current proof submitter.
 func (s *ProofSubmitterOntake) Tier() uint16 {
-	return s.proofProducer.Tier()
+	return s.Producer().Tier()
 }
 
 // BufferSize returns the size of the proof buffer.
 func (s *ProofSubmitterOntake) BufferSize() uint64 {
-	return s.proofBuffer.MaxLength
+	return s.proofBuffer.MaxLength()
 }
 
 // AggregationEnabled returns whether the proof submitter's aggregation feature is enabled.
 func (s *ProofSubmitterOntake) AggregationEnabled() bool {
 	return s.proofBuffer.Enabled()
 }
+
+// Reconfigure implements the Submitter interface.
+func (s *ProofSubmitterOntake) Reconfigure(producer proofProducer.ProofProducer, graffiti string, bufferSize uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.proofProducer = producer
+	s.graffiti = rpc.StringToBytes32(graffiti)
+	s.proofBuffer.SetMaxLength(bufferSize)
+}
+
+// currentGraffiti returns the graffiti of the proofs submitted.
+func (s *ProofSubmitterOntake) currentGraffiti() [32]byte {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.graffiti
+}
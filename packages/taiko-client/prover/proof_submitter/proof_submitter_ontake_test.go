@@ -99,6 +99,8 @@ func (s *ProofSubmitterTestSuite) SetupTest() {
 		0*time.Second,
 		0,
 		30*time.Minute,
+		nil,
+		nil,
 	)
 	s.Nil(err)
 	s.submitterPacaya, err = NewProofSubmitterPacaya(
@@ -114,6 +116,9 @@ func (s *ProofSubmitterTestSuite) SetupTest() {
 		txMgr,
 		nil,
 		builder,
+		0,
+		30*time.Minute,
+		nil,
 	)
 	s.Nil(err)
 	s.contesterOntake = NewProofContester(
@@ -127,7 +132,7 @@ func (s *ProofSubmitterTestSuite) SetupTest() {
 	)
 
 	// Init calldata syncer
-	testState, err := state.New(context.Background(), s.RPCClient)
+	testState, err := state.New(context.Background(), s.RPCClient, nil)
 	s.Nil(err)
 	s.Nil(testState.ResetL1Current(context.Background(), common.Big0))
 
@@ -139,8 +144,10 @@ func (s *ProofSubmitterTestSuite) SetupTest() {
 		testState,
 		tracker,
 		0,
+		0,
 		s.BlobServer.URL(),
 		nil,
+		nil,
 	)
 	s.Nil(err)
 
@@ -215,6 +222,8 @@ func (s *ProofSubmitterTestSuite) TestGetRandomBumpedSubmissionDelay() {
 		time.Duration(0),
 		0,
 		30*time.Minute,
+		nil,
+		nil,
 	)
 	s.Nil(err)
 
@@ -240,6 +249,8 @@ func (s *ProofSubmitterTestSuite) TestGetRandomBumpedSubmissionDelay() {
 		1*time.Hour,
 		0,
 		30*time.Minute,
+		nil,
+		nil,
 	)
 	s.Nil(err)
 	delay, err = submitter2.getRandomBumpedSubmissionDelay(time.Now())
@@ -292,6 +303,34 @@ func (s *ProofSubmitterTestSuite) TestSubmitProofs() {
 	}
 }
 
+func (s *ProofSubmitterTestSuite) TestBatchSubmitProofsPacaya() {
+	var metas []metadata.TaikoProposalMetaData
+	for _, m := range s.ProposeAndInsertEmptyBlocks(s.proposer, s.blobSyncer) {
+		if m.IsPacaya() {
+			metas = append(metas, m)
+		}
+	}
+	s.NotEmpty(metas)
+
+	// Aggregate all the batch proofs in one go.
+	s.submitterPacaya.proofBuffer = NewProofBuffer(uint64(len(metas) + 1))
+	s.submitterPacaya.forceBatchProvingInterval = 0
+	s.True(s.submitterPacaya.AggregationEnabled())
+
+	for _, m := range metas {
+		s.Nil(s.submitterPacaya.RequestProof(context.Background(), m))
+	}
+	s.Equal(len(metas), s.submitterPacaya.proofBuffer.Len())
+	s.Equal(s.submitterPacaya.Tier(), <-s.aggregationNotify)
+
+	s.Nil(s.submitterPacaya.AggregateProofs(context.Background()))
+	batchProof := <-s.batchProofGenerationCh
+	s.Equal(len(metas), len(batchProof.ProofResponses))
+
+	s.Nil(s.submitterPacaya.BatchSubmitProofs(context.Background(), batchProof))
+	s.Zero(s.submitterPacaya.proofBuffer.Len())
+}
+
 func (s *ProofSubmitterTestSuite) TestGuardianSubmitProofs() {
 	for _, m := range s.ProposeAndInsertEmptyBlocks(s.proposer, s.blobSyncer) {
 		if m.IsPacaya() {
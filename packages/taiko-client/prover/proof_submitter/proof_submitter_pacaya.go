@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -17,6 +18,7 @@ import (
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/metrics"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/accounting"
 	validator "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/anchor_tx_validator"
 	proofProducer "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_producer"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_submitter/transaction"
@@ -36,6 +38,13 @@ type ProofSubmitterPacaya struct {
 	proverAddress      common.Address
 	proverSetAddress   common.Address
 	taikoAnchorAddress common.Address
+	// Batch proof related
+	proofBuffer               *ProofBuffer
+	forceBatchProvingInterval time.Duration
+	// Proving cost accounting related
+	accountant *accounting.Accountant
+	// Guards the configurations which can be reconfigured at runtime.
+	mutex sync.RWMutex
 }
 
 // NewProofSubmitter creates a new ProofSubmitter instance.
@@ -52,30 +61,42 @@ func NewProofSubmitterPacaya(
 	txmgr txmgr.TxManager,
 	privateTxmgr txmgr.TxManager,
 	builder *transaction.ProveBlockTxBuilder,
+	proofBufferSize uint64,
+	forceBatchProvingInterval time.Duration,
+	accountant *accounting.Accountant,
 ) (*ProofSubmitterPacaya, error) {
 	anchorValidator, err := validator.New(taikoAnchorAddress, rpcClient.L2.ChainID, rpcClient)
 	if err != nil {
 		return nil, err
 	}
 
+	sender := transaction.NewSender(rpcClient, txmgr, privateTxmgr, proverSetAddress, gasLimit, accountant)
+
 	return &ProofSubmitterPacaya{
-		rpc:                rpcClient,
-		proofProducer:      proofProducer,
-		resultCh:           resultCh,
-		batchResultCh:      batchResultCh,
-		aggregationNotify:  aggregationNotify,
-		anchorValidator:    anchorValidator,
-		txBuilder:          builder,
-		sender:             transaction.NewSender(rpcClient, txmgr, privateTxmgr, proverSetAddress, gasLimit),
-		proverAddress:      txmgr.From(),
-		proverSetAddress:   proverSetAddress,
-		taikoAnchorAddress: taikoAnchorAddress,
+		rpc:                       rpcClient,
+		proofProducer:             proofProducer,
+		resultCh:                  resultCh,
+		batchResultCh:             batchResultCh,
+		aggregationNotify:         aggregationNotify,
+		anchorValidator:           anchorValidator,
+		txBuilder:                 builder,
+		sender:                    sender,
+		proverAddress:             txmgr.From(),
+		proverSetAddress:          proverSetAddress,
+		taikoAnchorAddress:        taikoAnchorAddress,
+		proofBuffer:               NewProofBuffer(proofBufferSize),
+		forceBatchProvingInterval: forceBatchProvingInterval,
+		accountant:                accountant,
 	}, nil
 }
 
 // RequestProof requests proof for the given Taiko batch after Pacaya fork.
 func (s *ProofSubmitterPacaya) RequestProof(ctx context.Context, meta metadata.TaikoProposalMetaData) error {
-	var headers []*types.Header
+	var (
+		headers []*types.Header
+		// The proof request keeps using the same producer, even if the submitter is reconfigured.
+		producer = s.Producer()
+	)
 	for i := 0; i < len(meta.Pacaya().GetBlocks()); i++ {
 		header, err := s.rpc.WaitL2Header(
 			ctx,
@@ -107,6 +128,7 @@ func (s *ProofSubmitterPacaya) RequestProof(ctx context.Context, meta metadata.T
 		ProverAddress:      s.proverAddress,
 		ProposeBlockTxHash: meta.GetTxHash(),
 		EventL1Hash:        meta.GetRawBlockHash(),
+		EventL1Height:      meta.GetRawBlockHeight(),
 		Headers:            headers,
 	}
 
@@ -117,12 +139,21 @@ func (s *ProofSubmitterPacaya) RequestProof(ctx context.Context, meta metadata.T
 	startTime := time.Now()
 
 	// Send the generated proof.
-	if err := backoff.Retry(
+	err := backoff.Retry(
 		func() error {
 			if ctx.Err() != nil {
 				log.Error("Failed to request proof, context is canceled", "batchID", opts.BatchID, "error", ctx.Err())
 				return nil
 			}
+			// Check if the proof buffer is full.
+			if s.proofBuffer.Enabled() && uint64(s.proofBuffer.Len()) >= s.proofBuffer.MaxLength() {
+				log.Warn(
+					"Proof buffer is full now",
+					"batchID", meta.Pacaya().GetBatchID(),
+					"size", s.proofBuffer.Len(),
+				)
+				return errBufferOverflow
+			}
 			// Check if there is a need to generate proof
 			proofStatus, err := rpc.GetBatchProofStatus(
 				ctx,
@@ -136,7 +167,7 @@ func (s *ProofSubmitterPacaya) RequestProof(ctx context.Context, meta metadata.T
 				return nil
 			}
 
-			result, err := s.proofProducer.RequestProof(
+			result, err := producer.RequestProof(
 				ctx,
 				opts,
 				meta.Pacaya().GetBatchID(),
@@ -150,7 +181,7 @@ func (s *ProofSubmitterPacaya) RequestProof(ctx context.Context, meta metadata.T
 						"Request proof has timed out, start to cancel",
 						"batchID", meta.Pacaya().GetBatchID(),
 					)
-					if cancelErr := s.proofProducer.RequestCancel(ctx, opts); cancelErr != nil {
+					if cancelErr := producer.RequestCancel(ctx, opts); cancelErr != nil {
 						log.Error("Failed to request cancellation of proof", "err", cancelErr)
 					}
 					return nil
@@ -158,12 +189,55 @@ func (s *ProofSubmitterPacaya) RequestProof(ctx context.Context, meta metadata.T
 				return fmt.Errorf("failed to request proof (id: %d): %w", meta.Pacaya().GetBatchID(), err)
 			}
 
-			s.resultCh <- result
+			s.accountant.ProofGenerated(
+				meta.Pacaya().GetBatchID(),
+				true,
+				result.Tier,
+				proofProducer.Name(producer),
+				time.Since(startTime),
+			)
+
+			if s.proofBuffer.Enabled() {
+				bufferSize, err := s.proofBuffer.Write(result)
+				if err != nil {
+					return fmt.Errorf(
+						"failed to add proof into buffer (id: %d) (current buffer size: %d): %w",
+						meta.Pacaya().GetBatchID(),
+						bufferSize,
+						err,
+					)
+				}
+				log.Info(
+					"Proof generated",
+					"batchID", meta.Pacaya().GetBatchID(),
+					"bufferSize", bufferSize,
+					"maxBufferSize", s.proofBuffer.MaxLength(),
+					"bufferIsAggregating", s.proofBuffer.IsAggregating(),
+					"bufferLastUpdatedAt", s.proofBuffer.lastUpdatedAt,
+				)
+				// Check if we need to aggregate proofs.
+				if !s.proofBuffer.IsAggregating() &&
+					(uint64(bufferSize) >= s.proofBuffer.MaxLength() ||
+						time.Since(s.proofBuffer.lastUpdatedAt) > s.forceBatchProvingInterval) {
+					s.aggregationNotify <- s.Tier()
+					s.proofBuffer.MarkAggregating()
+				}
+			} else {
+				s.resultCh <- result
+			}
 			metrics.ProverQueuedProofCounter.Add(1)
 			return nil
 		},
 		backoff.WithContext(backoff.NewConstantBackOff(proofPollingInterval), ctx),
-	); err != nil {
+	)
+	if errors.Is(context.Cause(ctx), ErrProofCanceled) {
+		log.Warn("Proof request canceled, start to cancel the proof generation", "batchID", opts.BatchID)
+		if cancelErr := producer.RequestCancel(context.WithoutCancel(ctx), opts); cancelErr != nil {
+			log.Error("Failed to request cancellation of proof", "err", cancelErr)
+		}
+		return nil
+	}
+	if err != nil {
 		log.Error("Request proof error", "batchID", meta.Pacaya().GetBatchID(), "error", err)
 		return err
 	}
@@ -206,6 +280,7 @@ func (s *ProofSubmitterPacaya) SubmitProof(
 			&proofProducer.BatchProofs{
 				ProofResponses: []*proofProducer.ProofResponse{proofResponse},
 				BatchProof:     proofResponse.Proof,
+				Composed:       proofResponse.Composed,
 			},
 		),
 	); err != nil {
@@ -224,32 +299,158 @@ func (s *ProofSubmitterPacaya) SubmitProof(
 
 // BatchSubmitProofs implements the Submitter interface to submit proof aggregation.
 func (s *ProofSubmitterPacaya) BatchSubmitProofs(ctx context.Context, batchProof *proofProducer.BatchProofs) error {
-	return fmt.Errorf("batch proofs submission has not been implemented for Pacaya")
+	if len(batchProof.ProofResponses) == 0 {
+		return proofProducer.ErrInvalidLength
+	}
+	log.Info(
+		"Batch submit batches proofs",
+		"proof", common.Bytes2Hex(batchProof.BatchProof),
+		"size", len(batchProof.ProofResponses),
+		"firstID", batchProof.BlockIDs[0],
+		"lastID", batchProof.BlockIDs[len(batchProof.BlockIDs)-1],
+	)
+	var (
+		invalidBatchIDs     []uint64
+		latestProvenBatchID = common.Big0
+		batchIDs            []uint64
+	)
+	for _, proof := range batchProof.ProofResponses {
+		batchID := proof.Meta.Pacaya().GetBatchID()
+		batchIDs = append(batchIDs, batchID.Uint64())
+
+		// Check if this proof is still needed to be submitted.
+		proofStatus, err := rpc.GetBatchProofStatus(ctx, s.rpc, batchID)
+		if err != nil {
+			return err
+		}
+		if proofStatus.IsSubmitted && !proofStatus.Invalid {
+			log.Error("A valid proof for batch is already submitted", "batchID", batchID)
+			invalidBatchIDs = append(invalidBatchIDs, batchID.Uint64())
+			continue
+		}
+
+		// The proven batch must still be on the canonical L2 chain.
+		headers := proof.Opts.PacayaOptions().Headers
+		if proofStatus.ParentHeader.Hash() != headers[0].ParentHash {
+			log.Error(
+				"Parent hash of the proven batch mismatches",
+				"batchID", batchID,
+				"parentHash", headers[0].ParentHash,
+				"canonicalParentHash", proofStatus.ParentHeader.Hash(),
+			)
+			invalidBatchIDs = append(invalidBatchIDs, batchID.Uint64())
+			continue
+		}
+
+		if batchID.Cmp(latestProvenBatchID) > 0 {
+			latestProvenBatchID = batchID
+		}
+	}
+
+	if len(invalidBatchIDs) > 0 {
+		log.Warn("Invalid proofs in batch", "batchIDs", invalidBatchIDs)
+		s.proofBuffer.ClearItems(invalidBatchIDs...)
+		return ErrInvalidProof
+	}
+
+	// Build the TaikoInbox.proveBatches transaction and send it to the L1 node.
+	if err := s.sender.SendBatchProof(
+		ctx,
+		s.txBuilder.BuildProveBatchesPacaya(batchProof),
+		batchProof,
+	); err != nil {
+		if err.Error() == transaction.ErrUnretryableSubmission.Error() {
+			// The reverted proofs will not be aggregated again.
+			s.proofBuffer.ClearItems(batchIDs...)
+			return nil
+		}
+		metrics.ProverAggregationSubmissionErrorCounter.Add(1)
+		return encoding.TryParsingCustomError(err)
+	}
+
+	metrics.ProverSentProofCounter.Add(float64(len(batchProof.BlockIDs)))
+	metrics.ProverLatestProvenBlockIDGauge.Set(float64(latestProvenBatchID.Uint64()))
+	s.proofBuffer.ClearItems(batchIDs...)
+	// Each time we submit a batch proof, we should update the LastUpdatedAt() of the buffer.
+	s.proofBuffer.UpdateLastUpdatedAt()
+
+	return nil
 }
 
 // AggregateProofs read all data from buffer and aggregate them.
 func (s *ProofSubmitterPacaya) AggregateProofs(ctx context.Context) error {
-	return fmt.Errorf("proof aggregation has not been implemented for Pacaya")
+	startTime := time.Now()
+	if err := backoff.Retry(
+		func() error {
+			buffer, err := s.proofBuffer.ReadAll()
+			if err != nil {
+				return fmt.Errorf("failed to read proof from buffer: %w", err)
+			}
+			if len(buffer) == 0 {
+				log.Debug("Buffer is empty now, skip aggregating")
+				return nil
+			}
+
+			result, err := s.Producer().Aggregate(
+				ctx,
+				buffer,
+				startTime,
+			)
+			if err != nil {
+				if errors.Is(err, proofProducer.ErrProofInProgress) ||
+					errors.Is(err, proofProducer.ErrRetry) {
+					log.Info(
+						"Aggregating proofs",
+						"status", err,
+						"batchSize", len(buffer),
+						"firstID", buffer[0].BlockID,
+						"lastID", buffer[len(buffer)-1].BlockID,
+					)
+				} else {
+					log.Error("Failed to request proof aggregation", "err", err)
+				}
+				return err
+			}
+			s.batchResultCh <- result
+			return nil
+		},
+		backoff.WithContext(backoff.NewConstantBackOff(proofPollingInterval), ctx),
+	); err != nil {
+		log.Error("Aggregate proof error", "error", err)
+		return err
+	}
+	return nil
 }
 
 // Producer implements the Submitter interface.
 func (s *ProofSubmitterPacaya) Producer() proofProducer.ProofProducer {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	return s.proofProducer
 }
 
-// Tier implements the Submitter interface.
+// Tier implements the Submitter interface, there is no proof tier after Pacaya fork, so it always
+// returns 0, which is never a valid Ontake tier ID.
 func (s *ProofSubmitterPacaya) Tier() uint16 {
-	log.Warn("Tier is not implemented for Pacaya submitter")
 	return 0
 }
 
 // BufferSize implements the Submitter interface.
 func (s *ProofSubmitterPacaya) BufferSize() uint64 {
-	log.Warn("BufferSize is not implemented for Pacaya submitter")
-	return 0
+	return s.proofBuffer.MaxLength()
 }
 
 // AggregationEnabled implements the Submitter interface.
 func (s *ProofSubmitterPacaya) AggregationEnabled() bool {
-	return false
+	return s.proofBuffer.Enabled()
+}
+
+// Reconfigure implements the Submitter interface, the graffiti is ignored, since there is no graffiti
+// in the proofs after Pacaya fork.
+func (s *ProofSubmitterPacaya) Reconfigure(producer proofProducer.ProofProducer, _ string, bufferSize uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.proofProducer = producer
+	s.proofBuffer.SetMaxLength(bufferSize)
 }
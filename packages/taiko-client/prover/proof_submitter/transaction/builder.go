@@ -213,26 +213,20 @@ func (a *ProveBlockTxBuilder) BuildProveBatchesPacaya(batchProof *proofProducer.
 			err         error
 			metas       = make([]metadata.TaikoProposalMetaData, len(batchProof.ProofResponses))
 			transitions = make([]pacayaBindings.ITaikoInboxTransition, len(batchProof.ProofResponses))
-			subProofs   = make([]encoding.SubProof, len(batchProof.ProofResponses))
 			batchIDs    = make([]uint64, len(batchProof.ProofResponses))
 		)
-		// NOTE: op_verifier is the only verifier address for now.
-		opVerifier, err := a.rpc.ResolvePacaya(&bind.CallOpts{Context: txOpts.Context}, "op_verifier", false)
-		if err != nil {
-			return nil, err
-		}
 		for i, proof := range batchProof.ProofResponses {
+			var (
+				headers    = proof.Opts.PacayaOptions().Headers
+				lastHeader = headers[len(headers)-1]
+			)
 			metas[i] = proof.Meta
 			transitions[i] = pacayaBindings.ITaikoInboxTransition{
-				ParentHash: proof.Opts.PacayaOptions().Headers[i].ParentHash,
-				BlockHash:  proof.Opts.PacayaOptions().Headers[i].Hash(),
-				StateRoot:  proof.Opts.PacayaOptions().Headers[i].Root,
+				ParentHash: headers[0].ParentHash,
+				BlockHash:  lastHeader.Hash(),
+				StateRoot:  lastHeader.Root,
 			}
 			batchIDs[i] = proof.Meta.Pacaya().GetBatchID().Uint64()
-			subProofs[i] = encoding.SubProof{
-				Verifier: opVerifier,
-				Proof:    batchProof.BatchProof,
-			}
 		}
 		log.Info(
 			"Build batch proof submission transaction",
@@ -243,9 +237,19 @@ func (a *ProveBlockTxBuilder) BuildProveBatchesPacaya(batchProof *proofProducer.
 		if err != nil {
 			return nil, err
 		}
-		encodedSubProofs, err := encoding.EncodeBatchesSubProofs(subProofs)
-		if err != nil {
-			return nil, err
+		// A composed proof is already encoded as the sub-proofs of the compose verifier, otherwise the
+		// op_verifier checks a single (aggregated) proof for all the batches in the transaction.
+		encodedSubProofs := batchProof.BatchProof
+		if !batchProof.Composed {
+			opVerifier, err := a.rpc.ResolvePacaya(&bind.CallOpts{Context: txOpts.Context}, "op_verifier", false)
+			if err != nil {
+				return nil, err
+			}
+			if encodedSubProofs, err = encoding.EncodeBatchesSubProofs(
+				[]encoding.SubProof{{Verifier: opVerifier, Proof: batchProof.BatchProof}},
+			); err != nil {
+				return nil, err
+			}
 		}
 
 		if a.proverSetAddress != ZeroAddress {
@@ -16,6 +16,7 @@ import (
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/metrics"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/utils"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/accounting"
 	producer "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_producer"
 )
 
@@ -25,6 +26,7 @@ type Sender struct {
 	txmgrSelector    *utils.TxMgrSelector
 	proverSetAddress common.Address
 	gasLimit         uint64
+	accountant       *accounting.Accountant
 }
 
 // NewSender creates a new Sener instance.
@@ -34,12 +36,14 @@ func NewSender(
 	privateTxmgr txmgr.TxManager,
 	proverSetAddress common.Address,
 	gasLimit uint64,
+	accountant *accounting.Accountant,
 ) *Sender {
 	return &Sender{
 		rpc:              cli,
 		txmgrSelector:    utils.NewTxMgrSelector(txmgr, privateTxmgr, nil),
 		proverSetAddress: proverSetAddress,
 		gasLimit:         gasLimit,
+		accountant:       accountant,
 	}
 }
 
@@ -98,6 +102,13 @@ func (s *Sender) Send(
 		return encoding.TryParsingCustomError(err)
 	}
 
+	// The fees of a reverted transaction are also paid, so they are recorded before checking its status.
+	id := proofResponse.BlockID
+	if proofResponse.Meta.IsPacaya() {
+		id = proofResponse.Meta.Pacaya().GetBatchID()
+	}
+	s.accountant.ProofsSubmitted([]*big.Int{id}, proofResponse.Meta.IsPacaya(), proofResponse.Tier, receipt)
+
 	if receipt.Status != types.ReceiptStatusSuccessful {
 		log.Error(
 			"Failed to submit proof",
@@ -154,6 +165,14 @@ func (s *Sender) SendBatchProof(
 		return encoding.TryParsingCustomError(err)
 	}
 
+	// The fees of a reverted transaction are also paid, so they are recorded before checking its status.
+	s.accountant.ProofsSubmitted(
+		batchProof.BlockIDs,
+		batchProof.ProofResponses[0].Meta.IsPacaya(),
+		batchProof.Tier,
+		receipt,
+	)
+
 	if receipt.Status != types.ReceiptStatusSuccessful {
 		log.Error(
 			"Failed to submit batch proofs",
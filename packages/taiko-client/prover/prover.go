@@ -2,8 +2,10 @@ package prover
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +19,7 @@ import (
 	"github.com/urfave/cli/v2"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/taikoxyz/taiko-mono/packages/signer"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
 	ontakeBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/ontake"
@@ -26,14 +29,21 @@ import (
 	eventIterator "github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/chain_iterator/event_iterator"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/config"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/pkg/rpc"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/accounting"
 	handler "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/event_handler"
+	approval "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/guardian_approval"
 	guardianProverHeartbeater "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/guardian_prover_heartbeater"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/policy"
 	proofProducer "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_producer"
 	proofSubmitter "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_submitter"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_submitter/transaction"
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/scheduler"
 	state "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/shared_state"
 )
 
+// serverShutdownTimeout is the time given to the HTTP servers of the prover to finish the in-flight requests.
+var serverShutdownTimeout = 5 * time.Second
+
 // eventHandlers contains all event handlers which will be used by the prover.
 type eventHandlers struct {
 	blockProposedHandler       handler.BlockProposedHandler
@@ -83,6 +93,20 @@ type Prover struct {
 	txmgr        txmgr.TxManager
 	privateTxmgr txmgr.TxManager
 
+	// Guardian proof approval
+	approvals      *approval.Manager
+	approvalServer *approval.Server
+
+	// Proving policy
+	policyEngine *policy.Engine
+
+	// Proof request scheduler
+	scheduler *scheduler.Scheduler
+
+	// Proving cost accounting
+	accountant       *accounting.Accountant
+	accountingServer *accounting.Server
+
 	ctx context.Context
 	wg  sync.WaitGroup
 }
@@ -106,6 +130,9 @@ func InitFromConfig(
 ) (err error) {
 	p.cfg = cfg
 	p.ctx = ctx
+	if cfg.L1ProverSigner == nil && cfg.L1ProverPrivKey != nil {
+		cfg.L1ProverSigner = signer.NewLocalSigner(cfg.L1ProverPrivKey)
+	}
 	// Initialize state which will be shared by event handlers.
 	p.sharedState = state.New()
 	p.backoff = backoff.WithContext(
@@ -167,11 +194,12 @@ func InitFromConfig(
 	if txMgr != nil {
 		p.txmgr = txMgr
 	} else {
-		if p.txmgr, err = txmgr.NewSimpleTxManager(
+		if p.txmgr, err = signer.NewTxManager(
 			"prover",
 			log.Root(),
 			&metrics.TxMgrMetrics,
 			*cfg.TxmgrConfigs,
+			cfg.L1ProverSigner,
 		); err != nil {
 			return err
 		}
@@ -181,11 +209,12 @@ func InitFromConfig(
 		p.privateTxmgr = privateTxMgr
 	} else {
 		if cfg.PrivateTxmgrConfigs != nil && len(cfg.PrivateTxmgrConfigs.L1RPCURL) > 0 {
-			if p.privateTxmgr, err = txmgr.NewSimpleTxManager(
+			if p.privateTxmgr, err = signer.NewTxManager(
 				"privateMempoolProver",
 				log.Root(),
 				&metrics.TxMgrMetrics,
 				*cfg.PrivateTxmgrConfigs,
+				cfg.L1ProverSigner,
 			); err != nil {
 				return err
 			}
@@ -194,6 +223,25 @@ func InitFromConfig(
 		}
 	}
 
+	// Proving cost accounting
+	if cfg.AccountingDataDir != "" {
+		store, err := accounting.OpenStore(cfg.AccountingDataDir)
+		if err != nil {
+			return err
+		}
+
+		p.accountant = accounting.New(p.rpc, store, p.sharedState.GetTiers(), p.txmgr.From(), cfg.ProverSetAddress)
+		if p.accountingServer, err = accounting.NewServer(store, cfg.AccountingJWTSecret); err != nil {
+			return err
+		}
+	}
+
+	// Guardian proof approval
+	if p.IsGuardianProver() && cfg.GuardianApprovalPort != 0 {
+		p.approvals = approval.NewManager(cfg.GuardianApprovalTimeout, cfg.GuardianApprovalAutoApprove)
+		p.approvalServer = approval.NewServer(p.approvals, cfg.GuardianApprovalJWTSecret)
+	}
+
 	// Proof submitters
 	if err := p.initProofSubmitters(txBuilder, p.sharedState.GetTiers()); err != nil {
 		return err
@@ -224,13 +272,23 @@ func InitFromConfig(
 		}
 
 		p.guardianProverHeartbeater = guardianProverHeartbeater.New(
-			p.cfg.L1ProverPrivKey,
+			p.cfg.L1ProverSigner,
 			p.cfg.GuardianProverHealthCheckServerEndpoint,
 			p.rpc,
 			p.ProverAddress(),
 		)
 	}
 
+	// Proof request scheduler
+	p.scheduler = scheduler.New(cfg.SchedulerMaxConcurrency, cfg.SchedulerProducerConcurrency)
+
+	// Proving policy
+	if cfg.ProvingPolicyFile != "" {
+		if p.policyEngine, err = policy.NewEngine(cfg.ProvingPolicyFile, cfg.ProvingPolicyReloadInterval); err != nil {
+			return fmt.Errorf("failed to load proving policy: %w", err)
+		}
+	}
+
 	// Initialize event handlers.
 	if err := p.initEventHandlers(); err != nil {
 		return err
@@ -265,7 +323,37 @@ func (p *Prover) Start() error {
 		go p.guardianProverHeartbeatLoop(p.ctx)
 	}
 
-	// 4. Start the main event loop of the prover.
+	// 4. Start the proving cost report server if the accounting is enabled.
+	if p.accountingServer != nil {
+		go func() {
+			if err := p.accountingServer.Start(p.cfg.HTTPServerPort); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Crit("Failed to start proving cost report server", "error", err)
+			}
+		}()
+	}
+
+	// 5. Start the guardian proof approval server if the manual approval is enabled.
+	if p.approvalServer != nil {
+		go func() {
+			if err := p.approvalServer.Start(p.cfg.GuardianApprovalPort); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Crit("Failed to start guardian proof approval server", "error", err)
+			}
+		}()
+	}
+
+	// 6. Start reloading the proving policy if it is set.
+	if p.policyEngine != nil {
+		go p.policyEngine.Watch(p.ctx)
+	}
+
+	// 7. Start the proof request scheduler.
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.scheduler.Loop(p.ctx)
+	}()
+
+	// 8. Start the main event loop of the prover.
 	go p.eventLoop()
 
 	return nil
@@ -332,7 +420,7 @@ func (p *Prover) eventLoop() {
 		case batchProof := <-p.batchProofGenerationCh:
 			p.withRetry(func() error { return p.submitProofAggregationOp(batchProof) })
 		case req := <-p.proofSubmissionCh:
-			p.withRetry(func() error { return p.requestProofOp(req.Meta, req.Tier) })
+			p.withRetry(func() error { return p.scheduleProofOp(req.Meta, req.Tier) })
 		case <-p.proveNotify:
 			if err := p.proveOp(); err != nil {
 				log.Error("Prove new blocks error", "error", err)
@@ -340,11 +428,17 @@ func (p *Prover) eventLoop() {
 		case tier := <-p.aggregationNotify:
 			p.withRetry(func() error { return p.aggregateOp(tier) })
 		case e := <-blockVerifiedV2Ch:
-			p.eventHandlers.blockVerifiedHandler.Handle(e)
+			p.eventHandlers.blockVerifiedHandler.Handle(p.ctx, e)
+		case e := <-batchesVerifiedCh:
+			p.eventHandlers.blockVerifiedHandler.HandlePacaya(p.ctx, e)
 		case e := <-transitionProvedV2Ch:
 			p.withRetry(func() error {
 				return p.eventHandlers.transitionProvedHandler.Handle(p.ctx, e)
 			})
+		case e := <-batchesProvedCh:
+			p.withRetry(func() error {
+				return p.eventHandlers.transitionProvedHandler.HandlePacaya(p.ctx, e)
+			})
 		case e := <-transitionContestedV2Ch:
 			p.withRetry(func() error {
 				return p.eventHandlers.transitionContestedHandler.Handle(p.ctx, e)
@@ -363,7 +457,28 @@ func (p *Prover) eventLoop() {
 
 // Close closes the prover instance.
 func (p *Prover) Close(_ context.Context) {
+	// The prover context is already canceled here, so the servers are shut down with a fresh one.
+	ctx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+	defer cancel()
+
+	// Close the proving cost report server and store if they are enabled.
+	if p.accountingServer != nil {
+		if err := p.accountingServer.Shutdown(ctx); err != nil {
+			log.Error("Failed to shutdown proving cost report server", "error", err)
+		}
+	}
+	// Close the guardian proof approval server if it is enabled.
+	if p.approvalServer != nil {
+		if err := p.approvalServer.Shutdown(ctx); err != nil {
+			log.Error("Failed to shutdown guardian proof approval server", "error", err)
+		}
+	}
 	p.wg.Wait()
+	if p.accountant != nil {
+		if err := p.accountant.Store().Close(); err != nil {
+			log.Error("Failed to close proving cost accounting store", "error", err)
+		}
+	}
 }
 
 // proveOp iterates through BlockProposed events.
@@ -375,6 +490,7 @@ func (p *Prover) proveOp() error {
 		StartHeight:          new(big.Int).SetUint64(p.sharedState.GetL1Current().Number.Uint64()),
 		OnBlockProposedEvent: p.eventHandlers.blockProposedHandler.Handle,
 		BlockConfirmations:   &p.cfg.BlockConfirmations,
+		L1Finality:           p.cfg.L1Finality,
 	})
 	if err != nil {
 		log.Error("Failed to start event iterator", "event", "BlockProposed", "error", err)
@@ -386,6 +502,15 @@ func (p *Prover) proveOp() error {
 
 // aggregateOp aggregates all proofs in buffer.
 func (p *Prover) aggregateOp(tier uint16) error {
+	if tier == p.proofSubmitterPacaya.Tier() {
+		if err := p.proofSubmitterPacaya.AggregateProofs(p.ctx); err != nil {
+			log.Error("Failed to aggregate batch proofs", "error", err)
+			return err
+		}
+
+		return nil
+	}
+
 	g, gCtx := errgroup.WithContext(p.ctx)
 	for _, submitter := range p.proofSubmittersOntake {
 		g.Go(func() error {
@@ -444,49 +569,109 @@ func (p *Prover) contestProofOp(req *proofProducer.ContestRequestBody) error {
 	return nil
 }
 
-// requestProofOp requests a new proof generation operation.
-func (p *Prover) requestProofOp(meta metadata.TaikoProposalMetaData, minTier uint16) error {
+// scheduleProofOp schedules a new proof generation operation, by the proving window deadline and the
+// expected value of the given proposal.
+func (p *Prover) scheduleProofOp(meta metadata.TaikoProposalMetaData, minTier uint16) error {
+	submitter := p.submitterOf(meta, minTier)
+	if submitter == nil {
+		log.Error(
+			"Failed to find proof submitter",
+			"blockID", meta.Ontake().GetBlockID(),
+			"minTier", minTier,
+		)
+		return nil
+	}
+
+	windowExpired, deadline, _, err := handler.IsProvingWindowExpired(p.rpc, meta, p.sharedState.GetTiers())
+	if err != nil {
+		return fmt.Errorf("failed to check if the proving window is expired: %w", err)
+	}
+	ownProposal := meta.GetProposer() == p.ProverAddress() || meta.GetProposer() == p.cfg.ProverSetAddress
+	value, lateValue, err := handler.ExpectedProvingRewards(p.ctx, p.rpc, meta, ownProposal)
+	if err != nil {
+		return err
+	}
+	// A proposal whose proving window is already expired has nothing to lose.
+	if windowExpired {
+		value = lateValue
+	}
+
+	job := &scheduler.Job{
+		Pacaya:    meta.IsPacaya(),
+		Tier:      minTier,
+		Producer:  proofProducer.Name(submitter.Producer()),
+		Deadline:  deadline,
+		Value:     value,
+		LateValue: lateValue,
+		Run: func(ctx context.Context) error {
+			return backoff.Retry(
+				func() error { return p.requestProofOp(ctx, meta, minTier) },
+				backoff.WithContext(p.backoff, ctx),
+			)
+		},
+	}
 	if meta.IsPacaya() {
-		if err := p.proofSubmitterPacaya.RequestProof(p.ctx, meta); err != nil {
+		job.ID = meta.Pacaya().GetBatchID()
+	} else {
+		job.ID = meta.Ontake().GetBlockID()
+	}
+	p.scheduler.Submit(job)
+
+	return nil
+}
+
+// requestProofOp requests a new proof generation operation.
+func (p *Prover) requestProofOp(ctx context.Context, meta metadata.TaikoProposalMetaData, minTier uint16) error {
+	p.sharedState.AddProvingQueueDepth(1)
+	defer p.sharedState.AddProvingQueueDepth(-1)
+
+	submitter := p.submitterOf(meta, minTier)
+	if submitter == nil {
+		log.Error(
+			"Failed to find proof submitter",
+			"blockID", meta.Ontake().GetBlockID(),
+			"minTier", minTier,
+		)
+		return nil
+	}
+
+	if err := submitter.RequestProof(ctx, meta); err != nil {
+		if meta.IsPacaya() {
 			log.Error(
 				"Request new batch proof error",
 				"batchID", meta.Pacaya().GetBatchID(),
 				"error", err,
 			)
-			return err
-		}
-
-		return nil
-	}
-	if p.IsGuardianProver() {
-		if minTier > encoding.TierGuardianMinorityID {
-			minTier = encoding.TierGuardianMajorityID
 		} else {
-			minTier = encoding.TierGuardianMinorityID
-		}
-	}
-	if submitter := p.selectSubmitter(minTier); submitter != nil {
-		if err := submitter.RequestProof(p.ctx, meta); err != nil {
 			log.Error(
 				"Request new proof error",
 				"blockID", meta.Ontake().GetBlockID(),
 				"minTier", meta.Ontake().GetMinTier(),
 				"error", err,
 			)
-			return err
 		}
-
-		return nil
+		return err
 	}
 
-	log.Error(
-		"Failed to find proof submitter",
-		"blockID", meta.Ontake().GetBlockID(),
-		"minTier", minTier,
-	)
 	return nil
 }
 
+// submitterOf returns the proof submitter for the given proposal, or nil if there is none.
+func (p *Prover) submitterOf(meta metadata.TaikoProposalMetaData, minTier uint16) proofSubmitter.Submitter {
+	if meta.IsPacaya() {
+		return p.proofSubmitterPacaya
+	}
+	if p.IsGuardianProver() {
+		if minTier > encoding.TierGuardianMinorityID {
+			minTier = encoding.TierGuardianMajorityID
+		} else {
+			minTier = encoding.TierGuardianMinorityID
+		}
+	}
+
+	return p.selectSubmitter(minTier)
+}
+
 // submitProofOp performs a proof submission operation.
 func (p *Prover) submitProofOp(proofResponse *proofProducer.ProofResponse) error {
 	var submitter proofSubmitter.Submitter
@@ -521,7 +706,12 @@ func (p *Prover) submitProofOp(proofResponse *proofProducer.ProofResponse) error
 
 // submitProofsOp performs a batch proof submission operation.
 func (p *Prover) submitProofAggregationOp(batchProof *proofProducer.BatchProofs) error {
-	submitter := p.getSubmitterByTier(batchProof.Tier)
+	var submitter proofSubmitter.Submitter
+	if len(batchProof.ProofResponses) != 0 && batchProof.ProofResponses[0].Meta.IsPacaya() {
+		submitter = p.proofSubmitterPacaya
+	} else {
+		submitter = p.getSubmitterByTier(batchProof.Tier)
+	}
 	if submitter == nil {
 		return nil
 	}
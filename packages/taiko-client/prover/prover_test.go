@@ -16,6 +16,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/taikoxyz/taiko-mono/packages/signer"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/encoding"
 	"github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/metadata"
 	ontakeBindings "github.com/taikoxyz/taiko-mono/packages/taiko-client/bindings/ontake"
@@ -155,7 +156,7 @@ func (s *ProverTestSuite) TestOnBlockProposed() {
 	m := s.ProposeAndInsertValidBlock(s.proposer, s.d.ChainSyncer().BlobSyncer())
 	s.Nil(s.p.eventHandlers.blockProposedHandler.Handle(context.Background(), m, func() {}))
 	req := <-s.p.proofSubmissionCh
-	s.Nil(s.p.requestProofOp(req.Meta, req.Tier))
+	s.Nil(s.p.requestProofOp(context.Background(), req.Meta, req.Tier))
 	if m.IsPacaya() {
 		s.Nil(s.p.proofSubmitterPacaya.SubmitProof(context.Background(), <-s.p.proofGenerationCh))
 	} else {
@@ -165,7 +166,7 @@ func (s *ProverTestSuite) TestOnBlockProposed() {
 
 func (s *ProverTestSuite) TestOnBlockVerifiedEmptyBlockHash() {
 	s.NotPanics(func() {
-		s.p.eventHandlers.blockVerifiedHandler.Handle(&ontakeBindings.TaikoL1ClientBlockVerifiedV2{
+		s.p.eventHandlers.blockVerifiedHandler.Handle(context.Background(), &ontakeBindings.TaikoL1ClientBlockVerifiedV2{
 			BlockId:   common.Big1,
 			BlockHash: common.Hash{},
 		})
@@ -200,7 +201,7 @@ func (s *ProverTestSuite) TestSubmitProofOp() {
 func (s *ProverTestSuite) TestOnBlockVerified() {
 	id := testutils.RandomHash().Big().Uint64()
 	s.NotPanics(func() {
-		s.p.eventHandlers.blockVerifiedHandler.Handle(&ontakeBindings.TaikoL1ClientBlockVerifiedV2{
+		s.p.eventHandlers.blockVerifiedHandler.Handle(context.Background(), &ontakeBindings.TaikoL1ClientBlockVerifiedV2{
 			BlockId: testutils.RandomHash().Big(),
 			Raw: types.Log{
 				BlockHash:   testutils.RandomHash(),
@@ -228,7 +229,7 @@ func (s *ProverTestSuite) TestProveOp() {
 
 	s.Nil(s.p.proveOp())
 	req := <-s.p.proofSubmissionCh
-	s.Nil(s.p.requestProofOp(req.Meta, req.Tier))
+	s.Nil(s.p.requestProofOp(context.Background(), req.Meta, req.Tier))
 	if m.IsPacaya() {
 		s.Nil(s.p.proofSubmitterPacaya.SubmitProof(context.Background(), <-s.p.proofGenerationCh))
 	} else {
@@ -282,7 +283,7 @@ func (s *ProverTestSuite) TestGetBlockProofStatus() {
 	// Valid proof submitted
 	s.Nil(s.p.proveOp())
 	req := <-s.p.proofSubmissionCh
-	s.Nil(s.p.requestProofOp(req.Meta, req.Tier))
+	s.Nil(s.p.requestProofOp(context.Background(), req.Meta, req.Tier))
 	s.Nil(s.p.selectSubmitter(
 		m.Ontake().GetMinTier()).SubmitProof(context.Background(), <-s.p.proofGenerationCh),
 	)
@@ -313,7 +314,7 @@ func (s *ProverTestSuite) TestGetBlockProofStatus() {
 
 	s.Nil(s.p.proveOp())
 	req = <-s.p.proofSubmissionCh
-	s.Nil(s.p.requestProofOp(req.Meta, req.Tier))
+	s.Nil(s.p.requestProofOp(context.Background(), req.Meta, req.Tier))
 
 	proofWithHeader := <-s.p.proofGenerationCh
 	proofWithHeader.Opts.OntakeOptions().BlockHash = testutils.RandomHash()
@@ -370,9 +371,9 @@ func (s *ProverTestSuite) TestAggregateProofsAlreadyProved() {
 	s.Nil(batchProver.proveOp())
 	for i := 0; i < batchSize; i++ {
 		req1 := <-s.p.proofSubmissionCh
-		s.Nil(s.p.requestProofOp(req1.Meta, req1.Tier))
+		s.Nil(s.p.requestProofOp(context.Background(), req1.Meta, req1.Tier))
 		req2 := <-batchProver.proofSubmissionCh
-		s.Nil(batchProver.requestProofOp(req2.Meta, req2.Tier))
+		s.Nil(batchProver.requestProofOp(context.Background(), req2.Meta, req2.Tier))
 		s.Nil(s.p.selectSubmitter(req1.Tier).SubmitProof(context.Background(), <-s.p.proofGenerationCh))
 	}
 	tier := <-batchProver.aggregationNotify
@@ -431,7 +432,7 @@ func (s *ProverTestSuite) TestAggregateProofs() {
 	s.Nil(batchProver.proveOp())
 	for i := 0; i < batchSize; i++ {
 		req := <-batchProver.proofSubmissionCh
-		s.Nil(batchProver.requestProofOp(req.Meta, req.Tier))
+		s.Nil(batchProver.requestProofOp(context.Background(), req.Meta, req.Tier))
 	}
 	tier := <-batchProver.aggregationNotify
 	s.Nil(batchProver.aggregateOp(tier))
@@ -494,7 +495,7 @@ func (s *ProverTestSuite) initProver(
 	}, s.txmgr, s.txmgr))
 
 	p.guardianProverHeartbeater = guardianProverHeartbeater.New(
-		key,
+		signer.NewLocalSigner(key),
 		p.cfg.GuardianProverHealthCheckServerEndpoint,
 		p.rpc,
 		p.ProverAddress(),
@@ -0,0 +1,72 @@
+package prover
+
+import (
+	"errors"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/cmd/flags"
+	proofProducer "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_producer"
+)
+
+// ReloadableFlags implements the ReloadableApplication interface.
+func (p *Prover) ReloadableFlags() []cli.Flag {
+	return []cli.Flag{
+		flags.Graffiti,
+		flags.RaikoHostEndpoint,
+		flags.RaikoZKVMHostEndpoint,
+		flags.RaikoRequestTimeout,
+		flags.SGXBatchSize,
+		flags.ZKVMBatchSize,
+	}
+}
+
+// Reload implements the ReloadableApplication interface, it rebuilds the proof producers with the new
+// configurations, the proof requests in progress keep using the former ones.
+func (p *Prover) Reload(c *cli.Context) error {
+	cfg := *p.cfg
+	cfg.Graffiti = c.String(flags.Graffiti.Name)
+	cfg.RaikoHostEndpoint = c.String(flags.RaikoHostEndpoint.Name)
+	cfg.RaikoZKVMHostEndpoint = c.String(flags.RaikoZKVMHostEndpoint.Name)
+	cfg.RaikoRequestTimeout = c.Duration(flags.RaikoRequestTimeout.Name)
+	cfg.SGXProofBufferSize = c.Uint64(flags.SGXBatchSize.Name)
+	cfg.ZKVMProofBufferSize = c.Uint64(flags.ZKVMBatchSize.Name)
+
+	if !p.IsGuardianProver() && len(cfg.RaikoHostEndpoint) == 0 && len(cfg.ProofProducerEndpoint) == 0 {
+		return errors.New("empty raiko host endpoint")
+	}
+	// The proof buffers are only aggregated when they are enabled at startup.
+	if (cfg.SGXProofBufferSize > 1) != (p.cfg.SGXProofBufferSize > 1) ||
+		(cfg.ZKVMProofBufferSize > 1) != (p.cfg.ZKVMProofBufferSize > 1) {
+		return errors.New("enabling or disabling proof aggregation requires a restart")
+	}
+
+	// Build all the proof producers first, so that nothing is applied if any of them fails.
+	var (
+		producers   = make([]proofProducer.ProofProducer, len(p.proofSubmittersOntake))
+		bufferSizes = make([]uint64, len(p.proofSubmittersOntake))
+	)
+	for i, submitter := range p.proofSubmittersOntake {
+		producer, bufferSize, err := newOntakeProofProducer(&cfg, submitter.Tier())
+		if err != nil {
+			return err
+		}
+		producers[i], bufferSizes[i] = producer, bufferSize
+	}
+	pacayaProducer, pacayaBufferSize := newPacayaProofProducer(&cfg)
+
+	for i, submitter := range p.proofSubmittersOntake {
+		submitter.Reconfigure(producers[i], cfg.Graffiti, bufferSizes[i])
+	}
+	p.proofSubmitterPacaya.Reconfigure(pacayaProducer, cfg.Graffiti, pacayaBufferSize)
+	p.proofContesterOntake.SetGraffiti(cfg.Graffiti)
+
+	p.cfg.Graffiti = cfg.Graffiti
+	p.cfg.RaikoHostEndpoint = cfg.RaikoHostEndpoint
+	p.cfg.RaikoZKVMHostEndpoint = cfg.RaikoZKVMHostEndpoint
+	p.cfg.RaikoRequestTimeout = cfg.RaikoRequestTimeout
+	p.cfg.SGXProofBufferSize = cfg.SGXProofBufferSize
+	p.cfg.ZKVMProofBufferSize = cfg.ZKVMProofBufferSize
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Job is a proof request of a proposal, i.e. a block before Pacaya fork, or a batch after it.
+type Job struct {
+	ID       *big.Int
+	Pacaya   bool
+	Tier     uint16    // Proof tier of a block before Pacaya fork, a contested block is proven again with a higher tier
+	Producer string    // Name of the proof producer, which limits the concurrent requests
+	Deadline time.Time // Proving window expiration of the proposal, zero if it has none
+	// Value is the bond earned or kept by proving the proposal before its deadline, and LateValue after
+	// it, both in wei.
+	Value     *big.Int
+	LateValue *big.Int
+	Run       func(ctx context.Context) error
+
+	enqueuedAt time.Time
+}
+
+// key returns the key which tells the jobs of different proposals apart, and the jobs of different tiers of
+// the same block.
+func (j *Job) key() string {
+	if j.Pacaya {
+		return "batch-" + j.ID.String()
+	}
+	return "block-" + j.ID.String() + "-tier-" + strconv.FormatUint(uint64(j.Tier), 10)
+}
+
+// valueAt returns the expected value of the job if it is proven at the given time.
+func (j *Job) valueAt(now time.Time) *big.Int {
+	value := j.Value
+	if !j.Deadline.IsZero() && now.After(j.Deadline) {
+		value = j.LateValue
+	}
+	if value == nil {
+		return common.Big0
+	}
+	return value
+}
+
+// urgent checks whether the job still has a deadline to meet at the given time.
+func (j *Job) urgent(now time.Time) bool {
+	return !j.Deadline.IsZero() && !now.After(j.Deadline)
+}
+
+// hopeless checks whether the job can no longer pay off at the given time, i.e. it has missed its deadline,
+// and proving it late earns nothing, while proving it in time would have.
+func (j *Job) hopeless(now time.Time) bool {
+	return !j.urgent(now) && !j.Deadline.IsZero() && j.Value != nil && j.Value.Sign() > 0 &&
+		j.valueAt(now).Sign() == 0
+}
+
+// jobQueue is a priority queue of jobs, implementing heap.Interface. The jobs which still have deadlines
+// to meet come first, the earliest deadline first, and then the rest of the jobs, the most valuable first.
+// Since the order depends on the time, the queue is initialized again with the current time before use.
+type jobQueue struct {
+	jobs []*Job
+	now  time.Time
+}
+
+// Len implements the heap.Interface.
+func (q *jobQueue) Len() int { return len(q.jobs) }
+
+// Less implements the heap.Interface.
+func (q *jobQueue) Less(i, j int) bool {
+	a, b := q.jobs[i], q.jobs[j]
+	if aUrgent, bUrgent := a.urgent(q.now), b.urgent(q.now); aUrgent != bUrgent {
+		return aUrgent
+	} else if aUrgent && !a.Deadline.Equal(b.Deadline) {
+		return a.Deadline.Before(b.Deadline)
+	}
+	if cmp := a.valueAt(q.now).Cmp(b.valueAt(q.now)); cmp != 0 {
+		return cmp > 0
+	}
+	return a.enqueuedAt.Before(b.enqueuedAt)
+}
+
+// Swap implements the heap.Interface.
+func (q *jobQueue) Swap(i, j int) { q.jobs[i], q.jobs[j] = q.jobs[j], q.jobs[i] }
+
+// Push implements the heap.Interface.
+func (q *jobQueue) Push(x any) { q.jobs = append(q.jobs, x.(*Job)) }
+
+// Pop implements the heap.Interface.
+func (q *jobQueue) Pop() any {
+	n := len(q.jobs)
+	job := q.jobs[n-1]
+	q.jobs[n-1] = nil
+	q.jobs = q.jobs[:n-1]
+	return job
+}
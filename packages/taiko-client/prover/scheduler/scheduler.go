@@ -0,0 +1,226 @@
+// Package scheduler schedules the proof requests of the prover by their proving window deadlines and
+// expected values, instead of the order of their proposals.
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/taikoxyz/taiko-mono/packages/taiko-client/internal/metrics"
+	submitter "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_submitter"
+)
+
+// checkInterval is the interval to check the jobs for missed deadlines, which is one L1 slot.
+var checkInterval = 12 * time.Second
+
+// runningJob is a job whose proof is being requested.
+type runningJob struct {
+	job    *Job
+	cancel context.CancelCauseFunc
+}
+
+// Scheduler keeps the pending proof jobs in a priority queue, and runs them in the order of their deadlines
+// and expected values, with limited concurrent jobs of each proof producer. The jobs which can no longer pay
+// off are dropped from the queue, or canceled if they are running.
+type Scheduler struct {
+	maxConcurrency      uint64
+	producerConcurrency map[string]uint64
+
+	mu      sync.Mutex
+	queue   *jobQueue
+	keys    map[string]struct{} // Keys of both the queued and running jobs
+	running map[string]*runningJob
+	active  map[string]uint64 // Number of the running jobs of each proof producer
+
+	notify chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates a new scheduler, which runs at most maxConcurrency jobs of each proof producer at a time,
+// unless overridden by producerConcurrency. Zero means no limit.
+func New(maxConcurrency uint64, producerConcurrency map[string]uint64) *Scheduler {
+	return &Scheduler{
+		maxConcurrency:      maxConcurrency,
+		producerConcurrency: producerConcurrency,
+		queue:               &jobQueue{},
+		keys:                make(map[string]struct{}),
+		running:             make(map[string]*runningJob),
+		active:              make(map[string]uint64),
+		notify:              make(chan struct{}, 1),
+	}
+}
+
+// Submit adds the given job to the queue, and reports whether it is added, a job is not added if there is
+// one of the same proposal and tier already queued or running.
+func (s *Scheduler) Submit(job *Job) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.keys[job.key()]; ok {
+		log.Debug("Proof job already scheduled", "id", job.ID, "pacaya", job.Pacaya, "tier", job.Tier)
+		return false
+	}
+
+	job.enqueuedAt = time.Now()
+	s.keys[job.key()] = struct{}{}
+	heap.Push(s.queue, job)
+
+	log.Info(
+		"Proof job scheduled",
+		"id", job.ID,
+		"pacaya", job.Pacaya,
+		"tier", job.Tier,
+		"producer", job.Producer,
+		"deadline", job.Deadline,
+		"value", job.Value,
+		"lateValue", job.LateValue,
+		"queued", s.queue.Len(),
+	)
+
+	s.wake()
+
+	return true
+}
+
+// Loop runs the scheduled jobs until the given context is canceled, and then waits for the running jobs
+// to return.
+func (s *Scheduler) Loop(ctx context.Context) {
+	ticker := time.NewTicker(checkInterval)
+	defer func() {
+		ticker.Stop()
+		s.wg.Wait()
+	}()
+
+	for {
+		s.schedule(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.notify:
+		}
+	}
+}
+
+// schedule drops or cancels the hopeless jobs, and then starts the queued jobs in order, as long as their
+// proof producers have capacity.
+func (s *Scheduler) schedule(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, r := range s.running {
+		if r.job.hopeless(now) {
+			log.Warn("Cancel hopeless proof job", "id", r.job.ID, "pacaya", r.job.Pacaya, "deadline", r.job.Deadline)
+			metrics.ProverSchedulerHopelessCounter.WithLabelValues(r.job.Producer, "running").Inc()
+			r.cancel(submitter.ErrProofCanceled)
+			delete(s.running, key)
+		}
+	}
+
+	s.queue.now = now
+	heap.Init(s.queue)
+
+	var deferred []*Job
+	for s.queue.Len() > 0 && ctx.Err() == nil {
+		job := heap.Pop(s.queue).(*Job)
+
+		if job.hopeless(now) {
+			log.Warn("Drop hopeless proof job", "id", job.ID, "pacaya", job.Pacaya, "deadline", job.Deadline)
+			metrics.ProverSchedulerHopelessCounter.WithLabelValues(job.Producer, "queued").Inc()
+			delete(s.keys, job.key())
+			continue
+		}
+
+		if limit := s.limit(job.Producer); limit != 0 && s.active[job.Producer] >= limit {
+			deferred = append(deferred, job)
+			continue
+		}
+
+		s.start(ctx, job)
+	}
+	for _, job := range deferred {
+		heap.Push(s.queue, job)
+	}
+
+	s.updateMetrics(now)
+}
+
+// start runs the given job in a new goroutine, the caller must hold the lock.
+func (s *Scheduler) start(ctx context.Context, job *Job) {
+	jobCtx, cancel := context.WithCancelCause(ctx)
+	s.running[job.key()] = &runningJob{job: job, cancel: cancel}
+	s.active[job.Producer]++
+
+	log.Info(
+		"Start proof job",
+		"id", job.ID,
+		"pacaya", job.Pacaya,
+		"producer", job.Producer,
+		"deadline", job.Deadline,
+		"waited", time.Since(job.enqueuedAt),
+	)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		if err := job.Run(jobCtx); err != nil {
+			log.Error("Proof job failed", "id", job.ID, "pacaya", job.Pacaya, "error", err)
+		}
+		cancel(nil)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if r, ok := s.running[job.key()]; ok && r.job == job {
+			delete(s.running, job.key())
+		}
+		delete(s.keys, job.key())
+		s.active[job.Producer]--
+		s.wake()
+	}()
+}
+
+// limit returns the maximum number of concurrent jobs of the given proof producer, zero means no limit.
+func (s *Scheduler) limit(producer string) uint64 {
+	if limit, ok := s.producerConcurrency[producer]; ok {
+		return limit
+	}
+	return s.maxConcurrency
+}
+
+// wake requests another scheduling round, won't block if there is one requested already.
+func (s *Scheduler) wake() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// updateMetrics exports the queue state, the caller must hold the lock.
+func (s *Scheduler) updateMetrics(now time.Time) {
+	metrics.ProverSchedulerQueuedGauge.Reset()
+	metrics.ProverSchedulerRunningGauge.Reset()
+
+	var nextDeadline time.Time
+	for _, job := range s.queue.jobs {
+		metrics.ProverSchedulerQueuedGauge.WithLabelValues(job.Producer).Inc()
+		if job.urgent(now) && (nextDeadline.IsZero() || job.Deadline.Before(nextDeadline)) {
+			nextDeadline = job.Deadline
+		}
+	}
+	for producer, active := range s.active {
+		metrics.ProverSchedulerRunningGauge.WithLabelValues(producer).Set(float64(active))
+	}
+
+	if nextDeadline.IsZero() {
+		metrics.ProverSchedulerNextDeadlineGauge.Set(0)
+	} else {
+		metrics.ProverSchedulerNextDeadlineGauge.Set(nextDeadline.Sub(now).Seconds())
+	}
+}
@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	submitter "github.com/taikoxyz/taiko-mono/packages/taiko-client/prover/proof_submitter"
+)
+
+// blockingJob returns a job which runs until its context is canceled, and reports the cause.
+func blockingJob(id int64, producer string, deadline time.Time, causeCh chan<- error) *Job {
+	return &Job{
+		ID:        big.NewInt(id),
+		Pacaya:    true,
+		Producer:  producer,
+		Deadline:  deadline,
+		Value:     common.Big1,
+		LateValue: common.Big0,
+		Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			causeCh <- context.Cause(ctx)
+			return nil
+		},
+	}
+}
+
+func TestJobQueueOrder(t *testing.T) {
+	now := time.Now()
+	q := &jobQueue{now: now}
+	for _, job := range []*Job{
+		{ID: big.NewInt(1), Deadline: now.Add(10 * time.Minute)},
+		{ID: big.NewInt(2), Value: common.Big1},
+		{ID: big.NewInt(3), Deadline: now.Add(-time.Minute), LateValue: common.Big2},
+		{ID: big.NewInt(4), Deadline: now.Add(5 * time.Minute)},
+	} {
+		heap.Push(q, job)
+	}
+
+	var ids []int64
+	for q.Len() > 0 {
+		ids = append(ids, heap.Pop(q).(*Job).ID.Int64())
+	}
+	require.Equal(t, []int64{4, 1, 3, 2}, ids)
+}
+
+func TestJobHopeless(t *testing.T) {
+	now := time.Now()
+	require.True(t, (&Job{Deadline: now.Add(-time.Second), Value: common.Big1}).hopeless(now))
+	require.False(t, (&Job{Deadline: now.Add(time.Second), Value: common.Big1}).hopeless(now))
+	require.False(t, (&Job{Deadline: now.Add(-time.Second), Value: common.Big1, LateValue: common.Big1}).hopeless(now))
+	require.False(t, (&Job{Deadline: now.Add(-time.Second)}).hopeless(now))
+	require.False(t, (&Job{Value: common.Big1}).hopeless(now))
+}
+
+func TestSchedulerConcurrency(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		s       = New(1, map[string]uint64{"sp1": 2})
+		causeCh = make(chan error, 4)
+		now     = time.Now()
+	)
+	require.True(t, s.Submit(blockingJob(1, "sgx", now.Add(time.Hour), causeCh)))
+	require.True(t, s.Submit(blockingJob(2, "sgx", now.Add(time.Minute), causeCh)))
+	require.True(t, s.Submit(blockingJob(3, "sp1", now.Add(time.Hour), causeCh)))
+	require.True(t, s.Submit(blockingJob(4, "sp1", now.Add(time.Hour), causeCh)))
+	require.False(t, s.Submit(blockingJob(2, "sgx", now.Add(time.Minute), causeCh)))
+
+	s.schedule(ctx, now)
+
+	s.mu.Lock()
+	require.Equal(t, uint64(1), s.active["sgx"])
+	require.Equal(t, uint64(2), s.active["sp1"])
+	require.Contains(t, s.running, "batch-2")
+	require.Equal(t, 1, s.queue.Len())
+	s.mu.Unlock()
+
+	cancel()
+	s.wg.Wait()
+}
+
+func TestSchedulerSubmitTiers(t *testing.T) {
+	var (
+		s       = New(0, nil)
+		causeCh = make(chan error, 1)
+		now     = time.Now()
+		job     = func(tier uint16) *Job {
+			job := blockingJob(1, "sgx", now.Add(time.Hour), causeCh)
+			job.Pacaya = false
+			job.Tier = tier
+			return job
+		}
+	)
+
+	// A contested block is scheduled again with a higher tier, while the former job is still queued.
+	require.True(t, s.Submit(job(200)))
+	require.False(t, s.Submit(job(200)))
+	require.True(t, s.Submit(job(300)))
+	require.Equal(t, 2, s.queue.Len())
+}
+
+func TestSchedulerCancelHopeless(t *testing.T) {
+	var (
+		s       = New(0, nil)
+		causeCh = make(chan error, 1)
+		now     = time.Now()
+	)
+	require.True(t, s.Submit(blockingJob(1, "sgx", now.Add(time.Minute), causeCh)))
+
+	s.schedule(context.Background(), now)
+	s.schedule(context.Background(), now.Add(2*time.Minute))
+
+	require.ErrorIs(t, <-causeCh, submitter.ErrProofCanceled)
+	s.wg.Wait()
+
+	// The proposal can be scheduled again once the canceled job returns.
+	require.True(t, s.Submit(blockingJob(1, "sgx", now.Add(time.Hour), causeCh)))
+}
@@ -13,6 +13,7 @@ type SharedState struct {
 	lastHandledBlockID atomic.Uint64
 	l1Current          atomic.Value
 	tiers              []*rpc.TierProviderTierWithID
+	provingQueueDepth  atomic.Int64
 }
 
 // New creates a new prover shared state instance.
@@ -52,3 +53,16 @@ func (s *SharedState) GetTiers() []*rpc.TierProviderTierWithID {
 func (s *SharedState) SetTiers(tiers []*rpc.TierProviderTierWithID) {
 	s.tiers = tiers
 }
+
+// GetProvingQueueDepth returns the number of the proof requests in progress.
+func (s *SharedState) GetProvingQueueDepth() uint64 {
+	if depth := s.provingQueueDepth.Load(); depth > 0 {
+		return uint64(depth)
+	}
+	return 0
+}
+
+// AddProvingQueueDepth adds the given delta to the number of the proof requests in progress.
+func (s *SharedState) AddProvingQueueDepth(delta int64) {
+	s.provingQueueDepth.Add(delta)
+}